@@ -23,6 +23,13 @@ const (
 	// DBBackModePTrack is ptrack backup mode
 	DBBackModePTrack = "PTRACK"
 
+	// compress algorithms supported by gs_probackup
+	CompressAlgNone = "none"
+	CompressAlgZlib = "zlib"
+	CompressAlgPglz = "pglz"
+	// MaxCompressLevel is the max compress level of gs_probackup
+	MaxCompressLevel = 9
+
 	// opengauss backup status
 	OGBackupStatusRunning = "RUNNING"
 	OGBackupStatusOk      = "OK"
@@ -35,4 +42,25 @@ const (
 	// DBBackupStatusOther is used to indicate that the backup status is not in the above three states. and we will not handle it.
 	// the `Other` status may be some intermediate status, such as `Waiting`, `CheckError`, `Ok` etc.
 	DBBackupStatusOther = "Other"
+
+	// job kinds of the agent
+	JobKindBackup  = "backup"
+	JobKindRestore = "restore"
+	JobKindVerify  = "verify"
+
+	// job states of the agent
+	JobStateRunning   = "Running"
+	JobStateSucceeded = "Succeeded"
+	JobStateFailed    = "Failed"
+
+	// operations authorized by the policy of the agent
+	OperationShow    = "show"
+	OperationBackup  = "backup"
+	OperationDelete  = "delete"
+	OperationRestore = "restore"
+
+	// outcomes of the audit records
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailed  = "failed"
+	AuditOutcomeDenied  = "denied"
 )
@@ -53,4 +53,23 @@ var (
 	CleanPgDataTempFailed  = xerror.New(10028, "Clean pgdata temp dir failed.")
 	MissingDiskPath        = xerror.New(10029, "Missing disk path.")
 	MissingBackupID        = xerror.New(10030, "Missing backup id.")
+	InvalidRecoveryTarget  = xerror.New(10031, "Invalid recovery target.")
+	EnableArchiveFailed    = xerror.New(10032, "Failed to enable wal archive.")
+	InvalidStorage         = xerror.New(10033, "Invalid remote storage.")
+	PushBackupSetFailed    = xerror.New(10034, "Failed to push backup set to remote storage.")
+	PullBackupSetFailed    = xerror.New(10035, "Failed to pull backup set from remote storage.")
+	ValidateBackupFailed   = xerror.New(10036, "Failed to validate backup.")
+	VerifyRestoreFailed    = xerror.New(10037, "Failed to restore backup into scratch pgdata.")
+	InvalidSchema          = xerror.New(10038, "Invalid schema.")
+	InvalidCompressAlg     = xerror.New(10039, "Invalid compress algorithm.")
+	InvalidCompressLevel   = xerror.New(10040, "Invalid compress level.")
+	JobNotFound            = xerror.New(10041, "Job not found.")
+	Unauthorized           = xerror.New(10042, "Unauthorized caller.")
+	PermissionDenied       = xerror.New(10043, "Permission denied.")
+	InvalidInstance        = xerror.New(10044, "Invalid instance.")
+	InvalidDnBackupPath    = xerror.New(10045, "Invalid dn backup path.")
+	InvalidDnBackupID      = xerror.New(10046, "Invalid dn backup id.")
+	InvalidDiskPath        = xerror.New(10047, "Invalid disk path.")
+	PathNotAllowed         = xerror.New(10048, "Path is out of the allowed roots.")
+	UnknownDBPort          = xerror.New(10049, "No database managed by agent listens on the db port.")
 )
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+)
+
+func EnableArchive(ctx *fiber.Ctx) error {
+	in := &view.ArchiveIn{}
+	if err := ctx.BodyParser(in); err != nil {
+		return fmt.Errorf("body parse err=%s,wrap=%w", err, cons.BodyParseFailed)
+	}
+
+	if err := in.Validate(); err != nil {
+		return fmt.Errorf("invalid parameter,err=%w", err)
+	}
+
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
+	}
+
+	// archive-push requires the instance in backup path
+	if err := db.AddInstance(in.DnBackupPath, in.Instance); err != nil && !errors.Is(err, cons.InstanceAlreadyExist) {
+		return fmt.Errorf("add instance failed, err=%w", err)
+	}
+
+	if err := db.EnableArchive(in.DnBackupPath, in.Instance); err != nil {
+		efmt := "pkg.DB.EnableArchive failure[path=%s,instance=%s],err=%w"
+		return fmt.Errorf(efmt, in.DnBackupPath, in.Instance, err)
+	}
+
+	return responder.Success(ctx, "")
+}
+
+func ShowArchive(ctx *fiber.Ctx) error {
+	in := &view.ArchiveIn{}
+	if err := ctx.BodyParser(in); err != nil {
+		return fmt.Errorf("body parse err=%s,wrap=%w", err, cons.BodyParseFailed)
+	}
+
+	if err := in.Validate(); err != nil {
+		return fmt.Errorf("invalid parameter,err=%w", err)
+	}
+
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
+	}
+
+	data, err := db.ArchiveStatus(in.Username, in.Password, in.DBName, in.DBPort, in.DnBackupPath, in.Instance)
+	if err != nil {
+		efmt := "pkg.DB.ArchiveStatus failure[path=%s,instance=%s],err=%w"
+		return fmt.Errorf(efmt, in.DnBackupPath, in.Instance, err)
+	}
+
+	return responder.Success(ctx, view.NewArchiveStatusOut(data))
+}
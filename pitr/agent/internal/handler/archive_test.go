@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archive", func() {
+	var mockOG *mock_pkg.MockIDatabase
+	requestBody := `{
+		"db_port": 3306,
+		"db_name": "test_db",
+		"username": "user",
+		"password": "password",
+		"dn_backup_path": "/tmp",
+		"instance": "instance"
+	}`
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockOG = mock_pkg.NewMockIDatabase(ctrl)
+		pkg.DB = mockOG
+	})
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("enable archive success", func() {
+		mockOG.EXPECT().Auth(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOG.EXPECT().AddInstance("/tmp", "instance").Return(nil)
+		mockOG.EXPECT().EnableArchive("/tmp", "instance").Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/archive/enable", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("show archive status", func() {
+		mockOG.EXPECT().Auth(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOG.EXPECT().ArchiveStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "/tmp", "instance").Return(&model.ArchiveStatus{
+			ArchiveMode: "off",
+			Broken:      true,
+			Reason:      "archive_mode is off",
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/archive/show", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+		out := struct {
+			Code int `json:"code"`
+			Data struct {
+				Broken bool   `json:"broken"`
+				Reason string `json:"reason"`
+			} `json:"data"`
+		}{}
+		Expect(json.Unmarshal(body, &out)).To(Succeed())
+		Expect(out.Code).To(Equal(0))
+		Expect(out.Data.Broken).To(BeTrue())
+	})
+})
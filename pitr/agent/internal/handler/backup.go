@@ -39,24 +39,34 @@ func Backup(ctx *fiber.Ctx) error {
 		return fmt.Errorf("invalid parameter,err=%w", err)
 	}
 
-	if err := pkg.OG.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
-		efmt := "pkg.OG.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
 		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
 	}
 
 	// try to add backup instance
-	if err := pkg.OG.AddInstance(in.DnBackupPath, in.Instance); err != nil && !errors.Is(err, cons.InstanceAlreadyExist) {
+	if err := db.AddInstance(in.DnBackupPath, in.Instance); err != nil && !errors.Is(err, cons.InstanceAlreadyExist) {
 		return fmt.Errorf("add instance failed, err=%w", err)
 	}
 
-	backupID, err := pkg.OG.AsyncBackup(in.DnBackupPath, in.Instance, in.DnBackupMode, 1, in.DBPort)
+	// the job is finished when the backup tool exits
+	job := pkg.Jobs.New(cons.JobKindBackup, pkg.JobScope{Instance: in.Instance, DBPort: in.DBPort})
+	backupID, err := db.AsyncBackup(in.DnBackupPath, in.Instance, in.DnBackupMode, in.ToOptions(), in.DBPort, job)
 	if err != nil {
-		efmt := "pkg.OG.AsyncBackup[path=%s,instance=%s,mode=%s] failure,err=%w"
-		return fmt.Errorf(efmt, in.DnBackupPath, in.Instance, in.DnBackupMode, err)
+		efmt := "pkg.DB.AsyncBackup[path=%s,instance=%s,mode=%s] failure,err=%w"
+		err = fmt.Errorf(efmt, in.DnBackupPath, in.Instance, in.DnBackupMode, err)
+		job.Finish(nil, err)
+		return err
 	}
 
 	return responder.Success(ctx, view.BackupOut{
-		ID: backupID,
+		ID:    backupID,
+		JobID: job.ID(),
 	})
 }
 
@@ -70,12 +80,17 @@ func DeleteBackup(ctx *fiber.Ctx) error {
 		return fmt.Errorf("invalid parameter,err=%w", err)
 	}
 
-	if err := pkg.OG.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
-		efmt := "pkg.OG.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
 		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
 	}
 
-	if err := pkg.OG.DelBackup(in.DnBackupPath, in.Instance, in.BackupID); err != nil {
+	if err := db.DelBackup(in.DnBackupPath, in.Instance, in.BackupID); err != nil {
 		return fmt.Errorf("delete backup failure,err=%w", err)
 	}
 
@@ -24,6 +24,7 @@ import (
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
 	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -31,11 +32,11 @@ import (
 
 var _ = Describe("Backup", func() {
 	Context("delete backup", func() {
-		var mockOG *mock_pkg.MockIOpenGauss
+		var mockOG *mock_pkg.MockIDatabase
 		BeforeEach(func() {
 			ctrl = gomock.NewController(GinkgoT())
-			mockOG = mock_pkg.NewMockIOpenGauss(ctrl)
-			pkg.OG = mockOG
+			mockOG = mock_pkg.NewMockIDatabase(ctrl)
+			pkg.DB = mockOG
 		})
 		AfterEach(func() {
 			ctrl.Finish()
@@ -72,5 +73,121 @@ var _ = Describe("Backup", func() {
 			Expect(err).To(BeNil())
 			Expect(resp.StatusCode).To(Equal(http.StatusOK))
 		})
+
+		It("delete backup of the instance on the db port", func() {
+			other := mock_pkg.NewMockIDatabase(ctrl)
+			pkg.DBs = map[uint16]pkg.IDatabase{3306: other, 3307: mockOG}
+			defer func() { pkg.DBs = nil }()
+
+			requestBody := `{
+				"db_port": 3307,
+				"db_name": "test_db",
+				"username": "user",
+				"password": "password",
+				"dn_backup_path": "/tmp",
+				"backup_id": "backup_id",
+				"instance": "instance"
+			}`
+			mockOG.EXPECT().Auth(gomock.Any(), gomock.Any(), gomock.Any(), uint16(3307)).Return(nil)
+			mockOG.EXPECT().DelBackup("/tmp", "instance", "backup_id").Return(nil)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/backup", strings.NewReader(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			// no instance on the db port
+			req = httptest.NewRequest(http.MethodDelete, "/api/backup", strings.NewReader(strings.Replace(requestBody, "3307", "3308", 1)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err = app.Test(req)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).To(Equal(500))
+		})
+	})
+
+	Context("Backup", func() {
+		var mockOG *mock_pkg.MockIDatabase
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			mockOG = mock_pkg.NewMockIDatabase(ctrl)
+			pkg.DB = mockOG
+		})
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		backup := func(requestBody string) int {
+			req := httptest.NewRequest(http.MethodPost, "/api/backup", strings.NewReader(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			Expect(err).To(BeNil())
+			return resp.StatusCode
+		}
+
+		It("backup with threads and compression", func() {
+			requestBody := `{
+				"db_port": 3306,
+				"db_name": "test_db",
+				"username": "user",
+				"password": "password",
+				"dn_backup_path": "/tmp",
+				"dn_threads_num": 4,
+				"dn_backup_mode": "FULL",
+				"instance": "instance",
+				"dn_compress_alg": "zlib",
+				"dn_compress_level": 6,
+				"dn_stream": true
+			}`
+
+			mockOG.EXPECT().Auth(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockOG.EXPECT().AddInstance("/tmp", "instance").Return(nil)
+			mockOG.EXPECT().AsyncBackup("/tmp", "instance", "FULL", &model.BackupOptions{
+				ThreadsNum:    4,
+				CompressAlg:   "zlib",
+				CompressLevel: 6,
+				Stream:        true,
+			}, uint16(3306), gomock.Any()).Return("backup-id", nil)
+
+			Expect(backup(requestBody)).To(Equal(http.StatusOK))
+		})
+
+		It("invalid compress algorithm", func() {
+			requestBody := `{
+				"db_port": 3306,
+				"db_name": "test_db",
+				"username": "user",
+				"password": "password",
+				"dn_backup_path": "/tmp",
+				"dn_threads_num": 4,
+				"dn_backup_mode": "FULL",
+				"instance": "instance",
+				"dn_compress_alg": "lz4"
+			}`
+
+			Expect(backup(requestBody)).To(Equal(500))
+		})
+
+		It("injected backup path or instance is rejected before any operation", func() {
+			for _, field := range []string{
+				`"dn_backup_path": "/home/omm/data; rm -rf /", "instance": "instance"`,
+				`"dn_backup_path": "/home/omm/../../etc", "instance": "instance"`,
+				`"dn_backup_path": "/tmp", "instance": "ins$(id)"`,
+				`"dn_backup_path": "/tmp", "instance": "ins' --pgdata='/"`,
+			} {
+				requestBody := `{
+					"db_port": 3306,
+					"db_name": "test_db",
+					"username": "user",
+					"password": "password",
+					"dn_threads_num": 4,
+					"dn_backup_mode": "FULL",
+					` + field + `
+				}`
+
+				Expect(backup(requestBody)).To(Equal(500), field)
+			}
+		})
 	})
 })
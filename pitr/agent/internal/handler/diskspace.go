@@ -19,11 +19,10 @@ package handler
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
-	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/cmds"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
 	"github.com/gofiber/fiber/v2"
 )
@@ -39,12 +38,16 @@ func DiskSpace(ctx *fiber.Ctx) error {
 		return fmt.Errorf("invalid parameter,err=%w", err)
 	}
 
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
 	// show disk space
-	cmd := fmt.Sprintf("df -h %s", in.DiskPath)
-	output, err := cmds.Exec(os.Getenv("SHELL"), cmd)
+	ds, err := db.DiskSpace(in.DiskPath, in.Instance, in.DnBackupID)
 	if err != nil {
-		return fmt.Errorf("exec cmd [%s] failure,err=%w", cmd, err)
+		return fmt.Errorf("pkg.DB.DiskSpace failure[path=%s],err=%w", in.DiskPath, err)
 	}
 
-	return responder.Success(ctx, output)
+	return responder.Success(ctx, view.NewDiskSpaceOut(ds))
 }
@@ -18,10 +18,19 @@
 package handler_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -36,12 +45,79 @@ var _ = Describe("Disk Space", func() {
 	})
 
 	It("DiskSpace", func() {
-		requestBody := `{"diskPath": "/tmp"}`
+		ctrl = gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		mockOG := mock_pkg.NewMockIDatabase(ctrl)
+		pkg.DB = mockOG
+
+		mockOG.EXPECT().DiskSpace("/tmp", "instance", "").Return(&model.DiskSpace{
+			BackupPath:     &model.DiskUsage{Path: "/tmp", TotalBytes: 100, UsedBytes: 40, FreeBytes: 60},
+			PgData:         &model.DiskUsage{Path: "/data", TotalBytes: 200, UsedBytes: 150, FreeBytes: 50},
+			EstimatedBytes: 30,
+		}, nil)
+
+		requestBody := `{"diskPath": "/tmp", "instance": "instance"}`
 		req := httptest.NewRequest(http.MethodPost, "/api/diskspace", strings.NewReader(requestBody))
 		req.Header.Set("Content-Type", "application/json")
 
 		resp, err := app.Test(req)
 		Expect(err).To(BeNil())
 		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		out := &struct {
+			Data *view.DiskSpaceOut `json:"data"`
+		}{}
+		Expect(json.NewDecoder(resp.Body).Decode(out)).To(Succeed())
+		Expect(out.Data.BackupPath.FreeBytes).To(Equal(uint64(60)))
+		Expect(out.Data.PgData.TotalBytes).To(Equal(uint64(200)))
+		Expect(out.Data.EstimatedBytes).To(Equal(int64(30)))
+	})
+
+	It("DiskSpace with backup id but no instance", func() {
+		requestBody := `{"diskPath": "/tmp", "dn_backup_id": "RUS2A1"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/diskspace", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).NotTo(Equal(http.StatusOK))
+	})
+
+	It("DiskSpace with injected path", func() {
+		dir := GinkgoT().TempDir()
+		pwned := filepath.Join(dir, "pwned")
+
+		for _, path := range []string{
+			fmt.Sprintf("/tmp; touch %s", pwned),
+			fmt.Sprintf("/tmp && touch %s", pwned),
+			fmt.Sprintf("/tmp | touch %s", pwned),
+			fmt.Sprintf("/tmp$(touch %s)", pwned),
+			fmt.Sprintf("/tmp`touch %s`", pwned),
+			"/tmp/../etc",
+			"tmp",
+		} {
+			requestBody := fmt.Sprintf(`{"diskPath": %q}`, path)
+			req := httptest.NewRequest(http.MethodPost, "/api/diskspace", strings.NewReader(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			Expect(err).To(BeNil())
+			Expect(resp.StatusCode).NotTo(Equal(http.StatusOK), path)
+		}
+
+		_, err := os.Stat(pwned)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("DiskSpace out of allowed roots", func() {
+		view.SetAllowedRoots([]string{"/home/omm"})
+		defer view.SetAllowedRoots(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/diskspace", strings.NewReader(`{"diskPath": "/tmp"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).NotTo(Equal(http.StatusOK))
 	})
 })
@@ -18,10 +18,10 @@
 package handler_test
 
 import (
-	"os"
 	"testing"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
 	"github.com/gofiber/fiber/v2"
@@ -45,7 +45,7 @@ var _ = BeforeSuite(func() {
 	// init log
 	logging.Init(zap.DebugLevel)
 
-	Expect(os.Setenv("SHELL", "/bin/bash")).To(Succeed())
+	pkg.Jobs = pkg.NewJobs()
 
 	// init app
 	app = fiber.New()
@@ -55,7 +55,15 @@ var _ = BeforeSuite(func() {
 
 	app.Route("/api", func(r fiber.Router) {
 		r.Post("/diskspace", handler.DiskSpace)
+		r.Post("/backup", handler.Backup)
 		r.Delete("/backup", handler.DeleteBackup)
 		r.Post("/healthz", handler.HealthCheck)
+		r.Post("/archive/enable", handler.EnableArchive)
+		r.Post("/archive/show", handler.ShowArchive)
+		r.Post("/backup/push", handler.PushBackupSet)
+		r.Post("/backup/pull", handler.PullBackupSet)
+		r.Post("/verify", handler.Verify)
+		r.Get("/jobs/:id", handler.ShowJob)
+		r.Get("/jobs/:id/log", handler.JobLog)
 	})
 })
@@ -33,15 +33,20 @@ func HealthCheck(ctx *fiber.Ctx) error {
 		return fmt.Errorf("body parse err=%s,wrap=%w", err, cons.BodyParseFailed)
 	}
 
-	if err := pkg.OG.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
-		efmt := "pkg.OG.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
 		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
 	}
 
 	// check schema if needed
 	if in.Schema != "" {
-		if err := pkg.OG.CheckSchema(in.Username, in.Password, in.DBName, in.DBPort, in.Schema); err != nil {
-			return fmt.Errorf("pkg.OG.CheckSchema return err=%s,wrap=%w", err, err)
+		if err := db.CheckSchema(in.Username, in.Password, in.DBName, in.DBPort, in.Schema); err != nil {
+			return fmt.Errorf("pkg.DB.CheckSchema return err=%s,wrap=%w", err, err)
 		}
 	}
 
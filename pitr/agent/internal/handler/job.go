@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ShowJob(ctx *fiber.Ctx) error {
+	job, err := pkg.Jobs.Get(ctx.Params("id"))
+	if err != nil {
+		return fmt.Errorf("pkg.Jobs.Get return err=%w", err)
+	}
+
+	return responder.Success(ctx, view.NewJobOut(job.Snapshot()))
+}
+
+/*
+JobLog stream the output lines of the job in chunks until the job is done,
+the lines before the query `from` are skipped, so that the client can resume from where it stopped.
+*/
+func JobLog(ctx *fiber.Ctx) error {
+	job, err := pkg.Jobs.Get(ctx.Params("id"))
+	if err != nil {
+		return fmt.Errorf("pkg.Jobs.Get return err=%w", err)
+	}
+	from := ctx.QueryInt("from", 0)
+
+	ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		for {
+			lines, next, done, changed := job.Lines(from)
+			for _, line := range lines {
+				if _, err := fmt.Fprintln(w, line); err != nil {
+					return
+				}
+			}
+			from = next
+			if err := w.Flush(); err != nil || done {
+				return
+			}
+
+			<-changed
+		}
+	})
+	return nil
+}
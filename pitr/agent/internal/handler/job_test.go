@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type jobOut struct {
+	ID       string `json:"job_id"`
+	Kind     string `json:"kind"`
+	State    string `json:"state"`
+	Progress struct {
+		Done  int64 `json:"done"`
+		Total int64 `json:"total"`
+	} `json:"progress"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+func showJob(id string) (int, *jobOut) {
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+id, nil)
+	resp, err := app.Test(req)
+	Expect(err).To(BeNil())
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	Expect(err).To(BeNil())
+	out := struct {
+		Code int     `json:"code"`
+		Data *jobOut `json:"data"`
+	}{}
+	Expect(json.Unmarshal(body, &out)).To(Succeed())
+	return resp.StatusCode, out.Data
+}
+
+// waitJob poll the job until it is done.
+func waitJob(id string) *jobOut {
+	var job *jobOut
+	Eventually(func() string {
+		_, job = showJob(id)
+		return job.State
+	}).WithTimeout(5 * time.Second).WithPolling(10 * time.Millisecond).ShouldNot(Equal(cons.JobStateRunning))
+	return job
+}
+
+var _ = Describe("Job", func() {
+	It("job not found", func() {
+		code, _ := showJob("not-exist")
+		Expect(code).To(Equal(500))
+	})
+
+	It("show job", func() {
+		release := make(chan struct{})
+		job := pkg.Jobs.Submit(cons.JobKindRestore, pkg.JobScope{}, func(job *pkg.Job) (interface{}, error) {
+			job.Log("INFO: Progress: (1/4). Process file \"base/1/1\"")
+			<-release
+			return map[string]string{"replay_lsn": "0/5000028"}, nil
+		})
+
+		Eventually(func() int64 {
+			_, out := showJob(job.ID())
+			return out.Progress.Done
+		}).Should(Equal(int64(1)))
+		_, out := showJob(job.ID())
+		Expect(out.State).To(Equal(cons.JobStateRunning))
+		Expect(out.Progress.Total).To(Equal(int64(4)))
+
+		close(release)
+		out = waitJob(job.ID())
+		Expect(out.State).To(Equal(cons.JobStateSucceeded))
+		Expect(out.Progress.Done).To(Equal(int64(4)))
+		Expect(string(out.Result)).To(ContainSubstring("0/5000028"))
+	})
+
+	It("failed job", func() {
+		job := pkg.Jobs.Submit(cons.JobKindRestore, pkg.JobScope{}, func(job *pkg.Job) (interface{}, error) {
+			return nil, errors.New("restore failure")
+		})
+		out := waitJob(job.ID())
+		Expect(out.State).To(Equal(cons.JobStateFailed))
+		Expect(out.Error).To(Equal("restore failure"))
+	})
+
+	It("stream job log", func() {
+		release := make(chan struct{})
+		job := pkg.Jobs.Submit(cons.JobKindBackup, pkg.JobScope{}, func(job *pkg.Job) (interface{}, error) {
+			job.Log("line 1")
+			<-release
+			job.Log("line 2")
+			job.Log("line 3")
+			return nil, nil
+		})
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+		}()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+job.ID()+"/log?from=1", nil)
+		resp, err := app.Test(req, 5000)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal("line 2\nline 3\n"))
+	})
+})
@@ -0,0 +1,227 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/xerror"
+)
+
+const (
+	_bearerPrefix = "Bearer "
+	// _localsRule the name of the rule which allows the request
+	_localsRule = "authz-rule"
+	// _localsDenied set if the request is rejected by the policy
+	_localsDenied = "authz-denied"
+)
+
+// accessParams the fields of request body which are restricted by the policy.
+type accessParams struct {
+	Instance     string `json:"instance"`
+	DBPort       uint16 `json:"db_port"`
+	DnBackupPath string `json:"dn_backup_path"`
+	DiskPath     string `json:"disk_path"`
+}
+
+/*
+Authorize reject the request unless the policy allows the caller to do the operation on the instance, database and backup path.
+All requests are allowed if the policy is nil.
+
+The database is the one routed by the db port of the request, and the reads of a job are authorized against
+the instance and the database operated by the job.
+*/
+func Authorize(policy *pkg.Policy) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if policy == nil {
+			return ctx.Next()
+		}
+
+		op := operationOf(ctx.Method(), ctx.Path())
+		if op == "" {
+			ctx.Locals(_localsDenied, true)
+			return responder.Error(ctx, cons.PermissionDenied)
+		}
+
+		params := &accessParams{}
+		if body := ctx.Body(); len(body) > 0 {
+			if err := json.Unmarshal(body, params); err != nil {
+				return responder.Error(ctx, cons.BodyParseFailed)
+			}
+		}
+		backupPath := params.DnBackupPath
+		if backupPath == "" {
+			backupPath = params.DiskPath
+		}
+		if scope, ok := jobScopeOf(ctx.Method(), ctx.Path()); ok {
+			params.Instance, params.DBPort = scope.Instance, scope.DBPort
+		}
+
+		caller := &pkg.Caller{Token: bearerToken(ctx)}
+		if cert := clientCertificate(ctx); cert != nil {
+			caller.Identity = cert.Subject.CommonName
+		}
+
+		rule, err := policy.Authorize(caller, &pkg.Access{Operation: op, Instance: params.Instance, DBPort: params.DBPort, BackupPath: backupPath})
+		if err != nil {
+			ctx.Locals(_localsDenied, true)
+			return responder.Error(ctx, err)
+		}
+		ctx.Locals(_localsRule, rule)
+		return ctx.Next()
+	}
+}
+
+// Audit write every mutating request into the audit log with the outcome of it, nothing is written if the auditor is nil.
+func Audit(auditor pkg.IAuditor, log logging.ILog) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		op := operationOf(ctx.Method(), ctx.Path())
+		if auditor == nil || op == cons.OperationShow {
+			return ctx.Next()
+		}
+
+		rec := &model.AuditRecord{
+			Time:      time.Now().Format(time.RFC3339),
+			RequestID: ctx.Get(cons.RequestID),
+			Caller:    callerOf(ctx),
+			Method:    ctx.Method(),
+			Path:      ctx.Path(),
+			Operation: op,
+			Params:    pkg.RedactParams(ctx.Body()),
+		}
+
+		err := ctx.Next()
+
+		if rule, ok := ctx.Locals(_localsRule).(string); ok {
+			rec.Rule = rule
+		}
+		setOutcome(rec, ctx, err)
+
+		if werr := auditor.Write(rec); werr != nil {
+			//nolint:exhaustive
+			log.Fields(map[logging.FieldKey]string{
+				logging.ErrorKey:  werr.Error(),
+				logging.RequestID: rec.RequestID,
+			}).Error("write audit log failed")
+		}
+		return err
+	}
+}
+
+// operationOf map the api to the operation of policy, it returns empty string for unknown api.
+func operationOf(method, path string) string {
+	path = strings.TrimPrefix(path, "/api")
+	switch {
+	case method == fiber.MethodGet && strings.HasPrefix(path, "/jobs/"):
+		return cons.OperationShow
+	case method == fiber.MethodDelete && path == "/backup":
+		return cons.OperationDelete
+	case method != fiber.MethodPost:
+		return ""
+	}
+
+	switch path {
+	case "/healthz", "/show", "/show/list", "/diskspace", "/archive/show":
+		return cons.OperationShow
+	case "/backup", "/archive/enable", "/backup/push":
+		return cons.OperationBackup
+	case "/restore", "/backup/pull", "/verify":
+		return cons.OperationRestore
+	default:
+		return ""
+	}
+}
+
+// jobScopeOf return the scope of the job read by the request, it returns false if the request doesn't read a job
+// or the job is not found, which is reported by the handler then.
+func jobScopeOf(method, path string) (pkg.JobScope, bool) {
+	path = strings.TrimPrefix(path, "/api")
+	if method != fiber.MethodGet || !strings.HasPrefix(path, "/jobs/") || pkg.Jobs == nil {
+		return pkg.JobScope{}, false
+	}
+
+	id, _, _ := strings.Cut(strings.TrimPrefix(path, "/jobs/"), "/")
+	job, err := pkg.Jobs.Get(id)
+	if err != nil {
+		return pkg.JobScope{}, false
+	}
+	return job.Scope(), true
+}
+
+func bearerToken(ctx *fiber.Ctx) string {
+	auth := ctx.Get(fiber.HeaderAuthorization)
+	if !strings.HasPrefix(auth, _bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(auth, _bearerPrefix))
+}
+
+// callerOf the token itself is never recorded.
+func callerOf(ctx *fiber.Ctx) string {
+	if cert := clientCertificate(ctx); cert != nil {
+		return fmt.Sprintf("cn:%s,serial:%s", cert.Subject.CommonName, cert.SerialNumber.Text(16))
+	}
+	if bearerToken(ctx) != "" {
+		return "token"
+	}
+	return "anonymous"
+}
+
+func setOutcome(rec *model.AuditRecord, ctx *fiber.Ctx, err error) {
+	if err != nil {
+		rec.Outcome = cons.AuditOutcomeFailed
+		rec.Msg = err.Error()
+		if e, ok := xerror.FromError(err); ok {
+			rec.Code = e.Code
+			rec.Msg = e.Msg
+		}
+		return
+	}
+
+	resp := &struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+		Data struct {
+			JobID string `json:"job_id"`
+		} `json:"data"`
+	}{}
+	// the data of some responses is not an object, the job id is ignored then.
+	_ = json.Unmarshal(ctx.Response().Body(), resp)
+
+	rec.Code, rec.JobID = resp.Code, resp.Data.JobID
+	switch {
+	case ctx.Locals(_localsDenied) != nil:
+		rec.Outcome = cons.AuditOutcomeDenied
+		rec.Msg = resp.Msg
+	case resp.Code == 0:
+		rec.Outcome = cons.AuditOutcomeSuccess
+	default:
+		rec.Outcome = cons.AuditOutcomeFailed
+		rec.Msg = resp.Msg
+	}
+}
@@ -0,0 +1,200 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/middleware"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
+)
+
+type fakeAuditor struct {
+	records []*model.AuditRecord
+}
+
+func (a *fakeAuditor) Write(rec *model.AuditRecord) error {
+	a.records = append(a.records, rec)
+	return nil
+}
+
+func (a *fakeAuditor) Close() error {
+	return nil
+}
+
+var _ = Describe("Authorize and audit", func() {
+	var (
+		app     *fiber.App
+		auditor *fakeAuditor
+		policy  = &pkg.Policy{
+			Rules: []*pkg.PolicyRule{
+				{
+					Name:        "ops",
+					Tokens:      []string{"ops-token"},
+					Operations:  []string{"show", "backup", "delete", "restore"},
+					BackupPaths: []string{"/home/omm/data"},
+				},
+				{
+					Name:       "monitor",
+					Tokens:     []string{"monitor-token"},
+					Operations: []string{"show"},
+				},
+				{
+					Name:       "dn1",
+					Tokens:     []string{"dn1-token"},
+					Operations: []string{"show", "backup"},
+					Instances:  []string{"ins-default-0"},
+					DBPorts:    []uint16{5432},
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		auditor = &fakeAuditor{}
+		app = fiber.New()
+		app.Route("/api", func(r fiber.Router) {
+			r.Use(
+				middleware.Audit(auditor, logging.Init(zap.DebugLevel)),
+				middleware.Authorize(policy),
+			)
+			r.Post("/show", func(ctx *fiber.Ctx) error {
+				return responder.Success(ctx, "")
+			})
+			r.Post("/restore", func(ctx *fiber.Ctx) error {
+				return responder.Success(ctx, map[string]string{"job_id": "job-1"})
+			})
+			r.Delete("/backup", func(ctx *fiber.Ctx) error {
+				return cons.CmdOperateFailed
+			})
+			r.Get("/jobs/:id", func(ctx *fiber.Ctx) error {
+				return responder.Success(ctx, "")
+			})
+		})
+	})
+
+	request := func(method, path, token, body string) (int, string) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(cons.RequestID, "req-1")
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if token != "" {
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		}
+		resp, err := app.Test(req)
+		Expect(err).To(BeNil())
+		bs, err := io.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+
+		out := &struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		}{}
+		if json.Unmarshal(bs, out) != nil {
+			return resp.StatusCode, string(bs)
+		}
+		return out.Code, out.Msg
+	}
+
+	It("show is allowed and not audited", func() {
+		code, _ := request(fiber.MethodPost, "/api/show", "monitor-token", `{"instance":"ins-default-0"}`)
+		Expect(code).To(Equal(0))
+		Expect(auditor.records).To(BeEmpty())
+	})
+
+	It("unauthorized caller is denied and audited", func() {
+		code, msg := request(fiber.MethodPost, "/api/restore", "", `{"password":"secret","dn_backup_path":"/home/omm/data"}`)
+		Expect(code).To(Equal(10042))
+		Expect(msg).To(Equal("Unauthorized caller."))
+
+		Expect(auditor.records).To(HaveLen(1))
+		rec := auditor.records[0]
+		Expect(rec.Caller).To(Equal("anonymous"))
+		Expect(rec.Outcome).To(Equal(cons.AuditOutcomeDenied))
+		Expect(rec.Params["password"]).NotTo(Equal("secret"))
+	})
+
+	It("operation not allowed", func() {
+		code, _ := request(fiber.MethodPost, "/api/restore", "monitor-token", `{"dn_backup_path":"/home/omm/data"}`)
+		Expect(code).To(Equal(10043))
+		Expect(auditor.records[0].Outcome).To(Equal(cons.AuditOutcomeDenied))
+	})
+
+	It("restore is allowed and audited", func() {
+		code, _ := request(fiber.MethodPost, "/api/restore", "ops-token", `{"instance":"ins-default-0","dn_backup_path":"/home/omm/data","dn_backup_id":"RR3FIC"}`)
+		Expect(code).To(Equal(0))
+
+		Expect(auditor.records).To(HaveLen(1))
+		rec := auditor.records[0]
+		Expect(rec.RequestID).To(Equal("req-1"))
+		Expect(rec.Caller).To(Equal("token"))
+		Expect(rec.Rule).To(Equal("ops"))
+		Expect(rec.Operation).To(Equal(cons.OperationRestore))
+		Expect(rec.Outcome).To(Equal(cons.AuditOutcomeSuccess))
+		Expect(rec.JobID).To(Equal("job-1"))
+		Expect(rec.Params["dn_backup_id"]).To(Equal("RR3FIC"))
+	})
+
+	It("backup path is not allowed", func() {
+		code, _ := request(fiber.MethodDelete, "/api/backup", "ops-token", `{"dn_backup_path":"/tmp"}`)
+		Expect(code).To(Equal(10043))
+	})
+
+	It("failed operation is audited", func() {
+		request(fiber.MethodDelete, "/api/backup", "ops-token", `{"dn_backup_path":"/home/omm/data"}`)
+		Expect(auditor.records).To(HaveLen(1))
+		Expect(auditor.records[0].Outcome).To(Equal(cons.AuditOutcomeFailed))
+		Expect(auditor.records[0].Operation).To(Equal(cons.OperationDelete))
+	})
+
+	It("database routed by db port is authorized", func() {
+		code, _ := request(fiber.MethodPost, "/api/show", "dn1-token", `{"instance":"ins-default-0","db_port":5432}`)
+		Expect(code).To(Equal(0))
+
+		code, _ = request(fiber.MethodPost, "/api/show", "dn1-token", `{"instance":"ins-default-0","db_port":5433}`)
+		Expect(code).To(Equal(10043))
+	})
+
+	It("job is read by the callers allowed to its database", func() {
+		origin := pkg.Jobs
+		defer func() { pkg.Jobs = origin }()
+		pkg.Jobs = pkg.NewJobs()
+		dn1 := pkg.Jobs.New(cons.JobKindBackup, pkg.JobScope{Instance: "ins-default-0", DBPort: 5432})
+		dn2 := pkg.Jobs.New(cons.JobKindBackup, pkg.JobScope{Instance: "ins-default-0", DBPort: 5433})
+
+		code, _ := request(fiber.MethodGet, "/api/jobs/"+dn1.ID(), "dn1-token", "")
+		Expect(code).To(Equal(0))
+
+		code, _ = request(fiber.MethodGet, "/api/jobs/"+dn2.ID(), "dn1-token", "")
+		Expect(code).To(Equal(10043))
+
+		code, _ = request(fiber.MethodGet, "/api/jobs/"+dn2.ID(), "monitor-token", "")
+		Expect(code).To(Equal(0))
+	})
+})
@@ -18,6 +18,7 @@
 package middleware
 
 import (
+	"crypto/x509"
 	"fmt"
 	"time"
 
@@ -50,16 +51,30 @@ func Logger(log logging.ILog) fiber.Handler {
 	}
 }
 
-// AccessLog logging Access log.
+// AccessLog logging Access log, with the identity of client certificate if mutual TLS is enabled.
 func AccessLog(log logging.ILog) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		//nolint:exhaustive
-		log.Fields(map[logging.FieldKey]string{
+		m := map[logging.FieldKey]string{
 			logging.Path:       ctx.Route().Path,
 			logging.RequestURI: string(ctx.Request().RequestURI()),
 			logging.RequestID:  ctx.Get(cons.RequestID),
 			logging.HTTPMethod: ctx.Method(),
-		}).Info("Access log")
+		}
+		if cert := clientCertificate(ctx); cert != nil {
+			m[logging.ClientIdentity] = cert.Subject.CommonName
+			m[logging.ClientSerial] = cert.SerialNumber.Text(16)
+		}
+		log.Fields(m).Info("Access log")
 		return ctx.Next()
 	}
 }
+
+// clientCertificate return the verified client certificate, nil if the client does not present one.
+func clientCertificate(ctx *fiber.Ctx) *x509.Certificate {
+	state := ctx.Context().TLSConnectionState()
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	return state.PeerCertificates[0]
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
 
 	"github.com/gofiber/fiber/v2"
@@ -29,58 +30,75 @@ import (
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
 )
 
-func Restore(ctx *fiber.Ctx) (err error) {
+/*
+Restore check the parameters and restore in background, the job id is returned at once.
+
+The result of the job is the position that the database replayed to if a recovery target is specified.
+*/
+func Restore(ctx *fiber.Ctx) error {
 	in := &view.RestoreIn{}
 
-	if err = ctx.BodyParser(in); err != nil {
-		err = fmt.Errorf("body parse err=%s,wrap=%w", err, cons.BodyParseFailed)
-		return
+	if err := ctx.BodyParser(in); err != nil {
+		return fmt.Errorf("body parse err=%s,wrap=%w", err, cons.BodyParseFailed)
 	}
 
-	if err = in.Validate(); err != nil {
-		err = fmt.Errorf("invalid parameter,err=%w", err)
-		return
+	if err := in.Validate(); err != nil {
+		return fmt.Errorf("invalid parameter,err=%w", err)
 	}
 
-	if err = pkg.OG.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
-		efmt := "pkg.OG.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
-		err = fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
-		return
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
 	}
 
-	// stop openGauss
-	if err = pkg.OG.Stop(); err != nil {
-		err = fmt.Errorf("stop openGauss failure,err=%w", err)
+	job := pkg.Jobs.Submit(cons.JobKindRestore, pkg.JobScope{Instance: in.Instance, DBPort: in.DBPort}, func(job *pkg.Job) (interface{}, error) {
+		return restore(db, in, job)
+	})
+	return responder.Success(ctx, view.NewJobOut(job.Snapshot()))
+}
+
+func restore(db pkg.IDatabase, in *view.RestoreIn, job *pkg.Job) (out *view.RestoreOut, err error) {
+	// stop database
+	job.Log("Stopping database...")
+	if err = db.Stop(); err != nil {
+		err = fmt.Errorf("stop database failure,err=%w", err)
 		return
 	}
 
 	defer func() {
 		if err != nil {
-			err2 := pkg.OG.Start()
+			err2 := db.Start()
 			if err2 != nil {
-				err = fmt.Errorf("pkg.OG.Start() return err=%s,wrap=%w", err2, err)
+				err = fmt.Errorf("pkg.DB.Start() return err=%s,wrap=%w", err2, err)
 				return
 			}
 		}
 	}()
 
 	// move pgdata to temp
-	if err = pkg.OG.MvPgDataToTemp(); err != nil {
-		err = fmt.Errorf("pkg.OG.MvPgDataToTemp return err=%w", err)
+	if err = db.MvPgDataToTemp(); err != nil {
+		err = fmt.Errorf("pkg.DB.MvPgDataToTemp return err=%w", err)
 		return
 	}
 
 	var status = "restoring"
 	defer func() {
 		if status != "restore success" {
-			err2 := pkg.OG.MvTempToPgData()
-			err = fmt.Errorf("resotre failre[err=%s],pkg.OG.MvTempToPgData return err=%w", err, err2)
+			err2 := db.MvTempToPgData()
+			err = fmt.Errorf("resotre failre[err=%s],pkg.DB.MvTempToPgData return err=%w", err, err2)
 		}
 	}()
 
 	// restore data from backup
-	if err = pkg.OG.Restore(in.DnBackupPath, in.Instance, in.DnBackupID); err != nil {
-		efmt := "pkg.OG.Restore failure[path=%s,instance=%s,backupID=%s],err=%w"
+	job.Log("Restoring data from backup...")
+	target := in.RecoveryTarget.ToModel()
+	if err = db.Restore(in.DnBackupPath, in.Instance, in.DnBackupID, target, job); err != nil {
+		efmt := "pkg.DB.Restore failure[path=%s,instance=%s,backupID=%s],err=%w"
 		err = fmt.Errorf(efmt, in.DnBackupPath, in.Instance, in.DnBackupID, err)
 		status = "restore failure"
 		return
@@ -88,15 +106,25 @@ func Restore(ctx *fiber.Ctx) (err error) {
 	status = "restore success"
 
 	// clean temp
-	if err = pkg.OG.CleanPgDataTemp(); err != nil {
-		err = fmt.Errorf("pkg.OG.CleanPgDataTemp return err=%w", err)
+	if err = db.CleanPgDataTemp(); err != nil {
+		err = fmt.Errorf("pkg.DB.CleanPgDataTemp return err=%w", err)
 		return
 	}
 
-	if err = pkg.OG.Start(); err != nil {
-		err = fmt.Errorf("pkg.OG.Start return err=%w", err)
+	job.Log("Starting database...")
+	if err = db.Start(); err != nil {
+		err = fmt.Errorf("pkg.DB.Start return err=%w", err)
 		return
 	}
 
-	return responder.Success(ctx, nil)
+	if target.IsEmpty() {
+		return nil, nil
+	}
+
+	// the data has been restored, so only warn if the result can not be queried.
+	result, err2 := db.ShowRecoveryResult(in.Username, in.Password, in.DBName, in.DBPort)
+	if err2 != nil {
+		logging.Field(logging.ErrorKey, err2.Error()).Warn("pkg.DB.ShowRecoveryResult failure")
+	}
+	return view.NewRestoreOut(result), nil
 }
@@ -41,14 +41,19 @@ func Show(ctx *fiber.Ctx) error {
 		return fmt.Errorf("invalid parameter,err=%w", err)
 	}
 
-	if err := pkg.OG.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
-		efmt := "pkg.OG.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
 		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
 	}
 
-	data, err := pkg.OG.ShowBackup(in.DnBackupPath, in.Instance, in.DnBackupID)
+	data, err := db.ShowBackup(in.DnBackupPath, in.Instance, in.DnBackupID)
 	if err != nil {
-		efmt := "pkg.OG.ShowBackupDetail failure[backupPath=%s,instance=%s,backupID=%s],err=%w"
+		efmt := "pkg.DB.ShowBackupDetail failure[backupPath=%s,instance=%s,backupID=%s],err=%w"
 		return fmt.Errorf(efmt, in.DnBackupPath, in.Instance, in.DnBackupID, err)
 	}
 
@@ -66,15 +71,20 @@ func ShowList(ctx *fiber.Ctx) error {
 		return fmt.Errorf("invalid parameter,err=%w", err)
 	}
 
-	if err := pkg.OG.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
-		efmt := "pkg.OG.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
 		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
 	}
 
 	//Show list
-	list, err := pkg.OG.ShowBackupList(in.DnBackupPath, in.Instance)
+	list, err := db.ShowBackupList(in.DnBackupPath, in.Instance)
 	if err != nil {
-		efmt := "pkg.OG.ShowBackupList failure[backupPath=%s,instance=%s],err=%w"
+		efmt := "pkg.DB.ShowBackupList failure[backupPath=%s,instance=%s],err=%w"
 		return fmt.Errorf(efmt, in.DnBackupPath, in.Instance, err)
 	}
 
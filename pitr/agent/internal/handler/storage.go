@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/s3util"
+	"github.com/gofiber/fiber/v2"
+)
+
+func PushBackupSet(ctx *fiber.Ctx) error {
+	in, storage, err := parseBackupSetIn(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.PushBackupSet(in.DnBackupPath, in.Instance, in.DnBackupID); err != nil {
+		efmt := "storage.PushBackupSet failure[path=%s,instance=%s,backupID=%s],err=%w"
+		return fmt.Errorf(efmt, in.DnBackupPath, in.Instance, in.DnBackupID, err)
+	}
+
+	return responder.Success(ctx, "")
+}
+
+func PullBackupSet(ctx *fiber.Ctx) error {
+	in, storage, err := parseBackupSetIn(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.PullBackupSet(in.DnBackupPath, in.Instance, in.DnBackupID); err != nil {
+		efmt := "storage.PullBackupSet failure[path=%s,instance=%s,backupID=%s],err=%w"
+		return fmt.Errorf(efmt, in.DnBackupPath, in.Instance, in.DnBackupID, err)
+	}
+
+	return responder.Success(ctx, "")
+}
+
+func parseBackupSetIn(ctx *fiber.Ctx) (*view.BackupSetIn, pkg.IStorage, error) {
+	in := &view.BackupSetIn{}
+	if err := ctx.BodyParser(in); err != nil {
+		return nil, nil, fmt.Errorf("body parse err=%s,wrap=%w", err, cons.BodyParseFailed)
+	}
+
+	if err := in.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid parameter,err=%w", err)
+	}
+
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+		return nil, nil, fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
+	}
+
+	cli, err := s3util.NewClient(&s3util.Config{
+		Endpoint:  in.Storage.Endpoint,
+		Region:    in.Storage.Region,
+		AccessKey: in.Storage.AccessKey,
+		SecretKey: in.Storage.SecretKey,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("s3util.NewClient return err=%s,wrap=%w", err, cons.InvalidStorage)
+	}
+	return in, pkg.NewStorage(cli, in.Storage.Bucket, in.Storage.Prefix, logging.Log()), nil
+}
@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/s3util"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backup set", func() {
+	var (
+		mockOG *mock_pkg.MockIDatabase
+		s3     *httptest.Server
+		src    string
+		dst    string
+	)
+
+	writeFile := func(root, rel, content string) {
+		path := filepath.Join(root, rel)
+		Expect(os.MkdirAll(filepath.Dir(path), 0700)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+	}
+
+	send := func(api, backupPath string) *http.Response {
+		body := fmt.Sprintf(`{
+			"db_port": 5432,
+			"db_name": "postgres",
+			"username": "user",
+			"password": "password",
+			"dn_backup_path": "%s",
+			"dn_backup_id": "RUS2A1",
+			"instance": "ins-default-ss",
+			"storage": {
+				"endpoint": "%s",
+				"bucket": "pitr",
+				"access_key": "ak",
+				"secret_key": "sk",
+				"prefix": "gs_pitr/backup_sets/127.0.0.1_5432"
+			}
+		}`, backupPath, s3.URL)
+		req := httptest.NewRequest(http.MethodPost, api, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		Expect(err).To(BeNil())
+		return resp
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockOG = mock_pkg.NewMockIDatabase(ctrl)
+		pkg.DB = mockOG
+		mockOG.EXPECT().Auth(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		s3 = s3util.NewFakeServer()
+		src = GinkgoT().TempDir()
+		dst = GinkgoT().TempDir()
+	})
+	AfterEach(func() {
+		s3.Close()
+		ctrl.Finish()
+	})
+
+	It("push and pull backup set", func() {
+		writeFile(src, "backups/ins-default-ss/pg_probackup.conf", "conf")
+		writeFile(src, "backups/ins-default-ss/RUS2A1/backup.control", "control")
+		writeFile(src, "backups/ins-default-ss/RUS2A1/database/base/1/1249", "data")
+		writeFile(src, "wal/ins-default-ss/000000010000000000000002", "wal")
+
+		Expect(send("/api/backup/push", src).StatusCode).To(Equal(http.StatusOK))
+
+		// the wal already in the backup path is kept
+		writeFile(dst, "wal/ins-default-ss/000000010000000000000002", "local wal")
+		Expect(send("/api/backup/pull", dst).StatusCode).To(Equal(http.StatusOK))
+
+		for rel, content := range map[string]string{
+			"backups/ins-default-ss/pg_probackup.conf":           "conf",
+			"backups/ins-default-ss/RUS2A1/backup.control":       "control",
+			"backups/ins-default-ss/RUS2A1/database/base/1/1249": "data",
+			"wal/ins-default-ss/000000010000000000000002":        "local wal",
+		} {
+			data, err := os.ReadFile(filepath.Join(dst, rel))
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(content))
+		}
+	})
+
+	It("push a backup not exist", func() {
+		Expect(send("/api/backup/push", src).StatusCode).NotTo(Equal(http.StatusOK))
+	})
+
+	It("pull a backup not exist", func() {
+		Expect(send("/api/backup/pull", dst).StatusCode).NotTo(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+)
+
+/*
+Verify check the backup is restorable in background, the job id is returned at once.
+
+The result of every step is reported as the result of the job instead of an error,
+so that the failure of a backup is told apart from the failure of the job.
+*/
+func Verify(ctx *fiber.Ctx) error {
+	in := &view.VerifyIn{}
+	if err := ctx.BodyParser(in); err != nil {
+		return fmt.Errorf("body parse err=%s,wrap=%w", err, cons.BodyParseFailed)
+	}
+
+	if err := in.Validate(); err != nil {
+		return fmt.Errorf("invalid parameter,err=%w", err)
+	}
+
+	db, err := pkg.GetDB(in.DBPort)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Auth(in.Username, in.Password, in.DBName, in.DBPort); err != nil {
+		efmt := "pkg.DB.Auth failure[un=%s,pw.len=%d,db=%s],err=%w"
+		return fmt.Errorf(efmt, in.Username, len(in.Password), in.DBName, err)
+	}
+
+	job := pkg.Jobs.Submit(cons.JobKindVerify, pkg.JobScope{Instance: in.Instance, DBPort: in.DBPort}, func(job *pkg.Job) (interface{}, error) {
+		return verify(db, in, job), nil
+	})
+	return responder.Success(ctx, view.NewJobOut(job.Snapshot()))
+}
+
+func verify(db pkg.IDatabase, in *view.VerifyIn, job *pkg.Job) *view.VerifyOut {
+	out := &view.VerifyOut{
+		Validate:    view.VerifyStatusFailed,
+		Restore:     view.VerifyStatusSkipped,
+		CheckSchema: view.VerifyStatusSkipped,
+	}
+
+	job.Log("Validating backup...")
+	if err := db.ValidateBackup(in.DnBackupPath, in.Instance, in.DnBackupID); err != nil {
+		out.Reason = err.Error()
+		return out
+	}
+	out.Validate = view.VerifyStatusOK
+
+	if !in.Restore {
+		return out
+	}
+
+	out.Restore = view.VerifyStatusFailed
+	out.ScratchPort = in.ScratchPort
+	if out.ScratchPort == 0 {
+		port, err := sparePort()
+		if err != nil {
+			out.Reason = err.Error()
+			return out
+		}
+		out.ScratchPort = port
+	}
+
+	job.Log(fmt.Sprintf("Restoring backup into scratch pgdata on port %d...", out.ScratchPort))
+	pgData, err := db.RestoreScratch(in.DnBackupPath, in.Instance, in.DnBackupID, out.ScratchPort)
+	if err != nil {
+		out.Reason = err.Error()
+		return out
+	}
+	defer func() {
+		if err := db.CleanScratch(pgData); err != nil {
+			logging.Field(logging.ErrorKey, err.Error()).Warn("pkg.DB.CleanScratch failure")
+		}
+	}()
+	out.Restore = view.VerifyStatusOK
+
+	job.Log("Checking schemas...")
+	out.CheckSchema = view.VerifyStatusOK
+	for _, s := range in.Schemas {
+		if err := db.CheckSchema(in.Username, in.Password, in.DBName, out.ScratchPort, db.SchemaQuery(s)); err != nil {
+			out.CheckSchema = view.VerifyStatusFailed
+			out.Reason = fmt.Sprintf("schema[%s] not found,err=%s", s, err)
+			break
+		}
+	}
+	return out
+}
+
+func sparePort() (uint16, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("net.Listen return err=%s,wrap=%w", err, cons.Internal)
+	}
+	defer l.Close()
+
+	//nolint:forcetypeassert
+	return uint16(l.Addr().(*net.TCPAddr).Port), nil
+}
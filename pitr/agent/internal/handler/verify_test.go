@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Verify", func() {
+	var mockOG *mock_pkg.MockIDatabase
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockOG = mock_pkg.NewMockIDatabase(ctrl)
+		pkg.DB = mockOG
+	})
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	verify := func(body string) *view.VerifyOut {
+		req := httptest.NewRequest(http.MethodPost, "/api/verify", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+		out := struct {
+			Code int     `json:"code"`
+			Data *jobOut `json:"data"`
+		}{}
+		Expect(json.Unmarshal(data, &out)).To(Succeed())
+		Expect(out.Code).To(Equal(0))
+		Expect(out.Data.Kind).To(Equal(cons.JobKindVerify))
+
+		job := waitJob(out.Data.ID)
+		Expect(job.State).To(Equal(cons.JobStateSucceeded))
+		result := &view.VerifyOut{}
+		Expect(json.Unmarshal(job.Result, result)).To(Succeed())
+		return result
+	}
+
+	newBody := func(restore bool, schemas string) string {
+		return `{
+			"db_port": 5432,
+			"db_name": "test_db",
+			"username": "user",
+			"password": "password",
+			"dn_backup_path": "/tmp",
+			"dn_backup_id": "backup-id",
+			"instance": "instance",
+			"restore": ` + map[bool]string{true: "true", false: "false"}[restore] + `,
+			"scratch_port": 15432,
+			"schemas": [` + schemas + `]
+		}`
+	}
+
+	It("invalid schema", func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/verify", strings.NewReader(newBody(true, `"public'; DROP TABLE t; --"`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(500))
+	})
+
+	It("validate only", func() {
+		mockOG.EXPECT().Auth(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOG.EXPECT().ValidateBackup("/tmp", "instance", "backup-id").Return(nil)
+
+		out := verify(newBody(false, ""))
+		Expect(out.Validate).To(Equal(view.VerifyStatusOK))
+		Expect(out.Restore).To(Equal(view.VerifyStatusSkipped))
+	})
+
+	It("validate failed", func() {
+		mockOG.EXPECT().Auth(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOG.EXPECT().ValidateBackup("/tmp", "instance", "backup-id").Return(cons.ValidateBackupFailed)
+
+		out := verify(newBody(true, `"public"`))
+		Expect(out.Validate).To(Equal(view.VerifyStatusFailed))
+		Expect(out.Restore).To(Equal(view.VerifyStatusSkipped))
+		Expect(out.Reason).NotTo(BeEmpty())
+	})
+
+	It("restore and check schemas", func() {
+		mockOG.EXPECT().Auth(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOG.EXPECT().ValidateBackup("/tmp", "instance", "backup-id").Return(nil)
+		mockOG.EXPECT().RestoreScratch("/tmp", "instance", "backup-id", uint16(15432)).Return("/tmp/verify_backup-id_1", nil)
+		mockOG.EXPECT().SchemaQuery(gomock.Any()).Return("SELECT 1").Times(2)
+		mockOG.EXPECT().CheckSchema("user", "password", "test_db", uint16(15432), "SELECT 1").Return(nil)
+		mockOG.EXPECT().CheckSchema("user", "password", "test_db", uint16(15432), "SELECT 1").Return(errors.New("division by zero"))
+		mockOG.EXPECT().CleanScratch("/tmp/verify_backup-id_1").Return(nil)
+
+		out := verify(newBody(true, `"public", "sharding_db"`))
+		Expect(out.Restore).To(Equal(view.VerifyStatusOK))
+		Expect(out.CheckSchema).To(Equal(view.VerifyStatusFailed))
+		Expect(out.Reason).To(ContainSubstring("sharding_db"))
+	})
+})
@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package view
+
+import (
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+type (
+	ArchiveIn struct {
+		DBPort   uint16 `json:"db_port"`
+		DBName   string `json:"db_name"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+
+		DnBackupPath string `json:"dn_backup_path"`
+		Instance     string `json:"instance"`
+	}
+
+	ArchiveStatusOut struct {
+		ArchiveMode      string         `json:"archive_mode"`
+		ArchiveCommand   string         `json:"archive_command"`
+		CurrentSegment   string         `json:"current_segment"`
+		LatestArchived   string         `json:"latest_archived_segment"`
+		LagSegments      uint64         `json:"lag_segments"`
+		ArchivedSegments int            `json:"archived_segments"`
+		LostSegments     []LostSegments `json:"lost_segments"`
+		Broken           bool           `json:"broken"`
+		Reason           string         `json:"reason"`
+	}
+
+	LostSegments struct {
+		BeginSegment string `json:"begin_segment"`
+		EndSegment   string `json:"end_segment"`
+	}
+)
+
+//nolint:dupl
+func (in *ArchiveIn) Validate() error {
+	if in == nil {
+		return cons.Internal
+	}
+
+	if in.DBPort == 0 {
+		return cons.InvalidDBPort
+	}
+
+	if in.DBName == "" {
+		return cons.MissingDBName
+	}
+
+	if in.Username == "" {
+		return cons.MissingUsername
+	}
+
+	if in.Password == "" {
+		return cons.MissingPassword
+	}
+
+	if in.DnBackupPath == "" {
+		return cons.MissingDnBackupPath
+	}
+
+	if err := validateBackupPath(in.DnBackupPath); err != nil {
+		return err
+	}
+
+	if in.Instance == "" {
+		return cons.MissingInstance
+	}
+
+	if err := validateInstance(in.Instance); err != nil {
+		return err
+	}
+	return nil
+}
+
+func NewArchiveStatusOut(data *model.ArchiveStatus) *ArchiveStatusOut {
+	if data == nil {
+		return nil
+	}
+	lost := make([]LostSegments, 0, len(data.LostSegments))
+	for _, v := range data.LostSegments {
+		lost = append(lost, LostSegments{
+			BeginSegment: v.BeginSegno,
+			EndSegment:   v.EndSegno,
+		})
+	}
+	return &ArchiveStatusOut{
+		ArchiveMode:      data.ArchiveMode,
+		ArchiveCommand:   data.ArchiveCommand,
+		CurrentSegment:   data.CurrentSegment,
+		LatestArchived:   data.LatestArchived,
+		LagSegments:      data.LagSegments,
+		ArchivedSegments: data.ArchivedSegments,
+		LostSegments:     lost,
+		Broken:           data.Broken,
+		Reason:           data.Reason,
+	}
+}
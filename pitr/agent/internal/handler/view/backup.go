@@ -19,6 +19,7 @@ package view
 
 import (
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
 )
 
 type (
@@ -32,10 +33,19 @@ type (
 		DnThreadsNum uint8  `json:"dn_threads_num"`
 		DnBackupMode string `json:"dn_backup_mode"`
 		Instance     string `json:"instance"`
+
+		// the compress algorithm and level of data files, gs_probackup does not compress if empty.
+		DnCompressAlg   string `json:"dn_compress_alg"`
+		DnCompressLevel uint8  `json:"dn_compress_level"`
+		// DnStream stream the wal generated during backup into the backup set.
+		DnStream bool `json:"dn_stream"`
+		// DnSkipBlockValidation skip the page-level checksums validation of data files.
+		DnSkipBlockValidation bool `json:"dn_skip_block_validation"`
 	}
 
 	BackupOut struct {
-		ID string `json:"backup_id"`
+		ID    string `json:"backup_id"`
+		JobID string `json:"job_id"`
 	}
 
 	DeleteBackupIn struct {
@@ -75,6 +85,10 @@ func (in *BackupIn) Validate() error {
 		return cons.MissingDnBackupPath
 	}
 
+	if err := validateBackupPath(in.DnBackupPath); err != nil {
+		return err
+	}
+
 	if in.DnThreadsNum == 0 {
 		return cons.InvalidDnThreadsNum
 	}
@@ -90,9 +104,33 @@ func (in *BackupIn) Validate() error {
 	if in.Instance == "" {
 		return cons.MissingInstance
 	}
+
+	if err := validateInstance(in.Instance); err != nil {
+		return err
+	}
+
+	switch in.DnCompressAlg {
+	case "", cons.CompressAlgNone, cons.CompressAlgZlib, cons.CompressAlgPglz:
+	default:
+		return cons.InvalidCompressAlg
+	}
+
+	if in.DnCompressLevel > cons.MaxCompressLevel {
+		return cons.InvalidCompressLevel
+	}
 	return nil
 }
 
+func (in *BackupIn) ToOptions() *model.BackupOptions {
+	return &model.BackupOptions{
+		ThreadsNum:          in.DnThreadsNum,
+		CompressAlg:         in.DnCompressAlg,
+		CompressLevel:       in.DnCompressLevel,
+		Stream:              in.DnStream,
+		SkipBlockValidation: in.DnSkipBlockValidation,
+	}
+}
+
 // nolint:dupl
 func (in *DeleteBackupIn) Validate() error {
 	if in == nil {
@@ -119,12 +157,24 @@ func (in *DeleteBackupIn) Validate() error {
 		return cons.MissingDnBackupPath
 	}
 
+	if err := validateBackupPath(in.DnBackupPath); err != nil {
+		return err
+	}
+
 	if in.BackupID == "" {
 		return cons.MissingBackupID
 	}
 
+	if err := validateBackupID(in.BackupID); err != nil {
+		return err
+	}
+
 	if in.Instance == "" {
 		return cons.MissingInstance
 	}
+
+	if err := validateInstance(in.Instance); err != nil {
+		return err
+	}
 	return nil
 }
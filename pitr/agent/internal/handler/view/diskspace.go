@@ -19,16 +19,79 @@ package view
 
 import (
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
 )
 
-type DiskSpaceIn struct {
-	// DiskPath is the path of the disk
-	DiskPath string `json:"diskPath"`
-}
+type (
+	DiskSpaceIn struct {
+		// DiskPath is the path of the disk
+		DiskPath string `json:"diskPath"`
+		// DBPort is required if the agent manages more than one openGauss instance
+		DBPort uint16 `json:"db_port"`
+		// Instance and DnBackupID are optional, they are used to estimate the bytes of backup or restore
+		Instance   string `json:"instance"`
+		DnBackupID string `json:"dn_backup_id"`
+	}
+
+	DiskSpaceOut struct {
+		BackupPath     *DiskUsage `json:"backup_path"`
+		PgData         *DiskUsage `json:"pgdata"`
+		EstimatedBytes int64      `json:"estimated_bytes"`
+	}
+
+	DiskUsage struct {
+		Path       string `json:"path"`
+		TotalBytes uint64 `json:"total_bytes"`
+		UsedBytes  uint64 `json:"used_bytes"`
+		FreeBytes  uint64 `json:"free_bytes"`
+	}
+)
 
 func (in *DiskSpaceIn) Validate() error {
 	if in.DiskPath == "" {
 		return cons.MissingDiskPath
 	}
+
+	if err := validateDiskPath(in.DiskPath); err != nil {
+		return err
+	}
+
+	if in.Instance != "" {
+		if err := validateInstance(in.Instance); err != nil {
+			return err
+		}
+	}
+
+	if in.DnBackupID != "" {
+		if in.Instance == "" {
+			return cons.MissingInstance
+		}
+		if err := validateBackupID(in.DnBackupID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func NewDiskSpaceOut(ds *model.DiskSpace) *DiskSpaceOut {
+	if ds == nil {
+		return nil
+	}
+	return &DiskSpaceOut{
+		BackupPath:     newDiskUsage(ds.BackupPath),
+		PgData:         newDiskUsage(ds.PgData),
+		EstimatedBytes: ds.EstimatedBytes,
+	}
+}
+
+func newDiskUsage(u *model.DiskUsage) *DiskUsage {
+	if u == nil {
+		return nil
+	}
+	return &DiskUsage{
+		Path:       u.Path,
+		TotalBytes: u.TotalBytes,
+		UsedBytes:  u.UsedBytes,
+		FreeBytes:  u.FreeBytes,
+	}
+}
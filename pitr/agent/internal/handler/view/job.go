@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package view
+
+import (
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+type (
+	JobOut struct {
+		ID        string      `json:"job_id"`
+		Kind      string      `json:"kind"`
+		State     string      `json:"state"`
+		Progress  JobProgress `json:"progress"`
+		Result    interface{} `json:"result,omitempty"`
+		Error     string      `json:"error,omitempty"`
+		Lines     int         `json:"lines"`
+		StartTime string      `json:"start_time"`
+		EndTime   string      `json:"end_time,omitempty"`
+	}
+
+	JobProgress struct {
+		Done  int64 `json:"done"`
+		Total int64 `json:"total"`
+	}
+)
+
+func NewJobOut(data *model.Job) *JobOut {
+	if data == nil {
+		return nil
+	}
+	out := &JobOut{
+		ID:    data.ID,
+		Kind:  data.Kind,
+		State: data.State,
+		Progress: JobProgress{
+			Done:  data.Progress.Done,
+			Total: data.Progress.Total,
+		},
+		Result:    data.Result,
+		Error:     data.Error,
+		Lines:     data.Lines,
+		StartTime: data.StartTime.Format(time.RFC3339),
+	}
+	if !data.EndTime.IsZero() {
+		out.EndTime = data.EndTime.Format(time.RFC3339)
+	}
+	return out
+}
@@ -17,17 +17,58 @@
 
 package view
 
-import "github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
-
-type RestoreIn struct {
-	DBPort       uint16 `json:"db_port"`
-	DBName       string `json:"db_name"`
-	Username     string `json:"username"`
-	Password     string `json:"password"`
-	Instance     string `json:"instance"`
-	DnBackupPath string `json:"dn_backup_path"`
-	DnBackupID   string `json:"dn_backup_id"`
-}
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+type (
+	RestoreIn struct {
+		DBPort       uint16 `json:"db_port"`
+		DBName       string `json:"db_name"`
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		Instance     string `json:"instance"`
+		DnBackupPath string `json:"dn_backup_path"`
+		DnBackupID   string `json:"dn_backup_id"`
+
+		// RecoveryTarget is optional, restore to the end of the backup if it is nil.
+		RecoveryTarget *RecoveryTarget `json:"recovery_target,omitempty"`
+	}
+
+	RecoveryTarget struct {
+		Time      string `json:"time,omitempty"`
+		LSN       string `json:"lsn,omitempty"`
+		Xid       string `json:"xid,omitempty"`
+		Name      string `json:"name,omitempty"`
+		CSN       string `json:"csn,omitempty"`
+		Position  string `json:"position,omitempty"`
+		GTID      string `json:"gtid,omitempty"`
+		Inclusive *bool  `json:"inclusive,omitempty"`
+	}
+
+	RestoreOut struct {
+		InRecovery bool   `json:"in_recovery"`
+		ReplayLSN  string `json:"replay_lsn"`
+		ReplayTime string `json:"replay_time"`
+	}
+)
+
+// RecoveryTimeLayouts are the accepted layouts of recovery target time.
+var RecoveryTimeLayouts = model.RecoveryTimeLayouts
+
+var (
+	lsnRegex              = regexp.MustCompile(`^[0-9A-Fa-f]{1,8}/[0-9A-Fa-f]{1,8}$`)
+	xidRegex              = regexp.MustCompile(`^[0-9]+$`)
+	csnRegex              = regexp.MustCompile(`^[0-9]+$`)
+	restorePointNameRegex = regexp.MustCompile(`^[\w.-]{1,63}$`)
+	positionRegex         = regexp.MustCompile(`^[\w.-]+\.[0-9]{6}:[0-9]+$`)
+	gtidRegex             = regexp.MustCompile(`^[0-9A-Fa-f-]{36}(:[0-9]+(-[0-9]+)?)+(,[0-9A-Fa-f-]{36}(:[0-9]+(-[0-9]+)?)+)*$`)
+)
 
 //nolint:dupl
 func (in *RestoreIn) Validate() error {
@@ -55,12 +96,106 @@ func (in *RestoreIn) Validate() error {
 		return cons.MissingDnBackupPath
 	}
 
+	if err := validateBackupPath(in.DnBackupPath); err != nil {
+		return err
+	}
+
 	if in.DnBackupID == "" {
 		return cons.MissingDnBackupID
 	}
 
+	if err := validateBackupID(in.DnBackupID); err != nil {
+		return err
+	}
+
 	if in.Instance == "" {
 		return cons.MissingInstance
 	}
+
+	if err := validateInstance(in.Instance); err != nil {
+		return err
+	}
+	return in.RecoveryTarget.Validate()
+}
+
+func (t *RecoveryTarget) Validate() error {
+	if t == nil {
+		return nil
+	}
+
+	var n int
+	for _, v := range []string{t.Time, t.LSN, t.Xid, t.Name, t.CSN, t.Position, t.GTID} {
+		if v != "" {
+			n++
+		}
+	}
+	if n > 1 {
+		return fmt.Errorf("only one of time, lsn, xid, name, csn, position and gtid can be specified,err=%w", cons.InvalidRecoveryTarget)
+	}
+
+	if t.Time != "" {
+		if _, err := ParseRecoveryTime(t.Time); err != nil {
+			return err
+		}
+	}
+	if t.LSN != "" && !lsnRegex.MatchString(t.LSN) {
+		return fmt.Errorf("invalid lsn[%s],err=%w", t.LSN, cons.InvalidRecoveryTarget)
+	}
+	if t.Xid != "" && !xidRegex.MatchString(t.Xid) {
+		return fmt.Errorf("invalid xid[%s],err=%w", t.Xid, cons.InvalidRecoveryTarget)
+	}
+	if t.Name != "" && !restorePointNameRegex.MatchString(t.Name) {
+		return fmt.Errorf("invalid restore point name[%s],err=%w", t.Name, cons.InvalidRecoveryTarget)
+	}
+	if t.CSN != "" && !csnRegex.MatchString(t.CSN) {
+		return fmt.Errorf("invalid csn[%s],err=%w", t.CSN, cons.InvalidRecoveryTarget)
+	}
+	if t.Position != "" && !positionRegex.MatchString(t.Position) {
+		return fmt.Errorf("invalid binlog position[%s],err=%w", t.Position, cons.InvalidRecoveryTarget)
+	}
+	if t.GTID != "" && !gtidRegex.MatchString(t.GTID) {
+		return fmt.Errorf("invalid gtid[%s],err=%w", t.GTID, cons.InvalidRecoveryTarget)
+	}
 	return nil
 }
+
+// ToModel convert the recovery target to model, the target is inclusive by default.
+func (t *RecoveryTarget) ToModel() *model.RecoveryTarget {
+	if t == nil {
+		return nil
+	}
+	inclusive := true
+	if t.Inclusive != nil {
+		inclusive = *t.Inclusive
+	}
+	return &model.RecoveryTarget{
+		Time:      t.Time,
+		LSN:       t.LSN,
+		Xid:       t.Xid,
+		Name:      t.Name,
+		CSN:       t.CSN,
+		Position:  t.Position,
+		GTID:      t.GTID,
+		Inclusive: inclusive,
+	}
+}
+
+func ParseRecoveryTime(s string) (time.Time, error) {
+	for _, layout := range RecoveryTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time[%s],err=%w", s, cons.InvalidRecoveryTarget)
+}
+
+func NewRestoreOut(r *model.RecoveryResult) *RestoreOut {
+	if r == nil {
+		return nil
+	}
+	return &RestoreOut{
+		InRecovery: r.InRecovery,
+		ReplayLSN:  r.ReplayLSN,
+		ReplayTime: r.ReplayTime,
+	}
+}
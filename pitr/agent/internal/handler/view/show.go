@@ -43,13 +43,22 @@ type (
 	}
 
 	BackupInfo struct {
-		ID        string `json:"dn_backup_id"`
-		Path      string `json:"dn_backup_path"`
-		Mode      string `json:"db_backup_mode"`
-		Instance  string `json:"instance"`
-		StartTime string `json:"start_time"`
-		EndTime   string `json:"end_time"`
-		Status    string `json:"status"`
+		ID           string `json:"dn_backup_id"`
+		Path         string `json:"dn_backup_path"`
+		Mode         string `json:"db_backup_mode"`
+		ParentID     string `json:"parent_dn_backup_id"` // the backup which a PTRACK backup depends on
+		Instance     string `json:"instance"`
+		StartTime    string `json:"start_time"`
+		EndTime      string `json:"end_time"`
+		Status       string `json:"status"`
+		StartLsn     string `json:"start_lsn"`
+		StopLsn      string `json:"stop_lsn"`
+		RecoveryTime string `json:"recovery_time"`
+		RecoveryXid  int    `json:"recovery_xid"`
+
+		CompressAlg       string `json:"compress_alg"`
+		DataBytes         int    `json:"data_bytes"`
+		UncompressedBytes int    `json:"uncompressed_bytes"`
 	}
 )
 
@@ -79,13 +88,25 @@ func (in *ShowIn) Validate() error {
 		return cons.MissingDnBackupPath
 	}
 
+	if err := validateBackupPath(in.DnBackupPath); err != nil {
+		return err
+	}
+
 	if in.DnBackupID == "" {
 		return cons.MissingDnBackupID
 	}
 
+	if err := validateBackupID(in.DnBackupID); err != nil {
+		return err
+	}
+
 	if in.Instance == "" {
 		return cons.MissingInstance
 	}
+
+	if err := validateInstance(in.Instance); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -94,13 +115,22 @@ func NewBackupInfo(data *model.Backup, path, instance string) *BackupInfo {
 		return nil
 	}
 	return &BackupInfo{
-		ID:        data.ID,
-		Path:      path,
-		Mode:      data.BackupMode,
-		Instance:  instance,
-		StartTime: data.StartTime,
-		EndTime:   data.EndTime,
-		Status:    statusTrans(data.Status),
+		ID:           data.ID,
+		Path:         path,
+		Mode:         data.BackupMode,
+		ParentID:     data.ParentBackupID,
+		Instance:     instance,
+		StartTime:    data.StartTime,
+		EndTime:      data.EndTime,
+		Status:       statusTrans(data.Status),
+		StartLsn:     data.StartLsn,
+		StopLsn:      data.StopLsn,
+		RecoveryTime: data.RecoveryTime,
+		RecoveryXid:  data.RecoveryXid,
+
+		CompressAlg:       data.CompressAlg,
+		DataBytes:         data.DataBytes,
+		UncompressedBytes: data.UncompressedBytes,
 	}
 }
 
@@ -111,13 +141,22 @@ func NewBackupInfoList(list []*model.Backup, path, instance string) []BackupInfo
 	ret := make([]BackupInfo, 0, len(list))
 	for _, v := range list {
 		ret = append(ret, BackupInfo{
-			ID:        v.ID,
-			Path:      path,
-			Mode:      v.BackupMode,
-			Instance:  instance,
-			StartTime: v.StartTime,
-			EndTime:   v.EndTime,
-			Status:    statusTrans(v.Status),
+			ID:           v.ID,
+			Path:         path,
+			Mode:         v.BackupMode,
+			ParentID:     v.ParentBackupID,
+			Instance:     instance,
+			StartTime:    v.StartTime,
+			EndTime:      v.EndTime,
+			Status:       statusTrans(v.Status),
+			StartLsn:     v.StartLsn,
+			StopLsn:      v.StopLsn,
+			RecoveryTime: v.RecoveryTime,
+			RecoveryXid:  v.RecoveryXid,
+
+			CompressAlg:       v.CompressAlg,
+			DataBytes:         v.DataBytes,
+			UncompressedBytes: v.UncompressedBytes,
 		})
 	}
 	return ret
@@ -161,8 +200,16 @@ func (in *ShowListIn) Validate() error {
 		return cons.MissingDnBackupPath
 	}
 
+	if err := validateBackupPath(in.DnBackupPath); err != nil {
+		return err
+	}
+
 	if in.Instance == "" {
 		return cons.MissingInstance
 	}
+
+	if err := validateInstance(in.Instance); err != nil {
+		return err
+	}
 	return nil
 }
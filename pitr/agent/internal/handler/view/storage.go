@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+)
+
+type (
+	// BackupSetIn push or pull the backup set of dn_backup_id to or from the remote storage.
+	BackupSetIn struct {
+		DBPort   uint16 `json:"db_port"`
+		DBName   string `json:"db_name"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+
+		DnBackupPath string `json:"dn_backup_path"`
+		DnBackupID   string `json:"dn_backup_id"`
+		Instance     string `json:"instance"`
+
+		Storage *StorageIn `json:"storage"`
+	}
+
+	// StorageIn is a S3 compatible object storage, the objects are kept under the prefix of the bucket.
+	StorageIn struct {
+		Endpoint  string `json:"endpoint"`
+		Region    string `json:"region"`
+		Bucket    string `json:"bucket"`
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+		Prefix    string `json:"prefix"`
+	}
+)
+
+//nolint:dupl
+func (in *BackupSetIn) Validate() error {
+	if in == nil {
+		return cons.Internal
+	}
+
+	if in.DBPort == 0 {
+		return cons.InvalidDBPort
+	}
+
+	if in.DBName == "" {
+		return cons.MissingDBName
+	}
+
+	if in.Username == "" {
+		return cons.MissingUsername
+	}
+
+	if in.Password == "" {
+		return cons.MissingPassword
+	}
+
+	if in.DnBackupPath == "" {
+		return cons.MissingDnBackupPath
+	}
+
+	if err := validateBackupPath(in.DnBackupPath); err != nil {
+		return err
+	}
+
+	if in.DnBackupID == "" {
+		return cons.MissingDnBackupID
+	}
+
+	if err := validateBackupID(in.DnBackupID); err != nil {
+		return err
+	}
+
+	if in.Instance == "" {
+		return cons.MissingInstance
+	}
+
+	if err := validateInstance(in.Instance); err != nil {
+		return err
+	}
+	return in.Storage.Validate()
+}
+
+func (in *StorageIn) Validate() error {
+	if in == nil {
+		return fmt.Errorf("missing storage,err=%w", cons.InvalidStorage)
+	}
+
+	if in.Endpoint == "" || in.Bucket == "" {
+		return fmt.Errorf("missing storage endpoint or bucket,err=%w", cons.InvalidStorage)
+	}
+
+	if in.AccessKey == "" || in.SecretKey == "" {
+		return fmt.Errorf("missing storage access key or secret key,err=%w", cons.InvalidStorage)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package view
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+)
+
+var (
+	// pathRegex the backup path is also passed to the archive_command which is run by the shell of openGauss, so only safe chars are allowed.
+	pathRegex     = regexp.MustCompile(`^/[\w./-]*$`)
+	instanceRegex = regexp.MustCompile(`^[A-Za-z0-9][\w-]{0,63}$`)
+	backupIDRegex = regexp.MustCompile(`^[A-Za-z0-9][\w-]{0,63}$`)
+
+	// allowedRoots the paths in requests must be under one of them, no restriction if it is empty.
+	allowedRoots []string
+)
+
+// SetAllowedRoots the roots are cleaned, and empty ones are ignored.
+func SetAllowedRoots(roots []string) {
+	allowedRoots = allowedRoots[:0]
+	for _, r := range roots {
+		if r = strings.TrimSpace(r); r != "" {
+			allowedRoots = append(allowedRoots, filepath.Clean(r))
+		}
+	}
+}
+
+func validateInstance(instance string) error {
+	if !instanceRegex.MatchString(instance) {
+		return fmt.Errorf("invalid instance[%s],err=%w", instance, cons.InvalidInstance)
+	}
+	return nil
+}
+
+func validateBackupID(id string) error {
+	if !backupIDRegex.MatchString(id) {
+		return fmt.Errorf("invalid backup id[%s],err=%w", id, cons.InvalidDnBackupID)
+	}
+	return nil
+}
+
+func validateBackupPath(path string) error {
+	return validatePath(path, cons.InvalidDnBackupPath)
+}
+
+func validateDiskPath(path string) error {
+	return validatePath(path, cons.InvalidDiskPath)
+}
+
+// validatePath the path must be absolute, without `..` and under one of the allowed roots.
+func validatePath(path string, invalid error) error {
+	if !pathRegex.MatchString(path) {
+		return fmt.Errorf("invalid path[%s],err=%w", path, invalid)
+	}
+	for _, elem := range strings.Split(path, "/") {
+		if elem == ".." {
+			return fmt.Errorf("invalid path[%s],err=%w", path, invalid)
+		}
+	}
+
+	if len(allowedRoots) == 0 {
+		return nil
+	}
+	path = filepath.Clean(path)
+	for _, root := range allowedRoots {
+		if path == root || strings.HasPrefix(path, strings.TrimSuffix(root, "/")+"/") {
+			return nil
+		}
+	}
+	return fmt.Errorf("path[%s] is out of %v,err=%w", path, allowedRoots, cons.PathNotAllowed)
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package view
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+)
+
+const (
+	VerifyStatusOK      = "OK"
+	VerifyStatusFailed  = "Failed"
+	VerifyStatusSkipped = "Skipped"
+)
+
+type (
+	VerifyIn struct {
+		DBPort       uint16 `json:"db_port"`
+		DBName       string `json:"db_name"`
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		Instance     string `json:"instance"`
+		DnBackupPath string `json:"dn_backup_path"`
+		DnBackupID   string `json:"dn_backup_id"`
+
+		// Restore the backup into a scratch pgdata and check the schemas on it, otherwise only validate the backup.
+		Restore bool `json:"restore"`
+		// ScratchPort is the port of the scratch openGauss, a spare port is picked if it is 0.
+		ScratchPort uint16   `json:"scratch_port"`
+		Schemas     []string `json:"schemas"`
+	}
+
+	VerifyOut struct {
+		Validate    string `json:"validate"`
+		Restore     string `json:"restore"`
+		CheckSchema string `json:"check_schema"`
+		ScratchPort uint16 `json:"scratch_port"`
+		Reason      string `json:"reason"`
+	}
+)
+
+var schemaRegex = regexp.MustCompile(`^[A-Za-z_][\w$]{0,62}$`)
+
+//nolint:dupl
+func (in *VerifyIn) Validate() error {
+	if in == nil {
+		return cons.Internal
+	}
+
+	if in.DBPort == 0 {
+		return cons.InvalidDBPort
+	}
+
+	if in.DBName == "" {
+		return cons.MissingDBName
+	}
+
+	if in.Username == "" {
+		return cons.MissingUsername
+	}
+
+	if in.Password == "" {
+		return cons.MissingPassword
+	}
+
+	if in.DnBackupPath == "" {
+		return cons.MissingDnBackupPath
+	}
+
+	if err := validateBackupPath(in.DnBackupPath); err != nil {
+		return err
+	}
+
+	if in.DnBackupID == "" {
+		return cons.MissingDnBackupID
+	}
+
+	if err := validateBackupID(in.DnBackupID); err != nil {
+		return err
+	}
+
+	if in.Instance == "" {
+		return cons.MissingInstance
+	}
+
+	if err := validateInstance(in.Instance); err != nil {
+		return err
+	}
+
+	for _, s := range in.Schemas {
+		if !schemaRegex.MatchString(s) {
+			return fmt.Errorf("invalid schema[%s],err=%w", s, cons.InvalidSchema)
+		}
+	}
+	return nil
+}
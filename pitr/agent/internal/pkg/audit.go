@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+type (
+	auditor struct {
+		mu sync.Mutex
+		f  *os.File
+	}
+
+	// IAuditor write the audit records as json lines into an append-only file.
+	IAuditor interface {
+		Write(rec *model.AuditRecord) error
+		Close() error
+	}
+)
+
+const _redacted = "******"
+
+// _secretKeys the values of these keys are redacted from the params of audit records.
+var _secretKeys = []string{"password", "secret", "token", "access_key"}
+
+var _ IAuditor = (*auditor)(nil)
+
+func NewAuditor(file string) (IAuditor, error) {
+	f, err := os.OpenFile(filepath.Clean(file), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log failure,err=%w", err)
+	}
+	return &auditor{f: f}, nil
+}
+
+func (a *auditor) Write(rec *model.AuditRecord) error {
+	bs, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("json marshal audit record failure,err=%w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err = a.f.Write(append(bs, '\n')); err != nil {
+		return fmt.Errorf("write audit log failure,err=%w", err)
+	}
+	return a.f.Sync()
+}
+
+func (a *auditor) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.f.Close()
+}
+
+// RedactParams unmarshal the json body into params, the secrets in it are redacted.
+func RedactParams(body []byte) map[string]interface{} {
+	params := map[string]interface{}{}
+	if len(body) == 0 || json.Unmarshal(body, &params) != nil {
+		return nil
+	}
+	redact(params)
+	return params
+}
+
+func redact(m map[string]interface{}) {
+	for k, v := range m {
+		if isSecretKey(k) {
+			m[k] = _redacted
+			continue
+		}
+		if sub, ok := v.(map[string]interface{}); ok {
+			redact(sub)
+		}
+	}
+}
+
+func isSecretKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range _secretKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
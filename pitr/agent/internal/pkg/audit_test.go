@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+var _ = Describe("Auditor", func() {
+	It("append audit records", func() {
+		f := filepath.Join(GinkgoT().TempDir(), "audit.log")
+		Expect(os.WriteFile(f, []byte("{\"request_id\":\"old\"}\n"), 0600)).To(Succeed())
+
+		a, err := NewAuditor(f)
+		Expect(err).To(BeNil())
+		Expect(a.Write(&model.AuditRecord{RequestID: "1", Operation: "delete", Outcome: "success"})).To(Succeed())
+		Expect(a.Write(&model.AuditRecord{RequestID: "2", Operation: "restore", Outcome: "denied"})).To(Succeed())
+		Expect(a.Close()).To(Succeed())
+
+		bs, err := os.ReadFile(f)
+		Expect(err).To(BeNil())
+		lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(ContainSubstring(`"old"`))
+		Expect(lines[2]).To(ContainSubstring(`"outcome":"denied"`))
+	})
+
+	It("redact secrets in params", func() {
+		params := RedactParams([]byte(`{"username":"og","password":"secret","instance":"ins-default-0","storage":{"bucket":"b","access_key":"ak","secret_key":"sk"}}`))
+		Expect(params["username"]).To(Equal("og"))
+		Expect(params["password"]).To(Equal(_redacted))
+		Expect(params["storage"]).To(Equal(map[string]interface{}{"bucket": "b", "access_key": _redacted, "secret_key": _redacted}))
+
+		Expect(RedactParams(nil)).To(BeNil())
+		Expect(RedactParams([]byte("not json"))).To(BeNil())
+	})
+})
@@ -0,0 +1,247 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+)
+
+/*
+catalog is kept by the agent in the backup path for the engines which have no catalog tool like gs_probackup:
+
+	<backup path>/backups/<instance>/<backup id>/database     the data dir copied by the backup tool
+	<backup path>/backups/<instance>/<backup id>/backup.json  the model.Backup of the backup
+	<backup path>/<archive>/<instance>/                       the archived wal or binlog
+
+The backups are described in the layout of gs_probackup, so that the backups of all engines are shown in the same way.
+*/
+type catalog struct {
+	archive string
+	log     logging.ILog
+}
+
+const (
+	_catalogBackupsDir  = "backups"
+	_catalogDatabaseDir = "database"
+	_catalogFile        = "backup.json"
+
+	// the time layout of gs_probackup
+	_catalogTimeLayout = "2006-01-02 15:04:05-07"
+)
+
+func (c catalog) backupDir(backupPath, instance, backupID string) string {
+	return filepath.Join(backupPath, _catalogBackupsDir, instance, backupID)
+}
+
+func (c catalog) databaseDir(backupPath, instance, backupID string) string {
+	return filepath.Join(c.backupDir(backupPath, instance, backupID), _catalogDatabaseDir)
+}
+
+func (c catalog) archiveDir(backupPath, instance string) string {
+	return filepath.Join(backupPath, c.archive, instance)
+}
+
+// newBackup create the dir and catalog of a running FULL backup, the backup id is the base36 of the start time like gs_probackup.
+func (c catalog) newBackup(backupPath, instance, wal string) (*model.Backup, string, error) {
+	now := time.Now()
+	backup := &model.Backup{
+		ID:          strings.ToUpper(strconv.FormatInt(now.Unix(), 36)),
+		BackupMode:  cons.DBBackModeFull,
+		Wal:         wal,
+		CompressAlg: cons.CompressAlgNone,
+		StartTime:   now.Format(_catalogTimeLayout),
+		Status:      cons.OGBackupStatusRunning,
+	}
+
+	dir := c.backupDir(backupPath, instance, backup.ID)
+	if _, err := os.Stat(filepath.Dir(dir)); err != nil {
+		return nil, "", fmt.Errorf("instance[%s] not found,err=%s,wrap=%w", instance, err, cons.InstanceNotExist)
+	}
+	if err := os.Mkdir(dir, 0700); err != nil {
+		return nil, "", fmt.Errorf("create backup dir[%s] failure,err=%s,wrap=%w", dir, err, cons.Internal)
+	}
+	if err := writeCatalog(dir, backup); err != nil {
+		return nil, "", err
+	}
+	return backup, dir, nil
+}
+
+// finishBackup complete the catalog of the backup when the backup tool exits.
+func (c catalog) finishBackup(dir string, backup *model.Backup, err error) error {
+	backup.EndTime = time.Now().Format(_catalogTimeLayout)
+	backup.Status = cons.OGBackupStatusOk
+	if err != nil {
+		backup.Status = cons.OGBackupStatusError
+	} else {
+		size, err := dirSize(filepath.Join(dir, _catalogDatabaseDir))
+		if err != nil {
+			c.log.Warn(fmt.Sprintf("get size of backup[%s] failure,err=%s", dir, err))
+		}
+		backup.DataBytes = int(size)
+		backup.UncompressedBytes = int(size)
+		backup.PgdataBytes = int(size)
+		backup.RecoveryTime = backup.EndTime
+	}
+	return writeCatalog(dir, backup)
+}
+
+func writeCatalog(dir string, backup *model.Backup) error {
+	bs, err := json.Marshal(backup)
+	if err != nil {
+		return fmt.Errorf("json.Marshal[backup=%+v] return err=%s,wrap=%w", backup, err, cons.Internal)
+	}
+	file := filepath.Join(dir, _catalogFile)
+	if err = os.WriteFile(file, bs, 0600); err != nil {
+		return fmt.Errorf("write %s failure,err=%s,wrap=%w", file, err, cons.Internal)
+	}
+	return nil
+}
+
+func readCatalog(dir string) (*model.Backup, error) {
+	file := filepath.Join(dir, _catalogFile)
+	bs, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("backup catalog[%s] not found,err=%w", file, cons.DataNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s failure,err=%s,wrap=%w", file, err, cons.Internal)
+	}
+
+	backup := &model.Backup{}
+	if err = json.Unmarshal(bs, backup); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal[file=%s] return err=%s,wrap=%w", file, err, cons.Internal)
+	}
+	return backup, nil
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func (c catalog) ShowBackup(backupPath, instanceName, backupID string) (*model.Backup, error) {
+	return readCatalog(c.backupDir(backupPath, instanceName, backupID))
+}
+
+// ShowBackupList return the backups of the instance, the latest one is the first like gs_probackup.
+func (c catalog) ShowBackupList(backupPath, instanceName string) ([]*model.Backup, error) {
+	dir := filepath.Join(backupPath, _catalogBackupsDir, instanceName)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read instance dir[%s] failure,err=%s,wrap=%w", dir, err, cons.DataNotFound)
+	}
+
+	list := make([]*model.Backup, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		backup, err := readCatalog(filepath.Join(dir, e.Name()))
+		if err != nil {
+			c.log.Warn(fmt.Sprintf("skip backup[%s] without catalog,err=%s", e.Name(), err))
+			continue
+		}
+		list = append(list, backup)
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("instance[name=%s] has no backup,err=%w", instanceName, cons.DataNotFound)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].StartTime > list[j].StartTime
+	})
+	return list, nil
+}
+
+func (c catalog) DelBackup(backupPath, instanceName, backupID string) error {
+	dir := c.backupDir(backupPath, instanceName, backupID)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("backup[%s] not found,err=%s,wrap=%w", dir, err, cons.DataNotFound)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("remove backup[%s] failure,err=%s,wrap=%w", dir, err, cons.Internal)
+	}
+	return nil
+}
+
+func (c catalog) Init(backupPath string) error {
+	dir := filepath.Join(backupPath, _catalogBackupsDir)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("init backup path failure,backup catalog[%s] exists,err=%w", dir, cons.BackupPathAlreadyExist)
+	}
+
+	for _, d := range []string{dir, filepath.Join(backupPath, c.archive)} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			return fmt.Errorf("create dir[%s] failure,err=%s,wrap=%w", d, err, cons.Internal)
+		}
+	}
+	return nil
+}
+
+func (c catalog) AddInstance(backupPath, instance string) error {
+	for _, d := range []string{filepath.Join(backupPath, _catalogBackupsDir, instance), c.archiveDir(backupPath, instance)} {
+		err := os.Mkdir(d, 0700)
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("add instance failure,dir[%s] exists,err=%w", d, cons.InstanceAlreadyExist)
+		}
+		if err != nil {
+			return fmt.Errorf("create dir[%s] failure,err=%s,wrap=%w", d, err, cons.Internal)
+		}
+	}
+	return nil
+}
+
+func (c catalog) DelInstance(backupPath, instance string) error {
+	dir := filepath.Join(backupPath, _catalogBackupsDir, instance)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("delete instance failure,err=%s,wrap=%w", err, cons.InstanceNotExist)
+	}
+
+	for _, d := range []string{dir, c.archiveDir(backupPath, instance)} {
+		if err := os.RemoveAll(d); err != nil {
+			return fmt.Errorf("remove dir[%s] failure,err=%s,wrap=%w", d, err, cons.Internal)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+var _ = Describe("Catalog", func() {
+	var (
+		backupPath string
+		c          = catalog{archive: "wal", log: log}
+	)
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "catalog")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+		backupPath = filepath.Join(dir, "backup")
+	})
+
+	It("init, add and delete instance", func() {
+		Expect(c.Init(backupPath)).To(BeNil())
+		Expect(errors.Is(c.Init(backupPath), cons.BackupPathAlreadyExist)).To(BeTrue())
+
+		Expect(c.AddInstance(backupPath, "ins-default-ss")).To(BeNil())
+		Expect(errors.Is(c.AddInstance(backupPath, "ins-default-ss"), cons.InstanceAlreadyExist)).To(BeTrue())
+		Expect(filepath.Join(backupPath, "wal", "ins-default-ss")).To(BeADirectory())
+
+		Expect(c.DelInstance(backupPath, "ins-default-ss")).To(BeNil())
+		Expect(errors.Is(c.DelInstance(backupPath, "ins-default-ss"), cons.InstanceNotExist)).To(BeTrue())
+	})
+
+	It("new and finish backup", func() {
+		_, _, err := c.newBackup(backupPath, "ins-default-ss", "ARCHIVE")
+		Expect(errors.Is(err, cons.InstanceNotExist)).To(BeTrue())
+
+		Expect(c.Init(backupPath)).To(BeNil())
+		Expect(c.AddInstance(backupPath, "ins-default-ss")).To(BeNil())
+		backup, dir, err := c.newBackup(backupPath, "ins-default-ss", "ARCHIVE")
+		Expect(err).To(BeNil())
+		Expect(dir).To(Equal(c.backupDir(backupPath, "ins-default-ss", backup.ID)))
+		Expect(backup.Status).To(Equal(cons.OGBackupStatusRunning))
+
+		Expect(os.MkdirAll(c.databaseDir(backupPath, "ins-default-ss", backup.ID), 0700)).To(BeNil())
+		Expect(os.WriteFile(filepath.Join(dir, "database", "data"), []byte("data"), 0600)).To(BeNil())
+		Expect(c.finishBackup(dir, backup, nil)).To(BeNil())
+
+		shown, err := c.ShowBackup(backupPath, "ins-default-ss", backup.ID)
+		Expect(err).To(BeNil())
+		Expect(shown.Status).To(Equal(cons.OGBackupStatusOk))
+		Expect(shown.Wal).To(Equal("ARCHIVE"))
+		Expect(shown.PgdataBytes).To(Equal(4))
+		Expect(shown.RecoveryTime).To(Equal(shown.EndTime))
+
+		Expect(c.finishBackup(dir, backup, errors.New("exit 1"))).To(BeNil())
+		shown, err = c.ShowBackup(backupPath, "ins-default-ss", backup.ID)
+		Expect(err).To(BeNil())
+		Expect(shown.Status).To(Equal(cons.OGBackupStatusError))
+	})
+
+	It("show, list and delete backups", func() {
+		Expect(c.Init(backupPath)).To(BeNil())
+		Expect(c.AddInstance(backupPath, "ins-default-ss")).To(BeNil())
+
+		_, err := c.ShowBackupList(backupPath, "ins-default-ss")
+		Expect(errors.Is(err, cons.DataNotFound)).To(BeTrue())
+
+		for _, b := range []*model.Backup{
+			{ID: "RSFF01", StartTime: "2023-01-01 10:00:00+08", Status: cons.OGBackupStatusOk},
+			{ID: "RSFF02", StartTime: "2023-01-02 10:00:00+08", Status: cons.OGBackupStatusRunning},
+		} {
+			dir := c.backupDir(backupPath, "ins-default-ss", b.ID)
+			Expect(os.Mkdir(dir, 0700)).To(BeNil())
+			Expect(writeCatalog(dir, b)).To(BeNil())
+		}
+
+		backup, err := c.ShowBackup(backupPath, "ins-default-ss", "RSFF01")
+		Expect(err).To(BeNil())
+		Expect(backup.Status).To(Equal(cons.OGBackupStatusOk))
+
+		list, err := c.ShowBackupList(backupPath, "ins-default-ss")
+		Expect(err).To(BeNil())
+		Expect(list).To(HaveLen(2))
+		Expect(list[0].ID).To(Equal("RSFF02"))
+
+		Expect(c.DelBackup(backupPath, "ins-default-ss", "RSFF02")).To(BeNil())
+		_, err = c.ShowBackup(backupPath, "ins-default-ss", "RSFF02")
+		Expect(errors.Is(err, cons.DataNotFound)).To(BeTrue())
+		Expect(errors.Is(c.DelBackup(backupPath, "ins-default-ss", "RSFF02"), cons.DataNotFound)).To(BeTrue())
+	})
+})
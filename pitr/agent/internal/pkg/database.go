@@ -0,0 +1,273 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/cmds"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+)
+
+// database engines supported by agent
+const (
+	EngineOpenGauss  = "opengauss"
+	EnginePostgreSQL = "postgresql"
+	EngineMySQL      = "mysql"
+)
+
+/*
+IDatabase is the database engine managed by agent, the handlers and the cli are engine agnostic:
+
+	openGauss backups by gs_probackup;
+	PostgreSQL backups by pg_basebackup and archives wal into the backup path;
+	MySQL backups by xtrabackup and archives binlog into the backup path.
+
+The backups of all engines are described by model.Backup in the layout of gs_probackup.
+*/
+type IDatabase interface {
+	AsyncBackup(backupPath, instanceName, backupMode string, opts *model.BackupOptions, dbPort uint16, job *Job) (string, error)
+	ShowBackup(backupPath, instanceName, backupID string) (*model.Backup, error)
+	Init(backupPath string) error
+	AddInstance(backupPath, instance string) error
+	DelInstance(backupPath, instance string) error
+	DelBackup(backupPath, instance, backupID string) error
+	Start() error
+	Stop() error
+	Status() (string, error)
+	Restore(backupPath, instance, backupID string, target *model.RecoveryTarget, job *Job) error
+	ShowRecoveryResult(user, password, dbName string, dbPort uint16) (*model.RecoveryResult, error)
+	ShowBackupList(backupPath, instanceName string) ([]*model.Backup, error)
+	Auth(user, password, dbName string, dbPort uint16) error
+	CheckSchema(user, password, dbName string, dbPort uint16, schema string) error
+	SchemaQuery(schema string) string
+	MvTempToPgData() error
+	MvPgDataToTemp() error
+	CleanPgDataTemp() error
+	EnableArchive(backupPath, instance string) error
+	ArchiveStatus(user, password, dbName string, dbPort uint16, backupPath, instance string) (*model.ArchiveStatus, error)
+	ValidateBackup(backupPath, instance, backupID string) error
+	RestoreScratch(backupPath, instance, backupID string, port uint16) (string, error)
+	CleanScratch(pgData string) error
+	DiskSpace(backupPath, instance, backupID string) (*model.DiskSpace, error)
+}
+
+// NewDatabase return the database engine which manages the pgdata.
+func NewDatabase(engine, pgData string, log logging.ILog) (IDatabase, error) {
+	switch engine {
+	case EngineOpenGauss, "":
+		return NewOpenGauss(pgData, log), nil
+	case EnginePostgreSQL:
+		return NewPostgres(pgData, log), nil
+	case EngineMySQL:
+		return NewMySQL(pgData, log), nil
+	default:
+		return nil, fmt.Errorf("unknown database engine[%s]", engine)
+	}
+}
+
+// the binaries are executed with argv by cmds.ExecArgv, the args are never interpreted by shell.
+const (
+	_mv = "mv"
+	_rm = "rm"
+
+	// the division by zero fails the statement if the schema does not exist.
+	_pgCheckSchemaFmt = "SELECT 1/COUNT(*) FROM pg_namespace WHERE nspname = '%s'"
+
+	_archiveModeOn      = "on"
+	_archiveTimelineOK  = "OK"
+	_walSegmentsPerXLog = 0x100 // the wal segment size of both engines is 16MB by default
+)
+
+// dataDir is the pgdata and its temp dir which keeps the pgdata during restore.
+type dataDir struct {
+	pgData     string
+	pgDataTemp string
+}
+
+// newDataDir the temp dir of pgdata is beside it and named after it, several instances may share the parent dir.
+func newDataDir(pgData string) dataDir {
+	return dataDir{
+		pgData:     pgData,
+		pgDataTemp: filepath.Join(filepath.Dir(pgData), filepath.Base(pgData)+"_temp"),
+	}
+}
+
+func (d dataDir) MvPgDataToTemp() error {
+	args := []string{"--", d.pgData, d.pgDataTemp}
+	_, err := cmds.ExecArgv(_mv, args...)
+	if errors.Is(err, cons.CmdOperateFailed) {
+		return fmt.Errorf("mv pgdata to temp dir failure,err=%s,wrap=%w", err, cons.MvPgDataToTempFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
+	}
+
+	return nil
+}
+
+func (d dataDir) MvTempToPgData() error {
+	args := []string{"--", d.pgDataTemp, d.pgData}
+	_, err := cmds.ExecArgv(_mv, args...)
+	if errors.Is(err, cons.CmdOperateFailed) {
+		return fmt.Errorf("mv temp to pgdata dir failure,err=%s,wrap=%w", err, cons.MvTempToPgDataFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
+	}
+	return nil
+}
+
+func (d dataDir) CleanPgDataTemp() error {
+	args := []string{"-r", "--", d.pgDataTemp}
+	_, err := cmds.ExecArgv(_rm, args...)
+	if errors.Is(err, cons.CmdOperateFailed) {
+		return fmt.Errorf("clean pgdata temp dir failure,err=%s,wrap=%w", err, cons.CleanPgDataTempFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
+	}
+	return nil
+}
+
+/*
+diskSpace return the disk usage of the backup path and pgdata, and the estimated bytes of backup or restore.
+
+The estimation is the pgdata bytes of the backup, the latest one of the instance is used if backupID is empty,
+it is 0 if the instance is empty or has no backup.
+*/
+func diskSpace(db IDatabase, pgData string, log logging.ILog, backupPath, instance, backupID string) (*model.DiskSpace, error) {
+	backupUsage, err := diskUsage(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	pgDataUsage, err := diskUsage(pgData)
+	if err != nil {
+		return nil, err
+	}
+	ds := &model.DiskSpace{
+		BackupPath: backupUsage,
+		PgData:     pgDataUsage,
+	}
+
+	switch {
+	case instance == "":
+	case backupID != "":
+		backup, err := db.ShowBackup(backupPath, instance, backupID)
+		if err != nil {
+			return nil, fmt.Errorf("db.ShowBackup return err=%w", err)
+		}
+		ds.EstimatedBytes = int64(backup.PgdataBytes)
+	default:
+		list, err := db.ShowBackupList(backupPath, instance)
+		if err != nil {
+			log.Debug(fmt.Sprintf("no backup to estimate from[backupPath=%s,instance=%s],err=%s", backupPath, instance, err))
+			return ds, nil
+		}
+		if latest := latestBackup(list); latest != nil {
+			ds.EstimatedBytes = int64(latest.PgdataBytes)
+		}
+	}
+	return ds, nil
+}
+
+// latestBackup return the latest OK backup, the start time of gs_probackup is in the same layout, so it is compared as string.
+func latestBackup(list []*model.Backup) *model.Backup {
+	var latest *model.Backup
+	for _, b := range list {
+		if b.Status != cons.OGBackupStatusOk {
+			continue
+		}
+		if latest == nil || b.StartTime > latest.StartTime {
+			latest = b
+		}
+	}
+	return latest
+}
+
+/*
+newArchiveStatus the archive is broken if:
+
+	archive_mode is not on;
+	archive_command does not push wal to the backup path, which is checked by the engine;
+	the archived wal of any timeline is not continuous.
+*/
+func newArchiveStatus(settings *model.ArchiveSettings, timelines []*model.ArchiveTimeline, pushed bool, reason string) *model.ArchiveStatus {
+	status := &model.ArchiveStatus{
+		ArchiveMode:    settings.ArchiveMode,
+		ArchiveCommand: settings.ArchiveCommand,
+		CurrentSegment: settings.CurrentWalFile,
+		LostSegments:   []*model.LostSegment{},
+	}
+
+	var reasons []string
+	if reason != "" {
+		reasons = append(reasons, reason)
+	}
+	if settings.ArchiveMode != _archiveModeOn {
+		reasons = append(reasons, fmt.Sprintf("archive_mode is %s", settings.ArchiveMode))
+	}
+	if !pushed {
+		reasons = append(reasons, "archive_command does not push wal to the backup path")
+	}
+
+	for _, tl := range timelines {
+		status.ArchivedSegments += tl.NSegments
+		if tl.MaxSegno > status.LatestArchived {
+			status.LatestArchived = tl.MaxSegno
+		}
+		if len(tl.LostSegments) > 0 || (tl.Status != "" && tl.Status != _archiveTimelineOK) {
+			status.LostSegments = append(status.LostSegments, tl.LostSegments...)
+			reasons = append(reasons, fmt.Sprintf("wal chain of timeline %d is broken, status is %s", tl.Tli, tl.Status))
+		}
+	}
+
+	cur, err1 := walSegNo(status.CurrentSegment)
+	latest, err2 := walSegNo(status.LatestArchived)
+	if err1 == nil && err2 == nil && cur > latest {
+		status.LagSegments = cur - latest
+	}
+
+	if len(reasons) > 0 {
+		status.Broken = true
+		status.Reason = strings.Join(reasons, ";")
+	}
+	return status
+}
+
+// walSegNo convert the wal file name like `000000010000000000000003` to a segment number.
+func walSegNo(name string) (uint64, error) {
+	if len(name) != 24 {
+		return 0, fmt.Errorf("invalid wal file name[%s]", name)
+	}
+	xlog, err := strconv.ParseUint(name[8:16], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid wal file name[%s],err=%w", name, err)
+	}
+	seg, err := strconv.ParseUint(name[16:24], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid wal file name[%s],err=%w", name, err)
+	}
+	return xlog*_walSegmentsPerXLog + seg, nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+// diskUsage statfs the filesystem of the path, the nearest existing parent is used if the path does not exist yet,
+// e.g. the backup path before the first backup.
+func diskUsage(path string) (*model.DiskUsage, error) {
+	dir := filepath.Clean(path)
+	for {
+		_, err := os.Stat(dir)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) || dir == "/" {
+			return nil, fmt.Errorf("stat path[%s] return err=%s,wrap=%w", dir, err, cons.Internal)
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return nil, fmt.Errorf("statfs path[%s] return err=%s,wrap=%w", dir, err, cons.Internal)
+	}
+
+	bsize := uint64(st.Bsize)
+	return &model.DiskUsage{
+		Path:       path,
+		TotalBytes: st.Blocks * bsize,
+		UsedBytes:  (st.Blocks - st.Bfree) * bsize,
+		FreeBytes:  st.Bavail * bsize,
+	}, nil
+}
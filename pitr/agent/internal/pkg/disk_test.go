@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Disk", func() {
+	It("usage of existing path", func() {
+		dir := GinkgoT().TempDir()
+		usage, err := diskUsage(dir)
+		Expect(err).To(BeNil())
+		Expect(usage.Path).To(Equal(dir))
+		Expect(usage.TotalBytes).To(BeNumerically(">", 0))
+		Expect(usage.TotalBytes).To(BeNumerically(">=", usage.UsedBytes))
+		Expect(usage.TotalBytes).To(BeNumerically(">=", usage.FreeBytes))
+	})
+
+	It("usage of the nearest existing parent", func() {
+		dir := GinkgoT().TempDir()
+		usage, err := diskUsage(filepath.Join(dir, "not", "exist"))
+		Expect(err).To(BeNil())
+		Expect(usage.Path).To(Equal(filepath.Join(dir, "not", "exist")))
+		Expect(usage.TotalBytes).To(BeNumerically(">", 0))
+
+		_, err = os.Stat(filepath.Join(dir, "not"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
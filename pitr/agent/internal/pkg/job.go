@@ -0,0 +1,279 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+type (
+	jobs struct {
+		mu      sync.Mutex
+		m       map[string]*Job
+		order   []string
+		maxDone int
+	}
+
+	IJobs interface {
+		// New register a running job on the scope, which must be finished by the caller.
+		New(kind string, scope JobScope) *Job
+		// Submit run the fn in background as a job on the scope.
+		Submit(kind string, scope JobScope, fn func(job *Job) (interface{}, error)) *Job
+		Get(id string) (*Job, error)
+	}
+
+	// JobScope is the instance and the database operated by a job, the reads of the job are authorized against it.
+	JobScope struct {
+		Instance string
+		DBPort   uint16
+	}
+
+	// Job is a background operation, the latest output lines of it are kept until it is evicted.
+	Job struct {
+		mu       sync.Mutex
+		id       string
+		kind     string
+		scope    JobScope
+		state    string
+		progress model.JobProgress
+		result   interface{}
+		err      string
+		// lines is a ring buffer of the latest lines, the line n is kept at lines[n%maxLines]
+		lines    []string
+		maxLines int
+		// total is the number of logged lines, the first total-len(lines) lines are dropped
+		total     int
+		startTime time.Time
+		endTime   time.Time
+		// changed is closed and replaced when the job is changed
+		changed chan struct{}
+	}
+)
+
+const (
+	// the finished jobs are evicted from the oldest one if there are more than it.
+	defaultMaxDoneJobs = 100
+	// the latest lines of a job are kept, the older ones are dropped.
+	defaultMaxJobLines = 10000
+)
+
+var _ IJobs = (*jobs)(nil)
+
+// progressRegex match the progress of `gs_probackup --progress`, like `INFO: Progress: (12/345). Process file "base/1/2"`.
+var progressRegex = regexp.MustCompile(`Progress: \((\d+)/(\d+)\)`)
+
+func NewJobs() IJobs {
+	return &jobs{
+		m:       make(map[string]*Job),
+		maxDone: defaultMaxDoneJobs,
+	}
+}
+
+func (js *jobs) New(kind string, scope JobScope) *Job {
+	job := &Job{
+		id:        newJobID(),
+		kind:      kind,
+		scope:     scope,
+		state:     cons.JobStateRunning,
+		maxLines:  defaultMaxJobLines,
+		startTime: time.Now(),
+		changed:   make(chan struct{}),
+	}
+
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	js.m[job.id] = job
+	js.order = append(js.order, job.id)
+	js.evict()
+	return job
+}
+
+func (js *jobs) Submit(kind string, scope JobScope, fn func(job *Job) (interface{}, error)) *Job {
+	job := js.New(kind, scope)
+	go func() {
+		var (
+			result interface{}
+			err    error
+		)
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panic[%v],wrap=%w", r, cons.Internal)
+			}
+			job.Finish(result, err)
+		}()
+		result, err = fn(job)
+	}()
+	return job
+}
+
+func (js *jobs) Get(id string) (*Job, error) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	job, ok := js.m[id]
+	if !ok {
+		return nil, fmt.Errorf("job[id=%s],err=%w", id, cons.JobNotFound)
+	}
+	return job, nil
+}
+
+// evict the oldest finished jobs, the running ones are always kept.
+func (js *jobs) evict() {
+	var done int
+	for _, id := range js.order {
+		if js.m[id].Done() {
+			done++
+		}
+	}
+
+	order := make([]string, 0, len(js.order))
+	for _, id := range js.order {
+		if done > js.maxDone && js.m[id].Done() {
+			delete(js.m, id)
+			done--
+			continue
+		}
+		order = append(order, id)
+	}
+	js.order = order
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// ID return the id of the job, it is empty if the job is nil.
+func (j *Job) ID() string {
+	if j == nil {
+		return ""
+	}
+	return j.id
+}
+
+// Scope return the instance and the database operated by the job, it never changes.
+func (j *Job) Scope() JobScope {
+	return j.scope
+}
+
+// Log append an output line to the job and update the progress, it is ignored if the job is nil.
+// The oldest line is overwritten if there are maxLines lines already.
+func (j *Job) Log(line string) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.lines) < j.maxLines {
+		j.lines = append(j.lines, line)
+	} else {
+		j.lines[j.total%j.maxLines] = line
+	}
+	j.total++
+	if m := progressRegex.FindStringSubmatch(line); m != nil {
+		done, _ := strconv.ParseInt(m[1], 10, 64)
+		total, _ := strconv.ParseInt(m[2], 10, 64)
+		j.progress = model.JobProgress{Done: done, Total: total}
+	}
+	j.notify()
+}
+
+// Finish mark the job as succeeded with the result, or failed with the error.
+func (j *Job) Finish(result interface{}, err error) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.state != cons.JobStateRunning {
+		return
+	}
+	j.state, j.result = cons.JobStateSucceeded, result
+	if err != nil {
+		j.state, j.err = cons.JobStateFailed, err.Error()
+	}
+	if j.state == cons.JobStateSucceeded && j.progress.Total != 0 {
+		j.progress.Done = j.progress.Total
+	}
+	j.endTime = time.Now()
+	j.notify()
+}
+
+func (j *Job) notify() {
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+func (j *Job) Done() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state != cons.JobStateRunning
+}
+
+func (j *Job) Snapshot() *model.Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return &model.Job{
+		ID:        j.id,
+		Kind:      j.kind,
+		State:     j.state,
+		Progress:  j.progress,
+		Result:    j.result,
+		Error:     j.err,
+		Lines:     j.total,
+		StartTime: j.startTime,
+		EndTime:   j.endTime,
+	}
+}
+
+/*
+Lines return the output lines from the offset, the offset of the next line, whether the job is done,
+and a channel which is closed when there are new lines or the job is done.
+
+The lines are returned from the oldest kept one if the lines from the offset are dropped already,
+and the next offset is kept if it is beyond the logged lines.
+*/
+func (j *Job) Lines(from int) (lines []string, next int, done bool, changed <-chan struct{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if dropped := j.total - len(j.lines); from < dropped {
+		from = dropped
+	}
+	for i := from; i < j.total; i++ {
+		lines = append(lines, j.lines[i%j.maxLines])
+	}
+	// the offset is never rewound, the lines before it are not returned again
+	next = j.total
+	if from > next {
+		next = from
+	}
+	return lines, next, j.state != cons.JobStateRunning, j.changed
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Jobs", func() {
+	It("nil job is ignored", func() {
+		var job *Job
+		job.Log("line")
+		job.Finish(nil, errors.New("failure"))
+		Expect(job.ID()).To(BeEmpty())
+	})
+
+	It("parse progress", func() {
+		job := NewJobs().New(cons.JobKindBackup, JobScope{})
+		job.Log("INFO: Progress: (12/345). Process file \"base/1/2\"")
+		job.Log("INFO: Backup files are synced")
+		Expect(job.Snapshot().Progress.Done).To(Equal(int64(12)))
+		Expect(job.Snapshot().Progress.Total).To(Equal(int64(345)))
+
+		lines, next, done, _ := job.Lines(1)
+		Expect(lines).To(Equal([]string{"INFO: Backup files are synced"}))
+		Expect(next).To(Equal(2))
+		Expect(done).To(BeFalse())
+
+		job.Finish(nil, errors.New("failure"))
+		job.Finish("result", nil)
+		Expect(job.Snapshot().State).To(Equal(cons.JobStateFailed))
+		Expect(job.Snapshot().Error).To(Equal("failure"))
+	})
+
+	It("drop the oldest lines", func() {
+		job := &Job{maxLines: 3, changed: make(chan struct{})}
+		for i := 0; i < 5; i++ {
+			job.Log(strconv.Itoa(i))
+		}
+		Expect(job.Snapshot().Lines).To(Equal(5))
+
+		lines, next, _, _ := job.Lines(0)
+		Expect(lines).To(Equal([]string{"2", "3", "4"}))
+		Expect(next).To(Equal(5))
+
+		lines, next, _, _ = job.Lines(3)
+		Expect(lines).To(Equal([]string{"3", "4"}))
+		Expect(next).To(Equal(5))
+
+		lines, next, _, _ = job.Lines(5)
+		Expect(lines).To(BeEmpty())
+		Expect(next).To(Equal(5))
+
+		lines, next, _, _ = job.Lines(7)
+		Expect(lines).To(BeEmpty())
+		Expect(next).To(Equal(7))
+		job.Log("5")
+		lines, next, _, _ = job.Lines(7)
+		Expect(lines).To(BeEmpty())
+		Expect(next).To(Equal(7))
+	})
+
+	It("evict the oldest finished jobs", func() {
+		js := &jobs{m: make(map[string]*Job), maxDone: 1}
+		running := js.New(cons.JobKindRestore, JobScope{})
+		first := js.New(cons.JobKindBackup, JobScope{})
+		first.Finish(nil, nil)
+		second := js.New(cons.JobKindBackup, JobScope{})
+		second.Finish(nil, nil)
+		js.New(cons.JobKindVerify, JobScope{})
+
+		_, err := js.Get(first.ID())
+		Expect(errors.Is(err, cons.JobNotFound)).To(BeTrue())
+		_, err = js.Get(running.ID())
+		Expect(err).To(BeNil())
+		_, err = js.Get(second.ID())
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,380 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: database.go
+
+// Package mock_pkg is a generated GoMock package.
+package mock_pkg
+
+import (
+	reflect "reflect"
+
+	pkg "github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
+	model "github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIDatabase is a mock of IDatabase interface.
+type MockIDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDatabaseMockRecorder
+}
+
+// MockIDatabaseMockRecorder is the mock recorder for MockIDatabase.
+type MockIDatabaseMockRecorder struct {
+	mock *MockIDatabase
+}
+
+// NewMockIDatabase creates a new mock instance.
+func NewMockIDatabase(ctrl *gomock.Controller) *MockIDatabase {
+	mock := &MockIDatabase{ctrl: ctrl}
+	mock.recorder = &MockIDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDatabase) EXPECT() *MockIDatabaseMockRecorder {
+	return m.recorder
+}
+
+// AddInstance mocks base method.
+func (m *MockIDatabase) AddInstance(backupPath, instance string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInstance", backupPath, instance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddInstance indicates an expected call of AddInstance.
+func (mr *MockIDatabaseMockRecorder) AddInstance(backupPath, instance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInstance", reflect.TypeOf((*MockIDatabase)(nil).AddInstance), backupPath, instance)
+}
+
+// ArchiveStatus mocks base method.
+func (m *MockIDatabase) ArchiveStatus(user, password, dbName string, dbPort uint16, backupPath, instance string) (*model.ArchiveStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveStatus", user, password, dbName, dbPort, backupPath, instance)
+	ret0, _ := ret[0].(*model.ArchiveStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveStatus indicates an expected call of ArchiveStatus.
+func (mr *MockIDatabaseMockRecorder) ArchiveStatus(user, password, dbName, dbPort, backupPath, instance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveStatus", reflect.TypeOf((*MockIDatabase)(nil).ArchiveStatus), user, password, dbName, dbPort, backupPath, instance)
+}
+
+// AsyncBackup mocks base method.
+func (m *MockIDatabase) AsyncBackup(backupPath, instanceName, backupMode string, opts *model.BackupOptions, dbPort uint16, job *pkg.Job) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AsyncBackup", backupPath, instanceName, backupMode, opts, dbPort, job)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AsyncBackup indicates an expected call of AsyncBackup.
+func (mr *MockIDatabaseMockRecorder) AsyncBackup(backupPath, instanceName, backupMode, opts, dbPort, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AsyncBackup", reflect.TypeOf((*MockIDatabase)(nil).AsyncBackup), backupPath, instanceName, backupMode, opts, dbPort, job)
+}
+
+// Auth mocks base method.
+func (m *MockIDatabase) Auth(user, password, dbName string, dbPort uint16) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Auth", user, password, dbName, dbPort)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Auth indicates an expected call of Auth.
+func (mr *MockIDatabaseMockRecorder) Auth(user, password, dbName, dbPort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*MockIDatabase)(nil).Auth), user, password, dbName, dbPort)
+}
+
+// CheckSchema mocks base method.
+func (m *MockIDatabase) CheckSchema(user, password, dbName string, dbPort uint16, schema string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSchema", user, password, dbName, dbPort, schema)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSchema indicates an expected call of CheckSchema.
+func (mr *MockIDatabaseMockRecorder) CheckSchema(user, password, dbName, dbPort, schema interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSchema", reflect.TypeOf((*MockIDatabase)(nil).CheckSchema), user, password, dbName, dbPort, schema)
+}
+
+// CleanPgDataTemp mocks base method.
+func (m *MockIDatabase) CleanPgDataTemp() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanPgDataTemp")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanPgDataTemp indicates an expected call of CleanPgDataTemp.
+func (mr *MockIDatabaseMockRecorder) CleanPgDataTemp() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanPgDataTemp", reflect.TypeOf((*MockIDatabase)(nil).CleanPgDataTemp))
+}
+
+// CleanScratch mocks base method.
+func (m *MockIDatabase) CleanScratch(pgData string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanScratch", pgData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanScratch indicates an expected call of CleanScratch.
+func (mr *MockIDatabaseMockRecorder) CleanScratch(pgData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanScratch", reflect.TypeOf((*MockIDatabase)(nil).CleanScratch), pgData)
+}
+
+// DelBackup mocks base method.
+func (m *MockIDatabase) DelBackup(backupPath, instance, backupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelBackup", backupPath, instance, backupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelBackup indicates an expected call of DelBackup.
+func (mr *MockIDatabaseMockRecorder) DelBackup(backupPath, instance, backupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelBackup", reflect.TypeOf((*MockIDatabase)(nil).DelBackup), backupPath, instance, backupID)
+}
+
+// DelInstance mocks base method.
+func (m *MockIDatabase) DelInstance(backupPath, instance string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelInstance", backupPath, instance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelInstance indicates an expected call of DelInstance.
+func (mr *MockIDatabaseMockRecorder) DelInstance(backupPath, instance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelInstance", reflect.TypeOf((*MockIDatabase)(nil).DelInstance), backupPath, instance)
+}
+
+// DiskSpace mocks base method.
+func (m *MockIDatabase) DiskSpace(backupPath, instance, backupID string) (*model.DiskSpace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiskSpace", backupPath, instance, backupID)
+	ret0, _ := ret[0].(*model.DiskSpace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiskSpace indicates an expected call of DiskSpace.
+func (mr *MockIDatabaseMockRecorder) DiskSpace(backupPath, instance, backupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskSpace", reflect.TypeOf((*MockIDatabase)(nil).DiskSpace), backupPath, instance, backupID)
+}
+
+// EnableArchive mocks base method.
+func (m *MockIDatabase) EnableArchive(backupPath, instance string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableArchive", backupPath, instance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableArchive indicates an expected call of EnableArchive.
+func (mr *MockIDatabaseMockRecorder) EnableArchive(backupPath, instance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableArchive", reflect.TypeOf((*MockIDatabase)(nil).EnableArchive), backupPath, instance)
+}
+
+// Init mocks base method.
+func (m *MockIDatabase) Init(backupPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Init", backupPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Init indicates an expected call of Init.
+func (mr *MockIDatabaseMockRecorder) Init(backupPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockIDatabase)(nil).Init), backupPath)
+}
+
+// MvPgDataToTemp mocks base method.
+func (m *MockIDatabase) MvPgDataToTemp() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MvPgDataToTemp")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MvPgDataToTemp indicates an expected call of MvPgDataToTemp.
+func (mr *MockIDatabaseMockRecorder) MvPgDataToTemp() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MvPgDataToTemp", reflect.TypeOf((*MockIDatabase)(nil).MvPgDataToTemp))
+}
+
+// MvTempToPgData mocks base method.
+func (m *MockIDatabase) MvTempToPgData() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MvTempToPgData")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MvTempToPgData indicates an expected call of MvTempToPgData.
+func (mr *MockIDatabaseMockRecorder) MvTempToPgData() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MvTempToPgData", reflect.TypeOf((*MockIDatabase)(nil).MvTempToPgData))
+}
+
+// Restore mocks base method.
+func (m *MockIDatabase) Restore(backupPath, instance, backupID string, target *model.RecoveryTarget, job *pkg.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", backupPath, instance, backupID, target, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIDatabaseMockRecorder) Restore(backupPath, instance, backupID, target, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIDatabase)(nil).Restore), backupPath, instance, backupID, target, job)
+}
+
+// RestoreScratch mocks base method.
+func (m *MockIDatabase) RestoreScratch(backupPath, instance, backupID string, port uint16) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreScratch", backupPath, instance, backupID, port)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreScratch indicates an expected call of RestoreScratch.
+func (mr *MockIDatabaseMockRecorder) RestoreScratch(backupPath, instance, backupID, port interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreScratch", reflect.TypeOf((*MockIDatabase)(nil).RestoreScratch), backupPath, instance, backupID, port)
+}
+
+// SchemaQuery mocks base method.
+func (m *MockIDatabase) SchemaQuery(schema string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaQuery", schema)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SchemaQuery indicates an expected call of SchemaQuery.
+func (mr *MockIDatabaseMockRecorder) SchemaQuery(schema interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaQuery", reflect.TypeOf((*MockIDatabase)(nil).SchemaQuery), schema)
+}
+
+// ShowBackup mocks base method.
+func (m *MockIDatabase) ShowBackup(backupPath, instanceName, backupID string) (*model.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShowBackup", backupPath, instanceName, backupID)
+	ret0, _ := ret[0].(*model.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShowBackup indicates an expected call of ShowBackup.
+func (mr *MockIDatabaseMockRecorder) ShowBackup(backupPath, instanceName, backupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowBackup", reflect.TypeOf((*MockIDatabase)(nil).ShowBackup), backupPath, instanceName, backupID)
+}
+
+// ShowBackupList mocks base method.
+func (m *MockIDatabase) ShowBackupList(backupPath, instanceName string) ([]*model.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShowBackupList", backupPath, instanceName)
+	ret0, _ := ret[0].([]*model.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShowBackupList indicates an expected call of ShowBackupList.
+func (mr *MockIDatabaseMockRecorder) ShowBackupList(backupPath, instanceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowBackupList", reflect.TypeOf((*MockIDatabase)(nil).ShowBackupList), backupPath, instanceName)
+}
+
+// ShowRecoveryResult mocks base method.
+func (m *MockIDatabase) ShowRecoveryResult(user, password, dbName string, dbPort uint16) (*model.RecoveryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShowRecoveryResult", user, password, dbName, dbPort)
+	ret0, _ := ret[0].(*model.RecoveryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShowRecoveryResult indicates an expected call of ShowRecoveryResult.
+func (mr *MockIDatabaseMockRecorder) ShowRecoveryResult(user, password, dbName, dbPort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowRecoveryResult", reflect.TypeOf((*MockIDatabase)(nil).ShowRecoveryResult), user, password, dbName, dbPort)
+}
+
+// Start mocks base method.
+func (m *MockIDatabase) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIDatabaseMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIDatabase)(nil).Start))
+}
+
+// Status mocks base method.
+func (m *MockIDatabase) Status() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockIDatabaseMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockIDatabase)(nil).Status))
+}
+
+// Stop mocks base method.
+func (m *MockIDatabase) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockIDatabaseMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockIDatabase)(nil).Stop))
+}
+
+// ValidateBackup mocks base method.
+func (m *MockIDatabase) ValidateBackup(backupPath, instance, backupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBackup", backupPath, instance, backupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateBackup indicates an expected call of ValidateBackup.
+func (mr *MockIDatabaseMockRecorder) ValidateBackup(backupPath, instance, backupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBackup", reflect.TypeOf((*MockIDatabase)(nil).ValidateBackup), backupPath, instance, backupID)
+}
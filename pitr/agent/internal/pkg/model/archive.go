@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+type (
+	// ArchiveList is the output of `gs_probackup show --archive`.
+	ArchiveList struct {
+		Instance  string             `json:"instance"`
+		Timelines []*ArchiveTimeline `json:"timelines"`
+	}
+
+	ArchiveTimeline struct {
+		Tli          int            `json:"tli"`
+		ParentTli    int            `json:"parent-tli"`
+		MinSegno     string         `json:"min-segno"`
+		MaxSegno     string         `json:"max-segno"`
+		NSegments    int            `json:"n-segments"`
+		Size         int64          `json:"size"`
+		Status       string         `json:"status"`
+		LostSegments []*LostSegment `json:"lost-segments"`
+	}
+
+	LostSegment struct {
+		BeginSegno string `json:"begin-segno"`
+		EndSegno   string `json:"end-segno"`
+	}
+
+	// ArchiveSettings is the wal archive settings and current wal file of openGauss.
+	ArchiveSettings struct {
+		ArchiveMode    string
+		ArchiveCommand string
+		CurrentWalFile string
+	}
+
+	// ArchiveStatus is the health of continuous wal archiving toward the backup path.
+	ArchiveStatus struct {
+		ArchiveMode      string
+		ArchiveCommand   string
+		CurrentSegment   string
+		LatestArchived   string
+		LagSegments      uint64
+		ArchivedSegments int
+		LostSegments     []*LostSegment
+		Broken           bool
+		Reason           string
+	}
+)
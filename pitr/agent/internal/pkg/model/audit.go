@@ -0,0 +1,33 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+type AuditRecord struct {
+	Time      string                 `json:"time"`
+	RequestID string                 `json:"request_id"`
+	Caller    string                 `json:"caller"`
+	Rule      string                 `json:"rule,omitempty"`
+	Method    string                 `json:"method"`
+	Path      string                 `json:"path"`
+	Operation string                 `json:"operation"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Outcome   string                 `json:"outcome"`
+	Code      int                    `json:"code"`
+	Msg       string                 `json:"msg,omitempty"`
+	JobID     string                 `json:"job_id,omitempty"`
+}
@@ -21,6 +21,7 @@ type (
 	Backup struct {
 		ID                string `json:"id"`
 		BackupMode        string `json:"backup-mode"`
+		ParentBackupID    string `json:"parent-backup-id"`
 		Wal               string `json:"wal"`
 		CompressAlg       string `json:"compress-alg"`
 		CompressLevel     int    `json:"compress-level"`
@@ -47,6 +48,15 @@ type (
 		ContentCrc        int64  `json:"content-crc"`
 	}
 
+	// BackupOptions is the options of `gs_probackup backup`.
+	BackupOptions struct {
+		ThreadsNum          uint8
+		CompressAlg         string
+		CompressLevel       uint8
+		Stream              bool
+		SkipBlockValidation bool
+	}
+
 	BackupList struct {
 		Instance string    `json:"instance"`
 		List     []*Backup `json:"backups"`
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+type (
+	// Job is a snapshot of the background operation, such as backup, restore and verify.
+	Job struct {
+		ID        string
+		Kind      string
+		State     string
+		Progress  JobProgress
+		Result    interface{}
+		Error     string
+		Lines     int
+		StartTime time.Time
+		EndTime   time.Time
+	}
+
+	// JobProgress is the progress reported by `gs_probackup --progress`, the total is 0 if unknown.
+	JobProgress struct {
+		Done  int64
+		Total int64
+	}
+)
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+type (
+	// RecoveryTarget is the point that the restored instance replays archived WAL up to.
+	// At most one of Time, LSN, Xid, Name, CSN, Position and GTID is set, an empty target restores to the end of the backup.
+	// CSN is the global commit sequence number of a sharded cluster, all data nodes replay to the same
+	// CSN so that the cluster is transactionally consistent.
+	// Position and GTID are the binlog targets of MySQL, the position is in the format of `binlog.000003:1234`.
+	RecoveryTarget struct {
+		Time      string
+		LSN       string
+		Xid       string
+		Name      string
+		CSN       string
+		Position  string
+		GTID      string
+		Inclusive bool
+	}
+
+	// RecoveryResult is the position that the database actually replayed to after a restore.
+	RecoveryResult struct {
+		InRecovery bool
+		ReplayLSN  string
+		ReplayTime string
+	}
+)
+
+// RecoveryTimeLayouts are the accepted layouts of recovery target time.
+var RecoveryTimeLayouts = []string{
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05-07",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
+func (t *RecoveryTarget) IsEmpty() bool {
+	return t == nil || (t.Time == "" && t.LSN == "" && t.Xid == "" && t.Name == "" && t.CSN == "" && t.Position == "" && t.GTID == "")
+}
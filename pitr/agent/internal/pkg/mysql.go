@@ -0,0 +1,704 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/cmds"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/mysqlutil"
+)
+
+/*
+mysql backups MySQL by xtrabackup into the catalog kept by the agent, the binlog is copied into the archive of the backup path.
+
+Only FULL backups are supported, the backup is prepared once it is taken, so that it is restored by `xtrabackup --copy-back`.
+The point-in-time recovery replays the archived binlog from the position of the backup by mysqlbinlog.
+
+xtrabackup, mysqld and mysqlbinlog read the credentials and the other options from the my.cnf of the user who runs the agent,
+the pgdata of the agent is the datadir of MySQL.
+*/
+type mysql struct {
+	dataDir
+	catalog
+	log logging.ILog
+}
+
+var _ IDatabase = (*mysql)(nil)
+
+func NewMySQL(dataDir string, log logging.ILog) IDatabase {
+	return &mysql{
+		dataDir: newDataDir(dataDir),
+		catalog: catalog{archive: _mysqlBinlogDir, log: log},
+		log:     log,
+	}
+}
+
+// the binaries are executed with argv by cmds.ExecArgv, the args are never interpreted by shell.
+const (
+	_xtrabackup  = "xtrabackup"
+	_mysqld      = "mysqld"
+	_mysqlbinlog = "mysqlbinlog"
+	_mysqlClient = "mysql"
+
+	_mysqlBinlogDir     = "binlog"
+	_mysqlBinlogInfo    = "xtrabackup_binlog_info"
+	_mysqlCheckpoints   = "xtrabackup_checkpoints"
+	_mysqlFullPrepared  = "full-prepared"
+	_mysqlPidFile       = "mysqld.pid"
+	_mysqlErrorLog      = "mysqld.err"
+	_mysqlSocket        = "mysqld.sock"
+	_mysqlReplaySQL     = "replay.sql"
+	_mysqlRelayLogInfix = "relay-bin"
+
+	_mysqlTargetDirFmt     = "--target-dir=%s"
+	_mysqlDataDirFmt       = "--datadir=%s"
+	_mysqlPortFmt          = "--port=%d"
+	_mysqlParallelFmt      = "--parallel=%d"
+	_mysqlSocketFmt        = "--socket=%s"
+	_mysqlPidFileFmt       = "--pid-file=%s"
+	_mysqlLogErrorFmt      = "--log-error=%s"
+	_mysqlStartPositionFmt = "--start-position=%d"
+	_mysqlStopPositionFmt  = "--stop-position=%d"
+	_mysqlStopDatetimeFmt  = "--stop-datetime=%s"
+	_mysqlIncludeGtidsFmt  = "--include-gtids=%s"
+	_mysqlResultFileFmt    = "--result-file=%s"
+	_mysqlExecuteSourceFmt = "--execute=source %s"
+
+	// mysqlbinlog takes the datetime in the time zone of the agent.
+	_mysqlDatetimeLayout = "2006-01-02 15:04:05"
+	_mysqlCheckSchemaFmt = "USE `%s`"
+	_mysqlStopTimeout    = 5 * time.Minute
+)
+
+var binlogFileRegex = regexp.MustCompile(`^[\w.-]+\.([0-9]{6})$`)
+
+/*
+AsyncBackup write the catalog of the backup and start xtrabackup, the backup id is returned at once,
+the output is logged to the job, which is finished when the backup is prepared.
+*/
+func (m *mysql) AsyncBackup(backupPath, instanceName, backupMode string, opts *model.BackupOptions, dbPort uint16, job *Job) (string, error) {
+	if backupMode != cons.DBBackModeFull {
+		return "", fmt.Errorf("MySQL only supports FULL backup, backup mode is %s,err=%w", backupMode, cons.InvalidDnBackupMode)
+	}
+	if opts.CompressAlg != "" && opts.CompressAlg != cons.CompressAlgNone {
+		return "", fmt.Errorf("MySQL backup is not compressed, compress algorithm is %s,err=%w", opts.CompressAlg, cons.InvalidCompressAlg)
+	}
+
+	backup, dir, err := m.newBackup(backupPath, instanceName, _pgWalArchive)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{
+		"--backup",
+		fmt.Sprintf(_mysqlTargetDirFmt, filepath.Join(dir, _catalogDatabaseDir)),
+		fmt.Sprintf(_mysqlDataDirFmt, m.pgData),
+		fmt.Sprintf(_mysqlPortFmt, dbPort),
+		"--host=127.0.0.1",
+	}
+	if opts.ThreadsNum > 0 {
+		args = append(args, fmt.Sprintf(_mysqlParallelFmt, opts.ThreadsNum))
+	}
+	outputs, err := cmds.AsyncExecArgv(_xtrabackup, args...)
+	if err != nil {
+		backup.Status = cons.OGBackupStatusError
+		_ = writeCatalog(dir, backup)
+		return "", fmt.Errorf("cmds.AsyncExecArgv[args=%v] return err=%w", args, err)
+	}
+
+	go m.follow(outputs, backupPath, instanceName, dir, backup, job)
+	return backup.ID, nil
+}
+
+/*
+follow log the outputs to the job until xtrabackup exits, and then prepare the backup and complete its catalog.
+
+The binlog is archived after every backup, so that the backup is restorable to any point up to now.
+*/
+func (m *mysql) follow(outputs chan *cmds.Output, backupPath, instance, dir string, backup *model.Backup, job *Job) {
+	defer func() {
+		_ = recover()
+	}()
+
+	var err error
+	for output := range outputs {
+		m.log.
+			Field("backup_dir", dir).
+			Debug(fmt.Sprintf("AsyncBackup output[lineNo=%d,msg=%s,err=%v]", output.LineNo, output.Message, output.Error))
+		if output.Error != nil {
+			err = output.Error
+			continue
+		}
+		job.Log(output.Message)
+	}
+
+	database := filepath.Join(dir, _catalogDatabaseDir)
+	if err == nil {
+		job.Log("Preparing backup...")
+		var output string
+		output, err = cmds.ExecArgv(_xtrabackup, "--prepare", fmt.Sprintf(_mysqlTargetDirFmt, database))
+		m.log.Debug(fmt.Sprintf("Prepare backup[dir=%s,output=%s,err=%v]", dir, output, err))
+	}
+	if err == nil {
+		if info, err := os.ReadFile(filepath.Join(database, _mysqlBinlogInfo)); err == nil {
+			backup.StartLsn, backup.RecoveryName = parseBinlogInfo(string(info))
+			backup.StopLsn = backup.StartLsn
+		}
+		if err := m.archiveBinlog(m.pgData, m.archiveDir(backupPath, instance)); err != nil {
+			m.log.Warn(fmt.Sprintf("archive binlog after backup failure,err=%s", err))
+		}
+	}
+	if err2 := m.finishBackup(dir, backup, err); err2 != nil && err == nil {
+		err = err2
+	}
+	job.Finish(nil, err)
+}
+
+/*
+parseBinlogInfo return the binlog position and the executed gtid set of the backup from xtrabackup_binlog_info:
+
+	binlog.000003	1234	3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5
+*/
+func parseBinlogInfo(info string) (position, gtids string) {
+	fields := strings.Fields(info)
+	if len(fields) < 2 {
+		return "", ""
+	}
+	return fields[0] + ":" + fields[1], strings.Join(fields[2:], "")
+}
+
+// splitPosition split the position like `binlog.000003:1234` into the binlog file and the offset.
+func splitPosition(position string) (string, uint64, error) {
+	i := strings.LastIndex(position, ":")
+	if i < 0 {
+		return "", 0, fmt.Errorf("invalid binlog position[%s]", position)
+	}
+	pos, err := strconv.ParseUint(position[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid binlog position[%s],err=%w", position, err)
+	}
+	return position[:i], pos, nil
+}
+
+/*
+archiveBinlog copy the binlog files listed in the binlog index of the datadir into the archive,
+the active binlog file is copied again next time as it grows.
+*/
+func (m *mysql) archiveBinlog(dataDir, archiveDir string) error {
+	indexes, err := filepath.Glob(filepath.Join(dataDir, "*.index"))
+	if err != nil {
+		return fmt.Errorf("glob binlog index return err=%s,wrap=%w", err, cons.Internal)
+	}
+
+	var files []string
+	for _, index := range indexes {
+		if strings.Contains(filepath.Base(index), _mysqlRelayLogInfix) {
+			continue
+		}
+		bs, err := os.ReadFile(index)
+		if err != nil {
+			return fmt.Errorf("read binlog index[%s] failure,err=%s,wrap=%w", index, err, cons.Internal)
+		}
+		for _, line := range strings.Split(string(bs), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			if !filepath.IsAbs(line) {
+				line = filepath.Join(dataDir, line)
+			}
+			files = append(files, line)
+		}
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	if err = os.MkdirAll(archiveDir, 0700); err != nil {
+		return fmt.Errorf("create archive dir[%s] failure,err=%s,wrap=%w", archiveDir, err, cons.Internal)
+	}
+	args := append(append([]string{"-p", "--"}, files...), archiveDir)
+	output, err := cmds.ExecArgv(_cp, args...)
+	m.log.Debug(fmt.Sprintf("ArchiveBinlog[output=%s,err=%v]", output, err))
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
+	}
+	return nil
+}
+
+// archivedBinlogs return the archived binlog files in order, the files before the first one and after the last one are dropped if they are not empty.
+func archivedBinlogs(archiveDir, first, last string) ([]string, error) {
+	entries, err := os.ReadDir(archiveDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() || !binlogFileRegex.MatchString(name) {
+			continue
+		}
+		if (first != "" && name < first) || (last != "" && name > last) {
+			continue
+		}
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func binlogSeqNo(name string) (uint64, error) {
+	match := binlogFileRegex.FindStringSubmatch(name)
+	if match == nil {
+		return 0, fmt.Errorf("invalid binlog file name[%s]", name)
+	}
+	return strconv.ParseUint(match[1], 10, 64)
+}
+
+// binlogFileName is the reverse of binlogSeqNo, the basename is taken from the file name of the same binlog.
+func binlogFileName(name string, seqNo uint64) string {
+	return fmt.Sprintf("%s.%06d", strings.TrimSuffix(name, filepath.Ext(name)), seqNo)
+}
+
+/*
+Restore copy the prepared backup to the datadir, and replay the archived binlog up to the target.
+
+The binlog of the datadir before restore, which is moved to the temp dir by the handler, is archived first,
+so that the latest transactions are able to be replayed. The binlog is replayed into a mysqld which listens
+on a socket in the datadir only, and is stopped afterwards, MySQL is started by the handler.
+
+	the time target replays the events before the time, the events of the time are replayed too if inclusive.
+	the position target replays the events before the position, the event at the position is replayed too if inclusive.
+	the gtid target replays the transactions of the gtid set only, the transactions in the backup are skipped by MySQL.
+*/
+func (m *mysql) Restore(backupPath, instance, backupID string, target *model.RecoveryTarget, job *Job) error {
+	if target != nil && (target.LSN != "" || target.Xid != "" || target.Name != "" || target.CSN != "") {
+		return fmt.Errorf("MySQL only supports the time, position and gtid targets,err=%w", cons.InvalidRecoveryTarget)
+	}
+
+	backup, err := m.ShowBackup(backupPath, instance, backupID)
+	if err != nil {
+		return fmt.Errorf("show backup failure,err=%s,wrap=%w", err, cons.RestoreFailed)
+	}
+
+	archiveDir := m.archiveDir(backupPath, instance)
+	if err = m.archiveBinlog(m.pgDataTemp, archiveDir); err != nil {
+		m.log.Warn(fmt.Sprintf("archive binlog before restore failure,err=%s", err))
+	}
+
+	job.Log("Copying back the backup...")
+	database := m.databaseDir(backupPath, instance, backupID)
+	output, err := cmds.ExecArgv(_xtrabackup, "--copy-back", fmt.Sprintf(_mysqlTargetDirFmt, database), fmt.Sprintf(_mysqlDataDirFmt, m.pgData))
+	m.log.Debug(fmt.Sprintf("Restore MySQL[output=%s,err=%v]", output, err))
+	if err != nil {
+		_ = os.RemoveAll(m.pgData)
+		return fmt.Errorf("copy back failure[output=%s],err=%s,wrap=%w", output, err, cons.RestoreFailed)
+	}
+
+	if target.IsEmpty() {
+		return nil
+	}
+	if err = m.replay(archiveDir, backup.StartLsn, target, job); err != nil {
+		_ = os.RemoveAll(m.pgData)
+		return err
+	}
+	return nil
+}
+
+// replay the archived binlog from the position of the backup up to the target.
+func (m *mysql) replay(archiveDir, start string, target *model.RecoveryTarget, job *Job) error {
+	first, startPos, err := splitPosition(start)
+	if err != nil {
+		return fmt.Errorf("backup has no binlog position,err=%s,wrap=%w", err, cons.RestoreFailed)
+	}
+
+	replaySQL := filepath.Join(m.pgData, _mysqlReplaySQL)
+	args, last, err := m.replayArgs(target)
+	if err != nil {
+		return err
+	}
+	files, err := archivedBinlogs(archiveDir, first, last)
+	if err != nil || len(files) == 0 {
+		return fmt.Errorf("no archived binlog from %s,err=%v,wrap=%w", first, err, cons.RestoreFailed)
+	}
+	args = append(args, fmt.Sprintf(_mysqlStartPositionFmt, startPos), fmt.Sprintf(_mysqlResultFileFmt, replaySQL))
+	for _, f := range files {
+		args = append(args, filepath.Join(archiveDir, f))
+	}
+
+	job.Log(fmt.Sprintf("Decoding binlog %s to %s...", files[0], files[len(files)-1]))
+	output, err := cmds.ExecArgv(_mysqlbinlog, args...)
+	m.log.Debug(fmt.Sprintf("Decode binlog[args=%v,output=%s,err=%v]", args, output, err))
+	if err != nil {
+		return fmt.Errorf("decode binlog failure[output=%s],err=%s,wrap=%w", output, err, cons.RestoreFailed)
+	}
+	defer os.Remove(replaySQL)
+
+	job.Log("Replaying binlog...")
+	socket := filepath.Join(m.pgData, _mysqlSocket)
+	output, err = cmds.ExecArgv(_mysqld, append(m.startArgs(m.pgData),
+		fmt.Sprintf(_mysqlSocketFmt, socket), "--skip-networking", "--skip-grant-tables", "--skip-log-bin")...)
+	m.log.Debug(fmt.Sprintf("Start MySQL to replay binlog[output=%s,err=%v]", output, err))
+	if err != nil {
+		return fmt.Errorf("start MySQL to replay binlog failure[output=%s],err=%s,wrap=%w", output, err, cons.RestoreFailed)
+	}
+	defer func() {
+		if err := m.stop(m.pgData); err != nil {
+			m.log.Warn(fmt.Sprintf("stop MySQL after replaying binlog failure,err=%s", err))
+		}
+	}()
+
+	output, err = cmds.ExecArgv(_mysqlClient, fmt.Sprintf(_mysqlSocketFmt, socket), "--binary-mode", fmt.Sprintf(_mysqlExecuteSourceFmt, replaySQL))
+	m.log.Debug(fmt.Sprintf("Replay binlog[output=%s,err=%v]", output, err))
+	if err != nil {
+		return fmt.Errorf("replay binlog failure[output=%s],err=%s,wrap=%w", output, err, cons.RestoreFailed)
+	}
+	return nil
+}
+
+// replayArgs return the mysqlbinlog args of the target, and the last binlog file to replay if the target is a position.
+func (m *mysql) replayArgs(target *model.RecoveryTarget) ([]string, string, error) {
+	switch {
+	case target.Time != "":
+		t, err := parseRecoveryTime(target.Time)
+		if err != nil {
+			return nil, "", err
+		}
+		if target.Inclusive {
+			t = t.Add(time.Second)
+		}
+		return []string{fmt.Sprintf(_mysqlStopDatetimeFmt, t.Local().Format(_mysqlDatetimeLayout))}, "", nil
+	case target.Position != "":
+		file, pos, err := splitPosition(target.Position)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s,err=%w", err, cons.InvalidRecoveryTarget)
+		}
+		if target.Inclusive {
+			pos++
+		}
+		return []string{fmt.Sprintf(_mysqlStopPositionFmt, pos)}, file, nil
+	default:
+		return []string{fmt.Sprintf(_mysqlIncludeGtidsFmt, target.GTID)}, "", nil
+	}
+}
+
+func parseRecoveryTime(s string) (time.Time, error) {
+	for _, layout := range model.RecoveryTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time[%s],err=%w", s, cons.InvalidRecoveryTarget)
+}
+
+// startArgs return the args of `mysqld --daemonize`, which exits after mysqld is ready for connections.
+func (m *mysql) startArgs(dataDir string) []string {
+	return []string{
+		"--daemonize",
+		fmt.Sprintf(_mysqlDataDirFmt, dataDir),
+		fmt.Sprintf(_mysqlPidFileFmt, filepath.Join(dataDir, _mysqlPidFile)),
+		fmt.Sprintf(_mysqlLogErrorFmt, filepath.Join(dataDir, _mysqlErrorLog)),
+	}
+}
+
+func (m *mysql) Start() error {
+	args := m.startArgs(m.pgData)
+	output, err := cmds.ExecArgv(_mysqld, args...)
+	m.log.Debug(fmt.Sprintf("Start MySQL[output=%s]", output))
+
+	if errors.Is(err, cons.CmdOperateFailed) {
+		return fmt.Errorf("start MySQL failure[output=%s],err=%s,wrap=%w", output, err, cons.StartOpenGaussFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v,output=%s] return err=%w", args, output, err)
+	}
+	return nil
+}
+
+func (m *mysql) Stop() error {
+	if err := m.stop(m.pgData); err != nil {
+		return fmt.Errorf("stop MySQL failure,err=%s,wrap=%w", err, cons.StopOpenGaussFailed)
+	}
+	return nil
+}
+
+// stop send SIGTERM to the mysqld of the datadir, and wait for it to shutdown.
+func (m *mysql) stop(dataDir string) error {
+	pid, err := mysqldPid(dataDir)
+	if err != nil {
+		return err
+	}
+	if err = syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return fmt.Errorf("kill mysqld[pid=%d] return err=%w", pid, err)
+	}
+
+	deadline := time.Now().Add(_mysqlStopTimeout)
+	for time.Now().Before(deadline) {
+		if !processAlive(pid) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("mysqld[pid=%d] is still running after %s", pid, _mysqlStopTimeout)
+}
+
+// mysqldPid return the pid of the running mysqld from the pid file in the datadir, the pid file of agent is preferred.
+func mysqldPid(dataDir string) (int, error) {
+	files, _ := filepath.Glob(filepath.Join(dataDir, "*.pid"))
+	files = append([]string{filepath.Join(dataDir, _mysqlPidFile)}, files...)
+	for _, f := range files {
+		bs, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(bs)))
+		if err == nil && processAlive(pid) {
+			return pid, nil
+		}
+	}
+	return 0, fmt.Errorf("no mysqld running on datadir[%s]", dataDir)
+}
+
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// Status return MySQL server status like openGauss, "Running" or "Stopped".
+func (m *mysql) Status() (string, error) {
+	if _, err := mysqldPid(m.pgData); err != nil {
+		m.log.Debug(fmt.Sprintf("Status MySQL[err=%s]", err))
+		return "Stopped", nil
+	}
+	return "Running", nil
+}
+
+// DiskSpace see diskSpace.
+func (m *mysql) DiskSpace(backupPath, instance, backupID string) (*model.DiskSpace, error) {
+	return diskSpace(m, m.pgData, m.log, backupPath, instance, backupID)
+}
+
+func (m *mysql) Auth(user, password, dbName string, dbPort uint16) error {
+	if strings.Trim(user, " ") == "" ||
+		strings.Trim(password, " ") == "" ||
+		strings.Trim(dbName, " ") == "" ||
+		dbPort == 0 {
+		return fmt.Errorf("invalid inputs[user=%s,password=%s,dbName=%s,dbPort=%d]", user, password, dbName, dbPort)
+	}
+
+	_m, err := mysqlutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return fmt.Errorf("mysqlutil.Open failure,err=%w", err)
+	}
+
+	if err := _m.Ping(); err != nil {
+		return fmt.Errorf("ping MySQL fail[user=%s,pw length=%d,dbName=%s],err=%w", user, len(password), dbName, err)
+	}
+	return nil
+}
+
+func (m *mysql) CheckSchema(user, password, dbName string, dbPort uint16, schema string) error {
+	_m, err := mysqlutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return fmt.Errorf("mysqlutil.Open failure,err=%w", err)
+	}
+
+	if err := _m.CheckSchema(schema); err != nil {
+		return fmt.Errorf("check MySQL schema fail[user=%s,dbName=%s, schema=%s],err=%w", user, dbName, schema, err)
+	}
+	return nil
+}
+
+// SchemaQuery return the statement which fails if the schema does not exist in MySQL, where a schema is a database.
+func (m *mysql) SchemaQuery(schema string) string {
+	return fmt.Sprintf(_mysqlCheckSchemaFmt, schema)
+}
+
+// ShowRecoveryResult return the executed gtid set as the replayed location, MySQL has no replay time.
+func (m *mysql) ShowRecoveryResult(user, password, dbName string, dbPort uint16) (*model.RecoveryResult, error) {
+	_m, err := mysqlutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return nil, fmt.Errorf("mysqlutil.Open failure,err=%w", err)
+	}
+
+	gtids, err := _m.RecoveryResult()
+	if err != nil {
+		return nil, fmt.Errorf("query MySQL recovery result fail[user=%s,dbName=%s],err=%w", user, dbName, err)
+	}
+	return &model.RecoveryResult{ReplayLSN: gtids}, nil
+}
+
+/*
+EnableArchive copy the binlog into the archive of the backup path, MySQL has no archive_command,
+so the binlog is archived by the agent when the archive is enabled, after every backup and before restore.
+*/
+func (m *mysql) EnableArchive(backupPath, instance string) error {
+	if err := m.archiveBinlog(m.pgData, m.archiveDir(backupPath, instance)); err != nil {
+		return fmt.Errorf("enable archive failure,err=%s,wrap=%w", err, cons.EnableArchiveFailed)
+	}
+	return nil
+}
+
+// ArchiveStatus check the binlog is enabled and archived, the archived binlog files are reported as timeline 1.
+func (m *mysql) ArchiveStatus(user, password, dbName string, dbPort uint16, backupPath, instance string) (*model.ArchiveStatus, error) {
+	_m, err := mysqlutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return nil, fmt.Errorf("mysqlutil.Open failure,err=%w", err)
+	}
+
+	logBin, basename, binlogFile, err := _m.ArchiveSettings()
+	if err != nil {
+		return nil, fmt.Errorf("query MySQL archive settings fail[user=%s,dbName=%s],err=%w", user, dbName, err)
+	}
+	settings := &model.ArchiveSettings{
+		ArchiveMode:    "off",
+		ArchiveCommand: basename,
+		CurrentWalFile: binlogFile,
+	}
+	if logBin {
+		settings.ArchiveMode = _archiveModeOn
+	}
+
+	archiveDir := m.archiveDir(backupPath, instance)
+	files, err := archivedBinlogs(archiveDir, "", "")
+	if err != nil {
+		m.log.Debug(fmt.Sprintf("ShowArchive[archiveDir=%s,err=%v]", archiveDir, err))
+		return newArchiveStatus(settings, nil, false, fmt.Sprintf("show archive failure,err=%s", err)), nil
+	}
+
+	status := newArchiveStatus(settings, binlogTimelines(files), true, "")
+	cur, err1 := binlogSeqNo(binlogFile)
+	latest, err2 := binlogSeqNo(status.LatestArchived)
+	if err1 == nil && err2 == nil && cur > latest {
+		status.LagSegments = cur - latest
+	}
+	return status, nil
+}
+
+// binlogTimelines report the archived binlog files like the wal of timeline 1, the gaps of the sequence numbers are lost segments.
+func binlogTimelines(files []string) []*model.ArchiveTimeline {
+	if len(files) == 0 {
+		return nil
+	}
+
+	tl := &model.ArchiveTimeline{
+		Tli:          1,
+		MinSegno:     files[0],
+		MaxSegno:     files[len(files)-1],
+		NSegments:    len(files),
+		Status:       _archiveTimelineOK,
+		LostSegments: []*model.LostSegment{},
+	}
+	for i := 1; i < len(files); i++ {
+		prev, _ := binlogSeqNo(files[i-1])
+		cur, _ := binlogSeqNo(files[i])
+		if cur > prev+1 {
+			tl.LostSegments = append(tl.LostSegments, &model.LostSegment{
+				BeginSegno: binlogFileName(files[i], prev+1),
+				EndSegno:   binlogFileName(files[i], cur-1),
+			})
+		}
+	}
+	if len(tl.LostSegments) > 0 {
+		tl.Status = "DEGRADED"
+	}
+	return []*model.ArchiveTimeline{tl}
+}
+
+// ValidateBackup check the backup is completed and prepared by xtrabackup.
+func (m *mysql) ValidateBackup(backupPath, instance, backupID string) error {
+	backup, err := m.ShowBackup(backupPath, instance, backupID)
+	if err != nil {
+		return fmt.Errorf("validate backup failure,err=%s,wrap=%w", err, cons.ValidateBackupFailed)
+	}
+	if backup.Status != cons.OGBackupStatusOk {
+		return fmt.Errorf("backup status is %s,err=%w", backup.Status, cons.ValidateBackupFailed)
+	}
+
+	file := filepath.Join(m.databaseDir(backupPath, instance, backupID), _mysqlCheckpoints)
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read %s failure,err=%s,wrap=%w", file, err, cons.ValidateBackupFailed)
+	}
+	if !strings.Contains(string(bs), _mysqlFullPrepared) {
+		return fmt.Errorf("backup is not prepared[checkpoints=%s],err=%w", string(bs), cons.ValidateBackupFailed)
+	}
+	return nil
+}
+
+/*
+RestoreScratch copy back the backup into a scratch datadir beside the datadir, and start it on the port.
+
+It returns the scratch datadir which must be removed by CleanScratch, the running MySQL is never touched.
+*/
+func (m *mysql) RestoreScratch(backupPath, instance, backupID string, port uint16) (string, error) {
+	dataDir, err := os.MkdirTemp(filepath.Dir(m.pgData), _scratchDirPrefix+backupID+"_")
+	if err != nil {
+		return "", fmt.Errorf("create scratch datadir failure,err=%s,wrap=%w", err, cons.VerifyRestoreFailed)
+	}
+
+	database := m.databaseDir(backupPath, instance, backupID)
+	output, err := cmds.ExecArgv(_xtrabackup, "--copy-back", fmt.Sprintf(_mysqlTargetDirFmt, database), fmt.Sprintf(_mysqlDataDirFmt, dataDir))
+	m.log.Debug(fmt.Sprintf("RestoreScratch[datadir=%s,output=%s,err=%v]", dataDir, output, err))
+	if err != nil {
+		_ = m.CleanScratch(dataDir)
+		return "", fmt.Errorf("restore to scratch datadir failure[output=%s],err=%s,wrap=%w", output, err, cons.VerifyRestoreFailed)
+	}
+
+	// the scratch MySQL must not write binlog or take the ports of the original one.
+	args := append(m.startArgs(dataDir),
+		fmt.Sprintf(_mysqlPortFmt, port),
+		fmt.Sprintf(_mysqlSocketFmt, filepath.Join(dataDir, _mysqlSocket)),
+		"--bind-address=127.0.0.1", "--skip-log-bin", "--mysqlx=OFF")
+	output, err = cmds.ExecArgv(_mysqld, args...)
+	m.log.Debug(fmt.Sprintf("Start scratch MySQL[datadir=%s,port=%d,output=%s,err=%v]", dataDir, port, output, err))
+	if err != nil {
+		_ = m.CleanScratch(dataDir)
+		return "", fmt.Errorf("start scratch MySQL failure[output=%s],err=%s,wrap=%w", output, err, cons.VerifyRestoreFailed)
+	}
+	return dataDir, nil
+}
+
+// CleanScratch stop the scratch MySQL and remove its datadir.
+func (m *mysql) CleanScratch(dataDir string) error {
+	if filepath.Dir(dataDir) != filepath.Dir(m.pgData) || !strings.HasPrefix(filepath.Base(dataDir), _scratchDirPrefix) {
+		return fmt.Errorf("invalid scratch datadir[%s],err=%w", dataDir, cons.NoPermission)
+	}
+
+	err := m.stop(dataDir)
+	m.log.Debug(fmt.Sprintf("Stop scratch MySQL[datadir=%s,err=%v]", dataDir, err))
+
+	if err = os.RemoveAll(dataDir); err != nil {
+		return fmt.Errorf("remove scratch datadir[%s] failure,err=%s,wrap=%w", dataDir, err, cons.Internal)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+var _ = Describe("MySQL", func() {
+	var (
+		dir        string
+		backupPath string
+		m          *mysql
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "mysql")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+
+		backupPath = filepath.Join(dir, "backup")
+		m = NewMySQL(filepath.Join(dir, "datadir"), log).(*mysql)
+	})
+
+	Context("AsyncBackup", func() {
+		It("unsupported options", func() {
+			_, err := m.AsyncBackup(backupPath, "ins-default-ss", cons.DBBackModePTrack, &model.BackupOptions{}, 3306, nil)
+			Expect(errors.Is(err, cons.InvalidDnBackupMode)).To(BeTrue())
+
+			_, err = m.AsyncBackup(backupPath, "ins-default-ss", cons.DBBackModeFull, &model.BackupOptions{CompressAlg: cons.CompressAlgZlib}, 3306, nil)
+			Expect(errors.Is(err, cons.InvalidCompressAlg)).To(BeTrue())
+		})
+
+		It("parse xtrabackup_binlog_info", func() {
+			position, gtids := parseBinlogInfo("binlog.000003\t1234\t3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5\n")
+			Expect(position).To(Equal("binlog.000003:1234"))
+			Expect(gtids).To(Equal("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"))
+
+			position, gtids = parseBinlogInfo("binlog.000003\t1234\n")
+			Expect(position).To(Equal("binlog.000003:1234"))
+			Expect(gtids).To(BeEmpty())
+
+			position, _ = parseBinlogInfo("")
+			Expect(position).To(BeEmpty())
+		})
+	})
+
+	Context("Restore", func() {
+		It("unsupported targets", func() {
+			err := m.Restore(backupPath, "ins-default-ss", "RK3NBX", &model.RecoveryTarget{LSN: "0/2000028"}, nil)
+			Expect(errors.Is(err, cons.InvalidRecoveryTarget)).To(BeTrue())
+		})
+
+		It("split position", func() {
+			file, pos, err := splitPosition("binlog.000003:1234")
+			Expect(err).To(BeNil())
+			Expect(file).To(Equal("binlog.000003"))
+			Expect(pos).To(Equal(uint64(1234)))
+
+			_, _, err = splitPosition("binlog.000003")
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("mysqlbinlog args of targets", func() {
+			args, last, err := m.replayArgs(&model.RecoveryTarget{Position: "binlog.000004:120", Inclusive: true})
+			Expect(err).To(BeNil())
+			Expect(args).To(Equal([]string{"--stop-position=121"}))
+			Expect(last).To(Equal("binlog.000004"))
+
+			args, last, err = m.replayArgs(&model.RecoveryTarget{GTID: "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-7"})
+			Expect(err).To(BeNil())
+			Expect(args).To(Equal([]string{"--include-gtids=3e11fa47-71ca-11e1-9e33-c80aa9429562:1-7"}))
+			Expect(last).To(BeEmpty())
+
+			args, _, err = m.replayArgs(&model.RecoveryTarget{Time: "2023-03-01 10:00:00+00"})
+			Expect(err).To(BeNil())
+			Expect(args).To(HaveLen(1))
+			Expect(args[0]).To(HavePrefix("--stop-datetime="))
+
+			_, _, err = m.replayArgs(&model.RecoveryTarget{Time: "yesterday"})
+			Expect(errors.Is(err, cons.InvalidRecoveryTarget)).To(BeTrue())
+		})
+	})
+
+	Context("binlog archive", func() {
+		It("archive binlog of the index", func() {
+			dataDir := filepath.Join(dir, "datadir")
+			Expect(os.MkdirAll(dataDir, 0700)).To(BeNil())
+			for _, f := range []string{"binlog.000001", "binlog.000002", "relay-bin.000001"} {
+				Expect(os.WriteFile(filepath.Join(dataDir, f), []byte(f), 0600)).To(BeNil())
+			}
+			Expect(os.WriteFile(filepath.Join(dataDir, "binlog.index"), []byte("./binlog.000001\n./binlog.000002\n"), 0600)).To(BeNil())
+			Expect(os.WriteFile(filepath.Join(dataDir, "relay-bin.index"), []byte("./relay-bin.000001\n"), 0600)).To(BeNil())
+
+			archiveDir := m.archiveDir(backupPath, "ins-default-ss")
+			Expect(m.archiveBinlog(dataDir, archiveDir)).To(BeNil())
+
+			files, err := archivedBinlogs(archiveDir, "", "")
+			Expect(err).To(BeNil())
+			Expect(files).To(Equal([]string{"binlog.000001", "binlog.000002"}))
+
+			files, err = archivedBinlogs(archiveDir, "binlog.000002", "")
+			Expect(err).To(BeNil())
+			Expect(files).To(Equal([]string{"binlog.000002"}))
+		})
+
+		It("scan archived binlog", func() {
+			tls := binlogTimelines([]string{"binlog.000001", "binlog.000002", "binlog.000005"})
+			Expect(tls).To(HaveLen(1))
+			Expect(tls[0].MinSegno).To(Equal("binlog.000001"))
+			Expect(tls[0].MaxSegno).To(Equal("binlog.000005"))
+			Expect(tls[0].NSegments).To(Equal(3))
+			Expect(tls[0].Status).To(Equal("DEGRADED"))
+			Expect(tls[0].LostSegments).To(Equal([]*model.LostSegment{{BeginSegno: "binlog.000003", EndSegno: "binlog.000004"}}))
+
+			Expect(binlogTimelines(nil)).To(BeNil())
+		})
+	})
+
+	Context("mysqld", func() {
+		It("status without pid file", func() {
+			status, err := m.Status()
+			Expect(err).To(BeNil())
+			Expect(status).To(Equal("Stopped"))
+
+			Expect(errors.Is(m.Stop(), cons.StopOpenGaussFailed)).To(BeTrue())
+		})
+
+		It("pid of the running mysqld", func() {
+			Expect(os.MkdirAll(m.pgData, 0700)).To(BeNil())
+			Expect(os.WriteFile(filepath.Join(m.pgData, "mysqld.pid"), []byte(strconv.Itoa(os.Getpid())+"\n"), 0600)).To(BeNil())
+
+			pid, err := mysqldPid(m.pgData)
+			Expect(err).To(BeNil())
+			Expect(pid).To(Equal(os.Getpid()))
+		})
+
+		It("validate unprepared backup", func() {
+			Expect(errors.Is(m.ValidateBackup(backupPath, "ins-default-ss", "RK3NBX"), cons.ValidateBackupFailed)).To(BeTrue())
+		})
+	})
+})
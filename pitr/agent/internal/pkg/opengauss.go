@@ -21,6 +21,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
@@ -31,74 +34,82 @@ import (
 	"github.com/dlclark/regexp2"
 )
 
-type (
-	openGauss struct {
-		shell      string
-		pgData     string
-		pgDataTemp string
-		log        logging.ILog
-	}
-
-	IOpenGauss interface {
-		AsyncBackup(backupPath, instanceName, backupMode string, threadsNum uint8, dbPort uint16) (string, error)
-		ShowBackup(backupPath, instanceName, backupID string) (*model.Backup, error)
-		Init(backupPath string) error
-		AddInstance(backupPath, instance string) error
-		DelInstance(backupPath, instance string) error
-		DelBackup(backupPath, instance, backupID string) error
-		Start() error
-		Stop() error
-		Status() (string, error)
-		Restore(backupPath, instance, backupID string) error
-		ShowBackupList(backupPath, instanceName string) ([]*model.Backup, error)
-		Auth(user, password, dbName string, dbPort uint16) error
-		CheckSchema(user, password, dbName string, dbPort uint16, schema string) error
-		MvTempToPgData() error
-		MvPgDataToTemp() error
-		CleanPgDataTemp() error
-	}
-)
-
-var _ IOpenGauss = (*openGauss)(nil)
+type openGauss struct {
+	dataDir
+	log logging.ILog
+}
 
-func NewOpenGauss(shell, pgData string, log logging.ILog) IOpenGauss {
-	dirs := strings.Split(pgData, "/")
-	dirs = append(dirs[0:len(dirs)-1], "temp")
+var _ IDatabase = (*openGauss)(nil)
 
+func NewOpenGauss(pgData string, log logging.ILog) IDatabase {
 	return &openGauss{
-		shell:      shell,
-		pgData:     pgData,
-		pgDataTemp: strings.Join(dirs, "/"),
-		log:        log,
+		dataDir: newDataDir(pgData),
+		log:     log,
 	}
 }
 
+// the binaries are executed with argv by cmds.ExecArgv, the args are never interpreted by shell.
 const (
-	_backupFmt    = "gs_probackup backup --backup-path=%s --instance=%s --backup-mode=%s --pgdata=%s --threads=%d --pgport %d 2>&1"
-	_showFmt      = "gs_probackup show --instance=%s --backup-path=%s --backup-id=%s --format=json 2>&1"
-	_delBackupFmt = "gs_probackup delete --backup-path=%s --instance=%s --backup-id=%s 2>&1"
-	_restoreFmt   = "gs_probackup restore --backup-path=%s --instance=%s --backup-id=%s --pgdata=%s 2>&1"
-
-	_initFmt  = "gs_probackup init --backup-path=%s 2>&1"
-	_rmDirFmt = "rm -r %s"
-
-	_addInstanceFmt = "gs_probackup add-instance --backup-path=%s --instance=%s --pgdata=%s 2>&1"
-	_delInstanceFmt = "gs_probackup del-instance --backup-path=%s --instance=%s 2>&1"
-
-	_startOpenGaussFmt = "gs_ctl start --pgdata=%s"
-	_stopOpenGaussFmt  = "gs_ctl stop --pgdata=%s"
-	_statusGaussFmt    = "gs_ctl status --pgdata=%s"
-
-	_showListFmt = "gs_probackup show --instance=%s --backup-path=%s --format=json 2>&1"
-
-	_mvFmt = "mv %s %s"
+	_probackup = "gs_probackup"
+	_gsctl     = "gs_ctl"
+	_gsguc     = "gs_guc"
+
+	_backupPathFmt = "--backup-path=%s"
+	_instanceFmt   = "--instance=%s"
+	_backupIDFmt   = "--backup-id=%s"
+	_pgDataFmt     = "--pgdata=%s"
+	_backupModeFmt = "--backup-mode=%s"
+	_threadsFmt    = "--threads=%d"
+	_pgPortArg     = "--pgport"
+	_progressArg   = "--progress"
+	_formatJSON    = "--format=json"
+
+	_compressAlgFmt      = "--compress-algorithm=%s"
+	_compressLevelFmt    = "--compress-level=%d"
+	_streamArg           = "--stream"
+	_skipBlockValidation = "--skip-block-validation"
+
+	_recoveryTargetTimeFmt      = "--recovery-target-time=%s"
+	_recoveryTargetLsnFmt       = "--recovery-target-lsn=%s"
+	_recoveryTargetXidFmt       = "--recovery-target-xid=%s"
+	_recoveryTargetNameFmt      = "--recovery-target-name=%s"
+	_recoveryTargetInclusiveFmt = "--recovery-target-inclusive=%t"
+	_recoveryTargetLatest       = "--recovery-target=latest"
+
+	// gs_probackup has no csn target, so it is appended to the recovery.conf written by gs_probackup.
+	_recoveryConf             = "recovery.conf"
+	_recoveryConfCsnFmt       = "recovery_target_csn = '%s'\n"
+	_recoveryConfInclusiveFmt = "recovery_target_inclusive = %t\n"
+
+	// the archive_command is run by the shell of openGauss, the backup path and instance are validated by the handlers.
+	_archiveModeArg    = "archive_mode=on"
+	_archiveCommandFmt = "archive_command='%s'"
+	_archivePushFmt    = "%s archive-push --backup-path=%s --instance=%s --wal-file-path=%%p --wal-file-name=%%f"
+	_archiveArg        = "--archive"
+
+	// the scratch openGauss must not push wal into the archive of the original one.
+	_scratchOptionsFmt = "-p %d -c archive_mode=off"
+	_scratchDirPrefix  = "verify_"
 )
 
-func (og *openGauss) AsyncBackup(backupPath, instanceName, backupMode string, threadsNum uint8, dbPort uint16) (string, error) {
-	cmd := fmt.Sprintf(_backupFmt, backupPath, instanceName, backupMode, og.pgData, threadsNum, dbPort)
-	outputs, err := cmds.AsyncExec(og.shell, cmd)
+/*
+AsyncBackup start the backup and return the backup id from the first line of output,
+the rest of output is logged to the job, which is finished when gs_probackup exits.
+*/
+func (og *openGauss) AsyncBackup(backupPath, instanceName, backupMode string, opts *model.BackupOptions, dbPort uint16, job *Job) (string, error) {
+	args := append([]string{
+		"backup",
+		fmt.Sprintf(_backupPathFmt, backupPath),
+		fmt.Sprintf(_instanceFmt, instanceName),
+		fmt.Sprintf(_backupModeFmt, backupMode),
+		fmt.Sprintf(_pgDataFmt, og.pgData),
+		fmt.Sprintf(_threadsFmt, opts.ThreadsNum),
+		_pgPortArg, strconv.Itoa(int(dbPort)),
+		_progressArg,
+	}, og.backupArgs(opts)...)
+	outputs, err := cmds.AsyncExecArgv(_probackup, args...)
 	if err != nil {
-		return "", fmt.Errorf("cmds.AsyncExec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return "", fmt.Errorf("cmds.AsyncExecArgv[args=%v] return err=%w", args, err)
 	}
 
 	for output := range outputs {
@@ -114,22 +125,46 @@ func (og *openGauss) AsyncBackup(backupPath, instanceName, backupMode string, th
 		}
 
 		// get the backup id from the first line
+		job.Log(output.Message)
 		bid, err := og.getBackupID(output.Message)
 		if err != nil {
+			go og.follow(outputs, nil)
 			return "", fmt.Errorf("og.getBackupID[source=%s] return err=%w", output.Message, err)
 		}
-		// ignore other output
-		go og.ignore(outputs)
+		go og.follow(outputs, job)
 		return bid, nil //nolint
 	}
 	return "", fmt.Errorf("unknow err")
 }
 
+/*
+backupArgs return the optional args of `gs_probackup backup`:
+
+	the wal is streamed into the backup with `--stream`, so that the backup is restorable without the wal archive.
+	the page-level checksums of data files are validated during backup unless `--skip-block-validation`.
+*/
+func (og *openGauss) backupArgs(opts *model.BackupOptions) []string {
+	var args []string
+	if opts.CompressAlg != "" {
+		args = append(args, fmt.Sprintf(_compressAlgFmt, opts.CompressAlg))
+		if opts.CompressAlg != cons.CompressAlgNone && opts.CompressLevel != 0 {
+			args = append(args, fmt.Sprintf(_compressLevelFmt, opts.CompressLevel))
+		}
+	}
+	if opts.Stream {
+		args = append(args, _streamArg)
+	}
+	if opts.SkipBlockValidation {
+		args = append(args, _skipBlockValidation)
+	}
+	return args
+}
+
 func (og *openGauss) ShowBackup(backupPath, instanceName, backupID string) (*model.Backup, error) {
-	cmd := fmt.Sprintf(_showFmt, instanceName, backupPath, backupID)
-	output, err := cmds.Exec(og.shell, cmd)
+	args := []string{"show", fmt.Sprintf(_instanceFmt, instanceName), fmt.Sprintf(_backupPathFmt, backupPath), fmt.Sprintf(_backupIDFmt, backupID), _formatJSON}
+	output, err := cmds.ExecArgv(_probackup, args...)
 	if err != nil {
-		return nil, fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return nil, fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 
 	var list []*model.BackupList
@@ -151,25 +186,25 @@ func (og *openGauss) ShowBackup(backupPath, instanceName, backupID string) (*mod
 }
 
 func (og *openGauss) DelBackup(backupPath, instanceName, backupID string) error {
-	cmd := fmt.Sprintf(_delBackupFmt, backupPath, instanceName, backupID)
-	_, err := cmds.Exec(og.shell, cmd)
+	args := []string{"delete", fmt.Sprintf(_backupPathFmt, backupPath), fmt.Sprintf(_instanceFmt, instanceName), fmt.Sprintf(_backupIDFmt, backupID)}
+	_, err := cmds.ExecArgv(_probackup, args...)
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 	return nil
 }
 
 func (og *openGauss) Init(backupPath string) error {
-	cmd := fmt.Sprintf(_initFmt, backupPath)
+	args := []string{"init", fmt.Sprintf(_backupPathFmt, backupPath)}
 
-	output, err := cmds.Exec(og.shell, cmd)
+	output, err := cmds.ExecArgv(_probackup, args...)
 	og.log.Debug(fmt.Sprintf("Init output[msg=%s,err=%v]", output, err))
 
 	if errors.Is(err, cons.CmdOperateFailed) {
 		return fmt.Errorf("init backup path failure,err=%s,wrap=%w", err, cons.BackupPathAlreadyExist)
 	}
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 	return nil
 }
@@ -179,67 +214,67 @@ func (og *openGauss) deinit(backupPath string) error {
 		return cons.NoPermission
 	}
 
-	cmd := fmt.Sprintf(_rmDirFmt, backupPath)
-	if _, err := cmds.Exec(og.shell, cmd); err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+	args := []string{"-r", "--", backupPath}
+	if _, err := cmds.ExecArgv(_rm, args...); err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 	return nil
 }
 
 func (og *openGauss) AddInstance(backupPath, instance string) error {
-	cmd := fmt.Sprintf(_addInstanceFmt, backupPath, instance, og.pgData)
+	args := []string{"add-instance", fmt.Sprintf(_backupPathFmt, backupPath), fmt.Sprintf(_instanceFmt, instance), fmt.Sprintf(_pgDataFmt, og.pgData)}
 
-	output, err := cmds.Exec(og.shell, cmd)
+	output, err := cmds.ExecArgv(_probackup, args...)
 	og.log.Debug(fmt.Sprintf("AddInstance[output=%s,err=%v]", output, err))
 
 	if errors.Is(err, cons.CmdOperateFailed) {
 		return fmt.Errorf("add instance failure[output=%s],err=%s,wrap=%w", output, err, cons.InstanceAlreadyExist)
 	}
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 	return nil
 }
 
 func (og *openGauss) DelInstance(backupPath, instance string) error {
-	cmd := fmt.Sprintf(_delInstanceFmt, backupPath, instance)
-	output, err := cmds.Exec(og.shell, cmd)
+	args := []string{"del-instance", fmt.Sprintf(_backupPathFmt, backupPath), fmt.Sprintf(_instanceFmt, instance)}
+	output, err := cmds.ExecArgv(_probackup, args...)
 	og.log.Debug(fmt.Sprintf("DelInstance[output=%s,err=%v]", output, err))
 
 	if errors.Is(err, cons.CmdOperateFailed) {
 		return fmt.Errorf("delete instance failure[output=%s],err=%s,wrap=%w", output, err, cons.InstanceNotExist)
 	}
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 	return nil
 }
 
 func (og *openGauss) Start() error {
-	cmd := fmt.Sprintf(_startOpenGaussFmt, og.pgData)
-	output, err := cmds.Exec(og.shell, cmd)
+	args := []string{"start", fmt.Sprintf(_pgDataFmt, og.pgData)}
+	output, err := cmds.ExecArgv(_gsctl, args...)
 	og.log.Debug(fmt.Sprintf("Start openGauss[output=%s]", output))
 
 	if errors.Is(err, cons.CmdOperateFailed) {
 		return fmt.Errorf("start openGauss failure[output=%s],err=%s,wrap=%w", output, err, cons.StartOpenGaussFailed)
 	}
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s,output=%s] return err=%w", og.shell, cmd, output, err)
+		return fmt.Errorf("cmds.ExecArgv[args=%v,output=%s] return err=%w", args, output, err)
 	}
 
 	return nil
 }
 
 func (og *openGauss) Stop() error {
-	cmd := fmt.Sprintf(_stopOpenGaussFmt, og.pgData)
-	output, err := cmds.Exec(og.shell, cmd)
+	args := []string{"stop", fmt.Sprintf(_pgDataFmt, og.pgData)}
+	output, err := cmds.ExecArgv(_gsctl, args...)
 	og.log.Debug(fmt.Sprintf("Stop openGauss[output=%s]", output))
 
 	if errors.Is(err, cons.CmdOperateFailed) {
 		return fmt.Errorf("stop openGauss failure[output=%s],err=%s,wrap=%w", output, err, cons.StopOpenGaussFailed)
 	}
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s,output=%s] return err=%w", og.shell, cmd, output, err)
+		return fmt.Errorf("cmds.ExecArgv[args=%v,output=%s] return err=%w", args, output, err)
 	}
 
 	return nil
@@ -254,18 +289,18 @@ Status return openGauss server status:
 The others are abnormal states,return "" and error.
 */
 func (og *openGauss) Status() (string, error) {
-	cmd := fmt.Sprintf(_statusGaussFmt, og.pgData)
-	output, err := cmds.Exec(og.shell, cmd)
+	args := []string{"status", fmt.Sprintf(_pgDataFmt, og.pgData)}
+	output, err := cmds.ExecArgv(_gsctl, args...)
 	og.log.Debug(fmt.Sprintf("Status openGauss[output=%s]", output))
 
 	if errors.Is(err, cons.CmdOperateFailed) {
-		if strings.Contains(err.Error(), "no server running") {
+		if strings.Contains(output, "no server running") {
 			return "Stopped", nil
 		}
 		return "", fmt.Errorf("get openGauss status failure[output=%s],err=[%s],wrap=%w", output, err, cons.StopOpenGaussFailed)
 	}
 	if err != nil {
-		return "", fmt.Errorf("cmds.Exec[shell=%s,cmd=%s,output=%s] return err=%w", og.shell, cmd, output, err)
+		return "", fmt.Errorf("cmds.ExecArgv[args=%v,output=%s] return err=%w", args, output, err)
 	}
 
 	if strings.Contains(output, "server is running") {
@@ -275,10 +310,24 @@ func (og *openGauss) Status() (string, error) {
 	return "", cons.UnknownOgStatus
 }
 
-// Restore TODO:Dependent environments require integration testing
-func (og *openGauss) Restore(backupPath, instance, backupID string) error {
-	cmd := fmt.Sprintf(_restoreFmt, backupPath, instance, backupID, og.pgData)
-	outputs, err := cmds.AsyncExec(og.shell, cmd)
+/*
+Restore restore the backup to pgdata, TODO:Dependent environments require integration testing.
+
+If the target is not empty, gs_probackup writes the recovery target and a `restore_command`
+which fetches wal from the archive of the backup path, openGauss replays the archived wal up to
+the target when it starts next time.
+For the csn target, gs_probackup restores to the latest archived wal and then the csn is appended
+to the recovery.conf, so that openGauss stops replaying at the csn.
+*/
+func (og *openGauss) Restore(backupPath, instance, backupID string, target *model.RecoveryTarget, job *Job) error {
+	if target != nil && (target.Position != "" || target.GTID != "") {
+		return fmt.Errorf("the binlog targets are only supported by MySQL,err=%w", cons.InvalidRecoveryTarget)
+	}
+	args := append(og.restoreArgs(backupPath, instance, backupID, og.pgData), og.recoveryTargetArgs(target)...)
+	outputs, err := cmds.AsyncExecArgv(_probackup, args...)
+	if err != nil {
+		return fmt.Errorf("cmds.AsyncExecArgv[args=%v] return err=%s,wrap=%w", args, err, cons.RestoreFailed)
+	}
 	for output := range outputs {
 		og.log.
 			//nolint:exhaustive
@@ -286,24 +335,74 @@ func (og *openGauss) Restore(backupPath, instance, backupID string) error {
 				"backup_path": backupPath,
 				"instance":    instance,
 				"backup_id":   backupID,
+				"target":      fmt.Sprintf("%+v", target),
 			}).
 			Debug(fmt.Sprintf("Restore openGauss[lineNo=%d,msg=%s]", output.LineNo, output.Message))
-
-		if errors.Is(err, cons.CmdOperateFailed) {
-			return fmt.Errorf("cmds.AsyncExec[output=%s] return err=%s,wrap=%w", output.Message, output.Error, cons.RestoreFailed)
+		if output.Message != "" {
+			job.Log(output.Message)
 		}
+
 		if output.Error != nil {
-			return fmt.Errorf("cmds.AsyncExec outputs:Error[%s] is not nil,wrap=%w", output.Error, cons.RestoreFailed)
+			return fmt.Errorf("cmds.AsyncExecArgv outputs:Error[%s] is not nil,wrap=%w", output.Error, cons.RestoreFailed)
 		}
 	}
+
+	if target != nil && target.CSN != "" {
+		return og.writeRecoveryTargetCSN(target)
+	}
+	return nil
+}
+
+func (og *openGauss) writeRecoveryTargetCSN(target *model.RecoveryTarget) error {
+	file := filepath.Join(og.pgData, _recoveryConf)
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open %s failure,err=%s,wrap=%w", file, err, cons.RestoreFailed)
+	}
+	defer f.Close()
+
+	conf := fmt.Sprintf(_recoveryConfCsnFmt, target.CSN) + fmt.Sprintf(_recoveryConfInclusiveFmt, target.Inclusive)
+	if _, err = f.WriteString(conf); err != nil {
+		return fmt.Errorf("write %s failure,err=%s,wrap=%w", file, err, cons.RestoreFailed)
+	}
 	return nil
 }
 
+func (og *openGauss) restoreArgs(backupPath, instance, backupID, pgData string) []string {
+	return []string{
+		"restore",
+		fmt.Sprintf(_backupPathFmt, backupPath),
+		fmt.Sprintf(_instanceFmt, instance),
+		fmt.Sprintf(_backupIDFmt, backupID),
+		fmt.Sprintf(_pgDataFmt, pgData),
+		_progressArg,
+	}
+}
+
+func (og *openGauss) recoveryTargetArgs(target *model.RecoveryTarget) []string {
+	if target.IsEmpty() {
+		return nil
+	}
+
+	switch {
+	case target.Time != "":
+		return []string{fmt.Sprintf(_recoveryTargetTimeFmt, target.Time), fmt.Sprintf(_recoveryTargetInclusiveFmt, target.Inclusive)}
+	case target.LSN != "":
+		return []string{fmt.Sprintf(_recoveryTargetLsnFmt, target.LSN), fmt.Sprintf(_recoveryTargetInclusiveFmt, target.Inclusive)}
+	case target.Xid != "":
+		return []string{fmt.Sprintf(_recoveryTargetXidFmt, target.Xid), fmt.Sprintf(_recoveryTargetInclusiveFmt, target.Inclusive)}
+	case target.CSN != "":
+		return []string{_recoveryTargetLatest}
+	default:
+		return []string{fmt.Sprintf(_recoveryTargetNameFmt, target.Name)}
+	}
+}
+
 func (og *openGauss) ShowBackupList(backupPath, instanceName string) ([]*model.Backup, error) {
-	cmd := fmt.Sprintf(_showListFmt, instanceName, backupPath)
-	output, err := cmds.Exec(og.shell, cmd)
+	args := []string{"show", fmt.Sprintf(_instanceFmt, instanceName), fmt.Sprintf(_backupPathFmt, backupPath), _formatJSON}
+	output, err := cmds.ExecArgv(_probackup, args...)
 	if err != nil {
-		return nil, fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return nil, fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 
 	var list []*model.BackupList
@@ -324,15 +423,27 @@ func (og *openGauss) ShowBackupList(backupPath, instanceName string) ([]*model.B
 	return nil, fmt.Errorf("backupList[v=%+v],err=%w", list, cons.DataNotFound)
 }
 
-func (og *openGauss) ignore(outputs chan *cmds.Output) {
+// DiskSpace see diskSpace.
+func (og *openGauss) DiskSpace(backupPath, instance, backupID string) (*model.DiskSpace, error) {
+	return diskSpace(og, og.pgData, og.log, backupPath, instance, backupID)
+}
+
+// follow log the outputs to the job until outputs closed, the outputs are ignored if the job is nil.
+func (og *openGauss) follow(outputs chan *cmds.Output, job *Job) {
 	defer func() {
 		_ = recover()
 	}()
 
-	for range outputs {
-		//ignore all
+	var err error
+	for output := range outputs {
+		if output.Error != nil {
+			err = output.Error
+			continue
+		}
+		job.Log(output.Message)
 	}
 	//outputs closed
+	job.Finish(nil, err)
 }
 
 func (og *openGauss) getBackupID(msg string) (string, error) {
@@ -367,51 +478,154 @@ func (og *openGauss) Auth(user, password, dbName string, dbPort uint16) error {
 	return nil
 }
 
-func (og *openGauss) MvPgDataToTemp() error {
-	cmd := fmt.Sprintf(_mvFmt, og.pgData, og.pgDataTemp)
-	_, err := cmds.Exec(og.shell, cmd)
-	if errors.Is(err, cons.CmdOperateFailed) {
-		return fmt.Errorf("mv pgdata to temp dir failure,err=%s,wrap=%w", err, cons.MvPgDataToTempFailed)
-	}
+func (og *openGauss) CheckSchema(user, password, dbName string, dbPort uint16, schema string) error {
+	_og, err := gsutil.Open(user, password, dbName, dbPort)
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return fmt.Errorf("gsutil.Open failure,err=%w", err)
 	}
 
+	if err := _og.CheckSchema(schema); err != nil {
+		return fmt.Errorf("check openGauss schema fail[user=%s,dbName=%s, schema=%s],err=%w", user, dbName, schema, err)
+	}
 	return nil
 }
 
-func (og *openGauss) MvTempToPgData() error {
-	cmd := fmt.Sprintf(_mvFmt, og.pgDataTemp, og.pgData)
-	_, err := cmds.Exec(og.shell, cmd)
+// SchemaQuery return the statement which fails if the schema does not exist in openGauss.
+func (og *openGauss) SchemaQuery(schema string) string {
+	return fmt.Sprintf(_pgCheckSchemaFmt, schema)
+}
+
+// ShowRecoveryResult return the wal location and the transaction time that openGauss has replayed to.
+func (og *openGauss) ShowRecoveryResult(user, password, dbName string, dbPort uint16) (*model.RecoveryResult, error) {
+	_og, err := gsutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return nil, fmt.Errorf("gsutil.Open failure,err=%w", err)
+	}
+
+	inRecovery, lsn, ts, err := _og.RecoveryResult()
+	if err != nil {
+		return nil, fmt.Errorf("query openGauss recovery result fail[user=%s,dbName=%s],err=%w", user, dbName, err)
+	}
+
+	return &model.RecoveryResult{
+		InRecovery: inRecovery,
+		ReplayLSN:  lsn,
+		ReplayTime: ts,
+	}, nil
+}
+
+// EnableArchive turn on archive_mode and push wal to the backup path by `gs_probackup archive-push`.
+func (og *openGauss) EnableArchive(backupPath, instance string) error {
+	archiveCmd := fmt.Sprintf(_archivePushFmt, cmds.Binary(_probackup), backupPath, instance)
+	args := []string{"reload", "-D", og.pgData, "-c", _archiveModeArg, "-c", fmt.Sprintf(_archiveCommandFmt, archiveCmd)}
+	output, err := cmds.ExecArgv(_gsguc, args...)
+	og.log.Debug(fmt.Sprintf("EnableArchive[output=%s,err=%v]", output, err))
+
 	if errors.Is(err, cons.CmdOperateFailed) {
-		return fmt.Errorf("mv temp to pgdata dir failure,err=%s,wrap=%w", err, cons.MvTempToPgDataFailed)
+		return fmt.Errorf("enable archive failure[output=%s],err=%s,wrap=%w", output, err, cons.EnableArchiveFailed)
 	}
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 	return nil
 }
 
-func (og *openGauss) CleanPgDataTemp() error {
-	cmd := fmt.Sprintf(_rmDirFmt, og.pgDataTemp)
-	_, err := cmds.Exec(og.shell, cmd)
+// ArchiveStatus check the wal archive settings, the archived wal in backup path, and report the archive lag.
+func (og *openGauss) ArchiveStatus(user, password, dbName string, dbPort uint16, backupPath, instance string) (*model.ArchiveStatus, error) {
+	_og, err := gsutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return nil, fmt.Errorf("gsutil.Open failure,err=%w", err)
+	}
+
+	mode, command, walFile, err := _og.ArchiveSettings()
+	if err != nil {
+		return nil, fmt.Errorf("query openGauss archive settings fail[user=%s,dbName=%s],err=%w", user, dbName, err)
+	}
+	settings := &model.ArchiveSettings{
+		ArchiveMode:    mode,
+		ArchiveCommand: command,
+		CurrentWalFile: walFile,
+	}
+
+	args := []string{"show", fmt.Sprintf(_instanceFmt, instance), fmt.Sprintf(_backupPathFmt, backupPath), _archiveArg, _formatJSON}
+	output, err := cmds.ExecArgv(_probackup, args...)
+	if err != nil {
+		og.log.Debug(fmt.Sprintf("ShowArchive[output=%s,err=%v]", output, err))
+		return og.newArchiveStatus(settings, nil, backupPath, fmt.Sprintf("show archive failure,err=%s", err)), nil
+	}
+
+	var list []*model.ArchiveList
+	if err = json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal[output=%s] return err=%s,wrap=%w", output, err, cons.Internal)
+	}
+
+	for _, ins := range list {
+		if ins.Instance == instance {
+			return og.newArchiveStatus(settings, ins.Timelines, backupPath, ""), nil
+		}
+	}
+	return og.newArchiveStatus(settings, nil, backupPath, ""), nil
+}
+
+// newArchiveStatus see newArchiveStatus, the archive_command must push wal to the backup path by `gs_probackup archive-push`.
+func (og *openGauss) newArchiveStatus(settings *model.ArchiveSettings, timelines []*model.ArchiveTimeline, backupPath, reason string) *model.ArchiveStatus {
+	pushed := strings.Contains(settings.ArchiveCommand, "archive-push") && strings.Contains(settings.ArchiveCommand, backupPath)
+	return newArchiveStatus(settings, timelines, pushed, reason)
+}
+
+// ValidateBackup check the data files and wal of the backup by `gs_probackup validate`.
+func (og *openGauss) ValidateBackup(backupPath, instance, backupID string) error {
+	args := []string{"validate", fmt.Sprintf(_backupPathFmt, backupPath), fmt.Sprintf(_instanceFmt, instance), fmt.Sprintf(_backupIDFmt, backupID)}
+	output, err := cmds.ExecArgv(_probackup, args...)
+	og.log.Debug(fmt.Sprintf("ValidateBackup[output=%s,err=%v]", output, err))
+
 	if errors.Is(err, cons.CmdOperateFailed) {
-		return fmt.Errorf("clean pgdata temp dir failure,err=%s,wrap=%w", err, cons.CleanPgDataTempFailed)
+		return fmt.Errorf("validate backup failure[output=%s],err=%s,wrap=%w", output, err, cons.ValidateBackupFailed)
 	}
 	if err != nil {
-		return fmt.Errorf("cmds.Exec[shell=%s,cmd=%s] return err=%w", og.shell, cmd, err)
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
 	}
 	return nil
 }
 
-func (og *openGauss) CheckSchema(user, password, dbName string, dbPort uint16, schema string) error {
-	_og, err := gsutil.Open(user, password, dbName, dbPort)
+/*
+RestoreScratch restore the backup into a scratch pgdata beside the pgdata, and start it on the port.
+
+It returns the scratch pgdata which must be removed by CleanScratch, the running openGauss is never touched.
+*/
+func (og *openGauss) RestoreScratch(backupPath, instance, backupID string, port uint16) (string, error) {
+	pgData, err := os.MkdirTemp(filepath.Dir(og.pgData), _scratchDirPrefix+backupID+"_")
 	if err != nil {
-		return fmt.Errorf("gsutil.Open failure,err=%w", err)
+		return "", fmt.Errorf("create scratch pgdata failure,err=%s,wrap=%w", err, cons.VerifyRestoreFailed)
 	}
 
-	if err := _og.CheckSchema(schema); err != nil {
-		return fmt.Errorf("check openGauss schema fail[user=%s,dbName=%s, schema=%s],err=%w", user, dbName, schema, err)
+	output, err := cmds.ExecArgv(_probackup, og.restoreArgs(backupPath, instance, backupID, pgData)...)
+	og.log.Debug(fmt.Sprintf("RestoreScratch[pgdata=%s,output=%s,err=%v]", pgData, output, err))
+	if err != nil {
+		_ = og.CleanScratch(pgData)
+		return "", fmt.Errorf("restore to scratch pgdata failure[output=%s],err=%s,wrap=%w", output, err, cons.VerifyRestoreFailed)
+	}
+
+	output, err = cmds.ExecArgv(_gsctl, "start", fmt.Sprintf(_pgDataFmt, pgData), "-o", fmt.Sprintf(_scratchOptionsFmt, port))
+	og.log.Debug(fmt.Sprintf("Start scratch openGauss[pgdata=%s,port=%d,output=%s,err=%v]", pgData, port, output, err))
+	if err != nil {
+		_ = og.CleanScratch(pgData)
+		return "", fmt.Errorf("start scratch openGauss failure[output=%s],err=%s,wrap=%w", output, err, cons.VerifyRestoreFailed)
+	}
+	return pgData, nil
+}
+
+// CleanScratch stop the scratch openGauss and remove its pgdata.
+func (og *openGauss) CleanScratch(pgData string) error {
+	if filepath.Dir(pgData) != filepath.Dir(og.pgData) || !strings.HasPrefix(filepath.Base(pgData), _scratchDirPrefix) {
+		return fmt.Errorf("invalid scratch pgdata[%s],err=%w", pgData, cons.NoPermission)
+	}
+
+	output, err := cmds.ExecArgv(_gsctl, "stop", fmt.Sprintf(_pgDataFmt, pgData), "-m", "fast")
+	og.log.Debug(fmt.Sprintf("Stop scratch openGauss[pgdata=%s,output=%s,err=%v]", pgData, output, err))
+
+	if err = os.RemoveAll(pgData); err != nil {
+		return fmt.Errorf("remove scratch pgdata[%s] failure,err=%s,wrap=%w", pgData, err, cons.Internal)
 	}
 	return nil
 }
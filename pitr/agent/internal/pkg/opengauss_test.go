@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/gomega"
@@ -28,6 +30,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
 )
 
 var _ = Describe("OpenGauss,requires opengauss environment", func() {
@@ -35,9 +38,8 @@ var _ = Describe("OpenGauss,requires opengauss environment", func() {
 		It("backup, show and delete", func() {
 			Skip("")
 			og := &openGauss{
-				shell:  "/bin/sh",
-				pgData: "/data/opengauss/3.1.1/data/single_node/",
-				log:    log,
+				dataDir: newDataDir("/data/opengauss/3.1.1/data/single_node/"),
+				log:     log,
 			}
 
 			var (
@@ -49,8 +51,9 @@ var _ = Describe("OpenGauss,requires opengauss environment", func() {
 				data,
 				instance,
 				"full",
-				1,
+				&model.BackupOptions{ThreadsNum: 1},
 				3306,
+				nil,
 			)
 
 			Expect(err).To(BeNil())
@@ -88,8 +91,7 @@ var _ = Describe("OpenGauss,requires opengauss environment", func() {
 		It("Init backup and clean up the env", func() {
 			Skip("")
 			og := &openGauss{
-				shell: "/bin/sh",
-				log:   log,
+				log: log,
 			}
 
 			data2 := "/home/omm/data2"
@@ -114,8 +116,7 @@ var _ = Describe("OpenGauss,requires opengauss environment", func() {
 
 		It("[/home/omm/]:no permission to operate other dirs", func() {
 			og := &openGauss{
-				shell: "/bin/sh",
-				log:   log,
+				log: log,
 			}
 
 			data := "/home/omm2/data"
@@ -129,9 +130,8 @@ var _ = Describe("OpenGauss,requires opengauss environment", func() {
 		It("instance:add and delete", func() {
 			Skip("")
 			og := &openGauss{
-				shell:  "/bin/sh",
-				pgData: "/data/opengauss/3.1.1/data/single_node/",
-				log:    log,
+				dataDir: newDataDir("/data/opengauss/3.1.1/data/single_node/"),
+				log:     log,
 			}
 
 			var (
@@ -156,9 +156,8 @@ var _ = Describe("OpenGauss,requires opengauss environment", func() {
 		It("start and stop:may fail if no instance exists", func() {
 			Skip("")
 			og := &openGauss{
-				shell:  "/bin/sh",
-				pgData: "/data/opengauss/3.1.1/data/single_node/",
-				log:    log,
+				dataDir: newDataDir("/data/opengauss/3.1.1/data/single_node/"),
+				log:     log,
 			}
 
 			status, err := og.Status()
@@ -188,8 +187,7 @@ var _ = Describe("OpenGauss,requires opengauss environment", func() {
 	Context("ShowBackupList", func() {
 		It("manual:show all backup ", func() {
 			og := &openGauss{
-				shell: "/bin/sh",
-				log:   log,
+				log: log,
 			}
 
 			var (
@@ -203,4 +201,160 @@ var _ = Describe("OpenGauss,requires opengauss environment", func() {
 			fmt.Println(string(indent))
 		})
 	})
+
+	Context("recoveryTargetArgs", func() {
+		og := &openGauss{
+			log: log,
+		}
+
+		It("empty target", func() {
+			Expect(og.recoveryTargetArgs(nil)).To(BeEmpty())
+			Expect(og.recoveryTargetArgs(&model.RecoveryTarget{Inclusive: true})).To(BeEmpty())
+		})
+
+		It("time target", func() {
+			args := og.recoveryTargetArgs(&model.RecoveryTarget{Time: "2023-05-16 18:12:20+08:00", Inclusive: true})
+			Expect(args).To(Equal([]string{"--recovery-target-time=2023-05-16 18:12:20+08:00", "--recovery-target-inclusive=true"}))
+		})
+
+		It("lsn target", func() {
+			args := og.recoveryTargetArgs(&model.RecoveryTarget{LSN: "0/5000028"})
+			Expect(args).To(Equal([]string{"--recovery-target-lsn=0/5000028", "--recovery-target-inclusive=false"}))
+		})
+
+		It("xid target", func() {
+			args := og.recoveryTargetArgs(&model.RecoveryTarget{Xid: "10086", Inclusive: true})
+			Expect(args).To(Equal([]string{"--recovery-target-xid=10086", "--recovery-target-inclusive=true"}))
+		})
+
+		It("name target", func() {
+			args := og.recoveryTargetArgs(&model.RecoveryTarget{Name: "before_upgrade", Inclusive: true})
+			Expect(args).To(Equal([]string{"--recovery-target-name=before_upgrade"}))
+		})
+
+		It("csn target", func() {
+			args := og.recoveryTargetArgs(&model.RecoveryTarget{CSN: "2012", Inclusive: true})
+			Expect(args).To(Equal([]string{"--recovery-target=latest"}))
+		})
+	})
+
+	Context("restoreArgs", func() {
+		og := &openGauss{log: log}
+
+		It("every arg is a separate argv element", func() {
+			args := og.restoreArgs("/home/omm/data", "ins-default-0", "RUUZFD", "/data/pgdata")
+			Expect(args).To(Equal([]string{"restore", "--backup-path=/home/omm/data", "--instance=ins-default-0", "--backup-id=RUUZFD", "--pgdata=/data/pgdata", "--progress"}))
+		})
+	})
+
+	Context("backupArgs", func() {
+		og := &openGauss{
+			log: log,
+		}
+
+		It("no options", func() {
+			Expect(og.backupArgs(&model.BackupOptions{ThreadsNum: 4})).To(BeEmpty())
+		})
+
+		It("compress", func() {
+			args := og.backupArgs(&model.BackupOptions{CompressAlg: "zlib", CompressLevel: 6})
+			Expect(args).To(Equal([]string{"--compress-algorithm=zlib", "--compress-level=6"}))
+
+			args = og.backupArgs(&model.BackupOptions{CompressAlg: "none", CompressLevel: 6})
+			Expect(args).To(Equal([]string{"--compress-algorithm=none"}))
+		})
+
+		It("stream and skip block validation", func() {
+			args := og.backupArgs(&model.BackupOptions{CompressAlg: "pglz", Stream: true, SkipBlockValidation: true})
+			Expect(args).To(Equal([]string{"--compress-algorithm=pglz", "--stream", "--skip-block-validation"}))
+		})
+	})
+
+	Context("latestBackup", func() {
+		It("the latest OK backup", func() {
+			Expect(latestBackup(nil)).To(BeNil())
+
+			latest := latestBackup([]*model.Backup{
+				{ID: "RUS2A1", StartTime: "2023-05-16 18:12:20+08", Status: "OK", PgdataBytes: 100},
+				{ID: "RUS2A3", StartTime: "2023-05-18 18:12:20+08", Status: "ERROR", PgdataBytes: 300},
+				{ID: "RUS2A2", StartTime: "2023-05-17 18:12:20+08", Status: "OK", PgdataBytes: 200},
+			})
+			Expect(latest.ID).To(Equal("RUS2A2"))
+		})
+	})
+
+	Context("writeRecoveryTargetCSN", func() {
+		It("append csn to recovery.conf", func() {
+			dir, err := os.MkdirTemp("", "pgdata")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(dir)
+
+			conf := filepath.Join(dir, "recovery.conf")
+			Expect(os.WriteFile(conf, []byte("restore_command = 'gs_probackup archive-get'\n"), 0600)).To(BeNil())
+
+			og := &openGauss{dataDir: newDataDir(dir), log: log}
+			Expect(og.writeRecoveryTargetCSN(&model.RecoveryTarget{CSN: "2012", Inclusive: true})).To(BeNil())
+
+			bs, err := os.ReadFile(conf)
+			Expect(err).To(BeNil())
+			Expect(string(bs)).To(Equal("restore_command = 'gs_probackup archive-get'\nrecovery_target_csn = '2012'\nrecovery_target_inclusive = true\n"))
+		})
+
+		It("recovery.conf not exist", func() {
+			og := &openGauss{dataDir: newDataDir("/not/exist/pgdata"), log: log}
+			err := og.writeRecoveryTargetCSN(&model.RecoveryTarget{CSN: "2012"})
+			Expect(errors.Is(err, cons.RestoreFailed)).To(BeTrue())
+		})
+	})
+
+	Context("newArchiveStatus", func() {
+		og := &openGauss{
+			log: log,
+		}
+		settings := &model.ArchiveSettings{
+			ArchiveMode:    "on",
+			ArchiveCommand: "gs_probackup archive-push --backup-path=/home/omm/data --instance=ins-default-ss --wal-file-path=%p --wal-file-name=%f",
+			CurrentWalFile: "000000010000000100000002",
+		}
+
+		It("archive is healthy", func() {
+			status := og.newArchiveStatus(settings, []*model.ArchiveTimeline{
+				{Tli: 1, MaxSegno: "0000000100000000000000FF", NSegments: 255, Status: "OK"},
+			}, "/home/omm/data", "")
+			Expect(status.Broken).To(BeFalse())
+			Expect(status.LatestArchived).To(Equal("0000000100000000000000FF"))
+			Expect(status.LagSegments).To(Equal(uint64(3)))
+		})
+
+		It("archive is off", func() {
+			status := og.newArchiveStatus(&model.ArchiveSettings{ArchiveMode: "off"}, nil, "/home/omm/data", "")
+			Expect(status.Broken).To(BeTrue())
+		})
+
+		It("archive to other path", func() {
+			status := og.newArchiveStatus(settings, nil, "/home/omm/data2", "")
+			Expect(status.Broken).To(BeTrue())
+		})
+
+		It("wal chain has gaps", func() {
+			status := og.newArchiveStatus(settings, []*model.ArchiveTimeline{
+				{
+					Tli:          1,
+					MaxSegno:     "000000010000000100000001",
+					Status:       "DEGRADED",
+					LostSegments: []*model.LostSegment{{BeginSegno: "000000010000000000000003", EndSegno: "000000010000000000000005"}},
+				},
+			}, "/home/omm/data", "")
+			Expect(status.Broken).To(BeTrue())
+			Expect(status.LostSegments).To(HaveLen(1))
+		})
+
+		It("wal segment number", func() {
+			_, err := walSegNo("invalid")
+			Expect(err).NotTo(BeNil())
+			n, err := walSegNo("000000010000000100000002")
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(uint64(0x102)))
+		})
+	})
 })
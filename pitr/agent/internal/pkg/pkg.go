@@ -17,12 +17,53 @@
 
 package pkg
 
-import "github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+import (
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+)
 
 var (
-	OG IOpenGauss
+	// DB is the database if the agent manages only one, it is used whatever the db port of requests is.
+	DB IDatabase
+	// DBs are the databases keyed by their ports if the agent manages more than one.
+	DBs  map[uint16]IDatabase
+	Jobs IJobs
 )
 
-func Init(shell, pgData string, log logging.ILog) {
-	OG = NewOpenGauss(shell, pgData, log)
+func Init(engine, pgData string, log logging.ILog) error {
+	db, err := NewDatabase(engine, pgData, log)
+	if err != nil {
+		return err
+	}
+	DB = db
+	Jobs = NewJobs()
+	return nil
+}
+
+// InitInstances manage several databases on the same host, the requests are routed by db port.
+func InitInstances(engine string, pgDatas map[uint16]string, log logging.ILog) error {
+	DBs = make(map[uint16]IDatabase, len(pgDatas))
+	for port, pgData := range pgDatas {
+		db, err := NewDatabase(engine, pgData, log)
+		if err != nil {
+			return err
+		}
+		DBs[port] = db
+	}
+	Jobs = NewJobs()
+	return nil
+}
+
+// GetDB return the database which listens on the db port.
+func GetDB(dbPort uint16) (IDatabase, error) {
+	if len(DBs) == 0 {
+		return DB, nil
+	}
+	db, ok := DBs[dbPort]
+	if !ok {
+		return nil, fmt.Errorf("database of port[%d] not found,err=%w", dbPort, cons.UnknownDBPort)
+	}
+	return db, nil
 }
@@ -0,0 +1,204 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+)
+
+type (
+	/*
+		Policy authorize the callers of agent by rules, a request is allowed if any rule matches it.
+		The policy file is like:
+
+			rules:
+			  - name: ops
+			    identities: ["gs_pitr-ops"]
+			    tokens: ["a-long-random-string"]
+			    operations: ["show", "backup", "delete", "restore"]
+			    instances: ["ins-default-0"]
+			    db_ports: [5432]
+			    backup_paths: ["/home/omm/data"]
+
+		Identities are the subject common names of client certificates, tokens are sent by
+		`Authorization: Bearer <token>`. Empty instances, db_ports or backup_paths mean no restriction.
+		The database of a request is routed by its db port, so a rule with db_ports rejects the requests without it.
+	*/
+	Policy struct {
+		Rules []*PolicyRule `yaml:"rules"`
+	}
+
+	PolicyRule struct {
+		Name        string   `yaml:"name"`
+		Identities  []string `yaml:"identities"`
+		Tokens      []string `yaml:"tokens"`
+		Operations  []string `yaml:"operations"`
+		Instances   []string `yaml:"instances"`
+		DBPorts     []uint16 `yaml:"db_ports"`
+		BackupPaths []string `yaml:"backup_paths"`
+	}
+
+	// Caller of the agent, Identity is from the client certificate and Token is from the http header.
+	Caller struct {
+		Identity string
+		Token    string
+	}
+
+	// Access is what the caller requests, Instance, DBPort and BackupPath are empty if the request doesn't have them.
+	Access struct {
+		Operation  string
+		Instance   string
+		DBPort     uint16
+		BackupPath string
+	}
+)
+
+// LoadPolicy the tokens are kept in plain text, so the policy file should be readable by the agent only.
+func LoadPolicy(file string) (*Policy, error) {
+	bs, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("read policy file failure,err=%w", err)
+	}
+
+	p := &Policy{}
+	if err = yaml.Unmarshal(bs, p); err != nil {
+		return nil, fmt.Errorf("unmarshal policy file failure,err=%w", err)
+	}
+
+	if err = p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Policy) validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("no rule in policy")
+	}
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("rule[%d]:missing name", i)
+		}
+		if len(r.Identities) == 0 && len(r.Tokens) == 0 {
+			return fmt.Errorf("rule[%s]:neither identities nor tokens", r.Name)
+		}
+		for _, t := range r.Tokens {
+			if t == "" {
+				return fmt.Errorf("rule[%s]:empty token", r.Name)
+			}
+		}
+		for _, op := range r.Operations {
+			switch op {
+			case cons.OperationShow, cons.OperationBackup, cons.OperationDelete, cons.OperationRestore:
+			default:
+				return fmt.Errorf("rule[%s]:unknown operation %s", r.Name, op)
+			}
+		}
+	}
+	return nil
+}
+
+// Authorize return the name of the rule which allows the access.
+// It returns cons.Unauthorized if no rule matches the caller, and cons.PermissionDenied if the rules of the caller don't allow the access.
+func (p *Policy) Authorize(caller *Caller, access *Access) (string, error) {
+	known := false
+	for _, r := range p.Rules {
+		if !r.matchCaller(caller) {
+			continue
+		}
+		known = true
+		if r.allow(access) {
+			return r.Name, nil
+		}
+	}
+
+	if !known {
+		return "", cons.Unauthorized
+	}
+	return "", cons.PermissionDenied
+}
+
+func (r *PolicyRule) matchCaller(caller *Caller) bool {
+	if caller == nil {
+		return false
+	}
+	if caller.Identity != "" && contains(r.Identities, caller.Identity) {
+		return true
+	}
+	if caller.Token != "" {
+		for _, t := range r.Tokens {
+			if subtle.ConstantTimeCompare([]byte(t), []byte(caller.Token)) == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *PolicyRule) allow(access *Access) bool {
+	if !contains(r.Operations, access.Operation) {
+		return false
+	}
+	if access.Instance != "" && len(r.Instances) > 0 && !contains(r.Instances, access.Instance) {
+		return false
+	}
+	if len(r.DBPorts) > 0 && !containsPort(r.DBPorts, access.DBPort) {
+		return false
+	}
+	if access.BackupPath != "" && len(r.BackupPaths) > 0 {
+		for _, p := range r.BackupPaths {
+			if underPath(access.BackupPath, p) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPort(list []uint16, port uint16) bool {
+	for _, v := range list {
+		if v == port {
+			return true
+		}
+	}
+	return false
+}
+
+// underPath return true if the path is the dir or in the dir.
+func underPath(path, dir string) bool {
+	path, dir = filepath.Clean(path), filepath.Clean(dir)
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, "/")+"/")
+}
@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+)
+
+var _ = Describe("Policy", func() {
+	var policy = &Policy{
+		Rules: []*PolicyRule{
+			{
+				Name:        "ops",
+				Identities:  []string{"gs_pitr-ops"},
+				Operations:  []string{"show", "backup", "delete", "restore"},
+				BackupPaths: []string{"/home/omm/data"},
+			},
+			{
+				Name:       "monitor",
+				Tokens:     []string{"monitor-token"},
+				Operations: []string{"show"},
+				Instances:  []string{"ins-default-0"},
+			},
+		},
+	}
+
+	It("unknown caller", func() {
+		_, err := policy.Authorize(&Caller{Identity: "nobody", Token: "wrong"}, &Access{Operation: "show"})
+		Expect(errors.Is(err, cons.Unauthorized)).To(BeTrue())
+
+		_, err = policy.Authorize(&Caller{}, &Access{Operation: "show"})
+		Expect(errors.Is(err, cons.Unauthorized)).To(BeTrue())
+	})
+
+	It("allowed by identity", func() {
+		rule, err := policy.Authorize(&Caller{Identity: "gs_pitr-ops"}, &Access{Operation: "restore", Instance: "ins-default-1", BackupPath: "/home/omm/data/"})
+		Expect(err).To(BeNil())
+		Expect(rule).To(Equal("ops"))
+	})
+
+	It("backup path out of the allowed paths", func() {
+		_, err := policy.Authorize(&Caller{Identity: "gs_pitr-ops"}, &Access{Operation: "delete", BackupPath: "/home/omm/data2"})
+		Expect(errors.Is(err, cons.PermissionDenied)).To(BeTrue())
+
+		_, err = policy.Authorize(&Caller{Identity: "gs_pitr-ops"}, &Access{Operation: "delete", BackupPath: "/home/omm/data/../data2"})
+		Expect(errors.Is(err, cons.PermissionDenied)).To(BeTrue())
+	})
+
+	It("allowed by token", func() {
+		rule, err := policy.Authorize(&Caller{Token: "monitor-token"}, &Access{Operation: "show", Instance: "ins-default-0"})
+		Expect(err).To(BeNil())
+		Expect(rule).To(Equal("monitor"))
+
+		_, err = policy.Authorize(&Caller{Token: "monitor-token"}, &Access{Operation: "show", Instance: "ins-default-1"})
+		Expect(errors.Is(err, cons.PermissionDenied)).To(BeTrue())
+
+		_, err = policy.Authorize(&Caller{Token: "monitor-token"}, &Access{Operation: "delete", Instance: "ins-default-0"})
+		Expect(errors.Is(err, cons.PermissionDenied)).To(BeTrue())
+	})
+
+	It("database out of the allowed db ports", func() {
+		p := &Policy{Rules: []*PolicyRule{{
+			Name:       "dn1",
+			Tokens:     []string{"dn1-token"},
+			Operations: []string{"show", "backup"},
+			Instances:  []string{"ins-default-0"},
+			DBPorts:    []uint16{5432},
+		}}}
+		caller := &Caller{Token: "dn1-token"}
+
+		_, err := p.Authorize(caller, &Access{Operation: "backup", Instance: "ins-default-0", DBPort: 5432})
+		Expect(err).To(BeNil())
+
+		_, err = p.Authorize(caller, &Access{Operation: "backup", Instance: "ins-default-0", DBPort: 5433})
+		Expect(errors.Is(err, cons.PermissionDenied)).To(BeTrue())
+
+		_, err = p.Authorize(caller, &Access{Operation: "show", Instance: "ins-default-0"})
+		Expect(errors.Is(err, cons.PermissionDenied)).To(BeTrue())
+	})
+
+	Context("LoadPolicy", func() {
+		write := func(content string) string {
+			f := filepath.Join(GinkgoT().TempDir(), "policy.yaml")
+			Expect(os.WriteFile(f, []byte(content), 0600)).To(Succeed())
+			return f
+		}
+
+		It("load policy file", func() {
+			p, err := LoadPolicy(write(`
+rules:
+  - name: ops
+    identities: ["gs_pitr-ops"]
+    operations: ["show", "backup"]
+    backup_paths: ["/home/omm/data"]
+`))
+			Expect(err).To(BeNil())
+			Expect(p.Rules).To(HaveLen(1))
+			Expect(p.Rules[0].BackupPaths).To(Equal([]string{"/home/omm/data"}))
+		})
+
+		It("invalid policy file", func() {
+			_, err := LoadPolicy(write("rules: []"))
+			Expect(err).NotTo(BeNil())
+
+			_, err = LoadPolicy(write("rules:\n  - name: ops\n    operations: [show]\n"))
+			Expect(err).NotTo(BeNil())
+
+			_, err = LoadPolicy(write("rules:\n  - name: ops\n    tokens: [t]\n    operations: [drop]\n"))
+			Expect(err).NotTo(BeNil())
+
+			_, err = LoadPolicy("/not/exist/policy.yaml")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
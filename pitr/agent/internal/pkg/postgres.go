@@ -0,0 +1,625 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/cmds"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/pgutil"
+)
+
+/*
+postgres backups PostgreSQL by pg_basebackup into the catalog kept by the agent, the wal is archived by archive_command.
+
+Only FULL backups are supported, the point-in-time recovery replays the archived wal by recovery_target_* settings.
+*/
+type postgres struct {
+	dataDir
+	catalog
+	log logging.ILog
+}
+
+var _ IDatabase = (*postgres)(nil)
+
+func NewPostgres(pgData string, log logging.ILog) IDatabase {
+	return &postgres{
+		dataDir: newDataDir(pgData),
+		catalog: catalog{archive: _pgWalDir, log: log},
+		log:     log,
+	}
+}
+
+// the binaries are executed with argv by cmds.ExecArgv, the args are never interpreted by shell.
+const (
+	_pgBasebackup   = "pg_basebackup"
+	_pgCtl          = "pg_ctl"
+	_pgVerifybackup = "pg_verifybackup"
+	_cp             = "cp"
+
+	_pgWalDir         = "wal"
+	_pgBackupLabel    = "backup_label"
+	_pgBackupManifest = "backup_manifest"
+	_pgCtlLog         = "pg_ctl.log"
+	_pgAutoConf       = "postgresql.auto.conf"
+	_pgRecoverySignal = "recovery.signal"
+
+	_pgWalStream  = "STREAM"
+	_pgWalArchive = "ARCHIVE"
+
+	_pgWalMethodFmt        = "--wal-method=%s"
+	_pgPortFmt             = "--port=%d"
+	_pgCheckpointFast      = "--checkpoint=fast"
+	_pgNoVerifyChecksums   = "--no-verify-checksums"
+	_pgVerifyNoParseWalArg = "--no-parse-wal"
+
+	// the settings are run by the shell of PostgreSQL, the backup path and instance are validated by the handlers.
+	_pgRestoreCommandFmt = "cp %s/%%f \"%%p\""
+	_pgArchiveCommandFmt = "test ! -f %s/%%f && cp %%p %s/%%f"
+
+	// the scratch PostgreSQL must not push wal into the archive of the original one.
+	_pgScratchOptionsFmt = "-p %d -c archive_mode=off"
+)
+
+/*
+AsyncBackup write the catalog of the backup and start pg_basebackup, the backup id is returned at once,
+the output is logged to the job, which is finished when pg_basebackup exits.
+*/
+func (pg *postgres) AsyncBackup(backupPath, instanceName, backupMode string, opts *model.BackupOptions, dbPort uint16, job *Job) (string, error) {
+	if backupMode != cons.DBBackModeFull {
+		return "", fmt.Errorf("PostgreSQL only supports FULL backup, backup mode is %s,err=%w", backupMode, cons.InvalidDnBackupMode)
+	}
+	if opts.CompressAlg != "" && opts.CompressAlg != cons.CompressAlgNone {
+		return "", fmt.Errorf("PostgreSQL backup is not compressed, compress algorithm is %s,err=%w", opts.CompressAlg, cons.InvalidCompressAlg)
+	}
+
+	wal := _pgWalArchive
+	if opts.Stream {
+		wal = _pgWalStream
+	}
+	backup, dir, err := pg.newBackup(backupPath, instanceName, wal)
+	if err != nil {
+		return "", err
+	}
+
+	args := append([]string{
+		fmt.Sprintf(_pgDataFmt, filepath.Join(dir, _catalogDatabaseDir)),
+		fmt.Sprintf(_pgPortFmt, dbPort),
+		_pgCheckpointFast,
+		_progressArg,
+	}, pg.backupArgs(opts)...)
+	outputs, err := cmds.AsyncExecArgv(_pgBasebackup, args...)
+	if err != nil {
+		backup.Status = cons.OGBackupStatusError
+		_ = writeCatalog(dir, backup)
+		return "", fmt.Errorf("cmds.AsyncExecArgv[args=%v] return err=%w", args, err)
+	}
+
+	go pg.follow(outputs, dir, backup, job)
+	return backup.ID, nil
+}
+
+/*
+backupArgs return the optional args of pg_basebackup:
+
+	the wal is streamed into the backup with `--wal-method=stream`, otherwise the wal is fetched from the archive when restoring.
+	the page-level checksums of data files are validated during backup unless `--no-verify-checksums`.
+*/
+func (pg *postgres) backupArgs(opts *model.BackupOptions) []string {
+	args := []string{fmt.Sprintf(_pgWalMethodFmt, "none")}
+	if opts.Stream {
+		args[0] = fmt.Sprintf(_pgWalMethodFmt, "stream")
+	}
+	if opts.SkipBlockValidation {
+		args = append(args, _pgNoVerifyChecksums)
+	}
+	return args
+}
+
+// follow log the outputs to the job until pg_basebackup exits, and then complete the catalog of the backup.
+func (pg *postgres) follow(outputs chan *cmds.Output, dir string, backup *model.Backup, job *Job) {
+	defer func() {
+		_ = recover()
+	}()
+
+	var err error
+	for output := range outputs {
+		pg.log.
+			Field("backup_dir", dir).
+			Debug(fmt.Sprintf("AsyncBackup output[lineNo=%d,msg=%s,err=%v]", output.LineNo, output.Message, output.Error))
+		if output.Error != nil {
+			err = output.Error
+			continue
+		}
+		job.Log(output.Message)
+	}
+
+	if err == nil {
+		pg.readBackupLabel(filepath.Join(dir, _catalogDatabaseDir), backup)
+	}
+	if err2 := pg.finishBackup(dir, backup, err); err2 != nil && err == nil {
+		err = err2
+	}
+	job.Finish(nil, err)
+}
+
+// readBackupLabel fill the wal locations and timeline of the backup from the copied pgdata.
+func (pg *postgres) readBackupLabel(database string, backup *model.Backup) {
+	if label, err := os.ReadFile(filepath.Join(database, _pgBackupLabel)); err == nil {
+		backup.StartLsn, backup.CurrentTli = parseBackupLabel(string(label))
+	}
+	if manifest, err := os.ReadFile(filepath.Join(database, _pgBackupManifest)); err == nil {
+		backup.StopLsn = parseBackupManifest(manifest)
+	}
+}
+
+/*
+parseBackupLabel return the start wal location and timeline from the backup_label written by pg_basebackup:
+
+	START WAL LOCATION: 0/2000028 (file 000000010000000000000002)
+	START TIMELINE: 1
+*/
+func parseBackupLabel(label string) (lsn string, tli int) {
+	scanner := bufio.NewScanner(strings.NewReader(label))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if v, ok := strings.CutPrefix(line, "START WAL LOCATION: "); ok {
+			lsn, _, _ = strings.Cut(v, " ")
+		}
+		if v, ok := strings.CutPrefix(line, "START TIMELINE: "); ok {
+			tli, _ = strconv.Atoi(strings.TrimSpace(v))
+		}
+	}
+	return lsn, tli
+}
+
+// parseBackupManifest return the end wal location of the backup from the backup_manifest, which exists since PostgreSQL 13.
+func parseBackupManifest(manifest []byte) string {
+	var m struct {
+		WalRanges []struct {
+			EndLsn string `json:"End-LSN"`
+		} `json:"WAL-Ranges"`
+	}
+	if err := json.Unmarshal(manifest, &m); err != nil || len(m.WalRanges) == 0 {
+		return ""
+	}
+	return m.WalRanges[len(m.WalRanges)-1].EndLsn
+}
+
+func (pg *postgres) Start() error {
+	args := []string{"start", "-D", pg.pgData, "-w", "-l", filepath.Join(pg.pgData, _pgCtlLog)}
+	output, err := cmds.ExecArgv(_pgCtl, args...)
+	pg.log.Debug(fmt.Sprintf("Start PostgreSQL[output=%s]", output))
+
+	if errors.Is(err, cons.CmdOperateFailed) {
+		return fmt.Errorf("start PostgreSQL failure[output=%s],err=%s,wrap=%w", output, err, cons.StartOpenGaussFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v,output=%s] return err=%w", args, output, err)
+	}
+	return nil
+}
+
+func (pg *postgres) Stop() error {
+	args := []string{"stop", "-D", pg.pgData, "-m", "fast", "-w"}
+	output, err := cmds.ExecArgv(_pgCtl, args...)
+	pg.log.Debug(fmt.Sprintf("Stop PostgreSQL[output=%s]", output))
+
+	if errors.Is(err, cons.CmdOperateFailed) {
+		return fmt.Errorf("stop PostgreSQL failure[output=%s],err=%s,wrap=%w", output, err, cons.StopOpenGaussFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v,output=%s] return err=%w", args, output, err)
+	}
+	return nil
+}
+
+// Status return PostgreSQL server status like openGauss, "Running" or "Stopped".
+func (pg *postgres) Status() (string, error) {
+	args := []string{"status", "-D", pg.pgData}
+	output, err := cmds.ExecArgv(_pgCtl, args...)
+	pg.log.Debug(fmt.Sprintf("Status PostgreSQL[output=%s]", output))
+
+	if errors.Is(err, cons.CmdOperateFailed) {
+		if strings.Contains(output, "no server running") {
+			return "Stopped", nil
+		}
+		return "", fmt.Errorf("get PostgreSQL status failure[output=%s],err=[%s],wrap=%w", output, err, cons.StopOpenGaussFailed)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cmds.ExecArgv[args=%v,output=%s] return err=%w", args, output, err)
+	}
+
+	if strings.Contains(output, "server is running") {
+		return "Running", nil
+	}
+	return "", cons.UnknownOgStatus
+}
+
+/*
+Restore copy the pgdata of the backup to pgdata, and write the recovery settings so that PostgreSQL
+fetches wal from the archive of the backup path and replays it up to the target when it starts next time.
+
+An empty target replays to the end of the backup, the csn and binlog targets are not supported by PostgreSQL.
+*/
+func (pg *postgres) Restore(backupPath, instance, backupID string, target *model.RecoveryTarget, job *Job) error {
+	if target != nil && (target.CSN != "" || target.Position != "" || target.GTID != "") {
+		return fmt.Errorf("PostgreSQL only supports the time, lsn, xid and name targets,err=%w", cons.InvalidRecoveryTarget)
+	}
+
+	database := pg.databaseDir(backupPath, instance, backupID)
+	if _, err := os.Stat(database); err != nil {
+		return fmt.Errorf("backup[%s] not found,err=%s,wrap=%w", database, err, cons.RestoreFailed)
+	}
+
+	job.Log(fmt.Sprintf("Copying %s to %s...", database, pg.pgData))
+	output, err := cmds.ExecArgv(_cp, "-a", "--", database, pg.pgData)
+	pg.log.Debug(fmt.Sprintf("Restore PostgreSQL[output=%s,err=%v]", output, err))
+	if err != nil {
+		_ = os.RemoveAll(pg.pgData)
+		return fmt.Errorf("copy backup failure[output=%s],err=%s,wrap=%w", output, err, cons.RestoreFailed)
+	}
+
+	if err = writeRecoveryConf(pg.pgData, pg.archiveDir(backupPath, instance), target); err != nil {
+		_ = os.RemoveAll(pg.pgData)
+		return err
+	}
+	return nil
+}
+
+// writeRecoveryConf create recovery.signal and append the recovery settings to postgresql.auto.conf.
+func writeRecoveryConf(pgData, walDir string, target *model.RecoveryTarget) error {
+	signal := filepath.Join(pgData, _pgRecoverySignal)
+	if err := os.WriteFile(signal, nil, 0600); err != nil {
+		return fmt.Errorf("write %s failure,err=%s,wrap=%w", signal, err, cons.RestoreFailed)
+	}
+
+	file := filepath.Join(pgData, _pgAutoConf)
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open %s failure,err=%s,wrap=%w", file, err, cons.RestoreFailed)
+	}
+	defer f.Close()
+
+	if _, err = f.WriteString(recoveryConf(walDir, target)); err != nil {
+		return fmt.Errorf("write %s failure,err=%s,wrap=%w", file, err, cons.RestoreFailed)
+	}
+	return nil
+}
+
+func recoveryConf(walDir string, target *model.RecoveryTarget) string {
+	settings := [][2]string{{"restore_command", fmt.Sprintf(_pgRestoreCommandFmt, walDir)}}
+	switch {
+	case target.IsEmpty():
+		settings = append(settings, [2]string{"recovery_target", "immediate"})
+	case target.Time != "":
+		settings = append(settings, [2]string{"recovery_target_time", target.Time})
+	case target.LSN != "":
+		settings = append(settings, [2]string{"recovery_target_lsn", target.LSN})
+	case target.Xid != "":
+		settings = append(settings, [2]string{"recovery_target_xid", target.Xid})
+	default:
+		settings = append(settings, [2]string{"recovery_target_name", target.Name})
+	}
+	if !target.IsEmpty() && target.Name == "" {
+		settings = append(settings, [2]string{"recovery_target_inclusive", strconv.FormatBool(target.Inclusive)})
+	}
+	settings = append(settings, [2]string{"recovery_target_action", "promote"})
+
+	var conf strings.Builder
+	for _, s := range settings {
+		conf.WriteString(fmt.Sprintf("%s = '%s'\n", s[0], strings.ReplaceAll(s[1], "'", "''")))
+	}
+	return conf.String()
+}
+
+// DiskSpace see diskSpace.
+func (pg *postgres) DiskSpace(backupPath, instance, backupID string) (*model.DiskSpace, error) {
+	return diskSpace(pg, pg.pgData, pg.log, backupPath, instance, backupID)
+}
+
+func (pg *postgres) Auth(user, password, dbName string, dbPort uint16) error {
+	if strings.Trim(user, " ") == "" ||
+		strings.Trim(password, " ") == "" ||
+		strings.Trim(dbName, " ") == "" ||
+		dbPort == 0 {
+		return fmt.Errorf("invalid inputs[user=%s,password=%s,dbName=%s,dbPort=%d]", user, password, dbName, dbPort)
+	}
+
+	_pg, err := pgutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return fmt.Errorf("pgutil.Open failure,err=%w", err)
+	}
+
+	if err := _pg.Ping(); err != nil {
+		return fmt.Errorf("ping PostgreSQL fail[user=%s,pw length=%d,dbName=%s],err=%w", user, len(password), dbName, err)
+	}
+	return nil
+}
+
+func (pg *postgres) CheckSchema(user, password, dbName string, dbPort uint16, schema string) error {
+	_pg, err := pgutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return fmt.Errorf("pgutil.Open failure,err=%w", err)
+	}
+
+	if err := _pg.CheckSchema(schema); err != nil {
+		return fmt.Errorf("check PostgreSQL schema fail[user=%s,dbName=%s, schema=%s],err=%w", user, dbName, schema, err)
+	}
+	return nil
+}
+
+// SchemaQuery return the statement which fails if the schema does not exist in PostgreSQL.
+func (pg *postgres) SchemaQuery(schema string) string {
+	return fmt.Sprintf(_pgCheckSchemaFmt, schema)
+}
+
+// ShowRecoveryResult return the wal location and the transaction time that PostgreSQL has replayed to.
+func (pg *postgres) ShowRecoveryResult(user, password, dbName string, dbPort uint16) (*model.RecoveryResult, error) {
+	_pg, err := pgutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return nil, fmt.Errorf("pgutil.Open failure,err=%w", err)
+	}
+
+	inRecovery, lsn, ts, err := _pg.RecoveryResult()
+	if err != nil {
+		return nil, fmt.Errorf("query PostgreSQL recovery result fail[user=%s,dbName=%s],err=%w", user, dbName, err)
+	}
+
+	return &model.RecoveryResult{
+		InRecovery: inRecovery,
+		ReplayLSN:  lsn,
+		ReplayTime: ts,
+	}, nil
+}
+
+/*
+EnableArchive set archive_mode and an archive_command which copies wal to the backup path in postgresql.auto.conf,
+and reload PostgreSQL.
+
+The archive_command takes effect at once, but archive_mode=on takes effect after PostgreSQL restarts,
+the archive is reported broken by ArchiveStatus until then.
+*/
+func (pg *postgres) EnableArchive(backupPath, instance string) error {
+	walDir := pg.archiveDir(backupPath, instance)
+	settings := map[string]string{
+		"archive_mode":    _archiveModeOn,
+		"archive_command": fmt.Sprintf(_pgArchiveCommandFmt, walDir, walDir),
+	}
+	if err := setAutoConf(filepath.Join(pg.pgData, _pgAutoConf), settings); err != nil {
+		return fmt.Errorf("enable archive failure,err=%s,wrap=%w", err, cons.EnableArchiveFailed)
+	}
+
+	args := []string{"reload", "-D", pg.pgData}
+	output, err := cmds.ExecArgv(_pgCtl, args...)
+	pg.log.Debug(fmt.Sprintf("EnableArchive[output=%s,err=%v]", output, err))
+
+	if errors.Is(err, cons.CmdOperateFailed) {
+		return fmt.Errorf("enable archive failure[output=%s],err=%s,wrap=%w", output, err, cons.EnableArchiveFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
+	}
+	return nil
+}
+
+// setAutoConf replace the settings in postgresql.auto.conf like `ALTER SYSTEM`, the missing ones are appended.
+func setAutoConf(file string, settings map[string]string) error {
+	bs, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(string(bs), "\n"), "\n") {
+		key, _, _ := strings.Cut(line, "=")
+		if _, ok := settings[strings.TrimSpace(key)]; ok || line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s = '%s'", k, strings.ReplaceAll(settings[k], "'", "''")))
+	}
+	return os.WriteFile(file, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
+
+// ArchiveStatus check the wal archive settings, the archived wal in backup path, and report the archive lag.
+func (pg *postgres) ArchiveStatus(user, password, dbName string, dbPort uint16, backupPath, instance string) (*model.ArchiveStatus, error) {
+	_pg, err := pgutil.Open(user, password, dbName, dbPort)
+	if err != nil {
+		return nil, fmt.Errorf("pgutil.Open failure,err=%w", err)
+	}
+
+	mode, command, walFile, err := _pg.ArchiveSettings()
+	if err != nil {
+		return nil, fmt.Errorf("query PostgreSQL archive settings fail[user=%s,dbName=%s],err=%w", user, dbName, err)
+	}
+	settings := &model.ArchiveSettings{
+		ArchiveMode:    mode,
+		ArchiveCommand: command,
+		CurrentWalFile: walFile,
+	}
+
+	walDir := pg.archiveDir(backupPath, instance)
+	pushed := strings.Contains(command, walDir)
+	timelines, err := archiveTimelines(walDir)
+	if err != nil {
+		pg.log.Debug(fmt.Sprintf("ShowArchive[walDir=%s,err=%v]", walDir, err))
+		return newArchiveStatus(settings, nil, pushed, fmt.Sprintf("show archive failure,err=%s", err)), nil
+	}
+	return newArchiveStatus(settings, timelines, pushed, ""), nil
+}
+
+// archiveTimelines scan the archived wal segments like `gs_probackup show --archive`, the gaps of each timeline are lost segments.
+func archiveTimelines(walDir string) ([]*model.ArchiveTimeline, error) {
+	entries, err := os.ReadDir(walDir)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := map[int][]string{}
+	sizes := map[int]int64{}
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() {
+			continue
+		}
+		if _, err := walSegNo(name); err != nil {
+			continue
+		}
+		tli, err := strconv.ParseInt(name[:8], 16, 32)
+		if err != nil {
+			continue
+		}
+		segments[int(tli)] = append(segments[int(tli)], name)
+		if info, err := e.Info(); err == nil {
+			sizes[int(tli)] += info.Size()
+		}
+	}
+
+	tlis := make([]int, 0, len(segments))
+	for tli := range segments {
+		tlis = append(tlis, tli)
+	}
+	sort.Ints(tlis)
+
+	timelines := make([]*model.ArchiveTimeline, 0, len(tlis))
+	for _, tli := range tlis {
+		names := segments[tli]
+		sort.Strings(names)
+		tl := &model.ArchiveTimeline{
+			Tli:          tli,
+			MinSegno:     names[0],
+			MaxSegno:     names[len(names)-1],
+			NSegments:    len(names),
+			Size:         sizes[tli],
+			Status:       _archiveTimelineOK,
+			LostSegments: []*model.LostSegment{},
+		}
+		for i := 1; i < len(names); i++ {
+			prev, _ := walSegNo(names[i-1])
+			cur, _ := walSegNo(names[i])
+			if cur > prev+1 {
+				tl.LostSegments = append(tl.LostSegments, &model.LostSegment{
+					BeginSegno: walFileName(tli, prev+1),
+					EndSegno:   walFileName(tli, cur-1),
+				})
+			}
+		}
+		if len(tl.LostSegments) > 0 {
+			tl.Status = "DEGRADED"
+		}
+		timelines = append(timelines, tl)
+	}
+	return timelines, nil
+}
+
+// walFileName is the reverse of walSegNo.
+func walFileName(tli int, segNo uint64) string {
+	return fmt.Sprintf("%08X%08X%08X", tli, segNo/_walSegmentsPerXLog, segNo%_walSegmentsPerXLog)
+}
+
+// ValidateBackup check the data files of the backup against its backup_manifest by pg_verifybackup.
+func (pg *postgres) ValidateBackup(backupPath, instance, backupID string) error {
+	dir := pg.backupDir(backupPath, instance, backupID)
+	backup, err := readCatalog(dir)
+	if err != nil {
+		return fmt.Errorf("validate backup failure,err=%s,wrap=%w", err, cons.ValidateBackupFailed)
+	}
+
+	args := []string{filepath.Join(dir, _catalogDatabaseDir)}
+	// the wal of the backup is in the archive, it can not be parsed from the backup.
+	if backup.Wal != _pgWalStream {
+		args = append([]string{_pgVerifyNoParseWalArg}, args...)
+	}
+	output, err := cmds.ExecArgv(_pgVerifybackup, args...)
+	pg.log.Debug(fmt.Sprintf("ValidateBackup[output=%s,err=%v]", output, err))
+
+	if errors.Is(err, cons.CmdOperateFailed) {
+		return fmt.Errorf("validate backup failure[output=%s],err=%s,wrap=%w", output, err, cons.ValidateBackupFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("cmds.ExecArgv[args=%v] return err=%w", args, err)
+	}
+	return nil
+}
+
+/*
+RestoreScratch copy the backup into a scratch pgdata beside the pgdata, and start it on the port.
+
+It returns the scratch pgdata which must be removed by CleanScratch, the running PostgreSQL is never touched.
+*/
+func (pg *postgres) RestoreScratch(backupPath, instance, backupID string, port uint16) (string, error) {
+	pgData, err := os.MkdirTemp(filepath.Dir(pg.pgData), _scratchDirPrefix+backupID+"_")
+	if err != nil {
+		return "", fmt.Errorf("create scratch pgdata failure,err=%s,wrap=%w", err, cons.VerifyRestoreFailed)
+	}
+
+	database := pg.databaseDir(backupPath, instance, backupID)
+	output, err := cmds.ExecArgv(_cp, "-a", "--", database+"/.", pgData)
+	pg.log.Debug(fmt.Sprintf("RestoreScratch[pgdata=%s,output=%s,err=%v]", pgData, output, err))
+	if err == nil {
+		err = writeRecoveryConf(pgData, pg.archiveDir(backupPath, instance), nil)
+	}
+	if err != nil {
+		_ = pg.CleanScratch(pgData)
+		return "", fmt.Errorf("restore to scratch pgdata failure[output=%s],err=%s,wrap=%w", output, err, cons.VerifyRestoreFailed)
+	}
+
+	args := []string{"start", "-D", pgData, "-w", "-l", filepath.Join(pgData, _pgCtlLog), "-o", fmt.Sprintf(_pgScratchOptionsFmt, port)}
+	output, err = cmds.ExecArgv(_pgCtl, args...)
+	pg.log.Debug(fmt.Sprintf("Start scratch PostgreSQL[pgdata=%s,port=%d,output=%s,err=%v]", pgData, port, output, err))
+	if err != nil {
+		_ = pg.CleanScratch(pgData)
+		return "", fmt.Errorf("start scratch PostgreSQL failure[output=%s],err=%s,wrap=%w", output, err, cons.VerifyRestoreFailed)
+	}
+	return pgData, nil
+}
+
+// CleanScratch stop the scratch PostgreSQL and remove its pgdata.
+func (pg *postgres) CleanScratch(pgData string) error {
+	if filepath.Dir(pgData) != filepath.Dir(pg.pgData) || !strings.HasPrefix(filepath.Base(pgData), _scratchDirPrefix) {
+		return fmt.Errorf("invalid scratch pgdata[%s],err=%w", pgData, cons.NoPermission)
+	}
+
+	output, err := cmds.ExecArgv(_pgCtl, "stop", "-D", pgData, "-m", "fast")
+	pg.log.Debug(fmt.Sprintf("Stop scratch PostgreSQL[pgdata=%s,output=%s,err=%v]", pgData, output, err))
+
+	if err = os.RemoveAll(pgData); err != nil {
+		return fmt.Errorf("remove scratch pgdata[%s] failure,err=%s,wrap=%w", pgData, err, cons.Internal)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg/model"
+)
+
+var _ = Describe("PostgreSQL", func() {
+	var (
+		backupPath string
+		pg         *postgres
+	)
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "postgres")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+
+		backupPath = filepath.Join(dir, "backup")
+		pg = NewPostgres(filepath.Join(dir, "pgdata"), log).(*postgres)
+	})
+
+	Context("AsyncBackup", func() {
+		It("unsupported options", func() {
+			_, err := pg.AsyncBackup(backupPath, "ins-default-ss", cons.DBBackModePTrack, &model.BackupOptions{}, 5432, nil)
+			Expect(errors.Is(err, cons.InvalidDnBackupMode)).To(BeTrue())
+
+			_, err = pg.AsyncBackup(backupPath, "ins-default-ss", cons.DBBackModeFull, &model.BackupOptions{CompressAlg: cons.CompressAlgZlib}, 5432, nil)
+			Expect(errors.Is(err, cons.InvalidCompressAlg)).To(BeTrue())
+		})
+
+		It("backup args", func() {
+			Expect(pg.backupArgs(&model.BackupOptions{})).To(Equal([]string{"--wal-method=none"}))
+			Expect(pg.backupArgs(&model.BackupOptions{Stream: true, SkipBlockValidation: true})).To(Equal([]string{"--wal-method=stream", "--no-verify-checksums"}))
+		})
+	})
+
+	Context("backup_label", func() {
+		It("read backup_label and backup_manifest", func() {
+			database := filepath.Join(backupPath, "database")
+			Expect(os.MkdirAll(database, 0700)).To(BeNil())
+			label := "START WAL LOCATION: 0/2000028 (file 000000010000000000000002)\nCHECKPOINT LOCATION: 0/2000060\nSTART TIMELINE: 1\n"
+			Expect(os.WriteFile(filepath.Join(database, "backup_label"), []byte(label), 0600)).To(BeNil())
+			manifest := `{"PostgreSQL-Backup-Manifest-Version": 1, "WAL-Ranges": [{"Timeline": 1, "Start-LSN": "0/2000028", "End-LSN": "0/2000100"}]}`
+			Expect(os.WriteFile(filepath.Join(database, "backup_manifest"), []byte(manifest), 0600)).To(BeNil())
+
+			backup := &model.Backup{}
+			pg.readBackupLabel(database, backup)
+			Expect(backup.StartLsn).To(Equal("0/2000028"))
+			Expect(backup.StopLsn).To(Equal("0/2000100"))
+			Expect(backup.CurrentTli).To(Equal(1))
+		})
+	})
+
+	Context("recovery settings", func() {
+		It("recovery conf of targets", func() {
+			Expect(recoveryConf("/backup/wal/ins", nil)).To(Equal(
+				"restore_command = 'cp /backup/wal/ins/%f \"%p\"'\nrecovery_target = 'immediate'\nrecovery_target_action = 'promote'\n"))
+			Expect(recoveryConf("/backup/wal/ins", &model.RecoveryTarget{Time: "2023-01-01 10:00:00+08", Inclusive: true})).To(Equal(
+				"restore_command = 'cp /backup/wal/ins/%f \"%p\"'\nrecovery_target_time = '2023-01-01 10:00:00+08'\nrecovery_target_inclusive = 'true'\nrecovery_target_action = 'promote'\n"))
+			Expect(recoveryConf("/backup/wal/ins", &model.RecoveryTarget{Name: "before'upgrade"})).To(Equal(
+				"restore_command = 'cp /backup/wal/ins/%f \"%p\"'\nrecovery_target_name = 'before''upgrade'\nrecovery_target_action = 'promote'\n"))
+		})
+
+		It("csn is not supported", func() {
+			err := pg.Restore(backupPath, "ins-default-ss", "RSFF01", &model.RecoveryTarget{CSN: "2012"}, nil)
+			Expect(errors.Is(err, cons.InvalidRecoveryTarget)).To(BeTrue())
+		})
+
+		It("write recovery.signal and settings", func() {
+			Expect(os.MkdirAll(pg.pgData, 0700)).To(BeNil())
+			Expect(writeRecoveryConf(pg.pgData, "/backup/wal/ins", &model.RecoveryTarget{LSN: "0/3000000"})).To(BeNil())
+			Expect(filepath.Join(pg.pgData, "recovery.signal")).To(BeARegularFile())
+
+			bs, err := os.ReadFile(filepath.Join(pg.pgData, "postgresql.auto.conf"))
+			Expect(err).To(BeNil())
+			Expect(string(bs)).To(ContainSubstring("recovery_target_lsn = '0/3000000'\n"))
+		})
+
+		It("replace archive settings in postgresql.auto.conf", func() {
+			file := filepath.Join(backupPath, "postgresql.auto.conf")
+			Expect(os.MkdirAll(backupPath, 0700)).To(BeNil())
+			Expect(os.WriteFile(file, []byte("# Do not edit this file manually!\narchive_mode = 'off'\nwork_mem = '8MB'\n"), 0600)).To(BeNil())
+
+			Expect(setAutoConf(file, map[string]string{"archive_mode": "on", "archive_command": "test ! -f /wal/%f && cp %p /wal/%f"})).To(BeNil())
+			bs, err := os.ReadFile(file)
+			Expect(err).To(BeNil())
+			Expect(string(bs)).To(Equal("# Do not edit this file manually!\nwork_mem = '8MB'\narchive_command = 'test ! -f /wal/%f && cp %p /wal/%f'\narchive_mode = 'on'\n"))
+		})
+	})
+
+	Context("archiveTimelines", func() {
+		It("scan archived wal", func() {
+			walDir := pg.archiveDir(backupPath, "ins-default-ss")
+			Expect(os.MkdirAll(walDir, 0700)).To(BeNil())
+			for _, name := range []string{
+				"000000010000000000000001",
+				"000000010000000000000002",
+				"000000010000000000000005",
+				"000000020000000000000006",
+				"00000002.history",
+			} {
+				Expect(os.WriteFile(filepath.Join(walDir, name), []byte("wal"), 0600)).To(BeNil())
+			}
+
+			timelines, err := archiveTimelines(walDir)
+			Expect(err).To(BeNil())
+			Expect(timelines).To(HaveLen(2))
+			Expect(timelines[0].NSegments).To(Equal(3))
+			Expect(timelines[0].Status).To(Equal("DEGRADED"))
+			Expect(timelines[0].LostSegments).To(Equal([]*model.LostSegment{
+				{BeginSegno: "000000010000000000000003", EndSegno: "000000010000000000000004"},
+			}))
+			Expect(timelines[1].Status).To(Equal("OK"))
+			Expect(timelines[1].MaxSegno).To(Equal("000000020000000000000006"))
+
+			_, err = archiveTimelines(filepath.Join(backupPath, "not-exist"))
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("wal file name", func() {
+			Expect(walFileName(1, 0x102)).To(Equal("000000010000000100000002"))
+		})
+	})
+})
@@ -0,0 +1,211 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/s3util"
+)
+
+type (
+	storage struct {
+		cli    s3util.IClient
+		bucket string
+		prefix string
+		log    logging.ILog
+	}
+
+	/*
+		IStorage push backup sets to the remote object storage, and pull them back before restore.
+
+		The layout of the backup path is kept in the remote storage under the prefix:
+
+			backups/<instance>/pg_probackup.conf
+			backups/<instance>/<backup id>/...
+			wal/<instance>/...
+	*/
+	IStorage interface {
+		PushBackupSet(backupPath, instance, backupID string) error
+		PullBackupSet(backupPath, instance, backupID string) error
+	}
+)
+
+const _probackupConf = "pg_probackup.conf"
+
+func NewStorage(cli s3util.IClient, bucket, prefix string, log logging.ILog) IStorage {
+	return &storage{
+		cli:    cli,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+		log:    log,
+	}
+}
+
+// PushBackupSet upload the backup and the wal archived but not uploaded yet.
+func (s *storage) PushBackupSet(backupPath, instance, backupID string) error {
+	backupDir := filepath.Join("backups", instance, backupID)
+	if _, err := os.Stat(filepath.Join(backupPath, backupDir)); err != nil {
+		return fmt.Errorf("backup[%s] not found,err=%s,wrap=%w", backupDir, err, cons.PushBackupSetFailed)
+	}
+
+	files := []string{filepath.Join("backups", instance, _probackupConf)}
+	err := filepath.WalkDir(filepath.Join(backupPath, backupDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(backupPath, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walk backup dir failed,err=%s,wrap=%w", err, cons.PushBackupSetFailed)
+	}
+
+	// archived wal never changes, so only the new ones are uploaded.
+	walDir := filepath.Join("wal", instance)
+	remote, err := s.listRemote(walDir)
+	if err != nil {
+		return fmt.Errorf("list remote wal failed,err=%s,wrap=%w", err, cons.PushBackupSetFailed)
+	}
+	entries, err := os.ReadDir(filepath.Join(backupPath, walDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read wal dir failed,err=%s,wrap=%w", err, cons.PushBackupSetFailed)
+	}
+	for _, e := range entries {
+		rel := filepath.Join(walDir, e.Name())
+		if !e.IsDir() && !remote[filepath.ToSlash(rel)] {
+			files = append(files, rel)
+		}
+	}
+
+	for _, rel := range files {
+		if err := s.upload(backupPath, rel); err != nil {
+			return fmt.Errorf("upload %s failed,err=%s,wrap=%w", rel, err, cons.PushBackupSetFailed)
+		}
+	}
+
+	s.log.Info(fmt.Sprintf("Push backup set[backup_id=%s,files=%d] to s3[bucket=%s,prefix=%s]", backupID, len(files), s.bucket, s.prefix))
+	return nil
+}
+
+// PullBackupSet download the backup and the archived wal, files already in the backup path are skipped.
+func (s *storage) PullBackupSet(backupPath, instance, backupID string) error {
+	files := make([]string, 0)
+	for _, dir := range []string{filepath.Join("backups", instance, backupID), filepath.Join("wal", instance)} {
+		remote, err := s.listRemote(dir)
+		if err != nil {
+			return fmt.Errorf("list remote %s failed,err=%s,wrap=%w", dir, err, cons.PullBackupSetFailed)
+		}
+		for rel := range remote {
+			files = append(files, filepath.FromSlash(rel))
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("backup[%s] not found in s3[bucket=%s,prefix=%s],wrap=%w", backupID, s.bucket, s.prefix, cons.PullBackupSetFailed)
+	}
+	files = append(files, filepath.Join("backups", instance, _probackupConf))
+
+	var n int
+	for _, rel := range files {
+		if _, err := os.Stat(filepath.Join(backupPath, rel)); err == nil {
+			continue
+		}
+		if err := s.download(backupPath, rel); err != nil {
+			return fmt.Errorf("download %s failed,err=%s,wrap=%w", rel, err, cons.PullBackupSetFailed)
+		}
+		n++
+	}
+
+	s.log.Info(fmt.Sprintf("Pull backup set[backup_id=%s,files=%d] from s3[bucket=%s,prefix=%s]", backupID, n, s.bucket, s.prefix))
+	return nil
+}
+
+// listRemote return the remote files under the dir, relative to the prefix.
+func (s *storage) listRemote(dir string) (map[string]bool, error) {
+	keys, err := s.cli.ListObjects(s.bucket, s.key(dir)+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		files[strings.TrimPrefix(strings.TrimPrefix(k, s.prefix), "/")] = true
+	}
+	return files, nil
+}
+
+func (s *storage) upload(backupPath, rel string) error {
+	f, err := os.Open(filepath.Join(backupPath, rel))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return s.cli.PutObject(s.bucket, s.key(rel), f, fi.Size())
+}
+
+func (s *storage) download(backupPath, rel string) error {
+	body, err := s.cli.GetObject(s.bucket, s.key(rel))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	path := filepath.Join(backupPath, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	// write to a temp file first, so a broken download never looks like a complete file.
+	tmp := path + ".part"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.ReadFrom(body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *storage) key(rel string) string {
+	if s.prefix == "" {
+		return filepath.ToSlash(rel)
+	}
+	return s.prefix + "/" + filepath.ToSlash(rel)
+}
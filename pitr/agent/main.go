@@ -22,11 +22,13 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/middleware"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/handler/view"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/pkg"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
 	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/responder"
@@ -40,16 +42,25 @@ const (
 )
 
 var (
-	app *fiber.App
-	log logging.ILog
+	app     *fiber.App
+	log     logging.ILog
+	policy  *pkg.Policy
+	auditor pkg.IAuditor
 )
 
 var (
 	logLevel      string
 	port          string
 	pgData        string
+	instances     string
+	engine        string
 	tlsCrt        string
 	tlsKey        string
+	clientCA      string
+	insecureNoCA  bool
+	policyFile    string
+	auditLog      string
+	allowedRoots  string
 	envSourceFile string
 )
 
@@ -60,8 +71,17 @@ func init() {
 
 	flag.StringVar(&tlsCrt, "tls-crt", "", "Require:TLS certificate file path")
 	flag.StringVar(&tlsKey, "tls-key", "", "Require:TLS key file path")
+	flag.StringVar(&clientCA, "client-ca", "", "Require:CA certificate file to verify client certificates, which are required by all requests")
+	flag.BoolVar(&insecureNoCA, "insecure-no-client-auth", false, "Optional:accept requests without client certificates, --client-ca is not required then, it is insecure and only for tests")
+
+	flag.StringVar(&policyFile, "policy-file", "", "Optional:policy file which maps client identities or tokens to allowed operations")
+	flag.StringVar(&auditLog, "audit-log", "", "Optional:append-only audit log file of mutating operations")
+
+	flag.StringVar(&allowedRoots, "allowed-roots", "", "Optional:comma-separated dirs, backup paths and disk paths of requests must be under one of them")
 
 	flag.StringVar(&pgData, "pgdata", "", "Optional:Get the value from cli flags or env")
+	flag.StringVar(&engine, "engine", pkg.EngineOpenGauss, "Optional:database engine of pgdata,option values:opengauss, postgresql or mysql")
+	flag.StringVar(&instances, "instances", "", "Optional:comma-separated port=pgdata of the database instances on this host, e.g. 5432=/data/dn1,5433=/data/dn2, --pgdata is ignored if it is set")
 
 	flag.StringVar(&envSourceFile, "env-source-file", "", "Optional:env source file path")
 }
@@ -76,27 +96,17 @@ func main() {
 		}
 	}
 
-	shell := os.Getenv("SHELL")
-	if shell == "" {
-		panic(fmt.Errorf("shell does not exist"))
-	}
-
-	if pgData == "" {
-		pgData = os.Getenv("PGDATA")
+	var pgDatas map[uint16]string
+	if instances != "" {
+		pgDatas = parseInstances(instances)
+	} else {
 		if pgData == "" {
-			panic(fmt.Errorf("PGDATA:no database directory specified and environment variable PGDATA unset"))
+			pgData = os.Getenv("PGDATA")
+			if pgData == "" {
+				panic(fmt.Errorf("PGDATA:no database directory specified and environment variable PGDATA unset"))
+			}
 		}
-	}
-
-	if _, err := os.Stat(pgData); os.IsNotExist(err) {
-		panic(fmt.Errorf("PGDATA:%s the database directory does not exist", pgData))
-	}
-
-	pgData = strings.Trim(pgData, " ")
-	if strings.HasSuffix(pgData, "/") {
-		dirs := strings.Split(pgData, "/")
-		dirs = dirs[0 : len(dirs)-1]
-		pgData = strings.Join(dirs, "/")
+		pgData = checkPgData(pgData)
 	}
 
 	if strings.Trim(tlsCrt, " ") == "" || strings.Trim(tlsKey, " ") == "" {
@@ -109,6 +119,17 @@ func main() {
 	if _, err := os.Stat(tlsKey); os.IsNotExist(err) {
 		panic(fmt.Errorf("TLS key file does not exist"))
 	}
+	if clientCA == "" && !insecureNoCA {
+		panic(fmt.Errorf("lack of client CA, please use --client-ca, or --insecure-no-client-auth to accept requests without client certificates"))
+	}
+	if clientCA != "" && insecureNoCA {
+		panic(fmt.Errorf("--client-ca conflicts with --insecure-no-client-auth"))
+	}
+	if clientCA != "" {
+		if _, err := os.Stat(clientCA); os.IsNotExist(err) {
+			panic(fmt.Errorf("client CA file does not exist"))
+		}
+	}
 
 	var level = zapcore.InfoLevel
 	if logLevel == debugLogLevel {
@@ -116,7 +137,43 @@ func main() {
 	}
 
 	log = logging.Init(level)
-	pkg.Init(shell, pgData, log)
+	var err error
+	if len(pgDatas) > 0 {
+		err = pkg.InitInstances(engine, pgDatas, log)
+	} else {
+		err = pkg.Init(engine, pgData, log)
+	}
+	if err != nil {
+		panic(fmt.Errorf("init database failure,err=%w", err))
+	}
+
+	if insecureNoCA {
+		log.Warn("client certificates are not verified because of --insecure-no-client-auth, anyone who can reach the agent is able to operate it, do not use it in production.")
+	}
+
+	if policyFile != "" {
+		p, err := pkg.LoadPolicy(policyFile)
+		if err != nil {
+			panic(fmt.Errorf("load policy file error:%s", err.Error()))
+		}
+		policy = p
+	} else {
+		log.Warn("no policy file is specified, all callers are allowed to do any operation, please use --policy-file.")
+	}
+
+	if allowedRoots != "" {
+		view.SetAllowedRoots(strings.Split(allowedRoots, ","))
+	} else {
+		log.Warn("no allowed roots are specified, requests are able to operate any path, please use --allowed-roots.")
+	}
+
+	if auditLog != "" {
+		a, err := pkg.NewAuditor(auditLog)
+		if err != nil {
+			panic(fmt.Errorf("open audit log error:%s", err.Error()))
+		}
+		auditor = a
+	}
 
 	SetupApp()
 
@@ -136,10 +193,53 @@ func main() {
 			log.Field(logging.ErrorKey, err.Error()).Error("http app closed failure")
 		}
 	}
+	if auditor != nil {
+		if err := auditor.Close(); err != nil {
+			log.Field(logging.ErrorKey, err.Error()).Error("audit log closed failure")
+		}
+	}
 	log.Info("app windup successfully.")
 	log.Info("app has exited...")
 }
 
+// checkPgData make sure the database directory exists, and trim the trailing slash of it.
+func checkPgData(pgData string) string {
+	if _, err := os.Stat(pgData); os.IsNotExist(err) {
+		panic(fmt.Errorf("PGDATA:%s the database directory does not exist", pgData))
+	}
+
+	pgData = strings.Trim(pgData, " ")
+	if strings.HasSuffix(pgData, "/") {
+		dirs := strings.Split(pgData, "/")
+		dirs = dirs[0 : len(dirs)-1]
+		pgData = strings.Join(dirs, "/")
+	}
+	return pgData
+}
+
+// parseInstances parse the port=pgdata pairs of --instances, every port and pgdata must be unique.
+func parseInstances(instances string) map[uint16]string {
+	pgDatas := make(map[uint16]string)
+	seen := make(map[string]bool)
+	for _, kv := range strings.Split(instances, ",") {
+		pair := strings.SplitN(strings.TrimSpace(kv), "=", 2)
+		if len(pair) != 2 {
+			panic(fmt.Errorf("instances:%s is not in the format of port=pgdata", kv))
+		}
+		p, err := strconv.ParseUint(pair[0], 10, 16)
+		if err != nil || p == 0 {
+			panic(fmt.Errorf("instances:invalid port %s", pair[0]))
+		}
+		dir := checkPgData(pair[1])
+		if _, ok := pgDatas[uint16(p)]; ok || seen[dir] {
+			panic(fmt.Errorf("instances:duplicate port or pgdata in %s", kv))
+		}
+		pgDatas[uint16(p)] = dir
+		seen[dir] = true
+	}
+	return pgDatas
+}
+
 func SetupApp() {
 	app = fiber.New()
 
@@ -159,7 +259,11 @@ func SetupApp() {
 	)
 
 	app.Route("/api", func(r fiber.Router) {
-		r.Use(middleware.RequestIDChecker())
+		r.Use(
+			middleware.RequestIDChecker(),
+			middleware.Audit(auditor, logging.Log()),
+			middleware.Authorize(policy),
+		)
 
 		r.Post("/healthz", handler.HealthCheck)
 		r.Post("/backup", handler.Backup)
@@ -168,6 +272,13 @@ func SetupApp() {
 		r.Post("/show", handler.Show)
 		r.Post("/show/list", handler.ShowList)
 		r.Post("/diskspace", handler.DiskSpace)
+		r.Post("/archive/enable", handler.EnableArchive)
+		r.Post("/archive/show", handler.ShowArchive)
+		r.Post("/backup/push", handler.PushBackupSet)
+		r.Post("/backup/pull", handler.PullBackupSet)
+		r.Post("/verify", handler.Verify)
+		r.Get("/jobs/:id", handler.ShowJob)
+		r.Get("/jobs/:id/log", handler.JobLog)
 	})
 
 	// 404
@@ -176,9 +287,10 @@ func SetupApp() {
 	})
 }
 
-// Serve run a http server on the specified port.
+// Serve run a https server on the specified port, client certificates are required unless --insecure-no-client-auth is set.
 func Serve(port string) error {
-
-	//return app.Listen(":18080")
-	return app.ListenTLS(fmt.Sprintf(":%s", port), tlsCrt, tlsKey)
+	if insecureNoCA {
+		return app.ListenTLS(fmt.Sprintf(":%s", port), tlsCrt, tlsKey)
+	}
+	return app.ListenMutualTLS(fmt.Sprintf(":%s", port), tlsCrt, tlsKey, clientCA)
 }
@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmds
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/logging"
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/pkg/syncutils"
+)
+
+/*
+ExecArgv exec the binary with argv and return the combined stdout and stderr.
+
+No shell is involved, so every arg is passed to the binary as is and never interpreted,
+the binary can be replaced by the env of the same name, e.g. `gs_probackup=/opt/bin/gs_probackup`.
+*/
+func ExecArgv(name string, args ...string) (string, error) {
+	cmd := exec.Command(Binary(name), args...)
+	logging.Debug(cmd.String())
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return string(output), fmt.Errorf("exec failure[ee=%s,stdout=%s],wrap=%w", ee, string(output), cons.CmdOperateFailed)
+		}
+		return string(output), fmt.Errorf("exec %s return err=%s,wrap=%w", name, err, cons.Internal)
+	}
+	return string(output), nil
+}
+
+// AsyncExecArgv is the async version of ExecArgv, the lines of combined stdout and stderr are sent to the channel.
+func AsyncExecArgv(name string, args ...string) (chan *Output, error) {
+	cmd := exec.Command(Binary(name), args...)
+	logging.Debug(cmd.String())
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("can not obtain stdout pipe for command[args=%+v]:%s", args, err)
+	}
+	cmd.Stderr = cmd.Stdout
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("the command is err[args=%+v]:%s", args, err)
+	}
+
+	var (
+		scanner = bufio.NewScanner(stdout)
+		output  = make(chan *Output)
+		index   = uint32(1)
+	)
+	go func() {
+		if err := syncutils.NewRecoverFuncWithErrRet("", func() error {
+			for scanner.Scan() {
+				output <- &Output{
+					LineNo:  index,
+					Message: scanner.Text(),
+				}
+				index++
+			}
+
+			if err := scanner.Err(); err != nil {
+				output <- &Output{
+					LineNo: index,
+					Error:  err,
+				}
+			}
+
+			if err := cmd.Wait(); err != nil {
+				if ee, ok := err.(*exec.ExitError); ok {
+					output <- &Output{
+						Error: fmt.Errorf("exec failure[ee=%s],wrap=%w", ee, cons.CmdOperateFailed),
+					}
+				} else {
+					output <- &Output{
+						Error: fmt.Errorf("cmd.Wait return err=%s,wrap=%w", err, cons.Internal),
+					}
+				}
+			}
+			return nil
+		})(); err != nil {
+			// only panic err
+			output <- &Output{
+				Error: err,
+			}
+		}
+		close(output)
+	}()
+
+	return output, nil
+}
+
+// Binary return the path of the binary from the env of the same name if it is set.
+func Binary(name string) string {
+	if v, ok := os.LookupEnv(name); ok && v != "" {
+		return v
+	}
+	return name
+}
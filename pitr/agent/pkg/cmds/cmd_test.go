@@ -18,9 +18,13 @@
 package cmds
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -69,4 +73,66 @@ var _ = Describe("Commands", func() {
 			Expect(strings.HasPrefix(args[0], newGS)).To(Equal(true))
 		})
 	})
+	Context("ExecArgv", func() {
+		It("args are not interpreted by shell", func() {
+			pwned := filepath.Join(GinkgoT().TempDir(), "pwned")
+			for _, arg := range []string{
+				"$(touch " + pwned + ")",
+				"`touch " + pwned + "`",
+				"; touch " + pwned,
+				"&& touch " + pwned,
+				"| touch " + pwned,
+				"'\"; touch " + pwned + "; echo \"'",
+			} {
+				output, err := ExecArgv("echo", arg)
+				Expect(err).To(BeNil())
+				Expect(output).To(Equal(arg + "\n"))
+			}
+			_, err := os.Stat(pwned)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("combine stdout and stderr", func() {
+			output, err := ExecArgv("ls", "/not/exist/dir")
+			Expect(errors.Is(err, cons.CmdOperateFailed)).To(BeTrue())
+			Expect(output).To(ContainSubstring("/not/exist/dir"))
+		})
+
+		It("binary not found", func() {
+			_, err := ExecArgv("not-exist-binary")
+			Expect(errors.Is(err, cons.Internal)).To(BeTrue())
+		})
+
+		It("replace binary by env", func() {
+			GinkgoT().Setenv("gs_probackup", "echo")
+			output, err := ExecArgv("gs_probackup", "show")
+			Expect(err).To(BeNil())
+			Expect(output).To(Equal("show\n"))
+		})
+	})
+
+	Context("AsyncExecArgv", func() {
+		It("lines of output", func() {
+			output, err := AsyncExecArgv("printf", "%s\n%s\n", "$HOME", "line 2")
+			Expect(err).To(BeNil())
+
+			var lines []string
+			for out := range output {
+				Expect(out.Error).To(BeNil())
+				lines = append(lines, out.Message)
+			}
+			Expect(lines).To(Equal([]string{"$HOME", "line 2"}))
+		})
+
+		It("exit with error", func() {
+			output, err := AsyncExecArgv("ls", "/not/exist/dir")
+			Expect(err).To(BeNil())
+
+			var last *Output
+			for out := range output {
+				last = out
+			}
+			Expect(errors.Is(last.Error, cons.CmdOperateFailed)).To(BeTrue())
+		})
+	})
 })
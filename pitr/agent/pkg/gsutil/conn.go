@@ -76,3 +76,27 @@ func (og *OpenGauss) CheckSchema(s string) error {
 	}
 	return og.db.Close()
 }
+
+// RecoveryResult return whether the server is still in recovery, and the last replayed wal location and transaction timestamp.
+func (og *OpenGauss) RecoveryResult() (inRecovery bool, lsn, ts string, err error) {
+	var (
+		_lsn sql.NullString
+		_ts  sql.NullString
+	)
+	row := og.db.QueryRow("SELECT pg_is_in_recovery(), pg_last_xlog_replay_location()::text, pg_last_xact_replay_timestamp()::text")
+	if err = row.Scan(&inRecovery, &_lsn, &_ts); err != nil {
+		efmt := "query recovery result fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return false, "", "", fmt.Errorf(efmt, og.user, og.pwLen, og.dbName, err, cons.DBConnectionFailed)
+	}
+	return inRecovery, _lsn.String, _ts.String, og.db.Close()
+}
+
+// ArchiveSettings return the archive_mode, archive_command and the name of current wal file.
+func (og *OpenGauss) ArchiveSettings() (mode, command, walFile string, err error) {
+	row := og.db.QueryRow("SELECT current_setting('archive_mode'), current_setting('archive_command'), pg_xlogfile_name(pg_current_xlog_location())")
+	if err = row.Scan(&mode, &command, &walFile); err != nil {
+		efmt := "query archive settings fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return "", "", "", fmt.Errorf(efmt, og.user, og.pwLen, og.dbName, err, cons.DBConnectionFailed)
+	}
+	return mode, command, walFile, og.db.Close()
+}
@@ -43,4 +43,7 @@ const (
 	RequestURI FieldKey = "requestUri" // http requesting uri
 	HTTPMethod FieldKey = "httpMethod"
 	HTTPStatus FieldKey = "httpStatus"
+
+	ClientIdentity FieldKey = "clientIdentity" // subject common name of the client certificate
+	ClientSerial   FieldKey = "clientSerial"   // serial number of the client certificate
 )
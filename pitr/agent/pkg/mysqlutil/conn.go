@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysqlutil
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	"github.com/go-sql-driver/mysql"
+)
+
+const defaultMySQLHost = "127.0.0.1"
+
+type MySQL struct {
+	db     *sql.DB
+	user   string
+	pwLen  int
+	dbName string
+}
+
+func Open(user, password, dbName string, dbPort uint16) (*MySQL, error) {
+	if strings.Trim(user, " ") == "" {
+		return nil, fmt.Errorf("user is empty")
+	}
+	if strings.Trim(password, " ") == "" {
+		return nil, fmt.Errorf("password is empty")
+	}
+	if strings.Trim(dbName, " ") == "" {
+		return nil, fmt.Errorf("db name is empty")
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.User = user
+	cfg.Passwd = password
+	cfg.Net = "tcp"
+	cfg.Addr = fmt.Sprintf("%s:%d", defaultMySQLHost, dbPort)
+	cfg.DBName = dbName
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		efmt := "sql:open fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return nil, fmt.Errorf(efmt, user, len(password), dbName, err, cons.DBConnectionFailed)
+	}
+
+	return &MySQL{
+		db:     db,
+		user:   user,
+		pwLen:  len(password),
+		dbName: dbName,
+	}, nil
+}
+
+func (m *MySQL) Ping() error {
+	if err := m.db.Ping(); err != nil {
+		efmt := "db ping fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return fmt.Errorf(efmt, m.user, m.pwLen, m.dbName, err, cons.DBConnectionFailed)
+	}
+	return m.db.Close()
+}
+
+func (m *MySQL) CheckSchema(s string) error {
+	_, err := m.db.Exec(s)
+	if err != nil {
+		return err
+	}
+	return m.db.Close()
+}
+
+// RecoveryResult return the executed gtid set, MySQL is never in recovery after the binlog is replayed.
+func (m *MySQL) RecoveryResult() (gtids string, err error) {
+	row := m.db.QueryRow("SELECT @@GLOBAL.gtid_executed")
+	if err = row.Scan(&gtids); err != nil {
+		efmt := "query recovery result fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return "", fmt.Errorf(efmt, m.user, m.pwLen, m.dbName, err, cons.DBConnectionFailed)
+	}
+	return gtids, m.db.Close()
+}
+
+// ArchiveSettings return whether the binlog is enabled, the basename of binlog files and the name of current binlog file.
+func (m *MySQL) ArchiveSettings() (logBin bool, basename, binlogFile string, err error) {
+	row := m.db.QueryRow("SELECT @@GLOBAL.log_bin, IFNULL(@@GLOBAL.log_bin_basename, '')")
+	if err = row.Scan(&logBin, &basename); err != nil {
+		efmt := "query archive settings fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return false, "", "", fmt.Errorf(efmt, m.user, m.pwLen, m.dbName, err, cons.DBConnectionFailed)
+	}
+
+	if logBin {
+		// SHOW MASTER STATUS returns Position, Binlog_Do_DB, Binlog_Ignore_DB and Executed_Gtid_Set besides File.
+		var pos, doDB, ignoreDB, gtids sql.NullString
+		row = m.db.QueryRow("SHOW MASTER STATUS")
+		if err = row.Scan(&binlogFile, &pos, &doDB, &ignoreDB, &gtids); err != nil {
+			efmt := "query binlog status fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+			return false, "", "", fmt.Errorf(efmt, m.user, m.pwLen, m.dbName, err, cons.DBConnectionFailed)
+		}
+	}
+	return logBin, basename, binlogFile, m.db.Close()
+}
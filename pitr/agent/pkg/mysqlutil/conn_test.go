@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysqlutil
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	user     = "root"
+	password = "1234567890@ss"
+	dbName   = "test-db"
+)
+
+var _ = Describe("MySQL", func() {
+	Context("Connection", func() {
+		It("Open and ping", func() {
+			Skip("Skip this test case, because it needs a real MySQL server.")
+			m, err := Open(user, password, dbName, uint16(3306))
+			Expect(err).To(BeNil())
+			Expect(m).NotTo(BeNil())
+
+			err = m.Ping()
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("check params", func() {
+		It("user is empty", func() {
+			m, err := Open("", password, dbName, uint16(3306))
+			Expect(err).NotTo(BeNil())
+			Expect(m).To(BeNil())
+		})
+		It("password is empty", func() {
+			m, err := Open(user, "", dbName, uint16(3306))
+			Expect(err).NotTo(BeNil())
+			Expect(m).To(BeNil())
+		})
+		It("database is empty", func() {
+			m, err := Open(user, password, "", uint16(3306))
+			Expect(err).NotTo(BeNil())
+			Expect(m).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgutil
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/agent/internal/cons"
+	_ "github.com/lib/pq"
+)
+
+const defaultPGHost = "127.0.0.1"
+
+type PostgreSQL struct {
+	db     *sql.DB
+	user   string
+	pwLen  int
+	dbName string
+}
+
+func Open(user, password, dbName string, dbPort uint16) (*PostgreSQL, error) {
+	if strings.Trim(user, " ") == "" {
+		return nil, fmt.Errorf("user is empty")
+	}
+	if strings.Trim(password, " ") == "" {
+		return nil, fmt.Errorf("password is empty")
+	}
+	if strings.Trim(dbName, " ") == "" {
+		return nil, fmt.Errorf("db name is empty")
+	}
+
+	connStr := "host=%s port=%d user=%s password=%s dbname=%s sslmode=disable"
+	db, err := sql.Open("postgres", fmt.Sprintf(connStr, defaultPGHost, dbPort, user, password, dbName))
+	if err != nil {
+		efmt := "sql:open fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return nil, fmt.Errorf(efmt, user, len(password), dbName, err, cons.DBConnectionFailed)
+	}
+
+	return &PostgreSQL{
+		db:     db,
+		user:   user,
+		pwLen:  len(password),
+		dbName: dbName,
+	}, nil
+}
+
+func (pg *PostgreSQL) Ping() error {
+	if err := pg.db.Ping(); err != nil {
+		efmt := "db ping fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return fmt.Errorf(efmt, pg.user, pg.pwLen, pg.dbName, err, cons.DBConnectionFailed)
+	}
+	return pg.db.Close()
+}
+
+func (pg *PostgreSQL) CheckSchema(s string) error {
+	_, err := pg.db.Exec(s)
+	if err != nil {
+		return err
+	}
+	return pg.db.Close()
+}
+
+// RecoveryResult return whether the server is still in recovery, and the last replayed wal location and transaction timestamp.
+func (pg *PostgreSQL) RecoveryResult() (inRecovery bool, lsn, ts string, err error) {
+	var (
+		_lsn sql.NullString
+		_ts  sql.NullString
+	)
+	row := pg.db.QueryRow("SELECT pg_is_in_recovery(), pg_last_wal_replay_lsn()::text, pg_last_xact_replay_timestamp()::text")
+	if err = row.Scan(&inRecovery, &_lsn, &_ts); err != nil {
+		efmt := "query recovery result fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return false, "", "", fmt.Errorf(efmt, pg.user, pg.pwLen, pg.dbName, err, cons.DBConnectionFailed)
+	}
+	return inRecovery, _lsn.String, _ts.String, pg.db.Close()
+}
+
+// ArchiveSettings return the archive_mode, archive_command and the name of current wal file.
+func (pg *PostgreSQL) ArchiveSettings() (mode, command, walFile string, err error) {
+	row := pg.db.QueryRow("SELECT current_setting('archive_mode'), current_setting('archive_command'), pg_walfile_name(pg_current_wal_lsn())")
+	if err = row.Scan(&mode, &command, &walFile); err != nil {
+		efmt := "query archive settings fail[user=%s,pwLen=%d,dbName=%s],err=%s,wrap=%w"
+		return "", "", "", fmt.Errorf(efmt, pg.user, pg.pwLen, pg.dbName, err, cons.DBConnectionFailed)
+	}
+	return mode, command, walFile, pg.db.Close()
+}
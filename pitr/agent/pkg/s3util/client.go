@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+/*
+Client is a S3 compatible client built on aws-sdk-go-v2, it works with AWS S3, MinIO and other S3 compatible
+object storages which accept path style requests.
+
+The objects are uploaded by the upload manager of aws-sdk-go-v2, the big ones are uploaded in parts,
+so they are not limited to the 5GB of a single PUT.
+*/
+type (
+	Config struct {
+		// Endpoint like https://s3.us-east-1.amazonaws.com or http://127.0.0.1:9000
+		Endpoint  string
+		Region    string
+		AccessKey string
+		SecretKey string
+	}
+
+	IClient interface {
+		PutObject(bucket, key string, body io.Reader, size int64) error
+		GetObject(bucket, key string) (io.ReadCloser, error)
+		ListObjects(bucket, prefix string) ([]string, error)
+		DeleteObject(bucket, key string) error
+	}
+
+	client struct {
+		s3Cli *s3.Client
+	}
+)
+
+const (
+	_defaultRegion = "us-east-1"
+	// S3 allows 10000 parts at most, the part size is raised for the objects bigger than 10000 default parts.
+	_maxParts = 10000
+)
+
+var ErrNotFound = errors.New("s3 object not found")
+
+func NewClient(cfg *Config) (IClient, error) {
+	if cfg == nil || cfg.Endpoint == "" {
+		return nil, errors.New("missing s3 endpoint")
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint[%s],err=%w", cfg.Endpoint, err)
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("missing s3 access key or secret key")
+	}
+	if cfg.Region == "" {
+		cfg.Region = _defaultRegion
+	}
+
+	return &client{
+		s3Cli: s3.New(s3.Options{
+			BaseEndpoint: aws.String(cfg.Endpoint),
+			Region:       cfg.Region,
+			Credentials:  credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, ""),
+			UsePathStyle: true,
+		}),
+	}, nil
+}
+
+// PutObject upload the object by the upload manager, which switches to multipart upload if it is bigger than a part.
+func (c *client) PutObject(bucket, key string, body io.Reader, size int64) error {
+	uploader := manager.NewUploader(c.s3Cli, func(u *manager.Uploader) {
+		if size > u.PartSize*_maxParts {
+			u.PartSize = size/_maxParts + 1
+		}
+	})
+
+	if _, err := uploader.Upload(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	}); err != nil {
+		return wrapErr(fmt.Sprintf("put object[bucket=%s,key=%s]", bucket, key), err)
+	}
+	return nil
+}
+
+// GetObject return ErrNotFound if the object does not exist, the caller must close the body.
+func (c *client) GetObject(bucket, key string) (io.ReadCloser, error) {
+	out, err := c.s3Cli.GetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, wrapErr(fmt.Sprintf("get object[bucket=%s,key=%s]", bucket, key), err)
+	}
+	return out.Body, nil
+}
+
+// ListObjects return all keys with the prefix, the pages are fetched one by one.
+func (c *client) ListObjects(bucket, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	pages := s3.NewListObjectsV2Paginator(c.s3Cli, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(context.Background())
+		if err != nil {
+			return nil, wrapErr(fmt.Sprintf("list objects[bucket=%s,prefix=%s]", bucket, prefix), err)
+		}
+		for _, v := range page.Contents {
+			keys = append(keys, aws.ToString(v.Key))
+		}
+	}
+	return keys, nil
+}
+
+func (c *client) DeleteObject(bucket, key string) error {
+	if _, err := c.s3Cli.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return wrapErr(fmt.Sprintf("delete object[bucket=%s,key=%s]", bucket, key), err)
+	}
+	return nil
+}
+
+// wrapErr wrap ErrNotFound if the object or the bucket does not exist.
+func wrapErr(op string, err error) error {
+	var re *awshttp.ResponseError
+	if errors.As(err, &re) && re.HTTPStatusCode() == http.StatusNotFound {
+		return fmt.Errorf("%s,err=%w", op, ErrNotFound)
+	}
+	return fmt.Errorf("%s failed,err=%w", op, err)
+}
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3util
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("S3 client", func() {
+	Context("objects", func() {
+		It("put, get, list and delete", func() {
+			srv := NewFakeServer()
+			defer srv.Close()
+
+			cli, err := NewClient(&Config{Endpoint: srv.URL, AccessKey: "ak", SecretKey: "sk"})
+			Expect(err).To(BeNil())
+
+			Expect(cli.PutObject("pitr", "backup/a.json", strings.NewReader("a"), 1)).To(Succeed())
+			Expect(cli.PutObject("pitr", "backup/b c.json", strings.NewReader("bc"), 2)).To(Succeed())
+			Expect(cli.PutObject("pitr", "wal/000000010000000000000001", strings.NewReader("w"), 1)).To(Succeed())
+
+			body, err := cli.GetObject("pitr", "backup/b c.json")
+			Expect(err).To(BeNil())
+			data, err := io.ReadAll(body)
+			Expect(err).To(BeNil())
+			Expect(body.Close()).To(Succeed())
+			Expect(string(data)).To(Equal("bc"))
+
+			keys, err := cli.ListObjects("pitr", "backup/")
+			Expect(err).To(BeNil())
+			Expect(keys).To(Equal([]string{"backup/a.json", "backup/b c.json"}))
+
+			Expect(cli.DeleteObject("pitr", "backup/a.json")).To(Succeed())
+			_, err = cli.GetObject("pitr", "backup/a.json")
+			Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+		})
+
+		It("upload big object in parts", func() {
+			srv := NewFakeServer()
+			defer srv.Close()
+
+			cli, err := NewClient(&Config{Endpoint: srv.URL, AccessKey: "ak", SecretKey: "sk"})
+			Expect(err).To(BeNil())
+
+			// bigger than two parts of 5MB, so it is uploaded by multipart upload
+			data := bytes.Repeat([]byte("0123456789"), 1024*1024+1)
+			Expect(cli.PutObject("pitr", "backup/big", bytes.NewReader(data), int64(len(data)))).To(Succeed())
+
+			body, err := cli.GetObject("pitr", "backup/big")
+			Expect(err).To(BeNil())
+			got, err := io.ReadAll(body)
+			Expect(err).To(BeNil())
+			Expect(body.Close()).To(Succeed())
+			Expect(got).To(Equal(data))
+		})
+
+		It("invalid config", func() {
+			_, err := NewClient(&Config{AccessKey: "ak", SecretKey: "sk"})
+			Expect(err).NotTo(BeNil())
+			_, err = NewClient(&Config{Endpoint: "http://127.0.0.1:9000"})
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
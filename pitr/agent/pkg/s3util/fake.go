@@ -0,0 +1,178 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3util
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type fakeServer struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	// uploads are the parts of the multipart uploads in progress, keyed by upload id
+	uploads  map[string]map[int][]byte
+	uploadID int
+}
+
+const _fakeAlgorithm = "AWS4-HMAC-SHA256"
+
+/*
+NewFakeServer start an in-memory S3 compatible server for tests, the buckets are created on demand.
+
+It only requires the requests to be signed, the signature is not verified.
+Single PUT, multipart upload, GET, ListObjectsV2 and DELETE are supported.
+*/
+func NewFakeServer() *httptest.Server {
+	fs := &fakeServer{
+		objects: map[string][]byte{},
+		uploads: map[string]map[int][]byte{},
+	}
+	return httptest.NewServer(fs)
+}
+
+func (fs *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), _fakeAlgorithm) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		fs.list(w, bucket, query.Get("prefix"))
+	case r.Method == http.MethodGet:
+		data, ok := fs.objects[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		fs.uploadID++
+		id := strconv.Itoa(fs.uploadID)
+		fs.uploads[id] = map[int][]byte{}
+		writeXML(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			Bucket   string   `xml:"Bucket"`
+			Key      string   `xml:"Key"`
+			UploadID string   `xml:"UploadId"`
+		}{Bucket: bucket, Key: key, UploadID: id})
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		fs.complete(w, path, bucket, key, query.Get("uploadId"))
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		parts, ok := fs.uploads[query.Get("uploadId")]
+		num, err := strconv.Atoi(query.Get("partNumber"))
+		if !ok || err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		parts[num] = data
+		w.Header().Set("ETag", fmt.Sprintf("\"%d\"", num))
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fs.objects[path] = data
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(fs.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		delete(fs.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (fs *fakeServer) complete(w http.ResponseWriter, path, bucket, key, uploadID string) {
+	parts, ok := fs.uploads[uploadID]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	nums := make([]int, 0, len(parts))
+	for n := range parts {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+
+	var data bytes.Buffer
+	for _, n := range nums {
+		data.Write(parts[n])
+	}
+	fs.objects[path] = data.Bytes()
+	delete(fs.uploads, uploadID)
+
+	writeXML(w, struct {
+		XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		Bucket  string   `xml:"Bucket"`
+		Key     string   `xml:"Key"`
+	}{Bucket: bucket, Key: key})
+}
+
+func (fs *fakeServer) list(w http.ResponseWriter, bucket, prefix string) {
+	result := struct {
+		XMLName  xml.Name `xml:"ListBucketResult"`
+		Contents []struct {
+			Key string `xml:"Key"`
+		} `xml:"Contents"`
+	}{}
+
+	keys := make([]string, 0)
+	for k := range fs.objects {
+		if key, ok := strings.CutPrefix(k, bucket+"/"); ok && strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		result.Contents = append(result.Contents, struct {
+			Key string `xml:"Key"`
+		}{Key: k})
+	}
+
+	writeXML(w, result)
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/httputils"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// AgentCA the CA certificate file to verify the certificate of agent server
+	AgentCA string
+	// AgentFingerprint the SHA-256 fingerprint of the agent server certificate, the certificate is pinned if it is set
+	AgentFingerprint string
+	// ClientCert the client certificate file presented to agent server
+	ClientCert string
+	// ClientKey the key file of the client certificate
+	ClientKey string
+	// AgentToken the token sent to agent server, it is authorized by the policy of agent server
+	AgentToken string
+	// InsecureSkipVerify the agent server certificate is not verified, it is only for tests
+	InsecureSkipVerify bool
+)
+
+// envAgentToken the token is read from it if --agent-token is not set, to keep it out of the process list
+const envAgentToken = "GS_PITR_AGENT_TOKEN"
+
+func addAgentAuthFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&AgentCA, "agent-ca", "", "", "CA certificate file to verify the agent server certificate")
+	cmd.Flags().StringVarP(&AgentFingerprint, "agent-fingerprint", "", "", "SHA-256 fingerprint of the agent server certificate to pin")
+	cmd.Flags().StringVarP(&ClientCert, "client-cert", "", "", "client certificate file presented to agent server")
+	cmd.Flags().StringVarP(&ClientKey, "client-key", "", "", "key file of the client certificate")
+	cmd.Flags().StringVarP(&AgentToken, "agent-token", "", "", fmt.Sprintf("token authorized by the policy of agent server, env %s is used if it is not set", envAgentToken))
+	cmd.Flags().BoolVarP(&InsecureSkipVerify, "insecure-skip-verify", "", false, "skip verifying the agent server certificate, it is insecure and only for tests")
+}
+
+// setupAgentAuth the agent server certificate is verified by --agent-ca, --agent-fingerprint or the system CA pool,
+// it is skipped only if --insecure-skip-verify is set.
+func setupAgentAuth() error {
+	token := AgentToken
+	if token == "" {
+		token = os.Getenv(envAgentToken)
+	}
+	httputils.SetAuthToken(token)
+
+	if InsecureSkipVerify {
+		logging.Warn("The agent server certificate is not verified because of --insecure-skip-verify, do not use it in production.")
+	}
+
+	cfg, err := httputils.NewTLSConfig(&httputils.TLSOptions{
+		CAFile:             AgentCA,
+		CertFile:           ClientCert,
+		KeyFile:            ClientKey,
+		Fingerprint:        AgentFingerprint,
+		InsecureSkipVerify: InsecureSkipVerify,
+	})
+	if err != nil {
+		return xerr.NewCliErr(fmt.Sprintf("setup tls of agent server failed, err:%s", err.Error()))
+	}
+	httputils.SetTLSConfig(cfg)
+	return nil
+}
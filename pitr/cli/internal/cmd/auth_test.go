@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/httputils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("agent tls", func() {
+	AfterEach(func() {
+		AgentCA, AgentFingerprint, ClientCert, ClientKey, AgentToken = "", "", "", "", ""
+		InsecureSkipVerify = false
+		httputils.SetAuthToken("")
+	})
+
+	It("verify agent server by system CA pool by default", func() {
+		Expect(setupAgentAuth()).To(BeNil())
+	})
+
+	It("skip verifying agent server if it is insecure", func() {
+		InsecureSkipVerify = true
+		Expect(setupAgentAuth()).To(BeNil())
+	})
+
+	It("insecure with CA file", func() {
+		InsecureSkipVerify, AgentCA = true, "/tmp/ca.crt"
+		Expect(setupAgentAuth()).NotTo(BeNil())
+	})
+
+	It("client certificate not exist", func() {
+		ClientCert, ClientKey = "/not/exist/client.crt", "/not/exist/client.key"
+		Expect(setupAgentAuth()).NotTo(BeNil())
+	})
+
+	It("CA file not exist", func() {
+		AgentCA = "/not/exist/ca.crt"
+		Expect(setupAgentAuth()).NotTo(BeNil())
+	})
+})
@@ -19,7 +19,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
@@ -42,14 +41,26 @@ const (
 	defaultShowDetailRetryTimes = 3
 )
 
-var filename string
+var (
+	filename string
+	// EnableArchive turn on wal archiving toward the backup path before backup
+	EnableArchive bool
+	// CompressAlg openGauss data backup compress algorithm (none|zlib|pglz)
+	CompressAlg string
+	// CompressLevel openGauss data backup compress level (0-9)
+	CompressLevel uint8
+	// Stream stream the wal generated during backup into the backup set
+	Stream bool
+	// SkipBlockValidation skip the page-level checksums validation of data files
+	SkipBlockValidation bool
+)
 
 var BackupCmd = &cobra.Command{
 	Use:   "backup",
 	Short: "Backup a database cluster",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
-			fmt.Printf("Flag: %s Value: %s\n", flag.Name, flag.Value)
+			fmt.Fprintf(textOut, "Flag: %s Value: %s\n", flag.Name, flag.Value)
 		})
 
 		// convert BackupModeStr to BackupMode
@@ -59,21 +70,33 @@ var BackupCmd = &cobra.Command{
 		case "PTRACK", "ptrack":
 			BackupMode = model.DBBackModePTrack
 		}
+		switch CompressAlg {
+		case "", "none", "zlib", "pglz":
+		default:
+			return xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("Invalid compress algorithm: %s", CompressAlg))
+		}
+		if CompressLevel > 9 {
+			return xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("Invalid compress level: %d", CompressLevel))
+		}
+
 		if BackupMode == model.DBBackModePTrack {
 			logging.Warn("Please make sure all openGauss nodes have been set correct configuration about ptrack. You can refer to https://support.huaweicloud.com/intl/zh-cn/devg-opengauss/opengauss_devg_1362.html for more details.")
 		}
 
+		if err := setupAgentAuth(); err != nil {
+			return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+		}
+
 		logging.Info(fmt.Sprintf("Default backup path: %s", pkg.DefaultRootDir()))
 
 		// Start backup
-		if err := backup(); err != nil {
-			logging.Error(err.Error())
-		}
+		return backup()
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(BackupCmd)
+	addProfileFlags(BackupCmd)
 
 	BackupCmd.Flags().StringVarP(&Host, "host", "H", "", "ss-proxy hostname or ip")
 	_ = BackupCmd.MarkFlagRequired("host")
@@ -82,37 +105,49 @@ func init() {
 	BackupCmd.Flags().StringVarP(&Username, "username", "u", "", "ss-proxy username")
 	_ = BackupCmd.MarkFlagRequired("username")
 	BackupCmd.Flags().StringVarP(&Password, "password", "p", "", "ss-proxy password")
+	BackupCmd.Flags().StringVarP(&ProxyProtocol, "proxy-protocol", "", pkg.ProtocolPostgreSQL, "ss-proxy frontend protocol (postgresql|mysql)")
 	_ = BackupCmd.MarkFlagRequired("password")
+	addPasswordFileFlags(BackupCmd)
 	BackupCmd.Flags().StringVarP(&BackupPath, "dn-backup-path", "B", "", "openGauss data backup path")
 	_ = BackupCmd.MarkFlagRequired("dn-backup-path")
 	BackupCmd.Flags().StringVarP(&BackupModeStr, "dn-backup-mode", "b", "", "openGauss data backup mode (FULL|PTRACK)")
 	_ = BackupCmd.MarkFlagRequired("dn-backup-mode")
 	BackupCmd.Flags().Uint8VarP(&ThreadsNum, "dn-threads-num", "j", 1, "openGauss data backup threads nums")
+	BackupCmd.Flags().StringVarP(&CompressAlg, "dn-compress-alg", "", "", "openGauss data backup compress algorithm (none|zlib|pglz)")
+	BackupCmd.Flags().Uint8VarP(&CompressLevel, "dn-compress-level", "", 0, "openGauss data backup compress level (0-9)")
+	BackupCmd.Flags().BoolVarP(&Stream, "dn-stream", "", false, "stream the wal generated during backup into the backup set")
+	BackupCmd.Flags().BoolVarP(&SkipBlockValidation, "dn-skip-block-validation", "", false, "skip the page-level checksums validation of data files")
 	BackupCmd.Flags().Uint16VarP(&AgentPort, "agent-port", "a", 443, "agent server port")
 	_ = BackupCmd.MarkFlagRequired("agent-port")
-
+	addAgentAuthFlags(BackupCmd)
+	BackupCmd.Flags().BoolVarP(&EnableArchive, "enable-archive", "", false, "turn on wal (binlog of MySQL) archiving toward the backup path before backup")
+	BackupCmd.Flags().BoolVarP(&AssumeYes, "assume-yes", "", false, "same as --yes")
+	addStorageFlags(BackupCmd)
 }
 
 // Steps of backup:
 // 1. lock cluster
 // 2. Get cluster info and save local backup info
-// 3. Operate backup by agent-server
-// 4. unlock cluster
-// 5. Waiting for backups finished
-// 6. Update local backup info
-// 7. Double check backups all finished
+// 3. Check agent server and wal archiving
+// 4. Operate backup by agent-server
+// 5. unlock cluster
+// 6. Waiting for backups finished
+// 7. Update local backup info
+// 8. Double check backups all finished
+// 9. Link the incremental backup to its parent
+// 10. Push backup sets to remote storage if --storage=s3
 // nolint:gocognit
 func backup() error {
 	var err error
 	var lsBackup *model.LsBackup
-	proxy, err := pkg.NewShardingSphereProxy(Username, Password, pkg.DefaultDBName, Host, Port)
+	proxy, err := newShardingSphereProxy()
 	if err != nil {
-		return xerr.NewCliErr("Create ss-proxy connect failed")
+		return xerr.NewCodeErr(xerr.ExitProxy, "Create ss-proxy connect failed")
 	}
 
-	ls, err := pkg.NewLocalStorage(pkg.DefaultRootDir())
+	ls, err := newStorage()
 	if err != nil {
-		return xerr.NewCliErr("Create local storage failed")
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("Create storage failed, err:%s", err.Error()))
 	}
 
 	defer func() {
@@ -133,7 +168,7 @@ func backup() error {
 	logging.Info("Starting lock cluster ...")
 	err = proxy.LockForBackup()
 	if err != nil {
-		return xerr.NewCliErr("Lock for backup failed")
+		return xerr.NewCodeErr(xerr.ExitProxy, "Lock for backup failed")
 	}
 
 	// Step2. Get cluster info and save local backup info
@@ -148,52 +183,83 @@ func backup() error {
 	logging.Info("Checking agent server status...")
 	if available := checkAgentServerStatus(lsBackup); !available {
 		logging.Error("Cancel! One or more agent server are not available.")
-		err = xerr.NewCliErr("One or more agent server are not available.")
+		err = xerr.NewCodeErr(xerr.ExitAgentServer, "One or more agent server are not available.")
+		return err
+	}
+
+	// Step4. Check wal archiving, point in time recovery after this backup depends on it
+	logging.Info("Checking wal archive status...")
+	if healthy := checkArchiveStatus(lsBackup); !healthy {
+		logging.Error("Cancel! Wal archiving of one or more data nodes is broken.")
+		err = xerr.NewCodeErr(xerr.ExitPreflight, "Wal archiving of one or more data nodes is broken.")
 		return err
 	}
 
-	// Step4. Show disk space
+	// Step5. Check disk space
 	logging.Info("Checking disk space...")
-	err = checkDiskSpace(lsBackup)
+	err = checkDiskSpace(lsBackup, false)
 	if err != nil {
-		return xerr.NewCliErr(err.Error())
+		return err
 	}
 
-	// Step5. send backup command to agent-server.
+	// Step6. send backup command to agent-server.
 	logging.Info("Starting backup ...")
 	err = execBackup(lsBackup)
 	if err != nil {
 		return xerr.NewCliErr(fmt.Sprintf("exec backup failed, err:%s", err.Error()))
 	}
 
-	// Step6. unlock cluster
+	// Step7. unlock cluster
 	logging.Info("Starting unlock cluster ...")
 	err = proxy.Unlock()
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("unlock cluster failed, err:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitProxy, fmt.Sprintf("unlock cluster failed, err:%s", err.Error()))
 	}
 
-	// Step7. update backup file
+	// Step8. update backup file
 	logging.Info("Starting update backup file ...")
 	err = ls.WriteByJSON(filename, lsBackup)
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("update backup file failed, err:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("update backup file failed, err:%s", err.Error()))
 	}
 
-	// Step8. check agent server backup
+	// Step9. check agent server backup
 	logging.Info("Starting check backup status ...")
 	status := checkBackupStatus(lsBackup)
 	logging.Info(fmt.Sprintf("Backup result: %s", status))
+	setResult(newRecordResult(lsBackup))
 	if status != model.SsBackupStatusCompleted && status != model.SsBackupStatusCanceled {
 		err = xerr.NewCliErr("Backup failed")
 		return err
 	}
 
-	// Step9. finished backup and update backup file
+	// Step10. link the incremental backup to its parent, restore needs the whole chain
+	if BackupMode == model.DBBackModePTrack {
+		logging.Info("Starting link backup chain ...")
+		if chainErr := linkBackupChain(ls, lsBackup); chainErr != nil {
+			logging.Warn(fmt.Sprintf("Link backup chain failed, err:%s", chainErr.Error()))
+		}
+	}
+
+	// Step11. push backup sets to remote storage, so they survive the loss of data nodes.
+	// The backup itself is fine if the push failed, so it is kept on data nodes instead of rolling back.
+	var pushErr error
+	if Storage == storageS3 {
+		logging.Info("Starting push backup sets ...")
+		if pushErr = pushBackupSets(lsBackup); pushErr != nil {
+			logging.Error(fmt.Sprintf("Push backup sets failed, backup sets are only kept on data nodes, err:%s", pushErr.Error()))
+		}
+	}
+
+	// Step12. finished backup and update backup file
 	logging.Info("Starting update backup file ...")
 	err = ls.WriteByJSON(filename, lsBackup)
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("update backup file failed, err: %s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("update backup file failed, err: %s", err.Error()))
+	}
+
+	if pushErr != nil {
+		return xerr.NewCodeErr(xerr.ExitStorage, "Backup finished, but push backup sets failed")
 	}
 
 	logging.Info("Backup finished!")
@@ -213,6 +279,8 @@ func exportData(proxy pkg.IShardingSphereProxy, ls pkg.ILocalStorage) (lsBackup
 		return nil, xerr.NewCliErr("export storage nodes failed")
 	}
 
+	setInstances(nodes)
+
 	// Step3. combine the backup contents
 	filename = ls.GenFilename(pkg.ExtnJSON)
 	csn := ""
@@ -243,6 +311,29 @@ func exportData(proxy pkg.IShardingSphereProxy, ls pkg.ILocalStorage) (lsBackup
 	return contents, nil
 }
 
+// setInstances give each storage node its own backup instance if several of them run on the same host,
+// they are managed by one agent server and share the backup path.
+func setInstances(nodes []*model.StorageNode) {
+	hosts := make(map[string]int)
+	for _, sn := range nodes {
+		hosts[sn.IP]++
+	}
+	for _, sn := range nodes {
+		if hosts[sn.IP] > 1 {
+			sn.Instance = fmt.Sprintf("%s-%d", defaultInstance, sn.Port)
+		}
+	}
+}
+
+// instanceOf return the backup instance of the storage node, the records before multiple instances per host
+// have no instance in storage nodes.
+func instanceOf(sn *model.StorageNode) string {
+	if sn.Instance != "" {
+		return sn.Instance
+	}
+	return defaultInstance
+}
+
 func execBackup(lsBackup *model.LsBackup) error {
 	sNodes := lsBackup.SsBackup.StorageNodes
 	dnCh := make(chan *model.DataNode, len(sNodes))
@@ -285,9 +376,14 @@ func _execBackup(as pkg.IAgentServer, node *model.StorageNode, dnCh chan *model.
 		DnBackupPath: BackupPath,
 		DnThreadsNum: ThreadsNum,
 		DnBackupMode: BackupMode,
-		Instance:     defaultInstance,
+		Instance:     instanceOf(node),
+
+		DnCompressAlg:         CompressAlg,
+		DnCompressLevel:       CompressLevel,
+		DnStream:              Stream,
+		DnSkipBlockValidation: SkipBlockValidation,
 	}
-	backupID, err := as.Backup(in)
+	out, err := as.Backup(in)
 	if err != nil {
 		return xerr.NewCliErr(fmt.Sprintf("backup failed, err:%s", err.Error()))
 	}
@@ -297,7 +393,8 @@ func _execBackup(as pkg.IAgentServer, node *model.StorageNode, dnCh chan *model.
 		IP:        node.IP,
 		Port:      node.Port,
 		Status:    model.SsBackupStatusRunning,
-		BackupID:  backupID,
+		BackupID:  out.ID,
+		JobID:     out.JobID,
 		StartTime: time.Now().Unix(),
 		EndTime:   0,
 	}
@@ -305,6 +402,73 @@ func _execBackup(as pkg.IAgentServer, node *model.StorageNode, dnCh chan *model.
 	return nil
 }
 
+// checkArchiveStatus check wal archiving of all data nodes, turn it on first if --enable-archive is set.
+func checkArchiveStatus(lsBackup *model.LsBackup) bool {
+	var (
+		statusList = make([]*model.ArchiveNodeStatus, 0)
+		healthy    = true
+	)
+
+	for _, node := range lsBackup.SsBackup.StorageNodes {
+		sn := node
+		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
+		in := &model.ArchiveIn{
+			DBPort:       sn.Port,
+			DBName:       sn.Database,
+			Username:     sn.Username,
+			Password:     sn.Password,
+			DnBackupPath: BackupPath,
+			Instance:     instanceOf(sn),
+		}
+		ns := &model.ArchiveNodeStatus{IP: sn.IP, Port: sn.Port}
+		statusList = append(statusList, ns)
+
+		if EnableArchive {
+			if err := as.EnableArchive(in); err != nil {
+				ns.Err = fmt.Sprintf("enable archive failed:%s", err.Error())
+				healthy = false
+				continue
+			}
+		}
+
+		status, err := as.ShowArchive(in)
+		if err != nil {
+			ns.Err = fmt.Sprintf("show archive failed:%s", err.Error())
+			healthy = false
+			continue
+		}
+		ns.Status = status
+		if status.Broken {
+			healthy = false
+		}
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(textOut)
+	t.SetTitle("Wal Archive Status")
+	t.AppendHeader(table.Row{"#", "Data Node IP", "Data Node Port", "Latest Archived Segment", "Lag Segments", "Status"})
+	t.SetColumnConfigs([]table.ColumnConfig{{Number: 6, WidthMax: 50}})
+
+	for i, ns := range statusList {
+		switch {
+		case ns.Status == nil:
+			t.AppendRow([]interface{}{i + 1, ns.IP, ns.Port, "", "", ns.Err})
+		case ns.Status.Broken:
+			t.AppendRow([]interface{}{i + 1, ns.IP, ns.Port, ns.Status.LatestArchived, ns.Status.LagSegments, fmt.Sprintf("Broken: %s", ns.Status.Reason)})
+		default:
+			t.AppendRow([]interface{}{i + 1, ns.IP, ns.Port, ns.Status.LatestArchived, ns.Status.LagSegments, "OK"})
+		}
+		t.AppendSeparator()
+	}
+
+	t.Render()
+
+	if !healthy && !EnableArchive {
+		logging.Warn("You can use --enable-archive to turn on wal archiving toward the backup path.")
+	}
+	return healthy
+}
+
 func checkBackupStatus(lsBackup *model.LsBackup) model.BackupStatus {
 	var (
 		dataNodeMap       = make(map[string]*model.DataNode)
@@ -320,15 +484,16 @@ func checkBackupStatus(lsBackup *model.LsBackup) model.BackupStatus {
 	}
 
 	for _, dn := range lsBackup.DnList {
-		dataNodeMap[dn.IP] = dn
+		dataNodeMap[dn.Key()] = dn
 	}
 
 	pw := prettyoutput.NewPW(totalNum)
+	pw.SetOutputWriter(textOut)
 	go pw.Render()
 	for idx := 0; idx < totalNum; idx++ {
 		sn := lsBackup.SsBackup.StorageNodes[idx]
 		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
-		dn := dataNodeMap[sn.IP]
+		dn := dataNodeMap[sn.Key()]
 		go checkStatus(as, sn, dn, dnCh, pw)
 	}
 
@@ -349,7 +514,7 @@ func checkBackupStatus(lsBackup *model.LsBackup) model.BackupStatus {
 
 	// print backup result formatted
 	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	t.SetOutputMirror(textOut)
 	t.SetTitle("Backup Task Result: %s", backupFinalStatus)
 	t.AppendHeader(table.Row{"#", "Data Node IP", "Data Node Port", "Result"})
 
@@ -383,7 +548,15 @@ func checkStatus(as pkg.IAgentServer, sn *model.StorageNode, dn *model.DataNode,
 		case <-done:
 			return
 		case <-ticker:
-			status, err := doCheck(as, sn, dn.BackupID, defaultShowDetailRetryTimes)
+			updateBackupProgress(as, dn, &tracker)
+			status := model.SsBackupStatusCheckError
+			info, err := showDetail(as, sn, dn.BackupID, defaultShowDetailRetryTimes)
+			if err == nil {
+				status = info.Status
+				dn.CompressAlg = info.CompressAlg
+				dn.DataBytes = info.DataBytes
+				dn.UncompressedBytes = info.UncompressedBytes
+			}
 			if err != nil {
 				tracker.MarkAsErrored()
 				dn.Status = status
@@ -402,7 +575,28 @@ func checkStatus(as pkg.IAgentServer, sn *model.StorageNode, dn *model.DataNode,
 	}
 }
 
+// updateBackupProgress update the tracker with the progress of backup job, the progress is only for display.
+func updateBackupProgress(as pkg.IAgentServer, dn *model.DataNode, tracker *progress.Tracker) {
+	if dn.JobID == "" {
+		return
+	}
+	job, err := as.ShowJob(dn.JobID)
+	if err != nil || job.Progress.Total == 0 {
+		return
+	}
+	tracker.UpdateTotal(job.Progress.Total)
+	tracker.SetValue(job.Progress.Done)
+}
+
 func doCheck(as pkg.IAgentServer, sn *model.StorageNode, backupID string, retries int) (status model.BackupStatus, err error) {
+	backupInfo, err := showDetail(as, sn, backupID, retries)
+	if err != nil {
+		return model.SsBackupStatusCheckError, err
+	}
+	return backupInfo.Status, nil
+}
+
+func showDetail(as pkg.IAgentServer, sn *model.StorageNode, backupID string, retries int) (*model.BackupInfo, error) {
 	in := &model.ShowDetailIn{
 		DBPort:       sn.Port,
 		DBName:       sn.Database,
@@ -410,18 +604,18 @@ func doCheck(as pkg.IAgentServer, sn *model.StorageNode, backupID string, retrie
 		Password:     sn.Password,
 		DnBackupID:   backupID,
 		DnBackupPath: BackupPath,
-		Instance:     defaultInstance,
+		Instance:     instanceOf(sn),
 	}
 	backupInfo, err := as.ShowDetail(in)
 	if err != nil {
 		if retries == 0 {
-			return model.SsBackupStatusCheckError, err
+			return nil, err
 		}
 		time.Sleep(time.Second * 1)
-		return doCheck(as, sn, backupID, retries-1)
+		return showDetail(as, sn, backupID, retries-1)
 	}
 
-	return backupInfo.Status, nil
+	return backupInfo, nil
 }
 
 func deleteBackupFiles(ls pkg.ILocalStorage, lsBackup *model.LsBackup) {
@@ -431,7 +625,7 @@ func deleteBackupFiles(ls pkg.ILocalStorage, lsBackup *model.LsBackup) {
 		resultCh    = make(chan *model.DeleteBackupResult, totalNum)
 	)
 	for _, dn := range lsBackup.DnList {
-		dataNodeMap[dn.IP] = dn
+		dataNodeMap[dn.Key()] = dn
 	}
 
 	if totalNum == 0 {
@@ -440,11 +634,12 @@ func deleteBackupFiles(ls pkg.ILocalStorage, lsBackup *model.LsBackup) {
 	}
 
 	pw := prettyoutput.NewPW(totalNum)
+	pw.SetOutputWriter(textOut)
 	go pw.Render()
 
 	for _, sn := range lsBackup.SsBackup.StorageNodes {
 		sn := sn
-		dn, ok := dataNodeMap[sn.IP]
+		dn, ok := dataNodeMap[sn.Key()]
 		if !ok {
 			logging.Warn(fmt.Sprintf("SKIPPED! data node %s:%d not found in backup info.", sn.IP, sn.Port))
 			continue
@@ -465,7 +660,7 @@ func deleteBackupFiles(ls pkg.ILocalStorage, lsBackup *model.LsBackup) {
 	close(resultCh)
 
 	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	t.SetOutputMirror(textOut)
 	t.SetTitle("Delete Backup Files Result")
 	t.AppendHeader(table.Row{"#", "Node IP", "Node Port", "Result", "Message"})
 	t.SetColumnConfigs([]table.ColumnConfig{{Number: 5, WidthMax: 50}})
@@ -493,15 +688,7 @@ func doDelete(as pkg.IAgentServer, sn *model.StorageNode, dn *model.DataNode, re
 
 	pw.AppendTracker(&tracker)
 
-	in := &model.DeleteBackupIn{
-		DBPort:       sn.Port,
-		DBName:       sn.Database,
-		Username:     sn.Username,
-		Password:     sn.Password,
-		DnBackupPath: BackupPath,
-		BackupID:     dn.BackupID,
-		Instance:     defaultInstance,
-	}
+	in := newDeleteBackupIn(sn, dn)
 
 	r := &model.DeleteBackupResult{
 		IP:   sn.IP,
@@ -519,3 +706,15 @@ func doDelete(as pkg.IAgentServer, sn *model.StorageNode, dn *model.DataNode, re
 		resultCh <- r
 	}
 }
+
+func newDeleteBackupIn(sn *model.StorageNode, dn *model.DataNode) *model.DeleteBackupIn {
+	return &model.DeleteBackupIn{
+		DBPort:       sn.Port,
+		DBName:       sn.Database,
+		Username:     sn.Username,
+		Password:     sn.Password,
+		DnBackupPath: BackupPath,
+		BackupID:     dn.BackupID,
+		Instance:     instanceOf(sn),
+	}
+}
@@ -150,13 +150,13 @@ var _ = Describe("Backup", func() {
 					Remark:   "",
 				},
 			}
-			as.EXPECT().Backup(gomock.Any()).Return("", nil)
+			as.EXPECT().Backup(gomock.Any()).Return(&model.BackupOut{ID: "backup-id", JobID: "job-id"}, nil)
 			dnCh := make(chan *model.DataNode, 10)
 
 			Expect(_execBackup(as, bak.SsBackup.StorageNodes[0], dnCh)).To(BeNil())
 			Expect(len(dnCh)).To(Equal(1))
 
-			as.EXPECT().Backup(gomock.Any()).Return("", xerr.NewCliErr("backup failed"))
+			as.EXPECT().Backup(gomock.Any()).Return(nil, xerr.NewCliErr("backup failed"))
 
 			Expect(_execBackup(as, bak.SsBackup.StorageNodes[0], dnCh)).ToNot(BeNil())
 			close(dnCh)
@@ -165,6 +165,55 @@ var _ = Describe("Backup", func() {
 		})
 	})
 
+	Context("check archive status", func() {
+		var (
+			as       *mock_pkg.MockIAgentServer
+			lsbackup = &model.LsBackup{
+				SsBackup: &model.SsBackup{
+					StorageNodes: []*model.StorageNode{
+						{IP: "127.0.0.1", Port: 3306},
+						{IP: "127.0.0.2", Port: 3307},
+					},
+				},
+			}
+		)
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			as = mock_pkg.NewMockIAgentServer(ctrl)
+			monkey.Patch(pkg.NewAgentServer, func(_ string) pkg.IAgentServer {
+				return as
+			})
+		})
+		AfterEach(func() {
+			EnableArchive = false
+			ctrl.Finish()
+			monkey.UnpatchAll()
+		})
+
+		It("archive is healthy", func() {
+			as.EXPECT().ShowArchive(gomock.Any()).Return(&model.ArchiveStatus{LatestArchived: "000000010000000000000003"}, nil).Times(2)
+			Expect(checkArchiveStatus(lsbackup)).To(BeTrue())
+		})
+
+		It("archive of one node is broken", func() {
+			as.EXPECT().ShowArchive(gomock.Any()).Return(&model.ArchiveStatus{}, nil)
+			as.EXPECT().ShowArchive(gomock.Any()).Return(&model.ArchiveStatus{Broken: true, Reason: "archive_mode is off"}, nil)
+			Expect(checkArchiveStatus(lsbackup)).To(BeFalse())
+		})
+
+		It("show archive failed", func() {
+			as.EXPECT().ShowArchive(gomock.Any()).Return(nil, errors.New("timeout")).Times(2)
+			Expect(checkArchiveStatus(lsbackup)).To(BeFalse())
+		})
+
+		It("enable archive before check", func() {
+			EnableArchive = true
+			as.EXPECT().EnableArchive(gomock.Any()).Return(nil).Times(2)
+			as.EXPECT().ShowArchive(gomock.Any()).Return(&model.ArchiveStatus{}, nil).Times(2)
+			Expect(checkArchiveStatus(lsbackup)).To(BeTrue())
+		})
+	})
+
 	Context("check backup status", func() {
 		var (
 			as       *mock_pkg.MockIAgentServer
@@ -236,6 +285,20 @@ var _ = Describe("Backup", func() {
 			as.EXPECT().ShowDetail(gomock.Any()).Return(&model.BackupInfo{Status: model.SsBackupStatusCompleted}, nil).AnyTimes()
 			Expect(checkBackupStatus(lsbackup)).To(Equal(model.SsBackupStatusCompleted))
 		})
+
+		It("record the size of compressed backup", func() {
+			as.EXPECT().ShowDetail(gomock.Any()).Return(&model.BackupInfo{
+				Status:            model.SsBackupStatusCompleted,
+				CompressAlg:       "zlib",
+				DataBytes:         1024,
+				UncompressedBytes: 4096,
+			}, nil).AnyTimes()
+			Expect(checkBackupStatus(lsbackup)).To(Equal(model.SsBackupStatusCompleted))
+			for _, dn := range lsbackup.DnList {
+				Expect(dn.CompressAlg).To(Equal("zlib"))
+				Expect(dn.UncompressedBytes).To(Equal(int64(4096)))
+			}
+		})
 	})
 })
 
@@ -399,6 +462,34 @@ var _ = Describe("test backup mock", func() {
 			mockIreq.EXPECT().Header(gomock.Any()).AnyTimes()
 			Expect(checkAgentServerStatus(ls)).To(BeFalse())
 		})
+
+		It("storage nodes on the same host", func() {
+			mockIreq.EXPECT().Send(gomock.Any()).Return(nil).Times(2)
+			mockIreq.EXPECT().Header(gomock.Any()).AnyTimes()
+			Expect(checkAgentServerStatus(&model.LsBackup{
+				SsBackup: &model.SsBackup{
+					StorageNodes: []*model.StorageNode{
+						{IP: "127.0.0.1", Port: 3306},
+						{IP: "127.0.0.1", Port: 3307},
+					},
+				},
+			})).To(BeTrue())
+		})
+	})
+
+	Context("test backup instances", func() {
+		It("storage nodes on the same host have their own instances", func() {
+			nodes := []*model.StorageNode{
+				{IP: "127.0.0.1", Port: 3306},
+				{IP: "127.0.0.1", Port: 3307},
+				{IP: "127.0.0.2", Port: 3306},
+			}
+			setInstances(nodes)
+			Expect(instanceOf(nodes[0])).To(Equal("ins-default-ss-3306"))
+			Expect(instanceOf(nodes[1])).To(Equal("ins-default-ss-3307"))
+			Expect(nodes[2].Instance).To(BeEmpty())
+			Expect(instanceOf(nodes[2])).To(Equal(defaultInstance))
+		})
 	})
 
 	Context("test delete backup data", func() {
@@ -0,0 +1,221 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+/*
+linkBackupChain record the parent of a PTRACK backup, on every data node and of the whole record.
+
+The parent of the record is the one whose backups are the parents on every data node.
+*/
+func linkBackupChain(ls pkg.ILocalStorage, lsBackup *model.LsBackup) error {
+	dataNodeMap := make(map[string]*model.DataNode)
+	for _, dn := range lsBackup.DnList {
+		dataNodeMap[dn.Key()] = dn
+	}
+
+	for _, sn := range lsBackup.SsBackup.StorageNodes {
+		dn, ok := dataNodeMap[sn.Key()]
+		if !ok {
+			return xerr.NewCliErr(fmt.Sprintf("data node %s:%d not found in backup info", sn.IP, sn.Port))
+		}
+
+		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
+		info, err := as.ShowDetail(&model.ShowDetailIn{
+			DBPort:       sn.Port,
+			DBName:       sn.Database,
+			Username:     sn.Username,
+			Password:     sn.Password,
+			DnBackupID:   dn.BackupID,
+			DnBackupPath: BackupPath,
+			Instance:     instanceOf(sn),
+		})
+		if err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("show backup detail of %s:%d failed:%s", sn.IP, sn.Port, err.Error()))
+		}
+		dn.ParentBackupID = info.ParentID
+	}
+
+	list, err := ls.ReadAll()
+	if err != nil {
+		return xerr.NewCliErr(fmt.Sprintf("read backup records failed:%s", err.Error()))
+	}
+	for _, bak := range list {
+		if isParentRecord(bak, lsBackup) {
+			lsBackup.Info.ParentID = bak.Info.ID
+			return nil
+		}
+	}
+	return xerr.NewCliErr("the parent backup record is not found")
+}
+
+func isParentRecord(parent, child *model.LsBackup) bool {
+	if parent.Info.ID == child.Info.ID || len(parent.DnList) != len(child.DnList) {
+		return false
+	}
+
+	parentIDs := make(map[string]string)
+	for _, dn := range parent.DnList {
+		parentIDs[dn.Key()] = dn.BackupID
+	}
+	for _, dn := range child.DnList {
+		if dn.ParentBackupID == "" || parentIDs[dn.Key()] != dn.ParentBackupID {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+checkBackupChain make sure the backup and all its parents are present and completed on every data node,
+gs_probackup needs the whole chain to restore a PTRACK backup.
+*/
+func checkBackupChain(lsBackup *model.LsBackup) error {
+	dataNodeMap := make(map[string]*model.DataNode)
+	for _, dn := range lsBackup.DnList {
+		dataNodeMap[dn.Key()] = dn
+	}
+
+	for _, sn := range lsBackup.SsBackup.StorageNodes {
+		dn, ok := dataNodeMap[sn.Key()]
+		if !ok {
+			return xerr.NewCliErr(fmt.Sprintf("data node %s:%d not found in backup info", sn.IP, sn.Port))
+		}
+
+		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
+		list, err := as.ShowList(&model.ShowListIn{
+			DBPort:       sn.Port,
+			DBName:       sn.Database,
+			Username:     sn.Username,
+			Password:     sn.Password,
+			DnBackupPath: BackupPath,
+			Instance:     instanceOf(sn),
+		})
+		if err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("show backup list of %s:%d failed:%s", sn.IP, sn.Port, err.Error()))
+		}
+
+		chain, err := backupChain(list, dn.BackupID)
+		if err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("data node %s:%d:%s", sn.IP, sn.Port, err.Error()))
+		}
+		logging.Info(fmt.Sprintf("Backup chain of data node %s:%d: %s", sn.IP, sn.Port, strings.Join(chain, " <- ")))
+	}
+	return nil
+}
+
+// backupChain return the chain from the FULL backup to the backup id.
+func backupChain(list []model.BackupInfo, backupID string) ([]string, error) {
+	infoMap := make(map[string]model.BackupInfo, len(list))
+	for _, info := range list {
+		infoMap[info.ID] = info
+	}
+
+	chain := make([]string, 0)
+	for id := backupID; ; {
+		info, ok := infoMap[id]
+		if !ok {
+			return nil, xerr.NewCliErr(fmt.Sprintf("backup %s of the chain is not found", id))
+		}
+		if info.Status != model.SsBackupStatusCompleted {
+			return nil, xerr.NewCliErr(fmt.Sprintf("backup %s of the chain is %s", id, info.Status))
+		}
+		chain = append([]string{id}, chain...)
+
+		if info.Mode != string(model.DBBackModePTrack) {
+			return chain, nil
+		}
+		if info.ParentID == "" {
+			return nil, xerr.NewCliErr(fmt.Sprintf("the parent of incremental backup %s is unknown", id))
+		}
+		if len(chain) > len(list) {
+			return nil, xerr.NewCliErr(fmt.Sprintf("the chain of backup %s is a loop", backupID))
+		}
+		id = info.ParentID
+	}
+}
+
+// formatChains render the records as trees, the FULL backups are the roots and the PTRACK backups are their children.
+func formatChains(backups []*model.LsBackup) {
+	var (
+		children = make(map[string][]*model.LsBackup)
+		roots    = make([]*model.LsBackup, 0)
+		ids      = make(map[string]bool)
+	)
+	for _, bak := range backups {
+		ids[bak.Info.ID] = true
+	}
+	for _, bak := range backups {
+		// the parent of PTRACK backups taken by older versions is unknown, show them as roots
+		if bak.Info.ParentID != "" && ids[bak.Info.ParentID] {
+			children[bak.Info.ParentID] = append(children[bak.Info.ParentID], bak)
+		} else {
+			roots = append(roots, bak)
+		}
+	}
+
+	byStartTime := func(list []*model.LsBackup) {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].Info.StartTime < list[j].Info.StartTime
+		})
+	}
+	byStartTime(roots)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(textOut)
+	t.SetTitle("Backup Chains")
+	t.AppendHeader(table.Row{"#", "ID", "Mode", "CSN", "Start Time", "Status"})
+
+	idx := 0
+	var walk func(bak *model.LsBackup, depth int)
+	walk = func(bak *model.LsBackup, depth int) {
+		idx++
+		id := bak.Info.ID
+		if depth > 0 {
+			id = strings.Repeat("  ", depth-1) + "└─ " + id
+		}
+		var status model.BackupStatus
+		if bak.SsBackup != nil {
+			status = bak.SsBackup.Status
+		}
+		t.AppendRow([]interface{}{idx, id, bak.Info.BackupMode, bak.Info.CSN, time.Unix(bak.Info.StartTime, 0).String(), status})
+
+		list := children[bak.Info.ID]
+		byStartTime(list)
+		for _, child := range list {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+		t.AppendSeparator()
+	}
+	t.Render()
+}
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bou.ke/monkey"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("backup chain", func() {
+	var (
+		ctrl *gomock.Controller
+		as   *mock_pkg.MockIAgentServer
+		ls   *mock_pkg.MockILocalStorage
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		as = mock_pkg.NewMockIAgentServer(ctrl)
+		ls = mock_pkg.NewMockILocalStorage(ctrl)
+		monkey.Patch(pkg.NewAgentServer, func(_ string) pkg.IAgentServer { return as })
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		monkey.UnpatchAll()
+	})
+
+	newRecord := func(id, dnBackupID string) *model.LsBackup {
+		return &model.LsBackup{
+			Info:     &model.BackupMetaInfo{ID: id},
+			DnList:   []*model.DataNode{{IP: "127.0.0.1", Port: 5432, BackupID: dnBackupID}},
+			SsBackup: &model.SsBackup{StorageNodes: []*model.StorageNode{{IP: "127.0.0.1", Port: 5432}}},
+		}
+	}
+
+	Context("link backup chain", func() {
+		It("parent found", func() {
+			full, ptrack := newRecord("full", "dn-full"), newRecord("ptrack", "dn-ptrack")
+			as.EXPECT().ShowDetail(gomock.Any()).Return(&model.BackupInfo{ID: "dn-ptrack", ParentID: "dn-full"}, nil)
+			ls.EXPECT().ReadAll().Return([]*model.LsBackup{ptrack, full}, nil)
+
+			Expect(linkBackupChain(ls, ptrack)).To(BeNil())
+			Expect(ptrack.DnList[0].ParentBackupID).To(Equal("dn-full"))
+			Expect(ptrack.Info.ParentID).To(Equal("full"))
+		})
+
+		It("parent not found", func() {
+			ptrack := newRecord("ptrack", "dn-ptrack")
+			as.EXPECT().ShowDetail(gomock.Any()).Return(&model.BackupInfo{ID: "dn-ptrack", ParentID: "dn-full"}, nil)
+			ls.EXPECT().ReadAll().Return([]*model.LsBackup{ptrack, newRecord("other", "dn-other")}, nil)
+
+			Expect(linkBackupChain(ls, ptrack)).NotTo(BeNil())
+			Expect(ptrack.Info.ParentID).To(BeEmpty())
+		})
+	})
+
+	Context("check backup chain", func() {
+		list := []model.BackupInfo{
+			{ID: "dn-full", Mode: "FULL", Status: model.SsBackupStatusCompleted},
+			{ID: "dn-ptrack-1", Mode: "PTRACK", ParentID: "dn-full", Status: model.SsBackupStatusCompleted},
+			{ID: "dn-ptrack-2", Mode: "PTRACK", ParentID: "dn-ptrack-1", Status: model.SsBackupStatusCompleted},
+			{ID: "dn-ptrack-3", Mode: "PTRACK", ParentID: "dn-missing", Status: model.SsBackupStatusCompleted},
+			{ID: "dn-ptrack-4", Mode: "PTRACK", Status: model.SsBackupStatusCompleted},
+			{ID: "dn-ptrack-5", Mode: "PTRACK", ParentID: "dn-ptrack-6", Status: model.SsBackupStatusCompleted},
+			{ID: "dn-ptrack-6", Mode: "PTRACK", ParentID: "dn-ptrack-5", Status: model.SsBackupStatusFailed},
+		}
+
+		It("resolve chains", func() {
+			chain, err := backupChain(list, "dn-ptrack-2")
+			Expect(err).To(BeNil())
+			Expect(chain).To(Equal([]string{"dn-full", "dn-ptrack-1", "dn-ptrack-2"}))
+
+			chain, err = backupChain(list, "dn-full")
+			Expect(err).To(BeNil())
+			Expect(chain).To(Equal([]string{"dn-full"}))
+
+			for _, id := range []string{"dn-ptrack-3", "dn-ptrack-4", "dn-ptrack-5", "dn-unknown"} {
+				_, err = backupChain(list, id)
+				Expect(err).NotTo(BeNil())
+			}
+		})
+
+		It("broken chain on data node", func() {
+			as.EXPECT().ShowList(gomock.Any()).Return(list, nil)
+			Expect(checkBackupChain(newRecord("ptrack", "dn-ptrack-3"))).NotTo(BeNil())
+		})
+
+		It("complete chain on data node", func() {
+			as.EXPECT().ShowList(gomock.Any()).Return(list, nil)
+			Expect(checkBackupChain(newRecord("ptrack", "dn-ptrack-2"))).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/jedib0t/go-pretty/v6/progress"
+)
+
+const (
+	// jobPollInterval is the interval of polling the job state if the log stream is broken.
+	jobPollInterval = time.Second * 2
+	// jobPollRetryTimes is the max number of continuous failures of polling the job state.
+	jobPollRetryTimes = 5
+)
+
+// progressRegex matches the progress line of gs_probackup, e.g. "Progress: (12/345). Process file ..."
+var progressRegex = regexp.MustCompile(`Progress: \((\d+)/(\d+)\)`)
+
+// waitJob follow the log of the job and update the tracker with the progress, then return the final state of the job.
+// It falls back to poll the job state if the log stream is broken.
+func waitJob(as pkg.IAgentServer, job *model.Job, tracker *progress.Tracker) (*model.Job, error) {
+	if job == nil || job.ID == "" {
+		return nil, fmt.Errorf("invalid job")
+	}
+	if job.Done() {
+		return job, nil
+	}
+
+	err := as.FollowJobLog(job.ID, 0, func(line string) {
+		updateTracker(tracker, line)
+	})
+	if err != nil {
+		logging.Warn(fmt.Sprintf("follow log of job %s failed, fall back to poll, err:%s", job.ID, err.Error()))
+	}
+
+	failures := 0
+	for {
+		j, err := as.ShowJob(job.ID)
+		if err != nil {
+			failures++
+			if failures >= jobPollRetryTimes {
+				return nil, err
+			}
+		} else {
+			failures = 0
+			if tracker != nil && j.Progress.Total > 0 {
+				tracker.UpdateTotal(j.Progress.Total)
+				tracker.SetValue(j.Progress.Done)
+			}
+			if j.Done() {
+				return j, nil
+			}
+		}
+		jobPollSleep()
+	}
+}
+
+func jobPollSleep() {
+	time.Sleep(jobPollInterval)
+}
+
+func updateTracker(tracker *progress.Tracker, line string) {
+	if tracker == nil {
+		return
+	}
+	matches := progressRegex.FindStringSubmatch(line)
+	if len(matches) != 3 {
+		return
+	}
+	done, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return
+	}
+	total, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil || total == 0 {
+		return
+	}
+	tracker.UpdateTotal(total)
+	tracker.SetValue(done)
+}
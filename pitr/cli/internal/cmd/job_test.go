@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"bou.ke/monkey"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/golang/mock/gomock"
+	"github.com/jedib0t/go-pretty/v6/progress"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("job", func() {
+	var (
+		ctrl *gomock.Controller
+		as   *mock_pkg.MockIAgentServer
+		job  = &model.Job{ID: "job-id", Kind: "restore", State: model.JobStateRunning}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		as = mock_pkg.NewMockIAgentServer(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		monkey.UnpatchAll()
+	})
+
+	It("invalid job", func() {
+		_, err := waitJob(as, nil, nil)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("job already done", func() {
+		done := &model.Job{ID: "job-id", State: model.JobStateSucceeded}
+		Expect(waitJob(as, done, nil)).To(Equal(done))
+	})
+
+	It("follow log and update progress", func() {
+		tracker := &progress.Tracker{}
+		as.EXPECT().FollowJobLog("job-id", 0, gomock.Any()).DoAndReturn(func(_ string, _ int, fn func(line string)) error {
+			fn("INFO: Start restoring backup files")
+			fn("Progress: (10/40). Process file \"base/1/1255\"")
+			return nil
+		})
+		as.EXPECT().ShowJob("job-id").Return(&model.Job{ID: "job-id", State: model.JobStateSucceeded}, nil)
+
+		j, err := waitJob(as, job, tracker)
+		Expect(err).To(BeNil())
+		Expect(j.State).To(Equal(model.JobStateSucceeded))
+		Expect(tracker.Total).To(Equal(int64(40)))
+		Expect(tracker.Value()).To(Equal(int64(10)))
+	})
+
+	It("fall back to poll if log stream is broken", func() {
+		monkey.Patch(jobPollSleep, func() {})
+		as.EXPECT().FollowJobLog("job-id", 0, gomock.Any()).Return(errors.New("connection reset"))
+		gomock.InOrder(
+			as.EXPECT().ShowJob("job-id").Return(&model.Job{ID: "job-id", State: model.JobStateRunning, Progress: model.JobProgress{Done: 1, Total: 2}}, nil),
+			as.EXPECT().ShowJob("job-id").Return(nil, errors.New("timeout")),
+			as.EXPECT().ShowJob("job-id").Return(&model.Job{ID: "job-id", State: model.JobStateFailed, Error: "restore failed"}, nil),
+		)
+
+		j, err := waitJob(as, job, nil)
+		Expect(err).To(BeNil())
+		Expect(j.Error).To(Equal("restore failed"))
+	})
+})
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Encrypt credentials and metadata in backup records written by older versions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migrate()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(MigrateCmd)
+	addStorageFlags(MigrateCmd)
+}
+
+func migrate() error {
+	raw, err := newRawStorage()
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("connect to storage failed, err:%s", err.Error()))
+	}
+	ls, err := newStorage()
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("connect to storage failed, err:%s", err.Error()))
+	}
+	return migrateRecords(raw, ls)
+}
+
+// migrateResult is the json result of migrate.
+type migrateResult struct {
+	Total    int `json:"total"`
+	Migrated int `json:"migrated"`
+}
+
+// migrateRecords rewrite the plaintext records and the ones encrypted by older versions by the encrypted storage,
+// the ones encrypted by the current version are skipped.
+func migrateRecords(raw, ls pkg.ILocalStorage) error {
+	names, err := raw.ListNames()
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("list backup records failed, err:%s", err.Error()))
+	}
+
+	var migrated int
+	for _, name := range names {
+		bak, err := raw.ReadByName(name)
+		if err != nil {
+			return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("read backup record %s failed, err:%s", name, err.Error()))
+		}
+		if bak.Encryption != nil && bak.Encryption.Version == pkg.EncryptionVersion {
+			continue
+		}
+
+		// the records encrypted by older versions are decrypted before rewritten
+		if bak, err = ls.ReadByName(name); err != nil {
+			return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("decrypt backup record %s failed, err:%s", name, err.Error()))
+		}
+
+		if err := ls.WriteByJSON(name, bak); err != nil {
+			return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("encrypt backup record %s failed, err:%s", name, err.Error()))
+		}
+		logging.Info(fmt.Sprintf("Backup record %s encrypted", name))
+		migrated++
+	}
+
+	logging.Info(fmt.Sprintf("Migrate finished, %d of %d backup records encrypted", migrated, len(names)))
+	setResult(&migrateResult{Total: len(names), Migrated: migrated})
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrate", func() {
+	It("encrypt plaintext records", func() {
+		root := GinkgoT().TempDir()
+		raw, err := pkg.NewLocalStorage(root)
+		Expect(err).To(BeNil())
+		ls := pkg.NewEncryptedStorage(raw, filepath.Join(root, "secret.key"), "")
+
+		Expect(raw.WriteByJSON("a.json", &model.LsBackup{
+			Info: &model.BackupMetaInfo{ID: "id-1"},
+			SsBackup: &model.SsBackup{
+				StorageNodes: []*model.StorageNode{{IP: "127.0.0.1", Username: "omm", Password: "Gauss@123"}},
+			},
+		})).To(Succeed())
+
+		Expect(migrateRecords(raw, ls)).To(Succeed())
+		bak, err := raw.ReadByName("a.json")
+		Expect(err).To(BeNil())
+		Expect(bak.Encryption).NotTo(BeNil())
+		Expect(bak.SsBackup.StorageNodes[0].Password).NotTo(Equal("Gauss@123"))
+
+		// the encrypted records are skipped
+		Expect(migrateRecords(raw, ls)).To(Succeed())
+		bak, err = ls.ReadByID("id-1")
+		Expect(err).To(BeNil())
+		Expect(bak.SsBackup.StorageNodes[0].Password).To(Equal("Gauss@123"))
+	})
+
+	It("encrypt the metadata of records encrypted by version 1", func() {
+		root := GinkgoT().TempDir()
+		raw, err := pkg.NewLocalStorage(root)
+		Expect(err).To(BeNil())
+		ls := pkg.NewEncryptedStorage(raw, filepath.Join(root, "secret.key"), "")
+
+		// version 1 encrypted only the credentials
+		Expect(ls.WriteByJSON("a.json", &model.LsBackup{
+			Info: &model.BackupMetaInfo{ID: "id-1"},
+			SsBackup: &model.SsBackup{
+				StorageNodes: []*model.StorageNode{{IP: "127.0.0.1", Username: "omm", Password: "Gauss@123"}},
+			},
+		})).To(Succeed())
+		bak, err := raw.ReadByName("a.json")
+		Expect(err).To(BeNil())
+		bak.Encryption.Version = 1
+		bak.SsBackup.ClusterInfo = &model.ClusterInfo{
+			MetaData: model.MetaData{Databases: map[string]string{"sharding_db": "password: Rule@123"}},
+		}
+		Expect(raw.WriteByJSON("a.json", bak)).To(Succeed())
+
+		Expect(migrateRecords(raw, ls)).To(Succeed())
+		bak, err = raw.ReadByName("a.json")
+		Expect(err).To(BeNil())
+		Expect(bak.Encryption.Version).To(Equal(pkg.EncryptionVersion))
+		Expect(bak.Encryption.MetaData).NotTo(BeEmpty())
+		Expect(bak.SsBackup.ClusterInfo.MetaData.Databases).To(BeEmpty())
+
+		bak, err = ls.ReadByID("id-1")
+		Expect(err).To(BeNil())
+		Expect(bak.SsBackup.StorageNodes[0].Password).To(Equal("Gauss@123"))
+		Expect(bak.SsBackup.ClusterInfo.MetaData.Databases).To(HaveKeyWithValue("sharding_db", "password: Rule@123"))
+	})
+})
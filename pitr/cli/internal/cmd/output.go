@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var (
+	// textOut is where the tables, progress bars and the other human readable outputs go,
+	// it is stderr in json mode to keep stdout parsable.
+	textOut io.Writer = os.Stdout
+	// result is the data of the running command, it is printed in json mode.
+	result interface{}
+	// started is set once the flags are parsed and checked, the errors before it are usage errors.
+	started bool
+)
+
+// Result is the json output of every command.
+type Result struct {
+	Command  string      `json:"command"`
+	Success  bool        `json:"success"`
+	ExitCode int         `json:"exit_code"`
+	Error    string      `json:"error,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
+}
+
+// recordResult is the json result of a backup record, the credentials of storage nodes are left out.
+type recordResult struct {
+	Info   *model.BackupMetaInfo `json:"info"`
+	Status model.BackupStatus    `json:"status"`
+	DnList []*model.DataNode     `json:"dn_list"`
+}
+
+func newRecordResult(bak *model.LsBackup) *recordResult {
+	if bak == nil {
+		return nil
+	}
+	r := &recordResult{
+		Info:   bak.Info,
+		DnList: bak.DnList,
+	}
+	if bak.SsBackup != nil {
+		r.Status = bak.SsBackup.Status
+	}
+	return r
+}
+
+func newRecordResults(backups []*model.LsBackup) []*recordResult {
+	ret := make([]*recordResult, 0, len(backups))
+	for _, bak := range backups {
+		ret = append(ret, newRecordResult(bak))
+	}
+	return ret
+}
+
+// setupOutput check flags before the command runs, cobra checks the required flags after PersistentPreRunE,
+// so they are checked here to tell usage errors apart.
+func setupOutput(cmd *cobra.Command) error {
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+	}
+	if err := cmd.ValidateFlagGroups(); err != nil {
+		return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+	}
+
+	switch Output {
+	case outputText:
+		textOut = os.Stdout
+	case outputJSON:
+		textOut = os.Stderr
+	default:
+		return xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("invalid output format: %s", Output))
+	}
+	started = true
+	return nil
+}
+
+// setResult set the data of the json result.
+func setResult(data interface{}) {
+	result = data
+}
+
+// Execute run the root command and return the exit code, the result is printed to stdout in json mode.
+func Execute() int {
+	cmd, err := RootCmd.ExecuteC()
+	code := xerr.ExitCode(err)
+	if err != nil && !started && code == xerr.ExitFailed {
+		code = xerr.ExitUsage
+	}
+
+	if Output != outputJSON {
+		if err != nil {
+			logging.Error(err.Error())
+			if code == xerr.ExitUsage {
+				fmt.Fprintln(os.Stderr, cmd.UsageString())
+			}
+		}
+		return code
+	}
+
+	out := &Result{
+		Command:  cmd.Name(),
+		Success:  err == nil,
+		ExitCode: code,
+		Data:     result,
+	}
+	if err != nil {
+		out.Error = err.Error()
+	}
+	data, mErr := json.MarshalIndent(out, "", "\t")
+	if mErr != nil {
+		logging.Error(fmt.Sprintf("marshal result failed, err:%s", mErr.Error()))
+		return xerr.ExitFailed
+	}
+	fmt.Println(string(data))
+	return code
+}
@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"bou.ke/monkey"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Output", func() {
+	var ls *mock_pkg.MockILocalStorage
+
+	// execute run gs_pitr with args and return the exit code and stdout
+	execute := func(args ...string) (int, string) {
+		r, w, err := os.Pipe()
+		Expect(err).To(BeNil())
+		stdout := os.Stdout
+		os.Stdout = w
+		defer func() { os.Stdout = stdout }()
+
+		RootCmd.SetArgs(args)
+		code := Execute()
+		Expect(w.Close()).To(Succeed())
+
+		out, err := io.ReadAll(r)
+		Expect(err).To(BeNil())
+		return code, string(out)
+	}
+
+	BeforeEach(func() {
+		CSN, RecordID = "", ""
+		ctrl = gomock.NewController(GinkgoT())
+		ls = mock_pkg.NewMockILocalStorage(ctrl)
+		monkey.Patch(newStorage, func() (pkg.ILocalStorage, error) {
+			return ls, nil
+		})
+	})
+	AfterEach(func() {
+		monkey.UnpatchAll()
+		ctrl.Finish()
+
+		Output, CSN, RecordID = outputText, "", ""
+		started, result, textOut = false, nil, os.Stdout
+	})
+
+	It("json result of show", func() {
+		ls.EXPECT().ReadAll().Return([]*model.LsBackup{
+			{
+				Info:     &model.BackupMetaInfo{ID: "record-id", CSN: "csn"},
+				SsBackup: &model.SsBackup{Status: model.SsBackupStatusCompleted, StorageNodes: []*model.StorageNode{{IP: "127.0.0.1", Password: "secret"}}},
+			},
+		}, nil)
+
+		code, out := execute("show", "--output", "json")
+		Expect(code).To(Equal(xerr.ExitOK))
+		Expect(out).NotTo(ContainSubstring("secret"))
+
+		res := &struct {
+			Result
+			Data []*recordResult `json:"data"`
+		}{}
+		Expect(json.Unmarshal([]byte(out), res)).To(Succeed())
+		Expect(res.Command).To(Equal("show"))
+		Expect(res.Success).To(BeTrue())
+		Expect(res.Data).To(HaveLen(1))
+		Expect(res.Data[0].Info.ID).To(Equal("record-id"))
+		Expect(res.Data[0].Status).To(Equal(model.SsBackupStatusCompleted))
+	})
+
+	It("json result of failure", func() {
+		ls.EXPECT().ReadByID("record-id").Return(nil, nil)
+
+		code, out := execute("show", "--id", "record-id", "-o", "json")
+		Expect(code).To(Equal(xerr.ExitNotFound))
+
+		res := &Result{}
+		Expect(json.Unmarshal([]byte(out), res)).To(Succeed())
+		Expect(res.Success).To(BeFalse())
+		Expect(res.ExitCode).To(Equal(xerr.ExitNotFound))
+		Expect(res.Error).NotTo(BeEmpty())
+	})
+
+	It("exit code of usage errors", func() {
+		code, _ := execute("show", "--not-exist-flag")
+		Expect(code).To(Equal(xerr.ExitUsage))
+
+		code, _ = execute("show", "--output", "yaml")
+		Expect(code).To(Equal(xerr.ExitUsage))
+
+		code, _ = execute("show", "--csn", "csn", "--id", "record-id")
+		Expect(code).To(Equal(xerr.ExitUsage))
+
+		code, out := execute("prune", "--retain-count", "1", "-o", "json")
+		Expect(code).To(Equal(xerr.ExitUsage))
+		Expect(out).To(ContainSubstring("required flag"))
+	})
+})
@@ -0,0 +1,202 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+var (
+	// Profile the cluster profile in config file, the current profile is used if it is not set
+	Profile string
+	// PasswordFile the file which contains the ss-proxy password
+	PasswordFile string
+)
+
+const (
+	// envConfig the config file is read from it if it is set
+	envConfig = "GS_PITR_CONFIG"
+	// envPrefix the flags are overridden by env, e.g. GS_PITR_DN_BACKUP_PATH for --dn-backup-path
+	envPrefix = "GS_PITR_"
+
+	flagProfile      = "profile"
+	flagPassword     = "password"
+	flagPasswordFile = "password-file"
+)
+
+// readPassword read the password from terminal without echo
+var readPassword = func() (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", nil
+	}
+	fmt.Fprint(os.Stderr, "ss-proxy password: ")
+	bs, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return string(bs), err
+}
+
+func addProfileFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&Profile, flagProfile, "", "", fmt.Sprintf("cluster profile in config file %s, the current profile is used if it is not set", configFile()))
+}
+
+func addPasswordFileFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&PasswordFile, flagPasswordFile, "", "", "file which contains the ss-proxy password, the password is prompted if neither it nor --password is set")
+}
+
+func configFile() string {
+	if f := os.Getenv(envConfig); f != "" {
+		return f
+	}
+	return pkg.DefaultConfigFile()
+}
+
+/*
+applyProfile fill the flags not set on the command line, in the order of:
+
+ 1. env, e.g. GS_PITR_HOST for --host
+ 2. the profile of --profile, or the current profile of config file, if the command has --profile
+ 3. the password in --password-file, or prompted in terminal, if the command has --password
+*/
+func applyProfile(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	if flags.Changed(flagPassword) {
+		logging.Warn("The password on command line may be kept in shell history, please use --password-file or the password prompt.")
+	}
+
+	if err := applyEnv(flags); err != nil {
+		return err
+	}
+
+	if flags.Lookup(flagProfile) != nil {
+		if err := applyConfigProfile(cmd); err != nil {
+			return err
+		}
+	}
+
+	if flags.Lookup(flagPassword) != nil && !flags.Changed(flagPassword) {
+		return applyPassword(flags)
+	}
+	return nil
+}
+
+func applyEnv(flags *pflag.FlagSet) error {
+	var err error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if err != nil || f.Changed {
+			return
+		}
+		env := envPrefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if v, ok := os.LookupEnv(env); ok {
+			if setErr := flags.Set(f.Name, v); setErr != nil {
+				err = xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("invalid env %s, err:%s", env, setErr.Error()))
+			}
+		}
+	})
+	return err
+}
+
+func applyConfigProfile(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	cfg, err := pkg.LoadConfig(configFile())
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+	}
+	name, _ := flags.GetString(flagProfile)
+	profile, err := cfg.Profile(name)
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+	}
+
+	for k, v := range profile {
+		if !isCommandFlag(cmd.Root(), k) {
+			return xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("unknown key %s in profile, it must be a flag of gs_pitr", k))
+		}
+		f := flags.Lookup(k)
+		if f == nil || f.Changed {
+			continue
+		}
+		if err := flags.Set(k, v); err != nil {
+			return xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("invalid %s in profile, err:%s", k, err.Error()))
+		}
+	}
+	return nil
+}
+
+// isCommandFlag return true if any command of gs_pitr has the flag, a profile is shared by the commands.
+func isCommandFlag(root *cobra.Command, name string) bool {
+	if root.PersistentFlags().Lookup(name) != nil {
+		return true
+	}
+	for _, c := range root.Commands() {
+		if c.Flags().Lookup(name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func applyPassword(flags *pflag.FlagSet) error {
+	var (
+		password string
+		err      error
+	)
+	file, _ := flags.GetString(flagPasswordFile)
+	switch {
+	case file != "":
+		password, err = readPasswordFile(file)
+		if err != nil {
+			return err
+		}
+	case !NonInteractive:
+		password, err = readPassword()
+		if err != nil {
+			return xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("read password failed, err:%s", err.Error()))
+		}
+	}
+
+	if password == "" {
+		return nil
+	}
+	return flags.Set(flagPassword, password)
+}
+
+// readPasswordFile the trailing newline is trimmed, and the file should not be accessible by others.
+func readPasswordFile(file string) (string, error) {
+	fi, err := os.Stat(file)
+	if err != nil {
+		return "", xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("read password file failed, err:%s", err.Error()))
+	}
+	if fi.Mode().Perm()&0o077 != 0 {
+		logging.Warn(fmt.Sprintf("The password file %s is accessible by others, please chmod 600 it.", file))
+	}
+
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return "", xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("read password file failed, err:%s", err.Error()))
+	}
+	return strings.TrimRight(string(bs), "\r\n"), nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"bou.ke/monkey"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Profile", func() {
+	var (
+		cmd                          *cobra.Command
+		host, password, passwordFile string
+		port                         uint16
+		dir                          string
+	)
+
+	writeConfig := func(content string) {
+		file := filepath.Join(dir, "config.yaml")
+		Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+		Expect(os.Setenv(envConfig, file)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		Expect(os.Setenv(envConfig, filepath.Join(dir, "not-exist.yaml"))).To(Succeed())
+
+		root := &cobra.Command{Use: "gs_pitr"}
+		cmd = &cobra.Command{Use: "backup"}
+		root.AddCommand(cmd)
+		cmd.Flags().StringVarP(&host, "host", "H", "", "")
+		cmd.Flags().Uint16VarP(&port, "port", "P", 0, "")
+		cmd.Flags().StringVarP(&password, "password", "p", "", "")
+		cmd.Flags().StringVarP(&passwordFile, flagPasswordFile, "", "", "")
+		cmd.Flags().StringVarP(&Profile, flagProfile, "", "", "")
+		NonInteractive = true
+	})
+
+	AfterEach(func() {
+		monkey.UnpatchAll()
+		Expect(os.Unsetenv(envConfig)).To(Succeed())
+		Expect(os.Unsetenv("GS_PITR_HOST")).To(Succeed())
+		Profile, NonInteractive = "", false
+	})
+
+	It("flag > env > profile", func() {
+		writeConfig(`
+current-profile: prod
+profiles:
+  prod:
+    host: 10.0.0.1
+    port: 3307
+    password: secret
+`)
+		Expect(os.Setenv("GS_PITR_HOST", "10.0.0.2")).To(Succeed())
+		Expect(cmd.ParseFlags([]string{"--port", "3308"})).To(Succeed())
+
+		Expect(applyProfile(cmd)).To(Succeed())
+		Expect(host).To(Equal("10.0.0.2"))
+		Expect(port).To(Equal(uint16(3308)))
+		Expect(password).To(Equal("secret"))
+		Expect(cmd.Flags().Changed("host")).To(BeTrue())
+	})
+
+	It("select profile by --profile", func() {
+		writeConfig(`
+current-profile: prod
+profiles:
+  prod:
+    host: 10.0.0.1
+  test:
+    host: 127.0.0.1
+`)
+		Expect(cmd.ParseFlags([]string{"--profile", "test"})).To(Succeed())
+		Expect(applyProfile(cmd)).To(Succeed())
+		Expect(host).To(Equal("127.0.0.1"))
+	})
+
+	It("invalid profile", func() {
+		writeConfig(`
+profiles:
+  prod:
+    hots: 10.0.0.1
+`)
+		Expect(cmd.ParseFlags([]string{"--profile", "prod"})).To(Succeed())
+		err := applyProfile(cmd)
+		Expect(xerr.ExitCode(err)).To(Equal(xerr.ExitUsage))
+
+		Expect(cmd.ParseFlags([]string{"--profile", "not-exist"})).To(Succeed())
+		err = applyProfile(cmd)
+		Expect(xerr.ExitCode(err)).To(Equal(xerr.ExitUsage))
+	})
+
+	It("read password from file", func() {
+		file := filepath.Join(dir, "password")
+		Expect(os.WriteFile(file, []byte("secret\n"), 0600)).To(Succeed())
+
+		Expect(cmd.ParseFlags([]string{"--password-file", file})).To(Succeed())
+		Expect(applyProfile(cmd)).To(Succeed())
+		Expect(password).To(Equal("secret"))
+	})
+
+	It("password file not exist", func() {
+		Expect(cmd.ParseFlags([]string{"--password-file", filepath.Join(dir, "not-exist")})).To(Succeed())
+		Expect(xerr.ExitCode(applyProfile(cmd))).To(Equal(xerr.ExitUsage))
+	})
+
+	It("prompt password", func() {
+		monkey.Patch(readPassword, func() (string, error) {
+			return "prompted", nil
+		})
+
+		Expect(cmd.ParseFlags([]string{})).To(Succeed())
+		Expect(applyProfile(cmd)).To(Succeed())
+		Expect(password).To(BeEmpty())
+
+		NonInteractive = false
+		Expect(applyProfile(cmd)).To(Succeed())
+		Expect(password).To(Equal("prompted"))
+	})
+})
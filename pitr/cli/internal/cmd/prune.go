@@ -0,0 +1,254 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// RetainCount keep the newest n backups
+	RetainCount uint
+	// RetainDays keep the backups started within n days
+	RetainDays uint
+	// RetainFull keep the newest n full backups and their incremental backups
+	RetainFull uint
+	// DryRun only show the backups to be pruned
+	DryRun bool
+)
+
+var PruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete backups which are out of the retention policies",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if RetainCount == 0 && RetainDays == 0 && RetainFull == 0 {
+			return xerr.NewCodeErr(xerr.ExitUsage, "Please specify at least one of retain count, retain days and retain full")
+		}
+
+		if err := setupAgentAuth(); err != nil {
+			return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+		}
+
+		return prune()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(PruneCmd)
+	addProfileFlags(PruneCmd)
+
+	PruneCmd.Flags().StringVarP(&Host, "host", "H", "", "ss-proxy hostname or ip, used to access the agent server of storage nodes registered as 127.0.0.1")
+	PruneCmd.Flags().StringVarP(&BackupPath, "dn-backup-path", "B", "", "openGauss data backup path")
+	_ = PruneCmd.MarkFlagRequired("dn-backup-path")
+	PruneCmd.Flags().Uint16VarP(&AgentPort, "agent-port", "a", 443, "agent server port")
+	_ = PruneCmd.MarkFlagRequired("agent-port")
+	addAgentAuthFlags(PruneCmd)
+
+	PruneCmd.Flags().UintVarP(&RetainCount, "retain-count", "", 0, "keep the newest n backups")
+	PruneCmd.Flags().UintVarP(&RetainDays, "retain-days", "", 0, "keep the backups started within n days")
+	PruneCmd.Flags().UintVarP(&RetainFull, "retain-full", "", 0, "keep the newest n full backups and their incremental backups")
+	PruneCmd.Flags().BoolVarP(&DryRun, "dry-run", "", false, "only show the backups to be pruned")
+	PruneCmd.Flags().BoolVarP(&AssumeYes, "assume-yes", "", false, "same as --yes")
+	addStorageFlags(PruneCmd)
+}
+
+// pruneResult is the json result of prune, the backups are the ids of backup records.
+type pruneResult struct {
+	DryRun  bool     `json:"dry_run"`
+	Expired []string `json:"expired"`
+	Failed  []string `json:"failed,omitempty"`
+}
+
+// backupRecord is a backup record with its name in the storage.
+type backupRecord struct {
+	name string
+	bak  *model.LsBackup
+}
+
+func prune() error {
+	ls, err := newStorage()
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("connect to storage failed, err:%s", err.Error()))
+	}
+
+	names, err := ls.ListNames()
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("list backup records failed, err:%s", err.Error()))
+	}
+	records := make([]*backupRecord, 0, len(names))
+	for _, name := range names {
+		bak, err := ls.ReadByName(name)
+		if err != nil {
+			return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("read backup record %s failed, err:%s", name, err.Error()))
+		}
+		records = append(records, &backupRecord{name: name, bak: bak})
+	}
+
+	_, expired := planPrune(records, time.Now())
+	res := &pruneResult{DryRun: DryRun, Expired: make([]string, 0, len(expired))}
+	for _, r := range expired {
+		res.Expired = append(res.Expired, r.bak.Info.ID)
+	}
+	setResult(res)
+	if len(expired) == 0 {
+		logging.Info("No backup need to be pruned")
+		return nil
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(textOut)
+	t.SetTitle("Backups To Be Pruned")
+	t.AppendHeader(table.Row{"#", "ID", "CSN", "Mode", "Start Time", "Status"})
+	for i, r := range expired {
+		t.AppendRow([]interface{}{i + 1, r.bak.Info.ID, r.bak.Info.CSN, r.bak.Info.BackupMode, time.Unix(r.bak.Info.StartTime, 0).String(), r.bak.SsBackup.Status})
+		t.AppendSeparator()
+	}
+	t.Render()
+
+	if DryRun {
+		return nil
+	}
+	if err := getUserApproveInTerminal("Are you sure to delete these backups? (Y/N)"); err != nil {
+		return err
+	}
+
+	for _, r := range expired {
+		if err := pruneBackup(ls, r); err != nil {
+			logging.Error(err.Error())
+			res.Failed = append(res.Failed, r.bak.Info.ID)
+		}
+	}
+	if failed := len(res.Failed); failed > 0 {
+		return xerr.NewCliErr(fmt.Sprintf("%d of %d backups are not pruned, their records are kept", failed, len(expired)))
+	}
+
+	logging.Info(fmt.Sprintf("Prune finished, %d backups deleted", len(expired)))
+	return nil
+}
+
+/*
+planPrune split the records into the ones to keep and the expired ones, both are sorted by start time desc.
+
+A completed record is kept if any of the retention policies keeps it. A PTRACK backup depends on the
+previous completed backup, so the whole chain back to the FULL backup is kept if any of its
+member is kept. Failed, CheckError and Canceled records are never used as a parent, so they are always
+expired, while the records in progress (Waiting or Running) are always kept since the backup may be running.
+*/
+func planPrune(records []*backupRecord, now time.Time) (keep, expired []*backupRecord) {
+	sorted := make([]*backupRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].bak.Info.StartTime > sorted[j].bak.Info.StartTime
+	})
+
+	var (
+		kept      = make(map[*backupRecord]bool)
+		completed = make([]*backupRecord, 0, len(sorted))
+		fulls     uint
+	)
+	for _, r := range sorted {
+		if r.bak.SsBackup != nil && r.bak.SsBackup.Status == model.SsBackupStatusCompleted {
+			completed = append(completed, r)
+		}
+	}
+
+	for i, r := range completed {
+		if RetainCount > 0 && uint(i) < RetainCount {
+			kept[r] = true
+		}
+		if RetainDays > 0 && now.Sub(time.Unix(r.bak.Info.StartTime, 0)) < time.Duration(RetainDays)*24*time.Hour {
+			kept[r] = true
+		}
+		// an incremental backup belongs to the chain of the next full backup older than it
+		chain := fulls + 1
+		if r.bak.Info.BackupMode != model.DBBackModePTrack {
+			fulls++
+			chain = fulls
+		}
+		if RetainFull > 0 && chain <= RetainFull {
+			kept[r] = true
+		}
+	}
+
+	// the parent of completed[i] is completed[i+1], walk from the newest so the whole chain is kept in one pass.
+	for i := 0; i < len(completed)-1; i++ {
+		if kept[completed[i]] && completed[i].bak.Info.BackupMode == model.DBBackModePTrack {
+			kept[completed[i+1]] = true
+		}
+	}
+
+	for _, r := range sorted {
+		if kept[r] || !isPrunable(r) {
+			keep = append(keep, r)
+		} else {
+			expired = append(expired, r)
+		}
+	}
+	return keep, expired
+}
+
+// isPrunable only the records which are finished could be pruned.
+func isPrunable(r *backupRecord) bool {
+	if r.bak.SsBackup == nil {
+		return false
+	}
+	switch r.bak.SsBackup.Status {
+	case model.SsBackupStatusCompleted, model.SsBackupStatusFailed, model.SsBackupStatusCheckError, model.SsBackupStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// pruneBackup delete the backup on every data node, the record is deleted last so a failed prune can be retried.
+func pruneBackup(ls pkg.ILocalStorage, r *backupRecord) error {
+	dataNodeMap := make(map[string]*model.DataNode)
+	for _, dn := range r.bak.DnList {
+		dataNodeMap[dn.Key()] = dn
+	}
+
+	if r.bak.SsBackup != nil {
+		for _, sn := range r.bak.SsBackup.StorageNodes {
+			dn, ok := dataNodeMap[sn.Key()]
+			if !ok || dn.BackupID == "" {
+				// the backup was never taken on the data node
+				continue
+			}
+
+			logging.Info(fmt.Sprintf("Deleting backup %s of data node %s:%d ...", dn.BackupID, sn.IP, sn.Port))
+			as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
+			if err := as.DeleteBackup(newDeleteBackupIn(sn, dn)); err != nil {
+				return xerr.NewCliErr(fmt.Sprintf("delete backup %s of data node %s:%d failed, err:%s", dn.BackupID, sn.IP, sn.Port, err.Error()))
+			}
+		}
+	}
+
+	if err := ls.DeleteByName(r.name); err != nil {
+		return xerr.NewCliErr(fmt.Sprintf("delete backup record %s failed, err:%s", r.name, err.Error()))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prune", func() {
+	var (
+		now = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+		day = int64(24 * time.Hour / time.Second)
+	)
+
+	newRecord := func(id string, mode model.DBBackupMode, daysAgo int64, status model.BackupStatus) *backupRecord {
+		return &backupRecord{
+			name: id + ".json",
+			bak: &model.LsBackup{
+				Info:     &model.BackupMetaInfo{ID: id, BackupMode: mode, StartTime: now.Unix() - daysAgo*day},
+				SsBackup: &model.SsBackup{Status: status},
+			},
+		}
+	}
+
+	ids := func(records []*backupRecord) []string {
+		list := make([]string, 0, len(records))
+		for _, r := range records {
+			list = append(list, r.bak.Info.ID)
+		}
+		return list
+	}
+
+	// f0 <- p1 <- p2, f3 <- p4, failed f5, f6
+	records := []*backupRecord{
+		newRecord("f0", model.BDBackModeFull, 10, model.SsBackupStatusCompleted),
+		newRecord("p1", model.DBBackModePTrack, 9, model.SsBackupStatusCompleted),
+		newRecord("p2", model.DBBackModePTrack, 8, model.SsBackupStatusCompleted),
+		newRecord("f3", model.BDBackModeFull, 6, model.SsBackupStatusCompleted),
+		newRecord("p4", model.DBBackModePTrack, 5, model.SsBackupStatusCompleted),
+		newRecord("f5", model.BDBackModeFull, 3, model.SsBackupStatusFailed),
+		newRecord("f6", model.BDBackModeFull, 1, model.SsBackupStatusCompleted),
+	}
+
+	AfterEach(func() {
+		RetainCount, RetainDays, RetainFull = 0, 0, 0
+	})
+
+	It("retain count keeps the parents of incremental backups", func() {
+		RetainCount = 2
+		keep, expired := planPrune(records, now)
+		Expect(ids(keep)).To(Equal([]string{"f6", "p4", "f3"}))
+		Expect(ids(expired)).To(Equal([]string{"f5", "p2", "p1", "f0"}))
+	})
+
+	It("retain days", func() {
+		RetainDays = 9
+		keep, _ := planPrune(records, now)
+		Expect(ids(keep)).To(Equal([]string{"f6", "p4", "f3", "p2", "p1", "f0"}))
+	})
+
+	It("retain full", func() {
+		RetainFull = 2
+		keep, expired := planPrune(records, now)
+		Expect(ids(keep)).To(Equal([]string{"f6", "p4", "f3"}))
+		Expect(ids(expired)).To(Equal([]string{"f5", "p2", "p1", "f0"}))
+	})
+
+	It("any of the policies keeps the backup", func() {
+		RetainCount, RetainFull = 1, 1
+		keep, _ := planPrune(records, now)
+		Expect(ids(keep)).To(Equal([]string{"f6"}))
+	})
+
+	It("never expire the backups in progress", func() {
+		RetainCount = 1
+		keep, expired := planPrune([]*backupRecord{
+			newRecord("f0", model.BDBackModeFull, 4, model.SsBackupStatusCompleted),
+			newRecord("f1", model.BDBackModeFull, 3, model.SsBackupStatusCheckError),
+			newRecord("f2", model.BDBackModeFull, 2, model.SsBackupStatusCanceled),
+			newRecord("f3", model.BDBackModeFull, 1, model.SsBackupStatusCompleted),
+			newRecord("f4", model.BDBackModeFull, 0, model.SsBackupStatusRunning),
+			newRecord("p5", model.DBBackModePTrack, 0, model.SsBackupStatusWaiting),
+		}, now)
+		Expect(ids(keep)).To(Equal([]string{"f4", "p5", "f3"}))
+		Expect(ids(expired)).To(Equal([]string{"f2", "f1", "f0"}))
+	})
+
+	Context("prune backup", func() {
+		var (
+			as *mock_pkg.MockIAgentServer
+			ls *mock_pkg.MockILocalStorage
+			r  = &backupRecord{
+				name: "a.json",
+				bak: &model.LsBackup{
+					Info:   &model.BackupMetaInfo{ID: "a"},
+					DnList: []*model.DataNode{{IP: "127.0.0.1", Port: 5432, BackupID: "RUS2A1"}},
+					SsBackup: &model.SsBackup{
+						StorageNodes: []*model.StorageNode{{IP: "127.0.0.1", Port: 5432}, {IP: "127.0.0.2", Port: 5432}},
+					},
+				},
+			}
+		)
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			as = mock_pkg.NewMockIAgentServer(ctrl)
+			ls = mock_pkg.NewMockILocalStorage(ctrl)
+			monkey.Patch(pkg.NewAgentServer, func(_ string) pkg.IAgentServer {
+				return as
+			})
+		})
+		AfterEach(func() {
+			ctrl.Finish()
+			monkey.UnpatchAll()
+		})
+
+		It("delete backups then the record", func() {
+			as.EXPECT().DeleteBackup(gomock.Any()).Return(nil)
+			ls.EXPECT().DeleteByName("a.json").Return(nil)
+			Expect(pruneBackup(ls, r)).To(Succeed())
+		})
+
+		It("keep the record if delete backup failed", func() {
+			as.EXPECT().DeleteBackup(gomock.Any()).Return(errors.New("timeout"))
+			Expect(pruneBackup(ls, r)).NotTo(Succeed())
+		})
+	})
+})
@@ -18,8 +18,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,34 +38,71 @@ import (
 var (
 	// database names exist in ss proxy and backup, will to be dropped
 	databaseNamesExist []string
+
+	// RecoveryTargetTime restore data nodes to the timestamp
+	RecoveryTargetTime string
+	// RecoveryTargetLSN restore data nodes to the wal location
+	RecoveryTargetLSN string
+	// RecoveryTargetXid restore data nodes to the transaction id
+	RecoveryTargetXid string
+	// RecoveryTargetName restore data nodes to the named restore point
+	RecoveryTargetName string
+	// RecoveryTargetPosition restore MySQL data nodes to the binlog position
+	RecoveryTargetPosition string
+	// RecoveryTargetGTID restore MySQL data nodes to the gtid set
+	RecoveryTargetGTID string
+	// RecoveryTargetInclusive whether to stop just after the recovery target
+	RecoveryTargetInclusive bool
+	// ConsistentCSN restore all data nodes to the global csn of the backup record
+	ConsistentCSN bool
 )
 
+// restoreResult is the json result of restore.
+type restoreResult struct {
+	Record         *recordResult         `json:"record"`
+	RecoveryTarget *model.RecoveryTarget `json:"recovery_target,omitempty"`
+}
+
+// recoveryTimeLayouts are the accepted layouts of --recovery-target-time, the first one is also used to send to agent server.
+var recoveryTimeLayouts = []string{
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05-07",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
 var RestoreCmd = &cobra.Command{
 	Use:   "restore",
 	Short: "Restore a database cluster ",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
-			fmt.Printf("Flag: %s Value: %s\n", flag.Name, flag.Value)
+			fmt.Fprintf(textOut, "Flag: %s Value: %s\n", flag.Name, flag.Value)
 		})
 
 		if CSN == "" && RecordID == "" {
-			logging.Error("Please specify csn or record id")
-			return
+			return xerr.NewCodeErr(xerr.ExitUsage, "Please specify csn or record id")
 		}
 
 		if CSN != "" && RecordID != "" {
-			logging.Error("Please specify only one of csn and record id")
-			return
+			return xerr.NewCodeErr(xerr.ExitUsage, "Please specify only one of csn and record id")
+		}
+
+		target, err := newRecoveryTarget()
+		if err != nil {
+			return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
 		}
 
-		if err := restore(); err != nil {
-			logging.Error(err.Error())
+		if err := setupAgentAuth(); err != nil {
+			return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
 		}
+
+		return restore(target)
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(RestoreCmd)
+	addProfileFlags(RestoreCmd)
 
 	RestoreCmd.Flags().StringVarP(&Host, "host", "H", "", "ss-proxy hostname or ip")
 	_ = RestoreCmd.MarkFlagRequired("host")
@@ -73,25 +111,40 @@ func init() {
 	RestoreCmd.Flags().StringVarP(&Username, "username", "u", "", "ss-proxy username")
 	_ = RestoreCmd.MarkFlagRequired("username")
 	RestoreCmd.Flags().StringVarP(&Password, "password", "p", "", "ss-proxy password")
+	RestoreCmd.Flags().StringVarP(&ProxyProtocol, "proxy-protocol", "", pkg.ProtocolPostgreSQL, "ss-proxy frontend protocol (postgresql|mysql)")
 	_ = RestoreCmd.MarkFlagRequired("password")
+	addPasswordFileFlags(RestoreCmd)
 	RestoreCmd.Flags().StringVarP(&BackupPath, "dn-backup-path", "B", "", "openGauss data backup path")
 	_ = RestoreCmd.MarkFlagRequired("dn-backup-path")
 	RestoreCmd.Flags().Uint16VarP(&AgentPort, "agent-port", "a", 443, "agent server port")
 	_ = RestoreCmd.MarkFlagRequired("agent-port")
+	addAgentAuthFlags(RestoreCmd)
 
 	RestoreCmd.Flags().StringVarP(&CSN, "csn", "", "", "commit sequence number")
 	RestoreCmd.Flags().StringVarP(&RecordID, "id", "", "", "backup record id")
+	addStorageFlags(RestoreCmd)
+
+	RestoreCmd.Flags().StringVarP(&RecoveryTargetTime, "recovery-target-time", "", "", "replay wal up to the timestamp, e.g. '2023-05-16 18:12:20+08:00'")
+	RestoreCmd.Flags().StringVarP(&RecoveryTargetLSN, "recovery-target-lsn", "", "", "replay wal up to the lsn, e.g. '0/5000028'")
+	RestoreCmd.Flags().StringVarP(&RecoveryTargetXid, "recovery-target-xid", "", "", "replay wal up to the transaction id")
+	RestoreCmd.Flags().StringVarP(&RecoveryTargetName, "recovery-target-name", "", "", "replay wal up to the named restore point")
+	RestoreCmd.Flags().StringVarP(&RecoveryTargetPosition, "recovery-target-position", "", "", "replay MySQL binlog up to the position, e.g. 'binlog.000003:1234'")
+	RestoreCmd.Flags().StringVarP(&RecoveryTargetGTID, "recovery-target-gtid", "", "", "replay MySQL binlog of the gtid set, e.g. '3e11fa47-71ca-11e1-9e33-c80aa9429562:1-7'")
+	RestoreCmd.Flags().BoolVarP(&ConsistentCSN, "consistent-csn", "", false, "replay wal of all data nodes up to the csn of the backup record, so the cluster is transactionally consistent")
+	RestoreCmd.Flags().BoolVarP(&RecoveryTargetInclusive, "recovery-target-inclusive", "", true, "stop just after the recovery target (true), or just before it (false)")
+	RestoreCmd.Flags().BoolVarP(&AssumeYes, "assume-yes", "", false, "same as --yes")
 }
 
-func restore() error {
+// restore the backup record to the recovery target, which is built from flags and may be nil.
+func restore(target *model.RecoveryTarget) error {
 	// init local storage
-	ls, err := pkg.NewLocalStorage(pkg.DefaultRootDir())
+	ls, err := newStorage()
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("new local storage failed, err:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("new storage failed, err:%s", err.Error()))
 	}
-	proxy, err := pkg.NewShardingSphereProxy(Username, Password, pkg.DefaultDBName, Host, Port)
+	proxy, err := newShardingSphereProxy()
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("new ss-proxy failed, err:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitProxy, fmt.Sprintf("new ss-proxy failed, err:%s", err.Error()))
 	}
 
 	// get backup record
@@ -99,42 +152,79 @@ func restore() error {
 	if CSN != "" {
 		bak, err = ls.ReadByCSN(CSN)
 		if err != nil {
-			return xerr.NewCliErr("read backup record by csn failed")
+			return xerr.NewCodeErr(xerr.ExitStorage, "read backup record by csn failed")
 		}
 	}
 
 	if RecordID != "" {
 		bak, err = ls.ReadByID(RecordID)
 		if err != nil {
-			return xerr.NewCliErr("read backup record by id failed")
+			return xerr.NewCodeErr(xerr.ExitStorage, "read backup record by id failed")
 		}
 	}
 	if bak == nil {
-		return xerr.NewCliErr("backup record not found")
+		return xerr.NewCodeErr(xerr.ExitNotFound, "backup record not found")
 	}
 
 	// check if the backup logic database exits,
 	// if exits, we need to warning user that we will drop the database.
 	if err := checkDatabaseExist(proxy, bak); err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("check database exist failed:%s", err.Error()))
+		return xerr.NewCliErrOf(err, fmt.Sprintf("check database exist failed:%s", err.Error()))
 	}
 
 	// check agent server status
 	logging.Info("Checking agent server status...")
 	if available := checkAgentServerStatus(bak); !available {
-		return xerr.NewCliErr("one or more agent server are not available.")
+		return xerr.NewCodeErr(xerr.ExitAgentServer, "one or more agent server are not available.")
+	}
+
+	// the backup sets may be lost with data nodes, pull them back from remote storage
+	if Storage == storageS3 {
+		logging.Info("Pulling backup sets from remote storage...")
+		if err := pullBackupSets(bak); err != nil {
+			return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("pull backup sets failed:%s", err.Error()))
+		}
 	}
 
+	// an incremental backup can only be restored with its whole chain
+	logging.Info("Checking backup chain...")
+	if err := checkBackupChain(bak); err != nil {
+		return xerr.NewCodeErr(xerr.ExitPreflight, fmt.Sprintf("check backup chain failed:%s", err.Error()))
+	}
+
+	// the restored data is written into pgdata, which must have room for the backup
+	logging.Info("Checking disk space...")
+	if err := checkDiskSpace(bak, true); err != nil {
+		return xerr.NewCliErrOf(err, fmt.Sprintf("check disk space failed:%s", err.Error()))
+	}
+
+	// all data nodes replay to the same csn captured when the cluster was locked for backup
+	if ConsistentCSN {
+		if bak.Info.CSN == "" {
+			return xerr.NewCodeErr(xerr.ExitPreflight, fmt.Sprintf("backup record [%s] has no csn, can not restore to a consistent csn", bak.Info.ID))
+		}
+		target.CSN = bak.Info.CSN
+	}
+	// check recovery target if specified
+	if target != nil {
+		logging.Info("Checking recovery target...")
+		if err := checkRecoveryTarget(bak, target); err != nil {
+			return xerr.NewCodeErr(xerr.ExitPreflight, fmt.Sprintf("check recovery target failed:%s", err.Error()))
+		}
+	}
+
+	setResult(&restoreResult{Record: newRecordResult(bak), RecoveryTarget: target})
+
 	// exec restore
 	logging.Info("Start restore backup data to openGauss...")
-	if err := execRestore(bak); err != nil {
+	if err := execRestore(bak, target); err != nil {
 		return xerr.NewCliErr(fmt.Sprintf("exec restore failed:%s", err.Error()))
 	}
 
 	logging.Info("Restore backup data to openGauss success!")
 	// restore metadata to ss-proxy
 	if err := restoreDataToSSProxy(proxy, bak); err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("restore metadata to ss-proxy failed:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitProxy, fmt.Sprintf("restore metadata to ss-proxy failed:%s", err.Error()))
 	}
 	logging.Info("Restore success!")
 	return nil
@@ -143,7 +233,7 @@ func restore() error {
 func checkDatabaseExist(proxy pkg.IShardingSphereProxy, bak *model.LsBackup) error {
 	clusterNow, err := proxy.ExportMetaData()
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("get cluster metadata failed:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitProxy, fmt.Sprintf("get cluster metadata failed:%s", err.Error()))
 	}
 
 	for k := range bak.SsBackup.ClusterInfo.MetaData.Databases {
@@ -181,7 +271,196 @@ func restoreDataToSSProxy(proxy pkg.IShardingSphereProxy, lsBackup *model.LsBack
 	return nil
 }
 
-func execRestore(lsBackup *model.LsBackup) error {
+// newRecoveryTarget build the recovery target from flags, return nil if no target is specified.
+// The csn of --consistent-csn is filled after the backup record is read.
+func newRecoveryTarget() (*model.RecoveryTarget, error) {
+	var n int
+	for _, v := range []string{RecoveryTargetTime, RecoveryTargetLSN, RecoveryTargetXid, RecoveryTargetName, RecoveryTargetPosition, RecoveryTargetGTID} {
+		if v != "" {
+			n++
+		}
+	}
+	if ConsistentCSN {
+		n++
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if n > 1 {
+		return nil, xerr.NewCliErr("Please specify only one of recovery target time, lsn, xid, name, position, gtid and consistent csn")
+	}
+
+	inclusive := RecoveryTargetInclusive
+	target := &model.RecoveryTarget{
+		LSN:       RecoveryTargetLSN,
+		Xid:       RecoveryTargetXid,
+		Name:      RecoveryTargetName,
+		Position:  RecoveryTargetPosition,
+		GTID:      RecoveryTargetGTID,
+		Inclusive: &inclusive,
+	}
+
+	if RecoveryTargetTime != "" {
+		t, err := parseRecoveryTime(RecoveryTargetTime)
+		if err != nil {
+			return nil, err
+		}
+		target.Time = t.Format(recoveryTimeLayouts[0])
+	}
+	if RecoveryTargetLSN != "" {
+		if _, err := parseLSN(RecoveryTargetLSN); err != nil {
+			return nil, err
+		}
+	}
+	if RecoveryTargetXid != "" {
+		if _, err := strconv.ParseUint(RecoveryTargetXid, 10, 64); err != nil {
+			return nil, xerr.NewCliErr(fmt.Sprintf("invalid recovery target xid:%s", RecoveryTargetXid))
+		}
+	}
+	if RecoveryTargetPosition != "" {
+		if _, _, err := parseBinlogPosition(RecoveryTargetPosition); err != nil {
+			return nil, err
+		}
+	}
+	return target, nil
+}
+
+// checkRecoveryTarget make sure the recovery target is reachable from the backup of every data node.
+func checkRecoveryTarget(lsBackup *model.LsBackup, target *model.RecoveryTarget) error {
+	dataNodeMap := make(map[string]*model.DataNode)
+	for _, dn := range lsBackup.DnList {
+		dataNodeMap[dn.Key()] = dn
+	}
+
+	for _, sn := range lsBackup.SsBackup.StorageNodes {
+		dn, ok := dataNodeMap[sn.Key()]
+		if !ok {
+			return xerr.NewCliErr(fmt.Sprintf("data node %s:%d not found in backup info", sn.IP, sn.Port))
+		}
+
+		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
+		in := &model.ShowDetailIn{
+			DBPort:       sn.Port,
+			DBName:       sn.Database,
+			Username:     sn.Username,
+			Password:     sn.Password,
+			DnBackupID:   dn.BackupID,
+			DnBackupPath: BackupPath,
+			Instance:     instanceOf(sn),
+		}
+		info, err := as.ShowDetail(in)
+		if err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("show backup detail of %s:%d failed:%s", sn.IP, sn.Port, err.Error()))
+		}
+
+		if err := validateRecoveryTarget(info, target); err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("data node %s:%d:%s", sn.IP, sn.Port, err.Error()))
+		}
+	}
+	return nil
+}
+
+// validateRecoveryTarget the target must not be earlier than the point that the backup becomes consistent.
+func validateRecoveryTarget(info *model.BackupInfo, target *model.RecoveryTarget) error {
+	switch {
+	case target.Time != "":
+		t, err := parseRecoveryTime(target.Time)
+		if err != nil {
+			return err
+		}
+		if info.RecoveryTime == "" {
+			return nil
+		}
+		rt, err := parseRecoveryTime(info.RecoveryTime)
+		if err != nil {
+			return err
+		}
+		if t.Before(rt) {
+			return xerr.NewCliErr(fmt.Sprintf("recovery target time %s is earlier than backup recovery time %s", target.Time, info.RecoveryTime))
+		}
+	case target.LSN != "":
+		lsn, err := parseLSN(target.LSN)
+		if err != nil {
+			return err
+		}
+		if info.StopLsn == "" {
+			return nil
+		}
+		stop, err := parseLSN(info.StopLsn)
+		if err != nil {
+			return err
+		}
+		if lsn < stop {
+			return xerr.NewCliErr(fmt.Sprintf("recovery target lsn %s is earlier than backup stop lsn %s", target.LSN, info.StopLsn))
+		}
+	case target.Xid != "":
+		xid, err := strconv.ParseUint(target.Xid, 10, 64)
+		if err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("invalid recovery target xid:%s", target.Xid))
+		}
+		if xid < uint64(info.RecoveryXid) {
+			return xerr.NewCliErr(fmt.Sprintf("recovery target xid %s is earlier than backup recovery xid %d", target.Xid, info.RecoveryXid))
+		}
+	case target.Position != "":
+		file, pos, err := parseBinlogPosition(target.Position)
+		if err != nil {
+			return err
+		}
+		if info.StopLsn == "" {
+			return nil
+		}
+		stopFile, stopPos, err := parseBinlogPosition(info.StopLsn)
+		if err != nil {
+			return err
+		}
+		if file < stopFile || (file == stopFile && pos < stopPos) {
+			return xerr.NewCliErr(fmt.Sprintf("recovery target position %s is earlier than backup binlog position %s", target.Position, info.StopLsn))
+		}
+	}
+	// the named restore point, the csn and the gtid set can only be checked by the database when replaying
+	return nil
+}
+
+// parseBinlogPosition parse the MySQL binlog position like `binlog.000003:1234`, the binlog files are ordered by name.
+func parseBinlogPosition(s string) (string, uint64, error) {
+	i := strings.LastIndex(s, ":")
+	if i <= 0 {
+		return "", 0, xerr.NewCliErr(fmt.Sprintf("invalid binlog position:%s", s))
+	}
+	pos, err := strconv.ParseUint(s[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, xerr.NewCliErr(fmt.Sprintf("invalid binlog position:%s", s))
+	}
+	return s[:i], pos, nil
+}
+
+func parseRecoveryTime(s string) (time.Time, error) {
+	for _, layout := range recoveryTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, xerr.NewCliErr(fmt.Sprintf("invalid recovery time:%s", s))
+}
+
+// parseLSN parse the lsn like `0/5000028` to a comparable number.
+func parseLSN(s string) (uint64, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return 0, xerr.NewCliErr(fmt.Sprintf("invalid lsn:%s", s))
+	}
+	hi, err := strconv.ParseUint(parts[0], 16, 32)
+	if err != nil {
+		return 0, xerr.NewCliErr(fmt.Sprintf("invalid lsn:%s", s))
+	}
+	lo, err := strconv.ParseUint(parts[1], 16, 32)
+	if err != nil {
+		return 0, xerr.NewCliErr(fmt.Sprintf("invalid lsn:%s", s))
+	}
+	return hi<<32 | lo, nil
+}
+
+func execRestore(lsBackup *model.LsBackup, target *model.RecoveryTarget) error {
 	var (
 		totalNum           = len(lsBackup.SsBackup.StorageNodes)
 		dataNodeMap        = make(map[string]*model.DataNode)
@@ -191,7 +470,7 @@ func execRestore(lsBackup *model.LsBackup) error {
 	)
 
 	for _, dataNode := range lsBackup.DnList {
-		dataNodeMap[dataNode.IP] = dataNode
+		dataNodeMap[dataNode.Key()] = dataNode
 	}
 
 	if totalNum == 0 {
@@ -199,12 +478,13 @@ func execRestore(lsBackup *model.LsBackup) error {
 	}
 
 	pw := prettyoutput.NewPW(totalNum)
+	pw.SetOutputWriter(textOut)
 	go pw.Render()
 	for i := 0; i < totalNum; i++ {
 		sn := lsBackup.SsBackup.StorageNodes[i]
-		dn := dataNodeMap[sn.IP]
+		dn := dataNodeMap[sn.Key()]
 		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
-		go doRestore(as, sn, dn.BackupID, resultCh, pw)
+		go doRestore(as, sn, dn.BackupID, target, resultCh, pw)
 	}
 
 	time.Sleep(time.Millisecond * 100)
@@ -223,12 +503,20 @@ func execRestore(lsBackup *model.LsBackup) error {
 
 	// print result formatted
 	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	t.SetOutputMirror(textOut)
 	t.SetTitle("Restore Task Result: %s", restoreFinalStatus)
-	t.AppendHeader(table.Row{"#", "Data Node IP", "Data Node Port", "Result"})
+	if target == nil {
+		t.AppendHeader(table.Row{"#", "Data Node IP", "Data Node Port", "Result"})
+	} else {
+		t.AppendHeader(table.Row{"#", "Data Node IP", "Data Node Port", "Result", "Replay LSN", "Replay Time"})
+	}
 
 	for i, dn := range dnResult {
-		t.AppendRow([]interface{}{i + 1, dn.IP, dn.Port, dn.Status})
+		if target == nil {
+			t.AppendRow([]interface{}{i + 1, dn.IP, dn.Port, dn.Status})
+		} else {
+			t.AppendRow([]interface{}{i + 1, dn.IP, dn.Port, dn.Status, dn.ReplayLSN, dn.ReplayTime})
+		}
 		t.AppendSeparator()
 	}
 
@@ -241,33 +529,64 @@ func execRestore(lsBackup *model.LsBackup) error {
 	return nil
 }
 
-func doRestore(as pkg.IAgentServer, sn *model.StorageNode, backupID string, resultCh chan *model.RestoreResult, pw progress.Writer) {
+func doRestore(as pkg.IAgentServer, sn *model.StorageNode, backupID string, target *model.RecoveryTarget, resultCh chan *model.RestoreResult, pw progress.Writer) {
 	tracker := &progress.Tracker{Message: fmt.Sprintf("Restore data to openGauss: %s", sn.IP)}
-	result := ""
+	r := &model.RestoreResult{
+		IP:   sn.IP,
+		Port: sn.Port,
+	}
 
 	in := &model.RestoreIn{
 		DBPort:       sn.Port,
 		DBName:       sn.Database,
 		Username:     sn.Username,
 		Password:     sn.Password,
-		Instance:     defaultInstance,
+		Instance:     instanceOf(sn),
 		DnBackupPath: BackupPath,
 		DnBackupID:   backupID,
+
+		RecoveryTarget: target,
 	}
 
 	pw.AppendTracker(tracker)
 
-	if err := as.Restore(in); err != nil {
+	out, err := restoreDataNode(as, in, tracker)
+	if err != nil {
+		logging.Error(fmt.Sprintf("restore data node %s:%d failed, err:%s", sn.IP, sn.Port, err.Error()))
 		tracker.MarkAsErrored()
-		result = "Failed"
+		r.Status = "Failed"
 	} else {
 		tracker.MarkAsDone()
-		result = "Completed"
+		r.Status = "Completed"
+	}
+	if out != nil {
+		r.ReplayLSN = out.ReplayLSN
+		r.ReplayTime = out.ReplayTime
+	}
+
+	resultCh <- r
+}
+
+// restoreDataNode submit the restore job to agent server and wait for it finished.
+func restoreDataNode(as pkg.IAgentServer, in *model.RestoreIn, tracker *progress.Tracker) (*model.RestoreOut, error) {
+	job, err := as.Restore(in)
+	if err != nil {
+		return nil, err
 	}
 
-	resultCh <- &model.RestoreResult{
-		IP:     sn.IP,
-		Port:   sn.Port,
-		Status: result,
+	job, err = waitJob(as, job, tracker)
+	if err != nil {
+		return nil, err
+	}
+	if job.State != model.JobStateSucceeded {
+		return nil, xerr.NewCliErr(job.Error)
+	}
+
+	out := &model.RestoreOut{}
+	if len(job.Result) > 0 {
+		if err := json.Unmarshal(job.Result, out); err != nil {
+			return nil, err
+		}
 	}
+	return out, nil
 }
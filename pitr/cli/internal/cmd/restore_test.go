@@ -71,7 +71,8 @@ var _ = Describe("test restore", func() {
 			},
 			DnList: []*model.DataNode{
 				{
-					IP: "127.0.0.1",
+					IP:       "127.0.0.1",
+					BackupID: "dn-backup-1",
 				},
 			},
 			SsBackup: &model.SsBackup{
@@ -126,9 +127,14 @@ var _ = Describe("test restore", func() {
 		proxy.EXPECT().ExportMetaData().Return(&model.ClusterInfo{}, nil)
 		proxy.EXPECT().ImportMetaData(gomock.Any()).Return(nil)
 		as.EXPECT().CheckStatus(gomock.Any()).Return(nil)
-		as.EXPECT().Restore(gomock.Any()).Return(nil)
+		as.EXPECT().ShowList(gomock.Any()).Return([]model.BackupInfo{{ID: "dn-backup-1", Mode: "FULL", Status: model.SsBackupStatusCompleted}}, nil)
+		as.EXPECT().ShowDiskSpace(gomock.Any()).Return(&model.DiskSpaceInfo{Data: model.DiskSpace{
+			PgData:         &model.DiskUsage{Path: "/data", TotalBytes: 1 << 30, FreeBytes: 1 << 29},
+			EstimatedBytes: 1 << 28,
+		}}, nil)
+		as.EXPECT().Restore(gomock.Any()).Return(&model.Job{ID: "job-id", State: model.JobStateSucceeded}, nil)
 
-		Expect(restore()).To(BeNil())
+		Expect(restore(nil)).To(BeNil())
 	})
 
 	// test getUserApproveInTerminal
@@ -136,7 +142,13 @@ var _ = Describe("test restore", func() {
 		// test user abort
 		It("user abort", func() {
 			// exec getUserApproveInTerminal
-			Expect(getUserApproveInTerminal("")).To(Equal(xerr.NewCliErr("User abort")))
+			Expect(getUserApproveInTerminal("")).To(Equal(xerr.NewCodeErr(xerr.ExitAborted, "User abort")))
+		})
+
+		It("confirmation is required in non-interactive mode", func() {
+			NonInteractive = true
+			defer func() { NonInteractive = false }()
+			Expect(xerr.ExitCode(getUserApproveInTerminal(""))).To(Equal(xerr.ExitAborted))
 		})
 	})
 
@@ -168,8 +180,92 @@ var _ = Describe("test restore", func() {
 			}()
 			as.EXPECT().Restore(gomock.Any()).Do(func(_ *model.RestoreIn) {
 				time.Sleep(3 * time.Second)
-			}).Return(nil)
-			Expect(execRestore(bak)).To(BeNil())
+			}).Return(&model.Job{ID: "job-id", State: model.JobStateSucceeded}, nil)
+			Expect(execRestore(bak, nil)).To(BeNil())
+		})
+	})
+
+	Context("test recovery target", func() {
+		AfterEach(func() {
+			RecoveryTargetTime, RecoveryTargetLSN, RecoveryTargetXid, RecoveryTargetName = "", "", "", ""
+			RecoveryTargetPosition, RecoveryTargetGTID = "", ""
+			ConsistentCSN = false
+		})
+
+		It("no recovery target", func() {
+			target, err := newRecoveryTarget()
+			Expect(err).To(BeNil())
+			Expect(target).To(BeNil())
+		})
+
+		It("more than one recovery target", func() {
+			RecoveryTargetLSN = "0/5000028"
+			RecoveryTargetXid = "100"
+			_, err := newRecoveryTarget()
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("invalid recovery target", func() {
+			RecoveryTargetTime = "yesterday"
+			_, err := newRecoveryTarget()
+			Expect(err).NotTo(BeNil())
+
+			RecoveryTargetTime = ""
+			RecoveryTargetLSN = "5000028"
+			_, err = newRecoveryTarget()
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("consistent csn", func() {
+			ConsistentCSN = true
+			target, err := newRecoveryTarget()
+			Expect(err).To(BeNil())
+			Expect(target).NotTo(BeNil())
+			Expect(*target.Inclusive).To(BeTrue())
+
+			RecoveryTargetXid = "100"
+			_, err = newRecoveryTarget()
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("normalize recovery target time", func() {
+			RecoveryTargetTime = "2023-05-16T18:12:20+08:00"
+			target, err := newRecoveryTarget()
+			Expect(err).To(BeNil())
+			Expect(target.Time).To(Equal("2023-05-16 18:12:20+08:00"))
+		})
+
+		It("validate recovery target against backup", func() {
+			info := &model.BackupInfo{StopLsn: "0/5000100", RecoveryTime: "2023-05-16 18:12:20+08", RecoveryXid: 100}
+
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{LSN: "0/5000028"})).NotTo(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{LSN: "1/0"})).To(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{Time: "2023-05-16 18:00:00+08:00"})).NotTo(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{Time: "2023-05-16 11:00:00+00:00"})).To(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{Xid: "99"})).NotTo(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{Xid: "101"})).To(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{Name: "before_upgrade"})).To(BeNil())
+		})
+
+		It("binlog recovery target", func() {
+			RecoveryTargetPosition = "binlog.000003"
+			_, err := newRecoveryTarget()
+			Expect(err).NotTo(BeNil())
+
+			RecoveryTargetPosition = "binlog.000003:1234"
+			target, err := newRecoveryTarget()
+			Expect(err).To(BeNil())
+			Expect(target.Position).To(Equal("binlog.000003:1234"))
+
+			RecoveryTargetGTID = "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-7"
+			_, err = newRecoveryTarget()
+			Expect(err).NotTo(BeNil())
+
+			info := &model.BackupInfo{StopLsn: "binlog.000003:1234"}
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{Position: "binlog.000002:9999"})).NotTo(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{Position: "binlog.000003:1000"})).NotTo(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{Position: "binlog.000004:4"})).To(BeNil())
+			Expect(validateRecoveryTarget(info, &model.RecoveryTarget{GTID: "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-7"})).To(BeNil())
 		})
 	})
 })
@@ -21,6 +21,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
@@ -39,6 +40,8 @@ var (
 	Username string
 	// Password ss-proxy password
 	Password string
+	// ProxyProtocol the frontend protocol of ss-proxy, postgresql or mysql
+	ProxyProtocol string
 	// AgentPort agent-server port
 	AgentPort uint16
 	// BackupPath openGauss data backup path
@@ -53,28 +56,79 @@ var (
 	CSN string
 	// RecordID openGauss data backup record id
 	RecordID string
+	// AssumeYes answer yes to all prompts, for unattended runs
+	AssumeYes bool
+	// NonInteractive never read from stdin, the prompts fail unless AssumeYes is set
+	NonInteractive bool
+	// Output the format of command result, text or json
+	Output string
 )
 
+const (
+	diskSpaceEnough    = "enough"
+	diskSpaceNotEnough = "not enough"
+	diskSpaceUnknown   = "unknown"
+)
+
+// newShardingSphereProxy connect to ss-proxy by the protocol of --proxy-protocol, the database is the default one of the protocol.
+func newShardingSphereProxy() (pkg.IShardingSphereProxy, error) {
+	switch ProxyProtocol {
+	case "", pkg.ProtocolPostgreSQL:
+		return pkg.NewShardingSphereProxy(Username, Password, pkg.DefaultDBName, Host, Port)
+	case pkg.ProtocolMySQL:
+		return pkg.NewMySQLShardingSphereProxy(Username, Password, "", Host, Port)
+	default:
+		return nil, xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("unknown proxy protocol:%s, only postgresql and mysql are supported", ProxyProtocol))
+	}
+}
+
 var RootCmd = &cobra.Command{
 	Use:   "gs_pitr",
 	Short: "PITR tools for openGauss",
 
+	// errors are printed by Execute, as text or in the json result
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyProfile(cmd); err != nil {
+			return err
+		}
+		return setupOutput(cmd)
+	},
+
 	CompletionOptions: cobra.CompletionOptions{
 		DisableDefaultCmd: true,
 		HiddenDefaultCmd:  true,
 	},
 }
 
+func init() {
+	RootCmd.PersistentFlags().BoolVarP(&AssumeYes, "yes", "y", false, "answer yes to all prompts")
+	RootCmd.PersistentFlags().BoolVarP(&NonInteractive, "non-interactive", "", false, "never wait for user input, prompts fail unless --yes is set")
+	RootCmd.PersistentFlags().StringVarP(&Output, "output", "o", outputText, "output format (text|json), the json result is printed to stdout and the others to stderr")
+
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+	})
+}
+
 func getUserApproveInTerminal(prompt string) error {
 	logging.Warn(fmt.Sprintf("\n%s", prompt))
+	if AssumeYes {
+		logging.Warn("yes (--yes)")
+		return nil
+	}
+	if NonInteractive {
+		return xerr.NewCodeErr(xerr.ExitAborted, "confirmation is required in non-interactive mode, use --yes to confirm")
+	}
 	scanner := bufio.NewScanner(os.Stdin)
 	scanner.Scan()
 	err := scanner.Err()
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("read user input failed:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitAborted, fmt.Sprintf("read user input failed:%s", err.Error()))
 	}
 	if scanner.Text() != "Y" && scanner.Text() != "y" && scanner.Text() != "yes" && scanner.Text() != "YES" && scanner.Text() != "Yes" {
-		return xerr.NewCliErr("User abort")
+		return xerr.NewCodeErr(xerr.ExitAborted, "User abort")
 	}
 	return nil
 }
@@ -93,10 +147,6 @@ func checkAgentServerStatus(lsBackup *model.LsBackup) bool {
 	// all agent server are available
 	available := true
 
-	// IMPORTANT: we don't support multiple agent server run on the same host
-	asMap := make(map[string]bool)
-	asDuplicate := false
-
 	for _, node := range lsBackup.SsBackup.StorageNodes {
 		sn := node
 		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
@@ -107,79 +157,145 @@ func checkAgentServerStatus(lsBackup *model.LsBackup) bool {
 			Password: sn.Password,
 		}
 		if err := as.CheckStatus(in); err != nil {
-			statusList = append(statusList, &model.AgentServerStatus{IP: sn.IP, Port: AgentPort, Status: "Unavailable"})
+			statusList = append(statusList, &model.AgentServerStatus{IP: sn.IP, Port: AgentPort, DBPort: sn.Port, Status: "Unavailable"})
 			available = false
 		} else {
-			statusList = append(statusList, &model.AgentServerStatus{IP: sn.IP, Port: AgentPort, Status: "Available"})
+			statusList = append(statusList, &model.AgentServerStatus{IP: sn.IP, Port: AgentPort, DBPort: sn.Port, Status: "Available"})
 		}
 	}
 
 	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	t.SetOutputMirror(textOut)
 	t.SetTitle("Agent Server Status")
-	t.AppendHeader(table.Row{"#", "Agent Server IP", "Agent Server Port", "Status"})
+	t.AppendHeader(table.Row{"#", "Agent Server IP", "Agent Server Port", "openGauss Port", "Status"})
 
 	for i, s := range statusList {
-		t.AppendRow([]interface{}{i + 1, s.IP, s.Port, s.Status})
+		t.AppendRow([]interface{}{i + 1, s.IP, s.Port, s.DBPort, s.Status})
 		t.AppendSeparator()
 	}
 
 	t.Render()
 
-	for _, node := range lsBackup.SsBackup.StorageNodes {
-		if _, ok := asMap[node.IP]; ok {
-			asDuplicate = true
-			break
-		}
-		asMap[node.IP] = true
-	}
-
-	if asDuplicate {
-		logging.Error("IMPORTANT!: we don't support multiple agent server run on the same host.\n")
-		return false
-	}
-
 	return available
 }
 
-func checkDiskSpace(lsBackup *model.LsBackup) error {
-	var (
-		diskspaceList = make([]*model.DiskSpaceStatus, 0)
-	)
+/*
+checkDiskSpace make sure every storage node has enough free space before backup or restore.
+
+The backup set is written into the backup path and the restored data into pgdata, the size of them is estimated by
+the pgdata bytes of the latest backup, or of the backup to restore. It fails if any node lacks space, and asks user
+to continue if the space of any node can not be estimated, unless --assume-yes is set.
+*/
+func checkDiskSpace(lsBackup *model.LsBackup, restore bool) error {
+	backupIDs := make(map[string]string)
+	if restore {
+		for _, dn := range lsBackup.DnList {
+			backupIDs[dn.Key()] = dn.BackupID
+		}
+	}
+
+	diskspaceList := make([]*model.DiskSpaceStatus, 0)
 	for _, sn := range lsBackup.SsBackup.StorageNodes {
-		var data string
 		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
 		in := &model.DiskSpaceIn{
-			DiskPath: BackupPath,
+			DiskPath:   BackupPath,
+			DBPort:     sn.Port,
+			Instance:   instanceOf(sn),
+			DnBackupID: backupIDs[sn.Key()],
 		}
 
 		out, err := as.ShowDiskSpace(in)
-
 		if err != nil {
-			data = "Check disk space failed."
-		} else {
-			data = out.Data
+			diskspaceList = append(diskspaceList, &model.DiskSpaceStatus{
+				IP:     sn.IP,
+				Path:   BackupPath,
+				Status: diskSpaceUnknown,
+				Error:  err.Error(),
+			})
+			continue
 		}
-
-		diskspaceList = append(diskspaceList, &model.DiskSpaceStatus{
-			IP:              sn.IP,
-			Path:            BackupPath,
-			DiskSpaceStatus: data,
-		})
+		diskspaceList = append(diskspaceList, newDiskSpaceStatus(sn.IP, &out.Data, restore))
 	}
 
 	// print diskspace result formatted
 	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	t.SetOutputMirror(textOut)
 	t.SetTitle("Disk Space Status")
-	t.AppendHeader(table.Row{"#", "Data Node IP", "Disk Path", "Disk Space Status"})
+	t.AppendHeader(table.Row{"#", "Data Node IP", "Disk Path", "Total", "Free", "Estimated", "Status"})
+
+	var notEnough, unknown []string
 	for i, ds := range diskspaceList {
-		t.AppendRow([]interface{}{i + 1, ds.IP, ds.Path, ds.DiskSpaceStatus})
+		t.AppendRow([]interface{}{i + 1, ds.IP, ds.Path, formatBytes(ds.TotalBytes), formatBytes(ds.FreeBytes), formatBytes(uint64(ds.EstimatedBytes)), ds.Status})
 		t.AppendSeparator()
+
+		switch ds.Status {
+		case diskSpaceNotEnough:
+			notEnough = append(notEnough, ds.IP)
+		case diskSpaceUnknown:
+			if ds.Error != "" {
+				logging.Warn(fmt.Sprintf("Check disk space of data node %s failed, err:%s", ds.IP, ds.Error))
+			}
+			unknown = append(unknown, ds.IP)
+		}
 	}
 	t.Render()
+
+	if len(notEnough) > 0 {
+		return xerr.NewCodeErr(xerr.ExitPreflight, fmt.Sprintf("not enough disk space on data nodes [%s]", strings.Join(notEnough, ",")))
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
 	prompt := fmt.Sprintf(
-		"Please Check All Nodes Disk Space, Make Sure Have Enough Space To Backup Or Restore Data.\n" +
-			"Are you sure to continue? (Y/N)")
+		"The Disk Space Of Data Nodes [%s] Can Not Be Estimated, Make Sure They Have Enough Space To Backup Or Restore Data.\n"+
+			"Are you sure to continue? (Y/N)", strings.Join(unknown, ","))
 	return getUserApproveInTerminal(prompt)
 }
+
+// newDiskSpaceStatus the backup is checked against the filesystem of backup path, and the restore is against pgdata.
+func newDiskSpaceStatus(ip string, ds *model.DiskSpace, restore bool) *model.DiskSpaceStatus {
+	usage := ds.BackupPath
+	if restore {
+		usage = ds.PgData
+	}
+
+	status := &model.DiskSpaceStatus{
+		IP:             ip,
+		Path:           BackupPath,
+		EstimatedBytes: ds.EstimatedBytes,
+		Status:         diskSpaceUnknown,
+	}
+	if usage == nil {
+		return status
+	}
+
+	status.Path = usage.Path
+	status.TotalBytes = usage.TotalBytes
+	status.FreeBytes = usage.FreeBytes
+	switch {
+	case ds.EstimatedBytes <= 0:
+	case usage.FreeBytes < uint64(ds.EstimatedBytes):
+		status.Status = diskSpaceNotEnough
+	default:
+		status.Status = diskSpaceEnough
+	}
+	return status
+}
+
+// formatBytes format bytes in IEC units, e.g. 1.5GiB.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n == 0 {
+		return "-"
+	}
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
 	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/mocks"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -47,24 +48,71 @@ var _ = Describe("Root", func() {
 			defer monkey.UnpatchAll()
 		})
 
-		It("should print help message", func() {
-			bak := &model.LsBackup{
-				SsBackup: &model.SsBackup{
-					StorageNodes: []*model.StorageNode{
-						{
-							IP: "127.0.0.1",
-						},
+		bak := &model.LsBackup{
+			DnList: []*model.DataNode{{IP: "127.0.0.1", BackupID: "RUS2A1"}},
+			SsBackup: &model.SsBackup{
+				StorageNodes: []*model.StorageNode{
+					{
+						IP: "127.0.0.1",
 					},
 				},
+			},
+		}
+		diskSpace := func(free uint64, estimated int64) *model.DiskSpaceInfo {
+			return &model.DiskSpaceInfo{
+				Data: model.DiskSpace{
+					BackupPath:     &model.DiskUsage{Path: "/tmp", TotalBytes: 1 << 30, FreeBytes: free},
+					PgData:         &model.DiskUsage{Path: "/data", TotalBytes: 1 << 30, FreeBytes: 1 << 20},
+					EstimatedBytes: estimated,
+				},
 			}
+		}
+
+		It("enough disk space to backup", func() {
+			BackupPath = "/tmp"
+			as.EXPECT().ShowDiskSpace(&model.DiskSpaceIn{DiskPath: "/tmp", Instance: defaultInstance}).Return(diskSpace(1<<29, 1<<28), nil)
+			Expect(checkDiskSpace(bak, false)).To(BeNil())
+		})
+
+		It("not enough disk space to backup", func() {
+			BackupPath = "/tmp"
+			as.EXPECT().ShowDiskSpace(gomock.Any()).Return(diskSpace(1<<27, 1<<28), nil)
+			Expect(checkDiskSpace(bak, false)).NotTo(BeNil())
+		})
+
+		It("not enough disk space of pgdata to restore", func() {
+			BackupPath = "/tmp"
+			as.EXPECT().ShowDiskSpace(&model.DiskSpaceIn{DiskPath: "/tmp", Instance: defaultInstance, DnBackupID: "RUS2A1"}).Return(diskSpace(1<<29, 1<<28), nil)
+			Expect(checkDiskSpace(bak, true)).NotTo(BeNil())
+		})
+
+		It("ask user if disk space can not be estimated", func() {
 			BackupPath = "/tmp"
-			as.EXPECT().ShowDiskSpace(gomock.Any()).Return(&model.DiskSpaceInfo{
-				Code: 0,
-				Msg:  "",
-				Data: "文件系统        容量  已用  可用 已用% 挂载点\n/dev/vda1        20G   13G  7.8G   62% /",
-			}, nil)
-			err := checkDiskSpace(bak)
-			Expect(err).To(BeNil())
+			monkey.Patch(getUserApproveInTerminal, func(_ string) error {
+				return xerr.NewCliErr("User abort")
+			})
+			as.EXPECT().ShowDiskSpace(gomock.Any()).Return(diskSpace(1<<29, 0), nil)
+			Expect(checkDiskSpace(bak, false)).NotTo(BeNil())
+
+			as.EXPECT().ShowDiskSpace(gomock.Any()).Return(nil, xerr.NewCliErr("agent server error"))
+			Expect(checkDiskSpace(bak, false)).NotTo(BeNil())
+		})
+	})
+
+	Context("when approve prompts", func() {
+		It("assume yes", func() {
+			AssumeYes = true
+			defer func() { AssumeYes = false }()
+			Expect(getUserApproveInTerminal("Are you sure to continue? (Y/N)")).To(BeNil())
+		})
+	})
+
+	Context("when format bytes", func() {
+		It("IEC units", func() {
+			Expect(formatBytes(0)).To(Equal("-"))
+			Expect(formatBytes(512)).To(Equal("512B"))
+			Expect(formatBytes(1536)).To(Equal("1.5KiB"))
+			Expect(formatBytes(3 << 30)).To(Equal("3.0GiB"))
 		})
 	})
 })
@@ -0,0 +1,244 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+
+	// shutdownTimeout wait for the metrics server to shut down
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// MetricsAddr the address to expose the prometheus metrics of scheduled backups
+	MetricsAddr string
+)
+
+var ServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the scheduled backups of profiles in config file and expose their metrics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := pkg.LoadConfig(configFile())
+		if err != nil {
+			return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+		}
+		if len(cfg.Schedules) == 0 {
+			return xerr.NewCodeErr(xerr.ExitUsage, fmt.Sprintf("no schedules in config file %s", configFile()))
+		}
+
+		// nobody answers the prompts of scheduled backups
+		NonInteractive, AssumeYes = true, true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return serve(ctx, cfg)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(ServeCmd)
+
+	ServeCmd.Flags().StringVarP(&MetricsAddr, "metrics-addr", "", ":9090", "address to expose the prometheus metrics of scheduled backups")
+}
+
+// serveMetrics the metrics of scheduled backups, the backups are labeled by profile and mode (FULL|PTRACK).
+type serveMetrics struct {
+	lastSuccess   *prometheus.GaugeVec
+	lastDuration  *prometheus.GaugeVec
+	runs          *prometheus.CounterVec
+	pruneFailures *prometheus.CounterVec
+}
+
+func newServeMetrics(reg prometheus.Registerer) *serveMetrics {
+	m := &serveMetrics{
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "gs_pitr_backup_last_success_timestamp_seconds",
+			Help: "Unix time of the last successful backup.",
+		}, []string{"profile", "mode"}),
+		lastDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "gs_pitr_backup_last_duration_seconds",
+			Help: "Duration of the last backup, whether it succeeded or not.",
+		}, []string{"profile", "mode"}),
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gs_pitr_backup_runs_total",
+			Help: "Number of scheduled backups by result.",
+		}, []string{"profile", "mode", "result"}),
+		pruneFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gs_pitr_prune_failures_total",
+			Help: "Number of failed prunes after scheduled backups.",
+		}, []string{"profile"}),
+	}
+	reg.MustRegister(m.lastSuccess, m.lastDuration, m.runs, m.pruneFailures)
+	return m
+}
+
+// scheduler run the scheduled backups one by one, the commands share package vars so they never run concurrently.
+type scheduler struct {
+	mu       sync.Mutex
+	profiles map[string]map[string]string
+	metrics  *serveMetrics
+}
+
+func serve(ctx context.Context, cfg *pkg.Config) error {
+	reg := prometheus.NewRegistry()
+	s := &scheduler{
+		profiles: cfg.Profiles,
+		metrics:  newServeMetrics(reg),
+	}
+
+	c := cron.New()
+	if err := s.register(c, cfg.Schedules); err != nil {
+		return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	srv := &http.Server{Addr: MetricsAddr, Handler: mux, ReadHeaderTimeout: shutdownTimeout}
+	errCh := make(chan error, 1)
+	go func() {
+		logging.Info(fmt.Sprintf("Serving metrics on %s/metrics", MetricsAddr))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	c.Start()
+	var err error
+	select {
+	case <-ctx.Done():
+		logging.Info("Stopping, waiting for the running backup to finish ...")
+	case err = <-errCh:
+		err = xerr.NewCliErr(fmt.Sprintf("serve metrics failed, err:%s", err.Error()))
+	}
+	<-c.Stop().Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	_ = srv.Shutdown(shutdownCtx)
+	return err
+}
+
+// register add the backups of schedules to cron, in the order of profile names.
+func (s *scheduler) register(c *cron.Cron, schedules map[string]*pkg.Schedule) error {
+	names := make([]string, 0, len(schedules))
+	for name := range schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sch := schedules[name]
+		if sch == nil {
+			continue
+		}
+		for _, b := range []struct{ mode, spec string }{{"FULL", sch.Full}, {"PTRACK", sch.Ptrack}} {
+			if b.spec == "" {
+				continue
+			}
+			profile, mode, spec := name, b.mode, b.spec
+			if _, err := c.AddFunc(spec, func() { s.run(profile, mode) }); err != nil {
+				return fmt.Errorf("invalid %s schedule %q of profile %s, err:%s", mode, spec, profile, err.Error())
+			}
+			logging.Info(fmt.Sprintf("Scheduled %s backup of profile %s at %q", mode, profile, spec))
+		}
+	}
+	return nil
+}
+
+// run backup the cluster of profile, then prune the backups if the profile has a retention policy.
+func (s *scheduler) run(profile, mode string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	logging.Info(fmt.Sprintf("Starting scheduled %s backup of profile %s ...", mode, profile))
+	start := time.Now()
+	err := runCommand(BackupCmd, "--profile", profile, "--dn-backup-mode", mode, "--assume-yes")
+	s.metrics.lastDuration.WithLabelValues(profile, mode).Set(time.Since(start).Seconds())
+	if err != nil {
+		logging.Error(fmt.Sprintf("Scheduled %s backup of profile %s failed, err:%s", mode, profile, err.Error()))
+		s.metrics.runs.WithLabelValues(profile, mode, resultFailure).Inc()
+		return
+	}
+	s.metrics.runs.WithLabelValues(profile, mode, resultSuccess).Inc()
+	s.metrics.lastSuccess.WithLabelValues(profile, mode).Set(float64(time.Now().Unix()))
+	logging.Info(fmt.Sprintf("Scheduled %s backup of profile %s finished in %s", mode, profile, time.Since(start).Round(time.Second)))
+
+	if !hasRetention(s.profiles[profile]) {
+		return
+	}
+	if err := runCommand(PruneCmd, "--profile", profile, "--assume-yes"); err != nil {
+		logging.Error(fmt.Sprintf("Prune backups of profile %s failed, err:%s", profile, err.Error()))
+		s.metrics.pruneFailures.WithLabelValues(profile).Inc()
+	}
+}
+
+func hasRetention(profile map[string]string) bool {
+	for k := range profile {
+		if strings.HasPrefix(k, "retain-") {
+			return true
+		}
+	}
+	return false
+}
+
+// runCommand run a command in process as it is run on command line. The flags are bound to package vars
+// shared by commands, so they are reset to default before parsing.
+func runCommand(cmd *cobra.Command, args ...string) error {
+	var err error
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if setErr := f.Value.Set(f.DefValue); setErr != nil && err == nil {
+			err = setErr
+		}
+		f.Changed = false
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.ParseFlags(args); err != nil {
+		return err
+	}
+	if err := cmd.Root().PersistentPreRunE(cmd, nil); err != nil {
+		return err
+	}
+	return cmd.RunE(cmd, nil)
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"bou.ke/monkey"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Serve", func() {
+	var (
+		s     *scheduler
+		calls [][]string
+	)
+
+	BeforeEach(func() {
+		calls = nil
+		s = &scheduler{
+			profiles: map[string]map[string]string{
+				"prod": {"host": "10.0.0.1", "retain-full": "4"},
+				"test": {"host": "127.0.0.1"},
+			},
+			metrics: newServeMetrics(prometheus.NewRegistry()),
+		}
+	})
+	AfterEach(func() {
+		monkey.UnpatchAll()
+	})
+
+	patchRunCommand := func(backupErr error) {
+		monkey.Patch(runCommand, func(cmd *cobra.Command, args ...string) error {
+			calls = append(calls, append([]string{cmd.Name()}, args...))
+			if cmd == BackupCmd {
+				return backupErr
+			}
+			return nil
+		})
+	}
+
+	It("register schedules", func() {
+		c := cron.New()
+		Expect(s.register(c, map[string]*pkg.Schedule{
+			"prod": {Full: "0 2 * * 0", Ptrack: "0 * * * *"},
+			"test": {Full: "@daily"},
+		})).To(Succeed())
+		Expect(c.Entries()).To(HaveLen(3))
+
+		Expect(s.register(cron.New(), map[string]*pkg.Schedule{
+			"prod": {Ptrack: "every hour"},
+		})).NotTo(Succeed())
+	})
+
+	It("backup and prune", func() {
+		patchRunCommand(nil)
+
+		s.run("prod", "PTRACK")
+		Expect(calls).To(Equal([][]string{
+			{"backup", "--profile", "prod", "--dn-backup-mode", "PTRACK", "--assume-yes"},
+			{"prune", "--profile", "prod", "--assume-yes"},
+		}))
+		Expect(testutil.ToFloat64(s.metrics.runs.WithLabelValues("prod", "PTRACK", resultSuccess))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(s.metrics.lastSuccess.WithLabelValues("prod", "PTRACK"))).To(BeNumerically(">", 0))
+
+		// no retention policy in profile test
+		calls = nil
+		s.run("test", "FULL")
+		Expect(calls).To(HaveLen(1))
+	})
+
+	It("backup failed", func() {
+		patchRunCommand(errors.New("lock for backup failed"))
+
+		s.run("prod", "FULL")
+		Expect(calls).To(HaveLen(1))
+		Expect(testutil.ToFloat64(s.metrics.runs.WithLabelValues("prod", "FULL", resultFailure))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(s.metrics.lastSuccess.WithLabelValues("prod", "FULL"))).To(Equal(float64(0)))
+	})
+
+	It("run command with flags reset", func() {
+		var (
+			host string
+			port uint16
+		)
+		root := &cobra.Command{
+			Use:               "gs_pitr",
+			PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+		}
+		cmd := &cobra.Command{
+			Use:  "backup",
+			RunE: func(cmd *cobra.Command, args []string) error { return nil },
+		}
+		root.AddCommand(cmd)
+		cmd.Flags().StringVarP(&host, "host", "H", "", "")
+		cmd.Flags().Uint16VarP(&port, "port", "P", 3307, "")
+
+		Expect(runCommand(cmd, "--host", "10.0.0.1", "--port", "3308")).To(Succeed())
+		Expect(host).To(Equal("10.0.0.1"))
+		Expect(port).To(Equal(uint16(3308)))
+
+		Expect(runCommand(cmd, "--host", "127.0.0.1")).To(Succeed())
+		Expect(host).To(Equal("127.0.0.1"))
+		Expect(port).To(Equal(uint16(3307)))
+		Expect(cmd.Flags().Changed("port")).To(BeFalse())
+	})
+})
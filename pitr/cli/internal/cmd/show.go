@@ -21,92 +21,98 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
 	"github.com/spf13/cobra"
 )
 
+// ShowChain show backup records as chains
+var ShowChain bool
+
 var ShowCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Show backup history",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if CSN != "" && RecordID != "" {
-			logging.Error("Please specify only one of csn and record id")
-			return
+			return xerr.NewCodeErr(xerr.ExitUsage, "Please specify only one of csn and record id")
 		}
 
-		if err := show(); err != nil {
-			logging.Error(err.Error())
-		}
+		return show()
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(ShowCmd)
+	addProfileFlags(ShowCmd)
 	ShowCmd.Flags().StringVarP(&CSN, "csn", "", "", "commit sequence number")
 	ShowCmd.Flags().StringVarP(&RecordID, "id", "", "", "backup record id")
+	ShowCmd.Flags().BoolVarP(&ShowChain, "chain", "", false, "show backup records as chains of full and incremental backups, ignored in json output")
+	addStorageFlags(ShowCmd)
 }
 
 func show() error {
-	ls, err := pkg.NewLocalStorage(pkg.DefaultRootDir())
+	ls, err := newStorage()
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("connect to local storage failed, err:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("connect to storage failed, err:%s", err.Error()))
 	}
 
 	// show backup record by csn
 	if CSN != "" {
 		bak, err := ls.ReadByCSN(CSN)
 		if err != nil {
-			return xerr.NewCliErr(fmt.Sprintf("read backup record failed, err:%s", err.Error()))
+			return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("read backup record failed, err:%s", err.Error()))
 		}
 		if bak == nil {
-			fmt.Println("Didn't find backup record by csn: ", CSN)
-			return nil
+			return xerr.NewCodeErr(xerr.ExitNotFound, fmt.Sprintf("Didn't find backup record by csn: %s", CSN))
 		}
 
-		if err := formatRecord([]*model.LsBackup{bak}); err != nil {
-			return err
-		}
-		return nil
+		return formatRecord([]*model.LsBackup{bak})
 	}
 
 	// show backup record by id
 	if RecordID != "" {
 		bak, err := ls.ReadByID(RecordID)
 		if err != nil {
-			return xerr.NewCliErr(fmt.Sprintf("read backup record failed, err:%s", err.Error()))
+			return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("read backup record failed, err:%s", err.Error()))
 		}
 		if bak == nil {
-			fmt.Println("Didn't find backup record by record id: ", RecordID)
-			return nil
+			return xerr.NewCodeErr(xerr.ExitNotFound, fmt.Sprintf("Didn't find backup record by record id: %s", RecordID))
 		}
 
-		if err := formatRecord([]*model.LsBackup{bak}); err != nil {
-			return err
-		}
-		return nil
+		return formatRecord([]*model.LsBackup{bak})
 	}
 
 	// show all backup record
 	backupList, err := ls.ReadAll()
 	if err != nil {
-		return xerr.NewCliErr(fmt.Sprintf("read backup record failed, err:%s", err.Error()))
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("read backup record failed, err:%s", err.Error()))
+	}
+
+	if Output == outputJSON {
+		setResult(newRecordResults(backupList))
+		return nil
 	}
 
 	if len(backupList) == 0 {
-		fmt.Println("Didn't find any backup record.")
+		fmt.Fprintln(textOut, "Didn't find any backup record.")
 		return nil
 	}
 
-	if err := formatRecord(backupList); err != nil {
-		return err
+	if ShowChain {
+		formatChains(backupList)
+		return nil
 	}
 
-	return nil
+	return formatRecord(backupList)
 }
+
 func formatRecord(backups []*model.LsBackup, mode ...string) error {
+	if Output == outputJSON {
+		setResult(newRecordResults(backups))
+		return nil
+	}
+
 	var m string
 
 	if len(mode) == 0 {
@@ -135,6 +141,6 @@ func formatRecordJSON(backups []*model.LsBackup) error {
 		}
 		ds = append(ds, string(data))
 	}
-	fmt.Println(ds)
+	fmt.Fprintln(textOut, ds)
 	return nil
 }
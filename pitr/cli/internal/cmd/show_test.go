@@ -25,6 +25,7 @@ import (
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
 	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/mocks"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -137,7 +138,7 @@ var _ = Describe("Show", func() {
 			CSN = "csn"
 			RecordID = ""
 			ls.EXPECT().ReadByCSN(gomock.Any()).Return(nil, nil)
-			Expect(show()).To(BeNil())
+			Expect(xerr.ExitCode(show())).To(Equal(xerr.ExitNotFound))
 		})
 
 		It("get by id", func() {
@@ -156,7 +157,7 @@ var _ = Describe("Show", func() {
 			CSN = ""
 			RecordID = "record-id"
 			ls.EXPECT().ReadByID(gomock.Any()).Return(nil, nil)
-			Expect(show()).To(BeNil())
+			Expect(xerr.ExitCode(show())).To(Equal(xerr.ExitNotFound))
 		})
 	})
 
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Storage where the backup records and backup sets are kept, local or s3
+	Storage string
+	// StorageEndpoint s3 compatible object storage endpoint
+	StorageEndpoint string
+	// StorageRegion s3 region
+	StorageRegion string
+	// StorageBucket s3 bucket
+	StorageBucket string
+	// StorageAccessKey s3 access key
+	StorageAccessKey string
+	// StorageSecretKey s3 secret key
+	StorageSecretKey string
+	// StoragePrefix the prefix of all objects in the bucket
+	StoragePrefix string
+	// KeyFile the master key file to encrypt credentials in backup records
+	KeyFile string
+)
+
+const (
+	storageLocal = "local"
+	storageS3    = "s3"
+
+	// envPassphrase derive the master key from the passphrase instead of the key file if it is set
+	envPassphrase = "GS_PITR_PASSPHRASE"
+)
+
+func addStorageFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&Storage, "storage", "", storageLocal, "where to keep backup records and backup sets, local or s3")
+	cmd.Flags().StringVarP(&StorageEndpoint, "storage-endpoint", "", "", "s3 compatible object storage endpoint, e.g. http://127.0.0.1:9000")
+	cmd.Flags().StringVarP(&StorageRegion, "storage-region", "", "us-east-1", "s3 region")
+	cmd.Flags().StringVarP(&StorageBucket, "storage-bucket", "", "", "s3 bucket")
+	cmd.Flags().StringVarP(&StorageAccessKey, "storage-access-key", "", "", "s3 access key")
+	cmd.Flags().StringVarP(&StorageSecretKey, "storage-secret-key", "", "", "s3 secret key")
+	cmd.Flags().StringVarP(&StoragePrefix, "storage-prefix", "", "gs_pitr", "prefix of the objects in the bucket")
+	cmd.Flags().StringVarP(&KeyFile, "key-file", "", pkg.DefaultKeyFile(), fmt.Sprintf("master key file to encrypt credentials in backup records, ignored if env %s is set", envPassphrase))
+}
+
+// newStorage return the storage of backup records, the credentials in records are encrypted transparently.
+func newStorage() (pkg.ILocalStorage, error) {
+	ls, err := newRawStorage()
+	if err != nil {
+		return nil, err
+	}
+	return pkg.NewEncryptedStorage(ls, KeyFile, os.Getenv(envPassphrase)), nil
+}
+
+// newRawStorage return the storage of backup records according to --storage, the records are read as is.
+func newRawStorage() (pkg.ILocalStorage, error) {
+	switch Storage {
+	case "", storageLocal:
+		return pkg.NewLocalStorage(pkg.DefaultRootDir())
+	case storageS3:
+		return pkg.NewS3Storage(storageConfig(StoragePrefix))
+	default:
+		return nil, xerr.NewCliErr(fmt.Sprintf("unknown storage:%s, only local and s3 are supported", Storage))
+	}
+}
+
+func storageConfig(prefix string) *model.StorageConfig {
+	return &model.StorageConfig{
+		Endpoint:  StorageEndpoint,
+		Region:    StorageRegion,
+		Bucket:    StorageBucket,
+		AccessKey: StorageAccessKey,
+		SecretKey: StorageSecretKey,
+		Prefix:    prefix,
+	}
+}
+
+// backupSetIn every data node has its own prefix, because all of them use the same instance name.
+func backupSetIn(sn *model.StorageNode, dn *model.DataNode) *model.BackupSetIn {
+	return &model.BackupSetIn{
+		DBPort:       sn.Port,
+		DBName:       sn.Database,
+		Username:     sn.Username,
+		Password:     sn.Password,
+		DnBackupPath: BackupPath,
+		DnBackupID:   dn.BackupID,
+		Instance:     instanceOf(sn),
+		Storage:      storageConfig(path.Join(StoragePrefix, "backup_sets", fmt.Sprintf("%s_%d", sn.IP, sn.Port))),
+	}
+}
+
+// pushBackupSets push the backup sets of all data nodes to the remote storage.
+func pushBackupSets(lsBackup *model.LsBackup) error {
+	return forEachBackupSet(lsBackup, "push", func(as pkg.IAgentServer, in *model.BackupSetIn) error {
+		return as.PushBackupSet(in)
+	})
+}
+
+// pullBackupSets pull the backup sets of all data nodes back to their backup path before restore.
+func pullBackupSets(lsBackup *model.LsBackup) error {
+	return forEachBackupSet(lsBackup, "pull", func(as pkg.IAgentServer, in *model.BackupSetIn) error {
+		return as.PullBackupSet(in)
+	})
+}
+
+func forEachBackupSet(lsBackup *model.LsBackup, action string, fn func(as pkg.IAgentServer, in *model.BackupSetIn) error) error {
+	dataNodeMap := make(map[string]*model.DataNode)
+	for _, dn := range lsBackup.DnList {
+		dataNodeMap[dn.Key()] = dn
+	}
+
+	for _, sn := range lsBackup.SsBackup.StorageNodes {
+		dn, ok := dataNodeMap[sn.Key()]
+		if !ok {
+			return xerr.NewCliErr(fmt.Sprintf("data node %s:%d not found in backup info", sn.IP, sn.Port))
+		}
+
+		logging.Info(fmt.Sprintf("%s backup set of data node %s:%d ...", action, sn.IP, sn.Port))
+		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
+		if err := fn(as, backupSetIn(sn, dn)); err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("%s backup set of data node %s:%d failed:%s", action, sn.IP, sn.Port, err.Error()))
+		}
+	}
+	return nil
+}
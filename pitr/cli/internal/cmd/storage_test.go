@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"bou.ke/monkey"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Storage", func() {
+	AfterEach(func() {
+		Storage, StoragePrefix = storageLocal, "gs_pitr"
+	})
+
+	It("unknown storage", func() {
+		Storage = "ftp"
+		_, err := newStorage()
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("backup set of every data node has its own prefix", func() {
+		in := backupSetIn(&model.StorageNode{IP: "127.0.0.1", Port: 5432}, &model.DataNode{BackupID: "RUS2A1"})
+		Expect(in.DnBackupID).To(Equal("RUS2A1"))
+		Expect(in.Storage.Prefix).To(Equal("gs_pitr/backup_sets/127.0.0.1_5432"))
+	})
+
+	Context("push and pull backup sets", func() {
+		var (
+			as       *mock_pkg.MockIAgentServer
+			lsbackup = &model.LsBackup{
+				DnList: []*model.DataNode{
+					{IP: "127.0.0.1", Port: 5432, BackupID: "RUS2A1"},
+					{IP: "127.0.0.2", Port: 5432, BackupID: "RUS2A2"},
+				},
+				SsBackup: &model.SsBackup{
+					StorageNodes: []*model.StorageNode{
+						{IP: "127.0.0.1", Port: 5432},
+						{IP: "127.0.0.2", Port: 5432},
+					},
+				},
+			}
+		)
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			as = mock_pkg.NewMockIAgentServer(ctrl)
+			monkey.Patch(pkg.NewAgentServer, func(_ string) pkg.IAgentServer {
+				return as
+			})
+		})
+		AfterEach(func() {
+			ctrl.Finish()
+			monkey.UnpatchAll()
+		})
+
+		It("push backup sets", func() {
+			as.EXPECT().PushBackupSet(gomock.Any()).Return(nil).Times(2)
+			Expect(pushBackupSets(lsbackup)).To(Succeed())
+		})
+
+		It("pull backup sets failed", func() {
+			as.EXPECT().PullBackupSet(gomock.Any()).Return(errors.New("not found"))
+			Expect(pullBackupSets(lsbackup)).NotTo(Succeed())
+		})
+	})
+})
@@ -0,0 +1,190 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/logging"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// VerifyRestore test restore the backup into a scratch pgdata on every data node
+	VerifyRestore bool
+	// ScratchPort the port of the scratch openGauss, a spare port is picked by agent server if it is 0
+	ScratchPort uint16
+	// Schemas the schemas expected in the restored data
+	Schemas []string
+)
+
+var VerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a backup is restorable without touching the running cluster",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := setupAgentAuth(); err != nil {
+			return xerr.NewCodeErr(xerr.ExitUsage, err.Error())
+		}
+
+		return verify()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(VerifyCmd)
+
+	VerifyCmd.Flags().StringVarP(&Host, "host", "H", "", "ss-proxy hostname or ip, used to access the agent server of storage nodes registered as 127.0.0.1")
+	VerifyCmd.Flags().StringVarP(&BackupPath, "dn-backup-path", "B", "", "openGauss data backup path")
+	_ = VerifyCmd.MarkFlagRequired("dn-backup-path")
+	VerifyCmd.Flags().Uint16VarP(&AgentPort, "agent-port", "a", 443, "agent server port")
+	_ = VerifyCmd.MarkFlagRequired("agent-port")
+	addAgentAuthFlags(VerifyCmd)
+	VerifyCmd.Flags().StringVarP(&RecordID, "id", "", "", "backup record id")
+	_ = VerifyCmd.MarkFlagRequired("id")
+
+	VerifyCmd.Flags().BoolVarP(&VerifyRestore, "restore", "", false, "test restore the backup into a scratch pgdata and check the schemas on it")
+	VerifyCmd.Flags().Uint16VarP(&ScratchPort, "scratch-port", "", 0, "port of the scratch openGauss, a spare port is picked if not set")
+	VerifyCmd.Flags().StringSliceVarP(&Schemas, "schemas", "", []string{"public"}, "schemas expected in the restored data")
+	addStorageFlags(VerifyCmd)
+}
+
+func verify() error {
+	ls, err := newStorage()
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("new storage failed, err:%s", err.Error()))
+	}
+
+	bak, err := ls.ReadByID(RecordID)
+	if err != nil {
+		return xerr.NewCodeErr(xerr.ExitNotFound, fmt.Sprintf("read backup record by id failed, err:%s", err.Error()))
+	}
+	if bak.SsBackup == nil || len(bak.DnList) == 0 {
+		return xerr.NewCliErr(fmt.Sprintf("backup record [%s] has no data node", RecordID))
+	}
+
+	logging.Info("Checking agent server status...")
+	if available := checkAgentServerStatus(bak); !available {
+		return xerr.NewCodeErr(xerr.ExitAgentServer, "one or more agent server are not available.")
+	}
+
+	if Storage == storageS3 {
+		logging.Info("Pulling backup sets from remote storage...")
+		if err := pullBackupSets(bak); err != nil {
+			return xerr.NewCodeErr(xerr.ExitStorage, fmt.Sprintf("pull backup sets failed:%s", err.Error()))
+		}
+	}
+
+	logging.Info("Verifying backup...")
+	if ok := verifyDataNodes(bak); !ok {
+		return xerr.NewCliErr(fmt.Sprintf("backup record [%s] is not restorable", RecordID))
+	}
+	logging.Info(fmt.Sprintf("Backup record [%s] is verified", RecordID))
+	return nil
+}
+
+// verifyDataNodes verify the backup on every data node one by one, the scratch openGauss may be heavy for the host.
+func verifyDataNodes(lsBackup *model.LsBackup) bool {
+	dataNodeMap := make(map[string]*model.DataNode)
+	for _, dn := range lsBackup.DnList {
+		dataNodeMap[dn.Key()] = dn
+	}
+
+	statusList := make([]*model.VerifyNodeStatus, 0, len(lsBackup.SsBackup.StorageNodes))
+	ok := true
+	for _, sn := range lsBackup.SsBackup.StorageNodes {
+		status := &model.VerifyNodeStatus{IP: sn.IP, Port: sn.Port}
+		statusList = append(statusList, status)
+
+		dn, exist := dataNodeMap[sn.Key()]
+		if !exist {
+			status.Err = "data node not found in backup info"
+			ok = false
+			continue
+		}
+
+		as := pkg.NewAgentServer(fmt.Sprintf("%s:%d", convertLocalhost(sn.IP), AgentPort))
+		out, err := verifyDataNode(as, &model.VerifyIn{
+			DBPort:       sn.Port,
+			DBName:       sn.Database,
+			Username:     sn.Username,
+			Password:     sn.Password,
+			Instance:     instanceOf(sn),
+			DnBackupPath: BackupPath,
+			DnBackupID:   dn.BackupID,
+			Restore:      VerifyRestore,
+			ScratchPort:  ScratchPort,
+			Schemas:      Schemas,
+		})
+		if err != nil {
+			status.Err = err.Error()
+			ok = false
+			continue
+		}
+		status.Out = out
+		if !out.OK() {
+			status.Err = out.Reason
+			ok = false
+		}
+	}
+
+	setResult(statusList)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(textOut)
+	t.SetTitle("Backup Verification")
+	t.AppendHeader(table.Row{"#", "Data Node IP", "Data Node Port", "Validate", "Restore", "Check Schema", "Reason"})
+
+	for i, s := range statusList {
+		validate, restore, checkSchema := model.VerifyStatusFailed, model.VerifyStatusSkipped, model.VerifyStatusSkipped
+		if s.Out != nil {
+			validate, restore, checkSchema = s.Out.Validate, s.Out.Restore, s.Out.CheckSchema
+		}
+		t.AppendRow([]interface{}{i + 1, s.IP, s.Port, validate, restore, checkSchema, s.Err})
+		t.AppendSeparator()
+	}
+
+	t.Render()
+	return ok
+}
+
+// verifyDataNode submit the verify job to agent server and wait for it finished.
+func verifyDataNode(as pkg.IAgentServer, in *model.VerifyIn) (*model.VerifyOut, error) {
+	job, err := as.Verify(in)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err = waitJob(as, job, nil)
+	if err != nil {
+		return nil, err
+	}
+	if job.State != model.JobStateSucceeded {
+		return nil, xerr.NewCliErr(job.Error)
+	}
+
+	out := &model.VerifyOut{}
+	if err := json.Unmarshal(job.Result, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+
+	"bou.ke/monkey"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg"
+	mock_pkg "github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/mocks"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("verify", func() {
+	var (
+		ctrl *gomock.Controller
+		as   *mock_pkg.MockIAgentServer
+		bak  = &model.LsBackup{
+			Info: &model.BackupMetaInfo{ID: "backup-id"},
+			DnList: []*model.DataNode{
+				{IP: "127.0.0.1", Port: 5432, BackupID: "dn-backup-1"},
+				{IP: "127.0.0.2", Port: 5432, BackupID: "dn-backup-2"},
+			},
+			SsBackup: &model.SsBackup{
+				StorageNodes: []*model.StorageNode{
+					{IP: "127.0.0.1", Port: 5432},
+					{IP: "127.0.0.2", Port: 5432},
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		as = mock_pkg.NewMockIAgentServer(ctrl)
+		monkey.Patch(pkg.NewAgentServer, func(_ string) pkg.IAgentServer { return as })
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		monkey.UnpatchAll()
+		VerifyRestore = false
+	})
+
+	It("all data nodes verified", func() {
+		VerifyRestore = true
+		as.EXPECT().Verify(gomock.Any()).DoAndReturn(func(in *model.VerifyIn) (*model.Job, error) {
+			Expect(in.Restore).To(BeTrue())
+			Expect(in.DnBackupID).To(HavePrefix("dn-backup-"))
+			return verifyJob(&model.VerifyOut{Validate: "OK", Restore: "OK", CheckSchema: "OK", ScratchPort: 15432}), nil
+		}).Times(2)
+		Expect(verifyDataNodes(bak)).To(BeTrue())
+	})
+
+	It("validate only", func() {
+		as.EXPECT().Verify(gomock.Any()).Return(verifyJob(&model.VerifyOut{Validate: "OK", Restore: "Skipped", CheckSchema: "Skipped"}), nil).Times(2)
+		Expect(verifyDataNodes(bak)).To(BeTrue())
+	})
+
+	It("one data node failed", func() {
+		as.EXPECT().Verify(gomock.Any()).Return(verifyJob(&model.VerifyOut{Validate: "OK", Restore: "OK", CheckSchema: "OK"}), nil)
+		as.EXPECT().Verify(gomock.Any()).Return(verifyJob(&model.VerifyOut{Validate: "Failed", Restore: "Skipped", CheckSchema: "Skipped", Reason: "corrupted"}), nil)
+		Expect(verifyDataNodes(bak)).To(BeFalse())
+	})
+
+	It("verify job failed", func() {
+		as.EXPECT().Verify(gomock.Any()).Return(&model.Job{ID: "job-id", State: model.JobStateFailed, Error: "agent restarted"}, nil).Times(2)
+		Expect(verifyDataNodes(bak)).To(BeFalse())
+	})
+
+	It("agent server unavailable", func() {
+		as.EXPECT().Verify(gomock.Any()).Return(nil, errors.New("timeout")).Times(2)
+		Expect(verifyDataNodes(bak)).To(BeFalse())
+	})
+})
+
+func verifyJob(out *model.VerifyOut) *model.Job {
+	result, _ := json.Marshal(out)
+	return &model.Job{ID: "job-id", Kind: "verify", State: model.JobStateSucceeded, Result: result}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
@@ -36,16 +37,34 @@ type agentServer struct {
 	_apiShowList    string
 	_apiDiskspace   string
 	_apiHealthCheck string
+
+	_apiEnableArchive string
+	_apiShowArchive   string
+
+	_apiPushBackupSet string
+	_apiPullBackupSet string
+
+	_apiVerify string
+
+	_apiJob    string
+	_apiJobLog string
 }
 
 type IAgentServer interface {
 	CheckStatus(in *model.HealthCheckIn) error
-	Backup(in *model.BackupIn) (string, error)
+	Backup(in *model.BackupIn) (*model.BackupOut, error)
 	DeleteBackup(in *model.DeleteBackupIn) error
-	Restore(in *model.RestoreIn) error
+	Restore(in *model.RestoreIn) (*model.Job, error)
 	ShowDetail(in *model.ShowDetailIn) (*model.BackupInfo, error)
 	ShowList(in *model.ShowListIn) ([]model.BackupInfo, error)
 	ShowDiskSpace(in *model.DiskSpaceIn) (*model.DiskSpaceInfo, error)
+	EnableArchive(in *model.ArchiveIn) error
+	ShowArchive(in *model.ArchiveIn) (*model.ArchiveStatus, error)
+	PushBackupSet(in *model.BackupSetIn) error
+	PullBackupSet(in *model.BackupSetIn) error
+	Verify(in *model.VerifyIn) (*model.Job, error)
+	ShowJob(id string) (*model.Job, error)
+	FollowJobLog(id string, from int, fn func(line string)) error
 }
 
 var _ IAgentServer = (*agentServer)(nil)
@@ -60,6 +79,17 @@ func NewAgentServer(addr string) IAgentServer {
 		_apiShowList:    "/api/show/list",
 		_apiDiskspace:   "/api/diskspace",
 		_apiHealthCheck: "/api/healthz",
+
+		_apiEnableArchive: "/api/archive/enable",
+		_apiShowArchive:   "/api/archive/show",
+
+		_apiPushBackupSet: "/api/backup/push",
+		_apiPullBackupSet: "/api/backup/pull",
+
+		_apiVerify: "/api/verify",
+
+		_apiJob:    "/api/jobs/%s",
+		_apiJobLog: "/api/jobs/%s/log",
 	}
 }
 
@@ -76,7 +106,8 @@ func (as *agentServer) CheckStatus(in *model.HealthCheckIn) error {
 	return nil
 }
 
-func (as *agentServer) Backup(in *model.BackupIn) (string, error) {
+// Backup return the backup id and the job which is finished when the backup is done.
+func (as *agentServer) Backup(in *model.BackupIn) (*model.BackupOut, error) {
 	url := fmt.Sprintf("%s%s", as.addr, as._apiBackup)
 
 	out := &model.BackupOutResp{}
@@ -84,33 +115,33 @@ func (as *agentServer) Backup(in *model.BackupIn) (string, error) {
 	r.Body(in)
 
 	if err := r.Send(out); err != nil {
-		return "", xerr.NewUnknownErr(url, in, out, err)
+		return nil, xerr.NewUnknownErr(url, in, out, err)
 	}
 
 	if out.Code != 0 {
-		return "", xerr.NewAgentServerErr(out.Code, out.Msg)
+		return nil, xerr.NewAgentServerErr(out.Code, out.Msg)
 	}
 
-	return out.Data.ID, nil
+	return &out.Data, nil
 }
 
-// nolint:dupl
-func (as *agentServer) Restore(in *model.RestoreIn) error {
+// Restore return the job of restore, the result of job is the position that data node actually replayed to if a recovery target is specified.
+func (as *agentServer) Restore(in *model.RestoreIn) (*model.Job, error) {
 	url := fmt.Sprintf("%s%s", as.addr, as._apiRestore)
 
-	out := &model.RestoreResp{}
+	out := &model.JobResp{}
 	r := httputils.NewRequest(context.Background(), http.MethodPost, url)
 	r.Body(in)
 
 	if err := r.Send(out); err != nil {
-		return xerr.NewUnknownErr(url, in, out, err)
+		return nil, xerr.NewUnknownErr(url, in, out, err)
 	}
 
 	if out.Code != 0 {
-		return xerr.NewAgentServerErr(out.Code, out.Msg)
+		return nil, xerr.NewAgentServerErr(out.Code, out.Msg)
 	}
 
-	return nil
+	return out.Data, nil
 }
 
 func (as *agentServer) ShowDetail(in *model.ShowDetailIn) (*model.BackupInfo, error) {
@@ -185,3 +216,120 @@ func (as *agentServer) DeleteBackup(in *model.DeleteBackupIn) error {
 
 	return nil
 }
+
+// EnableArchive turn on wal archiving of the data node toward the backup path
+// nolint:dupl
+func (as *agentServer) EnableArchive(in *model.ArchiveIn) error {
+	url := fmt.Sprintf("%s%s", as.addr, as._apiEnableArchive)
+
+	out := &model.EnableArchiveResp{}
+	r := httputils.NewRequest(context.Background(), http.MethodPost, url)
+	r.Body(in)
+
+	if err := r.Send(out); err != nil {
+		return xerr.NewUnknownErr(url, in, out, err)
+	}
+
+	if out.Code != 0 {
+		return xerr.NewAgentServerErr(out.Code, out.Msg)
+	}
+
+	return nil
+}
+
+// ShowArchive get the wal archiving status of the data node
+func (as *agentServer) ShowArchive(in *model.ArchiveIn) (*model.ArchiveStatus, error) {
+	url := fmt.Sprintf("%s%s", as.addr, as._apiShowArchive)
+
+	out := &model.ArchiveStatusResp{}
+	r := httputils.NewRequest(context.Background(), http.MethodPost, url)
+	r.Body(in)
+
+	if err := r.Send(out); err != nil {
+		return nil, xerr.NewUnknownErr(url, in, out, err)
+	}
+
+	if out.Code != 0 {
+		return nil, xerr.NewAgentServerErr(out.Code, out.Msg)
+	}
+
+	return &out.Data, nil
+}
+
+// PushBackupSet upload the backup set of the data node to the remote storage
+func (as *agentServer) PushBackupSet(in *model.BackupSetIn) error {
+	return as.sendBackupSet(as._apiPushBackupSet, in)
+}
+
+// PullBackupSet download the backup set of the data node from the remote storage
+func (as *agentServer) PullBackupSet(in *model.BackupSetIn) error {
+	return as.sendBackupSet(as._apiPullBackupSet, in)
+}
+
+func (as *agentServer) sendBackupSet(api string, in *model.BackupSetIn) error {
+	url := fmt.Sprintf("%s%s", as.addr, api)
+
+	out := &model.BackupSetResp{}
+	r := httputils.NewRequest(context.Background(), http.MethodPost, url)
+	r.Body(in)
+
+	if err := r.Send(out); err != nil {
+		return xerr.NewUnknownErr(url, in, out, err)
+	}
+
+	if out.Code != 0 {
+		return xerr.NewAgentServerErr(out.Code, out.Msg)
+	}
+
+	return nil
+}
+
+// Verify validate the backup of the data node, and test restore it into a scratch pgdata if required, the result of job is model.VerifyOut
+func (as *agentServer) Verify(in *model.VerifyIn) (*model.Job, error) {
+	url := fmt.Sprintf("%s%s", as.addr, as._apiVerify)
+
+	out := &model.JobResp{}
+	r := httputils.NewRequest(context.Background(), http.MethodPost, url)
+	r.Body(in)
+
+	if err := r.Send(out); err != nil {
+		return nil, xerr.NewUnknownErr(url, in, out, err)
+	}
+
+	if out.Code != 0 {
+		return nil, xerr.NewAgentServerErr(out.Code, out.Msg)
+	}
+
+	return out.Data, nil
+}
+
+// ShowJob get the state, progress and result of the job
+func (as *agentServer) ShowJob(id string) (*model.Job, error) {
+	url := fmt.Sprintf("%s%s", as.addr, fmt.Sprintf(as._apiJob, id))
+
+	out := &model.JobResp{}
+	r := httputils.NewRequest(context.Background(), http.MethodGet, url)
+
+	if err := r.Send(out); err != nil {
+		return nil, xerr.NewUnknownErr(url, id, out, err)
+	}
+
+	if out.Code != 0 {
+		return nil, xerr.NewAgentServerErr(out.Code, out.Msg)
+	}
+
+	return out.Data, nil
+}
+
+// FollowJobLog call the fn with the output lines of the job from the offset, until the job is done
+func (as *agentServer) FollowJobLog(id string, from int, fn func(line string)) error {
+	url := fmt.Sprintf("%s%s", as.addr, fmt.Sprintf(as._apiJobLog, id))
+
+	r := httputils.NewRequest(context.Background(), http.MethodGet, url)
+	r.Query(map[string]string{"from": strconv.Itoa(from)})
+
+	if err := r.Stream(fn); err != nil {
+		return xerr.NewUnknownErr(url, id, nil, err)
+	}
+	return nil
+}
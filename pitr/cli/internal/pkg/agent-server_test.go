@@ -58,7 +58,7 @@ func TestAgentServer_Restore(t *testing.T) {
 	//Note:just for test api,you need map you own host.
 	as := NewAgentServer("http://agent-server:18080")
 
-	err := as.Restore(&model.RestoreIn{
+	_, err := as.Restore(&model.RestoreIn{
 		DBPort:       5432,
 		DBName:       "omm",
 		Username:     "og",
@@ -152,27 +152,39 @@ var _ = Describe("AgentServer", func() {
 		It("backup success", func() {
 			req.EXPECT().Send(gomock.Any()).Do(func(i *model.BackupOutResp) {
 				i.Data.ID = "backup-id"
+				i.Data.JobID = "job-id"
 			}).Return(nil)
 			as := NewAgentServer("http://agent-server:18080")
 			resp, err := as.Backup(&model.BackupIn{})
 			Expect(err).Should(BeNil())
-			Expect(resp).Should(Equal("backup-id"))
+			Expect(resp.ID).Should(Equal("backup-id"))
+			Expect(resp.JobID).Should(Equal("job-id"))
 		})
 	})
 
 	Context("restore", func() {
 		It("restore failed", func() {
 			req.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("error"))
-			err := as.Restore(&model.RestoreIn{})
+			_, err := as.Restore(&model.RestoreIn{})
 			Expect(err).ShouldNot(BeNil())
 		})
 		// restore success
 		It("restore success", func() {
 			req.EXPECT().Send(gomock.Any()).Return(nil)
-			err := as.Restore(&model.RestoreIn{})
+			_, err := as.Restore(&model.RestoreIn{})
 			Expect(err).Should(BeNil())
 		})
 
+		It("restore job submitted", func() {
+			req.EXPECT().Send(gomock.Any()).Do(func(i *model.JobResp) {
+				i.Data = &model.Job{ID: "job-id", Kind: "restore", State: model.JobStateRunning}
+			}).Return(nil)
+			job, err := as.Restore(&model.RestoreIn{RecoveryTarget: &model.RecoveryTarget{LSN: "0/5000028"}})
+			Expect(err).Should(BeNil())
+			Expect(job.ID).Should(Equal("job-id"))
+			Expect(job.Done()).Should(BeFalse())
+		})
+
 	})
 
 	Context("show detail", func() {
@@ -208,4 +220,90 @@ var _ = Describe("AgentServer", func() {
 			Expect(resp).Should(Equal([]model.BackupInfo{}))
 		})
 	})
+
+	Context("archive", func() {
+		It("enable archive failed", func() {
+			req.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("error"))
+			Expect(as.EnableArchive(&model.ArchiveIn{})).ShouldNot(BeNil())
+		})
+
+		It("enable archive success", func() {
+			req.EXPECT().Send(gomock.Any()).Return(nil)
+			Expect(as.EnableArchive(&model.ArchiveIn{})).Should(BeNil())
+		})
+
+		It("show archive success", func() {
+			req.EXPECT().Send(gomock.Any()).Do(func(i *model.ArchiveStatusResp) {
+				i.Data = model.ArchiveStatus{Broken: true}
+			}).Return(nil)
+			status, err := as.ShowArchive(&model.ArchiveIn{})
+			Expect(err).Should(BeNil())
+			Expect(status.Broken).Should(BeTrue())
+		})
+	})
+	Context("backup set", func() {
+		It("push backup set failed", func() {
+			req.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("error"))
+			Expect(as.PushBackupSet(&model.BackupSetIn{})).ShouldNot(BeNil())
+		})
+
+		It("pull backup set success", func() {
+			req.EXPECT().Send(gomock.Any()).Return(nil)
+			Expect(as.PullBackupSet(&model.BackupSetIn{})).Should(BeNil())
+		})
+	})
+})
+
+var _ = Describe("AgentServer job", func() {
+	var (
+		ctrl *gomock.Controller
+		req  *mock_httputils.MockIreq
+		as   IAgentServer
+		url  string
+	)
+	BeforeEach(func() {
+		as = NewAgentServer("http://agent-server:18080")
+		ctrl = gomock.NewController(GinkgoT())
+		req = mock_httputils.NewMockIreq(ctrl)
+		monkey.Patch(httputils.NewRequest, func(c context.Context, method, u string) httputils.Ireq {
+			url = u
+			return req
+		})
+	})
+	AfterEach(func() {
+		ctrl.Finish()
+		monkey.UnpatchAll()
+	})
+
+	It("show job failed", func() {
+		req.EXPECT().Send(gomock.Any()).Do(func(i *model.JobResp) {
+			i.Code = 10041
+			i.Msg = "job not found"
+		}).Return(nil)
+		_, err := as.ShowJob("job-id")
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("show job success", func() {
+		req.EXPECT().Send(gomock.Any()).Do(func(i *model.JobResp) {
+			i.Data = &model.Job{ID: "job-id", State: model.JobStateSucceeded, Result: json.RawMessage(`{"replay_lsn":"0/5000028"}`)}
+		}).Return(nil)
+		job, err := as.ShowJob("job-id")
+		Expect(err).Should(BeNil())
+		Expect(url).Should(Equal("http://agent-server:18080/api/jobs/job-id"))
+		Expect(job.Done()).Should(BeTrue())
+	})
+
+	It("follow job log", func() {
+		req.EXPECT().Query(map[string]string{"from": "3"})
+		req.EXPECT().Stream(gomock.Any()).DoAndReturn(func(fn func(line string)) error {
+			fn("Progress: (1/2)")
+			return nil
+		})
+		var lines []string
+		err := as.FollowJobLog("job-id", 3, func(line string) { lines = append(lines, line) })
+		Expect(err).Should(BeNil())
+		Expect(url).Should(Equal("http://agent-server:18080/api/jobs/job-id/log"))
+		Expect(lines).Should(Equal([]string{"Progress: (1/2)"}))
+	})
 })
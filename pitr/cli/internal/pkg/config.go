@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+Config is the config file of gs_pitr, e.g.
+
+	current-profile: prod
+	profiles:
+	  prod:
+	    host: 10.0.0.1
+	    port: 3307
+	    username: root
+	    password-file: /home/omm/.gs_pitr/prod.password
+	    dn-backup-path: /home/omm/data
+	    agent-port: 443
+	    retain-full: 4
+	schedules:
+	  prod:
+	    full: "0 2 * * 0"
+	    ptrack: "0 * * * *"
+
+The keys of a profile are the flag names of commands, the keys not defined by a command are ignored by it.
+The schedules are used by `gs_pitr serve`, they are keyed by profile name.
+*/
+type Config struct {
+	// CurrentProfile is used if no profile is specified
+	CurrentProfile string                       `yaml:"current-profile"`
+	Profiles       map[string]map[string]string `yaml:"profiles"`
+	Schedules      map[string]*Schedule         `yaml:"schedules"`
+}
+
+// Schedule the cron expressions of the backups of a profile, e.g. "0 2 * * 0", an empty one is not scheduled.
+type Schedule struct {
+	Full   string `yaml:"full"`
+	Ptrack string `yaml:"ptrack"`
+}
+
+func DefaultConfigFile() string {
+	return filepath.Join(DefaultRootDir(), "config.yaml")
+}
+
+// LoadConfig return an empty config if the file does not exist.
+func LoadConfig(file string) (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("read config file %s failed, err:%s", file, err.Error()))
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("parse config file %s failed, err:%s", file, err.Error()))
+	}
+	for name := range cfg.Schedules {
+		if _, ok := cfg.Profiles[name]; !ok {
+			return nil, xerr.NewCliErr(fmt.Sprintf("profile %s of schedule not found in config file", name))
+		}
+	}
+	return cfg, nil
+}
+
+// Profile return the profile of the name, or the current profile if name is empty.
+// It returns nil if neither of them is set.
+func (c *Config) Profile(name string) (map[string]string, error) {
+	if name == "" {
+		name = c.CurrentProfile
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		return nil, xerr.NewCliErr(fmt.Sprintf("profile %s not found in config file", name))
+	}
+	return p, nil
+}
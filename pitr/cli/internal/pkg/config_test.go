@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	It("load profiles", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(file, []byte(`
+current-profile: prod
+profiles:
+  prod:
+    host: 10.0.0.1
+    port: 3307
+    dn-backup-path: /home/omm/data
+  test:
+    host: 127.0.0.1
+`), 0600)).To(Succeed())
+
+		cfg, err := LoadConfig(file)
+		Expect(err).To(BeNil())
+
+		p, err := cfg.Profile("")
+		Expect(err).To(BeNil())
+		Expect(p).To(Equal(map[string]string{"host": "10.0.0.1", "port": "3307", "dn-backup-path": "/home/omm/data"}))
+
+		p, err = cfg.Profile("test")
+		Expect(err).To(BeNil())
+		Expect(p).To(HaveKeyWithValue("host", "127.0.0.1"))
+
+		_, err = cfg.Profile("not-exist")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("load schedules", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(file, []byte(`
+profiles:
+  prod:
+    host: 10.0.0.1
+schedules:
+  prod:
+    full: "0 2 * * 0"
+    ptrack: "0 * * * *"
+`), 0600)).To(Succeed())
+
+		cfg, err := LoadConfig(file)
+		Expect(err).To(BeNil())
+		Expect(cfg.Schedules).To(HaveKeyWithValue("prod", &Schedule{Full: "0 2 * * 0", Ptrack: "0 * * * *"}))
+
+		Expect(os.WriteFile(file, []byte(`
+schedules:
+  prod:
+    full: "0 2 * * 0"
+`), 0600)).To(Succeed())
+		_, err = LoadConfig(file)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("config file not exist", func() {
+		cfg, err := LoadConfig(filepath.Join(GinkgoT().TempDir(), "config.yaml"))
+		Expect(err).To(BeNil())
+
+		p, err := cfg.Profile("")
+		Expect(err).To(BeNil())
+		Expect(p).To(BeNil())
+	})
+
+	It("invalid config file", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(file, []byte("profiles: [a"), 0600)).To(Succeed())
+
+		_, err := LoadConfig(file)
+		Expect(err).NotTo(BeNil())
+	})
+})
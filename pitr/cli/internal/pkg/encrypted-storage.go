@@ -0,0 +1,358 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+/*
+encryptedStorage encrypts the credentials of storage nodes and the cluster metadata before writing
+the backup records, and decrypts them transparently after reading.
+
+Every record has its own random data key, the data key is wrapped by the master key, which is
+read from the key file, or derived from the passphrase if it is set.
+Records written by older versions are in plaintext or have only the credentials encrypted, they are
+returned as is, and rewritten by the migrate command.
+*/
+type encryptedStorage struct {
+	ILocalStorage
+	keyFile    string
+	passphrase string
+
+	// derived master keys cached by salt, deriving a key from passphrase is slow on purpose
+	derivedKeys map[string][]byte
+}
+
+const (
+	// EncryptionVersion 2 encrypts the cluster metadata too, version 1 encrypts only the credentials.
+	EncryptionVersion   = 2
+	KeySourceFile       = "key_file"
+	KeySourcePassphrase = "passphrase"
+
+	_encryptedPrefix = "enc:"
+	_keyLen          = 32
+	_saltLen         = 16
+	_pbkdf2Iter      = 600000
+)
+
+func NewEncryptedStorage(ls ILocalStorage, keyFile, passphrase string) ILocalStorage {
+	return &encryptedStorage{
+		ILocalStorage: ls,
+		keyFile:       keyFile,
+		passphrase:    passphrase,
+		derivedKeys:   map[string][]byte{},
+	}
+}
+
+func DefaultKeyFile() string {
+	return filepath.Join(DefaultRootDir(), "secret.key")
+}
+
+func (es *encryptedStorage) WriteByJSON(name string, contents *model.LsBackup) error {
+	bak, err := es.encrypt(contents)
+	if err != nil {
+		return xerr.NewCliErr(fmt.Sprintf("encrypt backup record failed,err=%s", err))
+	}
+	return es.ILocalStorage.WriteByJSON(name, bak)
+}
+
+func (es *encryptedStorage) ReadAll() ([]*model.LsBackup, error) {
+	list, err := es.ILocalStorage.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range list {
+		if err := es.decrypt(v); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (es *encryptedStorage) ReadByID(id string) (*model.LsBackup, error) {
+	bak, err := es.ILocalStorage.ReadByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return bak, es.decrypt(bak)
+}
+
+func (es *encryptedStorage) ReadByCSN(csn string) (*model.LsBackup, error) {
+	bak, err := es.ILocalStorage.ReadByCSN(csn)
+	if err != nil {
+		return nil, err
+	}
+	return bak, es.decrypt(bak)
+}
+
+func (es *encryptedStorage) ReadByName(name string) (*model.LsBackup, error) {
+	bak, err := es.ILocalStorage.ReadByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return bak, es.decrypt(bak)
+}
+
+// encrypt return an encrypted copy, the contents are still used in plaintext by the caller.
+func (es *encryptedStorage) encrypt(contents *model.LsBackup) (*model.LsBackup, error) {
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return nil, err
+	}
+	bak := &model.LsBackup{}
+	if err := json.Unmarshal(data, bak); err != nil {
+		return nil, err
+	}
+	if bak.SsBackup == nil {
+		bak.Encryption = nil
+		return bak, nil
+	}
+
+	enc := &model.Encryption{Version: EncryptionVersion}
+	masterKey, err := es.newMasterKey(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, _keyLen)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	if enc.WrappedKey, err = sealAESGCM(masterKey, dataKey); err != nil {
+		return nil, err
+	}
+
+	for _, sn := range bak.SsBackup.StorageNodes {
+		if sn.Username, err = encryptField(dataKey, sn.Username); err != nil {
+			return nil, err
+		}
+		if sn.Password, err = encryptField(dataKey, sn.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	// the metadata holds the rules of logic databases, including the credentials of their storage units
+	if ci := bak.SsBackup.ClusterInfo; ci != nil {
+		md, err := json.Marshal(ci.MetaData)
+		if err != nil {
+			return nil, err
+		}
+		if enc.MetaData, err = sealAESGCM(dataKey, md); err != nil {
+			return nil, err
+		}
+		ci.MetaData = model.MetaData{}
+	}
+	bak.Encryption = enc
+	return bak, nil
+}
+
+func (es *encryptedStorage) decrypt(bak *model.LsBackup) error {
+	if bak == nil || bak.Encryption == nil {
+		return nil
+	}
+	if bak.Encryption.Version < 1 || bak.Encryption.Version > EncryptionVersion {
+		return xerr.NewCliErr(fmt.Sprintf("unsupported encryption version:%d", bak.Encryption.Version))
+	}
+
+	masterKey, err := es.masterKey(bak.Encryption)
+	if err != nil {
+		return err
+	}
+	dataKey, err := openAESGCM(masterKey, bak.Encryption.WrappedKey)
+	if err != nil {
+		return xerr.NewCliErr(fmt.Sprintf("unwrap data key failed, the key file or passphrase may be wrong,err=%s", err))
+	}
+
+	if bak.SsBackup != nil {
+		for _, sn := range bak.SsBackup.StorageNodes {
+			if sn.Username, err = decryptField(dataKey, sn.Username); err != nil {
+				return xerr.NewCliErr(fmt.Sprintf("decrypt username failed,err=%s", err))
+			}
+			if sn.Password, err = decryptField(dataKey, sn.Password); err != nil {
+				return xerr.NewCliErr(fmt.Sprintf("decrypt password failed,err=%s", err))
+			}
+		}
+	}
+
+	if bak.Encryption.MetaData != "" {
+		md, err := openAESGCM(dataKey, bak.Encryption.MetaData)
+		if err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("decrypt metadata failed,err=%s", err))
+		}
+		if bak.SsBackup == nil {
+			bak.SsBackup = &model.SsBackup{}
+		}
+		if bak.SsBackup.ClusterInfo == nil {
+			bak.SsBackup.ClusterInfo = &model.ClusterInfo{}
+		}
+		if err := json.Unmarshal(md, &bak.SsBackup.ClusterInfo.MetaData); err != nil {
+			return xerr.NewCliErr(fmt.Sprintf("unmarshal metadata failed,err=%s", err))
+		}
+	}
+	bak.Encryption = nil
+	return nil
+}
+
+// newMasterKey return the master key to encrypt a record, and fill the key source of the encryption.
+func (es *encryptedStorage) newMasterKey(enc *model.Encryption) ([]byte, error) {
+	if es.passphrase != "" {
+		salt := make([]byte, _saltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		enc.KeySource = KeySourcePassphrase
+		enc.Salt = base64.StdEncoding.EncodeToString(salt)
+		return es.masterKey(enc)
+	}
+
+	if err := es.initKeyFile(); err != nil {
+		return nil, err
+	}
+	enc.KeySource = KeySourceFile
+	return es.masterKey(enc)
+}
+
+func (es *encryptedStorage) masterKey(enc *model.Encryption) ([]byte, error) {
+	switch enc.KeySource {
+	case KeySourceFile:
+		return readKeyFile(es.keyFile)
+	case KeySourcePassphrase:
+		if es.passphrase == "" {
+			return nil, xerr.NewCliErr("the backup record is encrypted with a passphrase, please set the passphrase")
+		}
+		if key, ok := es.derivedKeys[enc.Salt]; ok {
+			return key, nil
+		}
+		salt, err := base64.StdEncoding.DecodeString(enc.Salt)
+		if err != nil {
+			return nil, xerr.NewCliErr(fmt.Sprintf("invalid salt,err=%s", err))
+		}
+		key := pbkdf2.Key([]byte(es.passphrase), salt, _pbkdf2Iter, _keyLen, sha256.New)
+		es.derivedKeys[enc.Salt] = key
+		return key, nil
+	default:
+		return nil, xerr.NewCliErr(fmt.Sprintf("unknown key source:%s", enc.KeySource))
+	}
+}
+
+// initKeyFile generate a random master key if the key file does not exist.
+func (es *encryptedStorage) initKeyFile() error {
+	if _, err := os.Stat(es.keyFile); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return xerr.NewCliErr(fmt.Sprintf("stat key file failed,err=%s", err))
+	}
+
+	key := make([]byte, _keyLen)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(es.keyFile), 0700); err != nil {
+		return xerr.NewCliErr(fmt.Sprintf("create key file dir failed,err=%s", err))
+	}
+	data := base64.StdEncoding.EncodeToString(key) + "\n"
+	if err := os.WriteFile(es.keyFile, []byte(data), 0600); err != nil {
+		return xerr.NewCliErr(fmt.Sprintf("write key file failed,err=%s", err))
+	}
+	return nil
+}
+
+func readKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("read key file failed,err=%s", err))
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != _keyLen {
+		return nil, xerr.NewCliErr(fmt.Sprintf("invalid key file:%s, it must be a base64 encoded %d bytes key", path, _keyLen))
+	}
+	return key, nil
+}
+
+func encryptField(key []byte, s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	ciphertext, err := sealAESGCM(key, []byte(s))
+	if err != nil {
+		return "", err
+	}
+	return _encryptedPrefix + ciphertext, nil
+}
+
+func decryptField(key []byte, s string) (string, error) {
+	if !strings.HasPrefix(s, _encryptedPrefix) {
+		return s, nil
+	}
+	plaintext, err := openAESGCM(key, strings.TrimPrefix(s, _encryptedPrefix))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// sealAESGCM encrypt with AES-256-GCM, return base64 of nonce and ciphertext.
+func sealAESGCM(key, plaintext []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+func openAESGCM(key []byte, s string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("encryptedStorage", func() {
+	var (
+		root string
+		ls   ILocalStorage
+	)
+
+	newBackup := func() *model.LsBackup {
+		return &model.LsBackup{
+			Info: &model.BackupMetaInfo{ID: "id-1", CSN: "2012"},
+			SsBackup: &model.SsBackup{
+				ClusterInfo: &model.ClusterInfo{
+					MetaData: model.MetaData{
+						Databases: map[string]string{"sharding_db": "password: Rule@123"},
+						Props:     "props",
+						Rules:     "rules",
+					},
+					SnapshotInfo: &model.SnapshotInfo{Csn: "2012"},
+				},
+				StorageNodes: []*model.StorageNode{
+					{IP: "127.0.0.1", Port: 5432, Username: "omm", Password: "Gauss@123"},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		root = GinkgoT().TempDir()
+		ls, err = NewLocalStorage(root)
+		Expect(err).To(BeNil())
+	})
+
+	It("encrypt with key file", func() {
+		keyFile := filepath.Join(root, "secret.key")
+		es := NewEncryptedStorage(ls, keyFile, "")
+
+		bak := newBackup()
+		Expect(es.WriteByJSON("a.json", bak)).To(Succeed())
+		// the caller still holds the plaintext
+		Expect(bak.SsBackup.StorageNodes[0].Password).To(Equal("Gauss@123"))
+
+		fi, err := os.Stat(keyFile)
+		Expect(err).To(BeNil())
+		Expect(fi.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		path := filepath.Join(root, "backup", "a.json")
+		data, err := os.ReadFile(path)
+		Expect(err).To(BeNil())
+		Expect(string(data)).NotTo(ContainSubstring("Gauss@123"))
+		Expect(string(data)).NotTo(ContainSubstring("Rule@123"))
+		Expect(string(data)).NotTo(ContainSubstring("sharding_db"))
+		Expect(string(data)).To(ContainSubstring(KeySourceFile))
+		fi, err = os.Stat(path)
+		Expect(err).To(BeNil())
+		Expect(fi.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		raw, err := ls.ReadByID("id-1")
+		Expect(err).To(BeNil())
+		Expect(strings.HasPrefix(raw.SsBackup.StorageNodes[0].Password, "enc:")).To(BeTrue())
+		Expect(raw.SsBackup.ClusterInfo.MetaData.Databases).To(BeEmpty())
+		Expect(raw.Encryption.MetaData).NotTo(BeEmpty())
+
+		got, err := es.ReadByCSN("2012")
+		Expect(err).To(BeNil())
+		Expect(got.Encryption).To(BeNil())
+		Expect(got.SsBackup.StorageNodes[0].Username).To(Equal("omm"))
+		Expect(got.SsBackup.StorageNodes[0].Password).To(Equal("Gauss@123"))
+		Expect(got.SsBackup.ClusterInfo.MetaData).To(Equal(bak.SsBackup.ClusterInfo.MetaData))
+		Expect(got.SsBackup.ClusterInfo.SnapshotInfo.Csn).To(Equal("2012"))
+
+		_, err = NewEncryptedStorage(ls, filepath.Join(root, "other.key"), "").ReadAll()
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("encrypt with passphrase", func() {
+		es := NewEncryptedStorage(ls, filepath.Join(root, "secret.key"), "pass phrase")
+		Expect(es.WriteByJSON("a.json", newBackup())).To(Succeed())
+
+		got, err := es.ReadByName("a.json")
+		Expect(err).To(BeNil())
+		Expect(got.SsBackup.StorageNodes[0].Password).To(Equal("Gauss@123"))
+
+		_, err = NewEncryptedStorage(ls, "", "wrong").ReadByID("id-1")
+		Expect(err).NotTo(BeNil())
+		_, err = NewEncryptedStorage(ls, "", "").ReadByID("id-1")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("plaintext records of older versions", func() {
+		Expect(ls.WriteByJSON("a.json", newBackup())).To(Succeed())
+
+		list, err := NewEncryptedStorage(ls, filepath.Join(root, "secret.key"), "").ReadAll()
+		Expect(err).To(BeNil())
+		Expect(list).To(HaveLen(1))
+		Expect(list[0].SsBackup.StorageNodes[0].Password).To(Equal("Gauss@123"))
+	})
+})
@@ -37,12 +37,15 @@ type (
 		backupDir string
 	}
 
+	// ILocalStorage keeps the backup records, in the local home directory or a remote object storage.
 	ILocalStorage interface {
 		WriteByJSON(name string, contents *model.LsBackup) error
 		GenFilename(extn Extension) string
 		ReadAll() ([]*model.LsBackup, error)
 		ReadByID(id string) (*model.LsBackup, error)
 		ReadByCSN(csn string) (*model.LsBackup, error)
+		ReadByName(name string) (*model.LsBackup, error)
+		ListNames() ([]string, error)
 		DeleteByName(name string) error
 	}
 
@@ -75,7 +78,7 @@ func (ls *localStorage) init() error {
 	fi, err := os.Stat(ls.rootDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			if err := os.Mkdir(ls.rootDir, 0777); err != nil {
+			if err := os.Mkdir(ls.rootDir, 0700); err != nil {
 				return fmt.Errorf("create root dir failure,dir=%s,err=%s", ls.rootDir, err)
 			}
 		} else if os.IsExist(err) {
@@ -91,7 +94,7 @@ func (ls *localStorage) init() error {
 	fi, err = os.Stat(ls.backupDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			if err := os.Mkdir(ls.backupDir, 0777); err != nil {
+			if err := os.Mkdir(ls.backupDir, 0700); err != nil {
 				return fmt.Errorf("create backup dir failure,dir=%s,err=%s", ls.backupDir, err)
 			}
 		} else if os.IsExist(err) {
@@ -103,6 +106,13 @@ func (ls *localStorage) init() error {
 		}
 	}
 
+	// the backup records contain credentials, only the owner can access them.
+	for _, dir := range []string{ls.rootDir, ls.backupDir} {
+		if err := os.Chmod(dir, 0700); err != nil {
+			return fmt.Errorf("restrict dir permission failure,dir=%s,err=%s", dir, err)
+		}
+	}
+
 	return nil
 }
 
@@ -117,10 +127,16 @@ func (ls *localStorage) WriteByJSON(name string, contents *model.LsBackup) error
 	}
 
 	path := fmt.Sprintf("%s/%s", ls.backupDir, name)
-	fi, err := os.Create(path)
+	fi, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
 	if err != nil {
 		return fmt.Errorf("create file failure,file path is %s", path)
 	}
+	defer fi.Close()
+
+	// the file may be created by an older version with a looser permission
+	if err := fi.Chmod(0600); err != nil {
+		return fmt.Errorf("restrict file permission failure,file path is %s,err=%s", path, err)
+	}
 
 	_, err = fi.Write(data)
 	if err != nil {
@@ -131,13 +147,30 @@ func (ls *localStorage) WriteByJSON(name string, contents *model.LsBackup) error
 }
 
 func (ls *localStorage) ReadAll() ([]*model.LsBackup, error) {
+	names, err := ls.ListNames()
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]*model.LsBackup, 0, len(names))
+	for _, name := range names {
+		b, err := ls.ReadByName(name)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, b)
+	}
+	return backups, nil
+}
+
+// ListNames return the file names of all backup records.
+func (ls *localStorage) ListNames() ([]string, error) {
 	entries, err := os.ReadDir(ls.backupDir)
 	if err != nil {
 		return nil, xerr.NewCliErr(fmt.Sprintf("read the dir[path:%s] failed,err=%s", ls.backupDir, err))
 	}
 
-	backups := make([]*model.LsBackup, 0, len(entries))
-
+	names := make([]string, 0, len(entries))
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -153,21 +186,23 @@ func (ls *localStorage) ReadAll() ([]*model.LsBackup, error) {
 		if !strings.HasSuffix(info.Name(), ".json") {
 			continue
 		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
 
-		path := fmt.Sprintf("%s/%s", ls.backupDir, info.Name())
-		file, err := os.ReadFile(path)
-		if err != nil {
-			return nil, xerr.NewCliErr(fmt.Sprintf("read file failed,err=%s", err))
-		}
-
-		b := &model.LsBackup{}
-		if err := json.Unmarshal(file, b); err != nil {
-			return nil, xerr.NewCliErr(fmt.Sprintf("invalid contents[filePath=%s],err=%s", path, err))
-		}
+func (ls *localStorage) ReadByName(name string) (*model.LsBackup, error) {
+	path := fmt.Sprintf("%s/%s", ls.backupDir, name)
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("read file failed,err=%s", err))
+	}
 
-		backups = append(backups, b)
+	b := &model.LsBackup{}
+	if err := json.Unmarshal(file, b); err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("invalid contents[filePath=%s],err=%s", path, err))
 	}
-	return backups, nil
+	return b, nil
 }
 
 func (ls *localStorage) ReadByCSN(csn string) (*model.LsBackup, error) {
@@ -203,6 +238,10 @@ GenFilename gen a filename based on the file extension
 	if extn=JSON,return the JSON filename like **.json
 */
 func (ls *localStorage) GenFilename(extn Extension) string {
+	return genFilename(extn)
+}
+
+func genFilename(extn Extension) string {
 	prefix := time.Now().UTC().Format("20060102150405")
 	suffix := strutil.Random(8)
 
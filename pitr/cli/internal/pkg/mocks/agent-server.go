@@ -35,10 +35,10 @@ func (m *MockIAgentServer) EXPECT() *MockIAgentServerMockRecorder {
 }
 
 // Backup mocks base method.
-func (m *MockIAgentServer) Backup(in *model.BackupIn) (string, error) {
+func (m *MockIAgentServer) Backup(in *model.BackupIn) (*model.BackupOut, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Backup", in)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*model.BackupOut)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -77,20 +77,92 @@ func (mr *MockIAgentServerMockRecorder) DeleteBackup(in interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBackup", reflect.TypeOf((*MockIAgentServer)(nil).DeleteBackup), in)
 }
 
-// Restore mocks base method.
-func (m *MockIAgentServer) Restore(in *model.RestoreIn) error {
+// EnableArchive mocks base method.
+func (m *MockIAgentServer) EnableArchive(in *model.ArchiveIn) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Restore", in)
+	ret := m.ctrl.Call(m, "EnableArchive", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableArchive indicates an expected call of EnableArchive.
+func (mr *MockIAgentServerMockRecorder) EnableArchive(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableArchive", reflect.TypeOf((*MockIAgentServer)(nil).EnableArchive), in)
+}
+
+// FollowJobLog mocks base method.
+func (m *MockIAgentServer) FollowJobLog(id string, from int, fn func(string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowJobLog", id, from, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowJobLog indicates an expected call of FollowJobLog.
+func (mr *MockIAgentServerMockRecorder) FollowJobLog(id, from, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowJobLog", reflect.TypeOf((*MockIAgentServer)(nil).FollowJobLog), id, from, fn)
+}
+
+// PullBackupSet mocks base method.
+func (m *MockIAgentServer) PullBackupSet(in *model.BackupSetIn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullBackupSet", in)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// PullBackupSet indicates an expected call of PullBackupSet.
+func (mr *MockIAgentServerMockRecorder) PullBackupSet(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullBackupSet", reflect.TypeOf((*MockIAgentServer)(nil).PullBackupSet), in)
+}
+
+// PushBackupSet mocks base method.
+func (m *MockIAgentServer) PushBackupSet(in *model.BackupSetIn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushBackupSet", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushBackupSet indicates an expected call of PushBackupSet.
+func (mr *MockIAgentServerMockRecorder) PushBackupSet(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushBackupSet", reflect.TypeOf((*MockIAgentServer)(nil).PushBackupSet), in)
+}
+
+// Restore mocks base method.
+func (m *MockIAgentServer) Restore(in *model.RestoreIn) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", in)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Restore indicates an expected call of Restore.
 func (mr *MockIAgentServerMockRecorder) Restore(in interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIAgentServer)(nil).Restore), in)
 }
 
+// ShowArchive mocks base method.
+func (m *MockIAgentServer) ShowArchive(in *model.ArchiveIn) (*model.ArchiveStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShowArchive", in)
+	ret0, _ := ret[0].(*model.ArchiveStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShowArchive indicates an expected call of ShowArchive.
+func (mr *MockIAgentServerMockRecorder) ShowArchive(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowArchive", reflect.TypeOf((*MockIAgentServer)(nil).ShowArchive), in)
+}
+
 // ShowDetail mocks base method.
 func (m *MockIAgentServer) ShowDetail(in *model.ShowDetailIn) (*model.BackupInfo, error) {
 	m.ctrl.T.Helper()
@@ -121,6 +193,21 @@ func (mr *MockIAgentServerMockRecorder) ShowDiskSpace(in interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowDiskSpace", reflect.TypeOf((*MockIAgentServer)(nil).ShowDiskSpace), in)
 }
 
+// ShowJob mocks base method.
+func (m *MockIAgentServer) ShowJob(id string) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShowJob", id)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShowJob indicates an expected call of ShowJob.
+func (mr *MockIAgentServerMockRecorder) ShowJob(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowJob", reflect.TypeOf((*MockIAgentServer)(nil).ShowJob), id)
+}
+
 // ShowList mocks base method.
 func (m *MockIAgentServer) ShowList(in *model.ShowListIn) ([]model.BackupInfo, error) {
 	m.ctrl.T.Helper()
@@ -135,3 +222,18 @@ func (mr *MockIAgentServerMockRecorder) ShowList(in interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowList", reflect.TypeOf((*MockIAgentServer)(nil).ShowList), in)
 }
+
+// Verify mocks base method.
+func (m *MockIAgentServer) Verify(in *model.VerifyIn) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", in)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockIAgentServerMockRecorder) Verify(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockIAgentServer)(nil).Verify), in)
+}
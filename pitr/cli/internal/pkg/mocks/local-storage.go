@@ -80,6 +80,21 @@ func (mr *MockILocalStorageMockRecorder) GenFilename(extn interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenFilename", reflect.TypeOf((*MockILocalStorage)(nil).GenFilename), extn)
 }
 
+// ListNames mocks base method.
+func (m *MockILocalStorage) ListNames() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNames")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNames indicates an expected call of ListNames.
+func (mr *MockILocalStorageMockRecorder) ListNames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNames", reflect.TypeOf((*MockILocalStorage)(nil).ListNames))
+}
+
 // ReadAll mocks base method.
 func (m *MockILocalStorage) ReadAll() ([]*model.LsBackup, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +140,21 @@ func (mr *MockILocalStorageMockRecorder) ReadByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByID", reflect.TypeOf((*MockILocalStorage)(nil).ReadByID), id)
 }
 
+// ReadByName mocks base method.
+func (m *MockILocalStorage) ReadByName(name string) (*model.LsBackup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByName", name)
+	ret0, _ := ret[0].(*model.LsBackup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByName indicates an expected call of ReadByName.
+func (mr *MockILocalStorageMockRecorder) ReadByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByName", reflect.TypeOf((*MockILocalStorage)(nil).ReadByName), name)
+}
+
 // WriteByJSON mocks base method.
 func (m *MockILocalStorage) WriteByJSON(name string, contents *model.LsBackup) error {
 	m.ctrl.T.Helper()
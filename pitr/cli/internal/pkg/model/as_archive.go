@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+type (
+	ArchiveIn struct {
+		DBPort   uint16 `json:"db_port"`
+		DBName   string `json:"db_name"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+
+		DnBackupPath string `json:"dn_backup_path"`
+		Instance     string `json:"instance"`
+	}
+
+	ArchiveStatus struct {
+		ArchiveMode      string         `json:"archive_mode"`
+		ArchiveCommand   string         `json:"archive_command"`
+		CurrentSegment   string         `json:"current_segment"`
+		LatestArchived   string         `json:"latest_archived_segment"`
+		LagSegments      uint64         `json:"lag_segments"`
+		ArchivedSegments int            `json:"archived_segments"`
+		LostSegments     []LostSegments `json:"lost_segments"`
+		Broken           bool           `json:"broken"`
+		Reason           string         `json:"reason"`
+	}
+
+	LostSegments struct {
+		BeginSegment string `json:"begin_segment"`
+		EndSegment   string `json:"end_segment"`
+	}
+
+	ArchiveStatusResp struct {
+		Code int           `json:"code" validate:"required"`
+		Msg  string        `json:"msg" validate:"required"`
+		Data ArchiveStatus `json:"data"`
+	}
+
+	EnableArchiveResp struct {
+		Code int    `json:"code" validate:"required"`
+		Msg  string `json:"msg" validate:"required"`
+		Data string `json:"data"`
+	}
+
+	ArchiveNodeStatus struct {
+		IP     string
+		Port   uint16
+		Status *ArchiveStatus
+		Err    string
+	}
+)
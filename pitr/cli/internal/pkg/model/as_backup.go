@@ -27,10 +27,16 @@ type (
 		DnThreadsNum uint8        `json:"dn_threads_num"`
 		DnBackupMode DBBackupMode `json:"dn_backup_mode"`
 		Instance     string       `json:"instance"`
+
+		DnCompressAlg         string `json:"dn_compress_alg"`
+		DnCompressLevel       uint8  `json:"dn_compress_level"`
+		DnStream              bool   `json:"dn_stream"`
+		DnSkipBlockValidation bool   `json:"dn_skip_block_validation"`
 	}
 
 	BackupOut struct {
-		ID string `json:"backup_id"`
+		ID    string `json:"backup_id"`
+		JobID string `json:"job_id"`
 	}
 
 	BackupOutResp struct {
@@ -60,6 +66,7 @@ type (
 type AgentServerStatus struct {
 	IP     string `json:"ip"`
 	Port   uint16 `json:"port"`
+	DBPort uint16 `json:"db_port"`
 	Status string `json:"status"`
 }
 
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "encoding/json"
+
+const (
+	JobStateRunning   = "Running"
+	JobStateSucceeded = "Succeeded"
+	JobStateFailed    = "Failed"
+)
+
+type (
+	// Job is a background operation of agent server, such as backup, restore and verify.
+	Job struct {
+		ID        string          `json:"job_id"`
+		Kind      string          `json:"kind"`
+		State     string          `json:"state"`
+		Progress  JobProgress     `json:"progress"`
+		Result    json.RawMessage `json:"result,omitempty"`
+		Error     string          `json:"error,omitempty"`
+		Lines     int             `json:"lines"`
+		StartTime string          `json:"start_time"`
+		EndTime   string          `json:"end_time,omitempty"`
+	}
+
+	// JobProgress the total is 0 if the progress is unknown.
+	JobProgress struct {
+		Done  int64 `json:"done"`
+		Total int64 `json:"total"`
+	}
+
+	JobResp struct {
+		Code int    `json:"code" validate:"required"`
+		Msg  string `json:"msg" validate:"required"`
+		Data *Job   `json:"data"`
+	}
+)
+
+func (j *Job) Done() bool {
+	return j != nil && j.State != JobStateRunning
+}
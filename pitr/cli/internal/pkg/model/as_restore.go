@@ -26,16 +26,33 @@ type (
 		Instance     string `json:"instance"`
 		DnBackupPath string `json:"dn_backup_path"`
 		DnBackupID   string `json:"dn_backup_id"`
+
+		RecoveryTarget *RecoveryTarget `json:"recovery_target,omitempty"`
+	}
+
+	// RecoveryTarget only one of Time, LSN, Xid, Name, CSN, Position and GTID can be set, Position and GTID are of MySQL binlog.
+	RecoveryTarget struct {
+		Time      string `json:"time,omitempty"`
+		LSN       string `json:"lsn,omitempty"`
+		Xid       string `json:"xid,omitempty"`
+		Name      string `json:"name,omitempty"`
+		CSN       string `json:"csn,omitempty"`
+		Position  string `json:"position,omitempty"`
+		GTID      string `json:"gtid,omitempty"`
+		Inclusive *bool  `json:"inclusive,omitempty"`
 	}
 
-	RestoreResp struct {
-		Code int    `json:"code" validate:"required"`
-		Msg  string `json:"msg" validate:"required"`
+	RestoreOut struct {
+		InRecovery bool   `json:"in_recovery"`
+		ReplayLSN  string `json:"replay_lsn"`
+		ReplayTime string `json:"replay_time"`
 	}
 
 	RestoreResult struct {
-		IP     string `json:"ip"`
-		Port   uint16 `json:"port"`
-		Status string `json:"status"`
+		IP         string `json:"ip"`
+		Port       uint16 `json:"port"`
+		Status     string `json:"status"`
+		ReplayLSN  string `json:"replay_lsn"`
+		ReplayTime string `json:"replay_time"`
 	}
 )
@@ -38,13 +38,22 @@ type (
 	}
 
 	BackupInfo struct {
-		ID        string       `json:"dn_backup_id"`
-		Path      string       `json:"dn_backup_path"`
-		Mode      string       `json:"db_backup_mode"`
-		Instance  string       `json:"instance"`
-		StartTime string       `json:"start_time"`
-		EndTime   string       `json:"end_time"`
-		Status    BackupStatus `json:"status"`
+		ID           string       `json:"dn_backup_id"`
+		Path         string       `json:"dn_backup_path"`
+		Mode         string       `json:"db_backup_mode"`
+		ParentID     string       `json:"parent_dn_backup_id"`
+		Instance     string       `json:"instance"`
+		StartTime    string       `json:"start_time"`
+		EndTime      string       `json:"end_time"`
+		Status       BackupStatus `json:"status"`
+		StartLsn     string       `json:"start_lsn"`
+		StopLsn      string       `json:"stop_lsn"`
+		RecoveryTime string       `json:"recovery_time"`
+		RecoveryXid  int          `json:"recovery_xid"`
+
+		CompressAlg       string `json:"compress_alg"`
+		DataBytes         int64  `json:"data_bytes"`
+		UncompressedBytes int64  `json:"uncompressed_bytes"`
 	}
 
 	BackupDetailResp struct {
@@ -62,17 +71,42 @@ type (
 	DiskSpaceIn struct {
 		// DiskPath is the path of the disk
 		DiskPath string `json:"diskPath"`
+		// DBPort tells apart the openGauss instances managed by the same agent server
+		DBPort uint16 `json:"db_port,omitempty"`
+		// Instance and DnBackupID are used to estimate the bytes of backup or restore,
+		// the latest backup of the instance is used if the backup id is not set.
+		Instance   string `json:"instance,omitempty"`
+		DnBackupID string `json:"dn_backup_id,omitempty"`
 	}
 
 	DiskSpaceInfo struct {
-		Code int    `json:"code" validate:"required"`
-		Msg  string `json:"msg" validate:"required"`
-		Data string `json:"data"`
+		Code int       `json:"code" validate:"required"`
+		Msg  string    `json:"msg" validate:"required"`
+		Data DiskSpace `json:"data"`
+	}
+
+	DiskSpace struct {
+		BackupPath *DiskUsage `json:"backup_path"`
+		PgData     *DiskUsage `json:"pgdata"`
+		// EstimatedBytes is the pgdata bytes of the backup, 0 means there is no backup to estimate from
+		EstimatedBytes int64 `json:"estimated_bytes"`
+	}
+
+	DiskUsage struct {
+		Path       string `json:"path"`
+		TotalBytes uint64 `json:"total_bytes"`
+		UsedBytes  uint64 `json:"used_bytes"`
+		FreeBytes  uint64 `json:"free_bytes"`
 	}
 
 	DiskSpaceStatus struct {
-		IP              string `json:"ip"`
-		Path            string `json:"path"`
-		DiskSpaceStatus string `json:"diskSpaceStatus"`
+		IP             string `json:"ip"`
+		Path           string `json:"path"`
+		TotalBytes     uint64 `json:"total_bytes"`
+		FreeBytes      uint64 `json:"free_bytes"`
+		EstimatedBytes int64  `json:"estimated_bytes"`
+		// Status is one of enough, not enough and unknown
+		Status string `json:"status"`
+		Error  string `json:"error,omitempty"`
 	}
 )
@@ -17,12 +17,27 @@
 
 package model
 
+import "fmt"
+
 type (
 	// LsBackup LocalStorageBackup
 	LsBackup struct {
 		Info     *BackupMetaInfo `json:"info"`
 		DnList   []*DataNode     `json:"dn_list"`
 		SsBackup *SsBackup       `json:"ss_backup"`
+
+		// Encryption is nil if the credentials of storage nodes and the cluster metadata are in plaintext.
+		Encryption *Encryption `json:"encryption,omitempty"`
+	}
+
+	// Encryption the credentials and the cluster metadata are encrypted by a random data key, which is wrapped by the master key.
+	Encryption struct {
+		Version    int    `json:"version"`
+		KeySource  string `json:"key_source"`     // key_file or passphrase
+		Salt       string `json:"salt,omitempty"` // salt to derive the master key from passphrase
+		WrappedKey string `json:"wrapped_key"`
+		// MetaData is the encrypted meta_data of cluster_info, which is emptied in the record.
+		MetaData string `json:"meta_data,omitempty"`
 	}
 
 	BackupMetaInfo struct {
@@ -31,6 +46,9 @@ type (
 		BackupMode DBBackupMode `json:"backup_mode"`
 		StartTime  int64        `json:"start_time"` // Unix time
 		EndTime    int64        `json:"end_time"`   // Unix time
+
+		// ParentID is the record which a PTRACK backup depends on
+		ParentID string `json:"parent_id,omitempty"`
 	}
 
 	DataNode struct {
@@ -40,6 +58,16 @@ type (
 		BackupID  string       `json:"backup_id"`
 		StartTime int64        `json:"start_time"` // Unix time
 		EndTime   int64        `json:"end_time"`   // Unix time
+
+		// ParentBackupID is the backup on the data node which a PTRACK backup depends on
+		ParentBackupID string `json:"parent_backup_id,omitempty"`
+		// JobID is the job of backup in agent server, it is only used to show the progress.
+		JobID string `json:"-"`
+
+		// the size of backup set, the uncompressed bytes is the size of data files before compression
+		CompressAlg       string `json:"compress_alg,omitempty"`
+		DataBytes         int64  `json:"data_bytes,omitempty"`
+		UncompressedBytes int64  `json:"uncompressed_bytes,omitempty"`
 	}
 )
 
@@ -57,9 +85,27 @@ type (
 		Password string `json:"password"`
 		Database string `json:"database"`
 		Remark   string `json:"remark,omitempty"`
+
+		// Instance is the backup instance of gs_probackup, the storage nodes on the same host share the backup path,
+		// so each of them has its own instance. It is empty if the storage node is the only one on its host.
+		Instance string `json:"instance,omitempty"`
 	}
 
 	StorageNodesInfo struct {
 		StorageNodes map[string][]*StorageNode `json:"storage_nodes"`
 	}
 )
+
+// Key identify the data node in a backup record, several data nodes may run on the same host.
+func (dn *DataNode) Key() string {
+	return nodeKey(dn.IP, dn.Port)
+}
+
+// Key identify the storage node in a backup record, it is the same as the key of its data node.
+func (sn *StorageNode) Key() string {
+	return nodeKey(sn.IP, sn.Port)
+}
+
+func nodeKey(ip string, port uint16) string {
+	return fmt.Sprintf("%s:%d", ip, port)
+}
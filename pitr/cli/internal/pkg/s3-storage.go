@@ -0,0 +1,166 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/s3util"
+)
+
+// s3Storage keeps the backup records in a S3 compatible object storage, under `<prefix>/backup/`.
+type s3Storage struct {
+	cli       s3util.IClient
+	bucket    string
+	backupDir string
+}
+
+func NewS3Storage(cfg *model.StorageConfig) (ILocalStorage, error) {
+	if cfg == nil || cfg.Bucket == "" {
+		return nil, xerr.NewCliErr("missing s3 bucket")
+	}
+
+	cli, err := s3util.NewClient(&s3util.Config{
+		Endpoint:  cfg.Endpoint,
+		Region:    cfg.Region,
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+	})
+	if err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("new s3 client failed,err=%s", err))
+	}
+
+	return &s3Storage{
+		cli:       cli,
+		bucket:    cfg.Bucket,
+		backupDir: strings.TrimPrefix(path.Join(cfg.Prefix, "backup"), "/"),
+	}, nil
+}
+
+func (s *s3Storage) WriteByJSON(name string, contents *model.LsBackup) error {
+	if !strings.HasSuffix(name, ".json") {
+		return fmt.Errorf("wrong file extension,file name is %s", name)
+	}
+
+	data, err := json.MarshalIndent(contents, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	key := path.Join(s.backupDir, name)
+	if err := s.cli.PutObject(s.bucket, key, bytes.NewReader(data), int64(len(data))); err != nil {
+		return fmt.Errorf("put object failure,key is %s,err=%s", key, err)
+	}
+	return nil
+}
+
+func (s *s3Storage) GenFilename(extn Extension) string {
+	return genFilename(extn)
+}
+
+func (s *s3Storage) ReadAll() ([]*model.LsBackup, error) {
+	names, err := s.ListNames()
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]*model.LsBackup, 0, len(names))
+	for _, name := range names {
+		b, err := s.ReadByName(name)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, b)
+	}
+	return backups, nil
+}
+
+// ListNames return the object names of all backup records, without the prefix.
+func (s *s3Storage) ListNames() ([]string, error) {
+	keys, err := s.cli.ListObjects(s.bucket, s.backupDir+"/")
+	if err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("list objects[bucket:%s,prefix:%s] failed,err=%s", s.bucket, s.backupDir, err))
+	}
+
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if strings.HasSuffix(key, ".json") {
+			names = append(names, path.Base(key))
+		}
+	}
+	return names, nil
+}
+
+func (s *s3Storage) ReadByName(name string) (*model.LsBackup, error) {
+	key := path.Join(s.backupDir, name)
+	body, err := s.cli.GetObject(s.bucket, key)
+	if err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("get object failed,err=%s", err))
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("read object failed,err=%s", err))
+	}
+
+	b := &model.LsBackup{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, xerr.NewCliErr(fmt.Sprintf("invalid contents[key=%s],err=%s", key, err))
+	}
+	return b, nil
+}
+
+func (s *s3Storage) ReadByCSN(csn string) (*model.LsBackup, error) {
+	list, err := s.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range list {
+		if v.Info.CSN == csn {
+			return v, nil
+		}
+	}
+	return nil, xerr.NewCliErr(xerr.NotFound)
+}
+
+func (s *s3Storage) ReadByID(id string) (*model.LsBackup, error) {
+	list, err := s.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range list {
+		if v.Info.ID == id {
+			return v, nil
+		}
+	}
+	return nil, xerr.NewCliErr(xerr.NotFound)
+}
+
+func (s *s3Storage) DeleteByName(name string) error {
+	if err := s.cli.DeleteObject(s.bucket, path.Join(s.backupDir, name)); err != nil {
+		return xerr.NewCliErr(fmt.Sprintf("delete object failed,err=%s", err))
+	}
+	return nil
+}
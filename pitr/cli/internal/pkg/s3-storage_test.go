@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/s3util"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("s3Storage", func() {
+	It("write, read and delete backup records", func() {
+		srv := s3util.NewFakeServer()
+		defer srv.Close()
+
+		s, err := NewS3Storage(&model.StorageConfig{
+			Endpoint:  srv.URL,
+			Bucket:    "pitr",
+			AccessKey: "ak",
+			SecretKey: "sk",
+			Prefix:    "gs_pitr",
+		})
+		Expect(err).To(BeNil())
+
+		filename := s.GenFilename(ExtnJSON)
+		Expect(s.WriteByJSON(filename, &model.LsBackup{Info: &model.BackupMetaInfo{ID: "id-1", CSN: "2012"}})).To(Succeed())
+		Expect(s.WriteByJSON("no-extension", &model.LsBackup{})).NotTo(Succeed())
+
+		list, err := s.ReadAll()
+		Expect(err).To(BeNil())
+		Expect(list).To(HaveLen(1))
+
+		bak, err := s.ReadByCSN("2012")
+		Expect(err).To(BeNil())
+		Expect(bak.Info.ID).To(Equal("id-1"))
+
+		_, err = s.ReadByID("id-2")
+		Expect(err.Error()).To(Equal("Not found"))
+
+		Expect(s.DeleteByName(filename)).To(Succeed())
+		list, err = s.ReadAll()
+		Expect(err).To(BeNil())
+		Expect(list).To(BeEmpty())
+	})
+
+	It("missing bucket", func() {
+		_, err := NewS3Storage(&model.StorageConfig{Endpoint: "http://127.0.0.1:9000", AccessKey: "ak", SecretKey: "sk"})
+		Expect(err).NotTo(BeNil())
+	})
+})
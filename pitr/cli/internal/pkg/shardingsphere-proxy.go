@@ -26,11 +26,13 @@ import (
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/xerr"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/gsutil"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/mysqlutil"
 )
 
 type (
 	shardingSphereProxy struct {
-		db *sql.DB
+		db       *sql.DB
+		protocol string
 	}
 
 	IShardingSphereProxy interface {
@@ -46,6 +48,10 @@ type (
 
 const (
 	DefaultDBName = "postgres"
+
+	// ProtocolPostgreSQL and ProtocolMySQL are the frontend protocols of ShardingSphere-Proxy.
+	ProtocolPostgreSQL = "postgresql"
+	ProtocolMySQL      = "mysql"
 )
 
 func NewShardingSphereProxy(user, password, dbName, host string, port uint16) (IShardingSphereProxy, error) {
@@ -57,14 +63,31 @@ func NewShardingSphereProxy(user, password, dbName, host string, port uint16) (I
 		efmt := "db ping fail[host=%s,port=%d,user=%s,pwLen=%d,dbName=%s],err=%s"
 		return nil, fmt.Errorf(efmt, host, port, user, len(password), dbName, err)
 	}
-	return &shardingSphereProxy{db: db}, nil
+	return &shardingSphereProxy{db: db, protocol: ProtocolPostgreSQL}, nil
+}
+
+// NewMySQLShardingSphereProxy connect to ShardingSphere-Proxy of MySQL frontend, the db name is optional.
+func NewMySQLShardingSphereProxy(user, password, dbName, host string, port uint16) (IShardingSphereProxy, error) {
+	db, err := mysqlutil.Open(user, password, dbName, host, port)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Ping(); err != nil {
+		efmt := "db ping fail[host=%s,port=%d,user=%s,pwLen=%d,dbName=%s],err=%s"
+		return nil, fmt.Errorf(efmt, host, port, user, len(password), dbName, err)
+	}
+	return &shardingSphereProxy{db: db, protocol: ProtocolMySQL}, nil
 }
 
 /*
-LockForBackup 停写，同时锁 CSN，备份场景使用
+LockForBackup 停写，同时锁 CSN，备份场景使用；MySQL 没有 CSN，只停写
 */
 func (ss *shardingSphereProxy) LockForBackup() error {
-	_, err := ss.db.Exec(`LOCK CLUSTER WITH LOCK_STRATEGY(TYPE(NAME="WRITE", PROPERTIES("lock_csn"=true)));`)
+	stmt := `LOCK CLUSTER WITH LOCK_STRATEGY(TYPE(NAME="WRITE", PROPERTIES("lock_csn"=true)));`
+	if ss.protocol == ProtocolMySQL {
+		stmt = `LOCK CLUSTER WITH LOCK_STRATEGY(TYPE(NAME="WRITE"));`
+	}
+	_, err := ss.db.Exec(stmt)
 	if err != nil {
 		return xerr.NewCliErr("ss lock for backup failure")
 	}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/internal/pkg/model"
 	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/gsutil"
+	"github.com/apache/shardingsphere-on-cloud/pitr/cli/pkg/mysqlutil"
 	"regexp"
 	"time"
 
@@ -85,6 +86,21 @@ var _ = Describe("Test ShardingSphere Proxy With Sqlmock", func() {
 		Expect(proxy.ImportMetaData(clusterInfo)).To(BeNil())
 	})
 
+	It("lock csn for backup", func() {
+		dbmock.ExpectExec(regexp.QuoteMeta(`PROPERTIES("lock_csn"=true)`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		Expect(proxy.LockForBackup()).To(BeNil())
+	})
+
+	It("lock for backup by mysql protocol", func() {
+		monkey.Patch(mysqlutil.Open, func(_, _, _, _ string, _ uint16) (*sql.DB, error) {
+			return db, nil
+		})
+		proxy, err = NewMySQLShardingSphereProxy("root", "root", "", "localhost", 3307)
+		Expect(err).To(BeNil())
+
+		dbmock.ExpectExec(regexp.QuoteMeta(`LOCK CLUSTER WITH LOCK_STRATEGY(TYPE(NAME="WRITE"));`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		Expect(proxy.LockForBackup()).To(BeNil())
+	})
 })
 
 var _ = Describe("IShardingSphereProxy", func() {
@@ -17,12 +17,37 @@
 
 package xerr
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type err struct {
-	msg string
+	msg  string
+	code int
 }
 
+// exit codes of gs_pitr, one for each class of failure, so scripts are able to tell them apart.
+const (
+	ExitOK = iota
+	// ExitFailed backup, restore or the other operation failed
+	ExitFailed
+	// ExitUsage invalid flags or arguments
+	ExitUsage
+	// ExitAborted aborted by user, or a confirmation is required in non-interactive mode
+	ExitAborted
+	// ExitPreflight the checks before operation failed and nothing is changed, e.g. not enough disk space
+	ExitPreflight
+	// ExitAgentServer agent server is unavailable or returns error
+	ExitAgentServer
+	// ExitProxy ss-proxy is unavailable or returns error
+	ExitProxy
+	// ExitStorage the storage of backup records is unavailable
+	ExitStorage
+	// ExitNotFound the backup record is not found
+	ExitNotFound
+)
+
 const (
 	postErrFmt = "httputils.NewRequest[url=%s,body=%v,out=%v] return err=%s,wrap=%w"
 
@@ -37,8 +62,37 @@ func (e *err) Error() string {
 
 func NewCliErr(msg string) error {
 	return &err{
-		msg: msg,
+		msg:  msg,
+		code: ExitFailed,
+	}
+}
+
+// NewCodeErr new a cli err with the exit code of its failure class.
+func NewCodeErr(code int, msg string) error {
+	return &err{
+		msg:  msg,
+		code: code,
+	}
+}
+
+// NewCliErrOf new a cli err with the exit code of e, so the failure class is kept when adding context to e.
+func NewCliErrOf(e error, msg string) error {
+	return &err{
+		msg:  msg,
+		code: ExitCode(e),
+	}
+}
+
+// ExitCode return the exit code of the err, ExitFailed if it is not a cli err.
+func ExitCode(e error) int {
+	if e == nil {
+		return ExitOK
+	}
+	var ce *err
+	if errors.As(e, &ce) {
+		return ce.code
 	}
+	return ExitFailed
 }
 
 func NewUnknownErr(url string, in, out interface{}, err error) error {
@@ -47,6 +101,7 @@ func NewUnknownErr(url string, in, out interface{}, err error) error {
 
 func NewAgentServerErr(code int, msg string) error {
 	return &err{
-		msg: fmt.Sprintf("agent server err[code=%d,msg=%s]", code, msg),
+		msg:  fmt.Sprintf("agent server err[code=%d,msg=%s]", code, msg),
+		code: ExitAgentServer,
 	}
 }
@@ -46,7 +46,5 @@ func main() {
 	}
 	logging.Init(logger)
 
-	if err := cmd.RootCmd.Execute(); err != nil {
-		os.Exit(1)
-	}
+	os.Exit(cmd.Execute())
 }
@@ -82,3 +82,17 @@ func (mr *MockIreqMockRecorder) Send(body interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockIreq)(nil).Send), body)
 }
+
+// Stream mocks base method.
+func (m *MockIreq) Stream(fn func(string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockIreqMockRecorder) Stream(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockIreq)(nil).Stream), fn)
+}
@@ -18,9 +18,9 @@
 package httputils
 
 import (
+	"bufio"
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -44,6 +44,7 @@ type Ireq interface {
 	Body(b any)
 	Query(m map[string]string)
 	Send(body any) error
+	Stream(fn func(line string)) error
 }
 
 func NewRequest(ctx context.Context, method, url string) Ireq {
@@ -71,6 +72,54 @@ func (r *req) Query(m map[string]string) {
 }
 
 func (r *req) Send(body any) error {
+	resp, err := r.do()
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	all, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("invalid response,err=%w", err)
+	}
+	if body != nil {
+		if err = json.Unmarshal(all, body); err != nil {
+			return fmt.Errorf("json unmarshal return err=%w", err)
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response status code is not 200, code=%d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Stream call the fn with every line of the response body until the body is closed by server.
+func (r *req) Stream(fn func(line string)) error {
+	resp, err := r.do()
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response status code is not 200, code=%d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fn(scanner.Text())
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("read response stream failure,err=%w", err)
+	}
+	return nil
+}
+
+func (r *req) do() (*http.Response, error) {
 	var (
 		bs  []byte
 		err error
@@ -79,13 +128,13 @@ func (r *req) Send(body any) error {
 	if r.body != nil {
 		bs, err = json.Marshal(r.body)
 		if err != nil {
-			return fmt.Errorf("json.Marshal return err=%w", err)
+			return nil, fmt.Errorf("json.Marshal return err=%w", err)
 		}
 	}
 
 	_req, err := http.NewRequestWithContext(r.ctx, r.method, r.url, bytes.NewReader(bs))
 	if err != nil {
-		return fmt.Errorf("new request failure,err=%w", err)
+		return nil, fmt.Errorf("new request failure,err=%w", err)
 	}
 
 	// set header
@@ -98,32 +147,14 @@ func (r *req) Send(body any) error {
 	}
 
 	tr := &http.Transport{
-		//nolint:gosec
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		TLSClientConfig: tlsConfig.Clone(),
 	}
 	c := &http.Client{Transport: tr}
 	resp, err := c.Do(_req)
 	if err != nil {
-		return fmt.Errorf("http request err=%w", err)
-	}
-
-	defer resp.Body.Close()
-
-	all, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("invalid response,err=%w", err)
+		return nil, fmt.Errorf("http request err=%w", err)
 	}
-	if body != nil {
-		if err = json.Unmarshal(all, body); err != nil {
-			return fmt.Errorf("json unmarshal return err=%w", err)
-		}
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("response status code is not 200, code=%d", resp.StatusCode)
-	}
-
-	return nil
+	return resp, nil
 }
 
 func (r *req) setReqHeader(req *http.Request) *http.Request {
@@ -136,9 +167,13 @@ func (r *req) setReqHeader(req *http.Request) *http.Request {
 		if req.Header.Get("Content-Type") == "" {
 			req.Header.Set("Content-Type", "application/json")
 		}
-		if req.Header.Get("x-request-id") == "" {
-			req.Header.Set("x-request-id", uuid.New().String())
-		}
+	}
+	// agent server requires request id of all apis, and it is recorded in the audit log
+	if req.Header.Get("x-request-id") == "" {
+		req.Header.Set("x-request-id", uuid.New().String())
+	}
+	if authToken != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", authToken))
 	}
 	return req
 }
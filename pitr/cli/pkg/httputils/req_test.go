@@ -20,7 +20,9 @@ package httputils
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -39,5 +41,43 @@ var _ = Describe("Test req", func() {
 			Expect(_req.Header.Get("Content-Type")).To(Equal("application/json"))
 			Expect(_req.Header.Get("x-request-id")).ToNot(BeEmpty())
 		})
+
+		It("should set request id and token for get", func() {
+			SetAuthToken("token")
+			defer SetAuthToken("")
+
+			r := NewRequest(context.Background(), http.MethodGet, "http://localhost:8080")
+			_req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://localhost:8080", nil)
+			Expect(err).To(BeNil())
+
+			_req = r.(*req).setReqHeader(_req)
+			Expect(_req.Header.Get("Content-Type")).To(BeEmpty())
+			Expect(_req.Header.Get("x-request-id")).ToNot(BeEmpty())
+			Expect(_req.Header.Get("Authorization")).To(Equal("Bearer token"))
+		})
+	})
+	Context("Test stream", func() {
+		It("should call fn with every line", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for i := 1; i <= 3; i++ {
+					_, _ = fmt.Fprintf(w, "line %d\n", i)
+					w.(http.Flusher).Flush()
+				}
+			}))
+			defer server.Close()
+
+			var lines []string
+			r := NewRequest(context.Background(), http.MethodGet, server.URL)
+			Expect(r.Stream(func(line string) { lines = append(lines, line) })).To(Succeed())
+			Expect(lines).To(Equal([]string{"line 1", "line 2", "line 3"}))
+		})
+
+		It("should return error if status code is not 200", func() {
+			server := httptest.NewServer(http.NotFoundHandler())
+			defer server.Close()
+
+			r := NewRequest(context.Background(), http.MethodGet, server.URL)
+			Expect(r.Stream(func(line string) {})).NotTo(Succeed())
+		})
 	})
 })
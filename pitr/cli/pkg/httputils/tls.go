@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httputils
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tlsConfig is used by all requests, the server certificate is verified by the system CA pool by default.
+var tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+// authToken is sent as bearer token by all requests if it is not empty.
+var authToken string
+
+// TLSOptions all fields are optional.
+type TLSOptions struct {
+	// CAFile the CA certificates to verify the server certificate
+	CAFile string
+	// CertFile and KeyFile the client certificate presented to the server
+	CertFile string
+	KeyFile  string
+	// Fingerprint the hex encoded SHA-256 fingerprint of the server certificate, colons are allowed
+	Fingerprint string
+	// InsecureSkipVerify the server certificate is not verified at all, it is only for tests
+	InsecureSkipVerify bool
+}
+
+// NewTLSConfig build the client TLS config. The server certificate is verified by CA if CAFile is set,
+// or by the system CA pool otherwise, and it must match the fingerprint if Fingerprint is set,
+// the certificate is pinned in this way.
+func NewTLSConfig(opts *TLSOptions) (*tls.Config, error) {
+	if opts == nil {
+		opts = &TLSOptions{}
+	}
+	if opts.InsecureSkipVerify && (opts.CAFile != "" || opts.Fingerprint != "") {
+		return nil, errors.New("insecure skip verify conflicts with CA and fingerprint")
+	}
+
+	//nolint:gosec
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: opts.InsecureSkipVerify}
+
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file failure,err=%w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificate found in CA file %s", opts.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate failure,err=%w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.Fingerprint != "" {
+		want := strings.ToLower(strings.ReplaceAll(opts.Fingerprint, ":", ""))
+		if _, err := hex.DecodeString(want); err != nil || len(want) != sha256.Size*2 {
+			return nil, fmt.Errorf("invalid SHA-256 fingerprint %s", opts.Fingerprint)
+		}
+		// the chain is still verified by CA if CAFile is set, otherwise only the pinned certificate is trusted.
+		if opts.CAFile == "" {
+			cfg.InsecureSkipVerify = true
+		}
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no server certificate")
+			}
+			sum := sha256.Sum256(cs.PeerCertificates[0].Raw)
+			if got := hex.EncodeToString(sum[:]); got != want {
+				return fmt.Errorf("server certificate fingerprint mismatch, got %s", got)
+			}
+			return nil
+		}
+	}
+
+	return cfg, nil
+}
+
+// SetTLSConfig set the TLS config used by all requests.
+func SetTLSConfig(cfg *tls.Config) {
+	tlsConfig = cfg
+}
+
+// SetAuthToken set the bearer token sent by all requests, no token is sent if it is empty.
+func SetAuthToken(token string) {
+	authToken = token
+}
@@ -0,0 +1,173 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httputils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test tls", func() {
+	var (
+		dir    string
+		origin *tls.Config
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		origin = tlsConfig
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("{}"))
+		}))
+	})
+
+	AfterEach(func() {
+		SetTLSConfig(origin)
+		server.Close()
+	})
+
+	writeCA := func(cert *x509.Certificate) string {
+		f := filepath.Join(dir, "ca.crt")
+		Expect(os.WriteFile(f, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600)).To(Succeed())
+		return f
+	}
+
+	send := func() error {
+		return NewRequest(context.Background(), http.MethodGet, server.URL).Send(nil)
+	}
+
+	It("should verify server certificate by system CA pool by default", func() {
+		server.StartTLS()
+		Expect(send()).NotTo(Succeed())
+
+		cfg, err := NewTLSConfig(nil)
+		Expect(err).To(BeNil())
+		SetTLSConfig(cfg)
+		Expect(send()).NotTo(Succeed())
+	})
+
+	It("should skip verifying server certificate if it is insecure", func() {
+		server.StartTLS()
+		cfg, err := NewTLSConfig(&TLSOptions{InsecureSkipVerify: true})
+		Expect(err).To(BeNil())
+		SetTLSConfig(cfg)
+		Expect(send()).To(Succeed())
+	})
+
+	It("should verify server certificate by CA", func() {
+		server.StartTLS()
+		cfg, err := NewTLSConfig(&TLSOptions{CAFile: writeCA(server.Certificate())})
+		Expect(err).To(BeNil())
+		SetTLSConfig(cfg)
+		Expect(send()).To(Succeed())
+
+		certFile, _ := newClientCert(dir)
+		cfg, err = NewTLSConfig(&TLSOptions{CAFile: certFile})
+		Expect(err).To(BeNil())
+		SetTLSConfig(cfg)
+		Expect(send()).NotTo(Succeed())
+	})
+
+	It("should pin server certificate by fingerprint", func() {
+		server.StartTLS()
+		sum := sha256.Sum256(server.Certificate().Raw)
+		cfg, err := NewTLSConfig(&TLSOptions{Fingerprint: hex.EncodeToString(sum[:])})
+		Expect(err).To(BeNil())
+		SetTLSConfig(cfg)
+		Expect(send()).To(Succeed())
+
+		sum[0]++
+		cfg, err = NewTLSConfig(&TLSOptions{Fingerprint: hex.EncodeToString(sum[:])})
+		Expect(err).To(BeNil())
+		SetTLSConfig(cfg)
+		Expect(send()).NotTo(Succeed())
+	})
+
+	It("should present client certificate", func() {
+		certFile, keyFile := newClientCert(dir)
+		bs, err := os.ReadFile(certFile)
+		Expect(err).To(BeNil())
+		pool := x509.NewCertPool()
+		Expect(pool.AppendCertsFromPEM(bs)).To(BeTrue())
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool, MinVersion: tls.VersionTLS12}
+		server.StartTLS()
+
+		cfg, err := NewTLSConfig(&TLSOptions{CAFile: writeCA(server.Certificate())})
+		Expect(err).To(BeNil())
+		SetTLSConfig(cfg)
+		Expect(send()).NotTo(Succeed())
+
+		cfg, err = NewTLSConfig(&TLSOptions{CAFile: writeCA(server.Certificate()), CertFile: certFile, KeyFile: keyFile})
+		Expect(err).To(BeNil())
+		SetTLSConfig(cfg)
+		Expect(send()).To(Succeed())
+	})
+
+	It("invalid options", func() {
+		_, err := NewTLSConfig(&TLSOptions{Fingerprint: "xx:yy"})
+		Expect(err).NotTo(BeNil())
+		_, err = NewTLSConfig(&TLSOptions{CAFile: filepath.Join(dir, "not-exist.crt")})
+		Expect(err).NotTo(BeNil())
+		certFile, _ := newClientCert(dir)
+		_, err = NewTLSConfig(&TLSOptions{CAFile: certFile, InsecureSkipVerify: true})
+		Expect(err).NotTo(BeNil())
+	})
+})
+
+// newClientCert generate a self-signed client certificate, it is the CA of itself.
+func newClientCert(dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gs_pitr"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).To(BeNil())
+
+	certFile, keyFile = filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)).To(Succeed())
+	return certFile, keyFile
+}
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysqlutil
+
+import (
+	"database/sql"
+
+	"bou.ke/monkey"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MySQL", func() {
+	Context("Connection", func() {
+		It("empty user", func() {
+			db, err := Open("", "root", "", "127.0.0.1", uint16(3307))
+			Expect(err.Error()).To(Equal("user is empty"))
+			Expect(db).To(BeNil())
+		})
+
+		It("empty password", func() {
+			db, err := Open("root", "", "", "127.0.0.1", uint16(3307))
+			Expect(err.Error()).To(Equal("password is empty"))
+			Expect(db).To(BeNil())
+		})
+
+		It("Open without database", func() {
+			var dsn string
+			monkey.Patch(sql.Open, func(driverName, dataSourceName string) (*sql.DB, error) {
+				dsn = dataSourceName
+				return &sql.DB{}, nil
+			})
+			defer monkey.UnpatchAll()
+			db, err := Open("root", "root", "", "127.0.0.1", uint16(3307))
+			Expect(err).To(BeNil())
+			Expect(db).NotTo(BeNil())
+			Expect(dsn).To(HavePrefix("root:root@tcp(127.0.0.1:3307)/"))
+		})
+	})
+})
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelBackupSchedule is the label of the backups created by a ShardingSphereBackupSchedule, the value is its name
+	LabelBackupSchedule = "shardingsphere.apache.org/backup-schedule"
+)
+
+// +kubebuilder:object:root=true
+
+// ShardingSphereBackupScheduleList contains a list of ShardingSphereBackupSchedule
+type ShardingSphereBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ShardingSphereBackupSchedule `json:"items"`
+}
+
+// +kubebuilder:printcolumn:JSONPath=".spec.schedule",name=Schedule,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.suspend",name=Suspend,type=boolean
+// +kubebuilder:printcolumn:JSONPath=".status.lastScheduleTime",name=LastSchedule,type=date
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+// +kubebuilder:resource:shortName=ssbackupschedule
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ShardingSphereBackupSchedule creates ShardingSphereBackups on a cron schedule, and deletes the backups
+// which are out of the retention policies. The backups are not deleted with the schedule.
+type ShardingSphereBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ShardingSphereBackupScheduleSpec `json:"spec,omitempty"`
+	// +optional
+	Status ShardingSphereBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// ShardingSphereBackupScheduleSpec defines the desired state of ShardingSphereBackupSchedule
+type ShardingSphereBackupScheduleSpec struct {
+	// Schedule in cron format, e.g. "0 2 * * *"
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+	// Suspend stops creating backups, the retention policies are still applied
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// BackupTemplate is the spec of the backups created
+	// +kubebuilder:validation:Required
+	BackupTemplate ShardingSphereBackupSpec `json:"backupTemplate"`
+	// +optional
+	Retention BackupRetention `json:"retention,omitempty"`
+}
+
+// BackupRetention a completed backup is kept if any of the policies keeps it, and a PTRACK backup keeps
+// the whole chain back to its FULL backup. Failed backups are always deleted, and nothing is deleted
+// if no policy is set.
+type BackupRetention struct {
+	// RetainCount keeps the newest n completed backups
+	// +optional
+	RetainCount int32 `json:"retainCount,omitempty"`
+	// RetainDays keeps the completed backups started within n days
+	// +optional
+	RetainDays int32 `json:"retainDays,omitempty"`
+	// RetainFull keeps the newest n FULL backups and their PTRACK backups
+	// +optional
+	RetainFull int32 `json:"retainFull,omitempty"`
+}
+
+// ShardingSphereBackupScheduleStatus defines the actual state of ShardingSphereBackupSchedule
+type ShardingSphereBackupScheduleStatus struct {
+	// The generation observed by the backup schedule controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastScheduleTime is the last time a backup was created
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastBackupName is the backup created last time
+	// +optional
+	LastBackupName string `json:"lastBackupName,omitempty"`
+	// LastSuccessfulTime is the start time of the last completed backup
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ShardingSphereBackupSchedule{}, &ShardingSphereBackupScheduleList{})
+}
@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type BackupPhase string
+
+const (
+	BackupPhasePending   BackupPhase = "Pending"
+	BackupPhaseRunning   BackupPhase = "Running"
+	BackupPhaseCompleted BackupPhase = "Completed"
+	BackupPhaseFailed    BackupPhase = "Failed"
+)
+
+const (
+	// BackupModeFull backups all data of the data nodes
+	BackupModeFull = "FULL"
+	// BackupModePTrack backups the pages changed since the last backup, only openGauss supports it
+	BackupModePTrack = "PTRACK"
+)
+
+// +kubebuilder:object:root=true
+
+// ShardingSphereBackupList contains a list of ShardingSphereBackup
+type ShardingSphereBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ShardingSphereBackup `json:"items"`
+}
+
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=Phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.computeNodeName",name=ComputeNode,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.backupMode",name=Mode,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.csn",name=CSN,type=string,priority=1
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+// +kubebuilder:resource:shortName=ssbackup
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ShardingSphereBackup is a backup of a ShardingSphere cluster taken by the PITR agents of its storage nodes.
+// The cluster is locked while the metadata is exported and the backups of all data nodes are started,
+// the backups on the data nodes and the Secret of metadata are deleted with it.
+type ShardingSphereBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ShardingSphereBackupSpec `json:"spec,omitempty"`
+	// +optional
+	Status ShardingSphereBackupStatus `json:"status,omitempty"`
+}
+
+// ShardingSphereBackupSpec defines the desired state of ShardingSphereBackup
+type ShardingSphereBackupSpec struct {
+	// ComputeNodeName is the ComputeNode in the same namespace, whose storage nodes are backed up
+	// +kubebuilder:validation:Required
+	ComputeNodeName string `json:"computeNodeName"`
+	// BackupPath is the directory of backups on the hosts of storage nodes
+	// +kubebuilder:validation:Required
+	BackupPath string `json:"backupPath"`
+	// +optional
+	// +kubebuilder:validation:Enum=FULL;PTRACK
+	// +kubebuilder:default=FULL
+	BackupMode string `json:"backupMode,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	ThreadsNum int32 `json:"threadsNum,omitempty"`
+	// +optional
+	Agent PITRAgent `json:"agent,omitempty"`
+}
+
+// PITRAgent is the PITR agent server running on the host of every storage node
+type PITRAgent struct {
+	// +optional
+	// +kubebuilder:default=443
+	Port int32 `json:"port,omitempty"`
+	// CASecretRef is the CA certificate to verify the agent servers,
+	// the certificates of agent servers are not verified if it is not set.
+	// +optional
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+	// TokenSecretRef is the bearer token authorized by the policy of agent servers
+	// +optional
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// ShardingSphereBackupStatus defines the actual state of ShardingSphereBackup
+type ShardingSphereBackupStatus struct {
+	// The generation observed by the backup controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is a brief summary of the backup:
+	// Pending: the backup is not started yet
+	// Running: the backups of data nodes are being started or started, the cluster is unlocked once all of them are started
+	// Completed: the backups of all data nodes are completed
+	// Failed: the backup failed, see message and data nodes
+	// +optional
+	Phase BackupPhase `json:"phase,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// BackupKey identifies the start of the backups of data nodes, it is persisted with the Running phase
+	// before any agent server is called, so the backups are never started twice.
+	// +optional
+	BackupKey string `json:"backupKey,omitempty"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// CSN is the commit sequence number locked with the cluster, only openGauss has it
+	// +optional
+	CSN string `json:"csn,omitempty"`
+	// MetaDataSecretRef refers to the cluster metadata exported from ShardingSphere-Proxy, it is imported when restored.
+	// The metadata holds the credentials of storage nodes, so it is kept in a Secret owned by the backup.
+	// +optional
+	MetaDataSecretRef *corev1.SecretKeySelector `json:"metaDataSecretRef,omitempty"`
+	// DataNodes are the backups of the storage nodes
+	// +optional
+	DataNodes []DataNodeBackupStatus `json:"dataNodes,omitempty"`
+}
+
+// DataNodeBackupStatus is the backup of a storage node taken by its agent server
+type DataNodeBackupStatus struct {
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+	// Instance is the backup instance of the storage node, storage nodes on the same host have their own instances
+	Instance string `json:"instance"`
+	// +optional
+	BackupID string `json:"backupID,omitempty"`
+	// Status is the backup status reported by the agent server, Running, Completed or Failed
+	// +optional
+	Status string `json:"status,omitempty"`
+	// +optional
+	StopLSN string `json:"stopLSN,omitempty"`
+	// +optional
+	RecoveryTime string `json:"recoveryTime,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ShardingSphereBackup{}, &ShardingSphereBackupList{})
+}
@@ -38,6 +38,7 @@ type ComputeNodeList struct {
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.spec.selectors
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // ComputeNode is the Schema for the ShardingSphere Proxy API
 type ComputeNode struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -63,9 +64,15 @@ type ComputeNodePrivilege struct {
 // Format:
 // user:<username>@<hostname>,hostname is % or empty string means do not care about authorized host
 // password:<password>
+// The password could be referred by passwordSecretRef instead of being written in plaintext.
 type ComputeNodeUser struct {
-	User     string `json:"user" yaml:"user"`
-	Password string `json:"password" yaml:"password"`
+	User string `json:"user" yaml:"user"`
+	// +optional
+	Password string `json:"password,omitempty" yaml:"password"`
+	// PasswordSecretRef refers to the key of a Secret in the namespace of compute node holding the password,
+	// it takes precedence over Password.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty" yaml:"-"`
 }
 
 // ComputeNodeAuthority  is used to set up initial user to login compute node, and authority data of storage node.
@@ -0,0 +1,262 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1beta1"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &ComputeNode{}
+
+// ConvertTo converts this ComputeNode to the hub version v1beta1
+func (in *ComputeNode) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1beta1.ComputeNode)
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	dst.Spec = convertComputeNodeSpecTo(in.Spec.DeepCopy())
+	dst.Status = convertComputeNodeStatusTo(in.Status.DeepCopy())
+	return nil
+}
+
+// ConvertFrom converts the hub version v1beta1 to this ComputeNode
+func (in *ComputeNode) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1beta1.ComputeNode)
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	in.Spec = convertComputeNodeSpecFrom(src.Spec.DeepCopy())
+	in.Status = convertComputeNodeStatusFrom(src.Status.DeepCopy())
+	return nil
+}
+
+func convertComputeNodeSpecTo(in *ComputeNodeSpec) v1beta1.ComputeNodeSpec {
+	out := v1beta1.ComputeNodeSpec{
+		ServerVersion:    in.ServerVersion,
+		Replicas:         in.Replicas,
+		Selector:         in.Selector,
+		Probes:           (*v1beta1.ProxyProbe)(in.Probes),
+		ImagePullSecrets: in.ImagePullSecrets,
+		Env:              in.Env,
+		Resources:        in.Resources,
+		ServiceType:      in.ServiceType,
+		Bootstrap: v1beta1.BootstrapConfig{
+			ServerConfig:  convertServerConfigTo(&in.Bootstrap.ServerConfig),
+			LogbackConfig: v1beta1.LogbackConfig(in.Bootstrap.LogbackConfig),
+			AgentConfig:   convertAgentConfigTo(&in.Bootstrap.AgentConfig),
+		},
+	}
+	if in.StorageNodeConnector != nil {
+		out.StorageNodeConnector = &v1beta1.StorageNodeConnector{
+			Type:    v1beta1.ConnectorType(in.StorageNodeConnector.Type),
+			Version: in.StorageNodeConnector.Version,
+		}
+	}
+	if in.PortBindings != nil {
+		out.PortBindings = make([]v1beta1.PortBinding, len(in.PortBindings))
+		for i := range in.PortBindings {
+			out.PortBindings[i] = v1beta1.PortBinding(in.PortBindings[i])
+		}
+	}
+	return out
+}
+
+func convertComputeNodeSpecFrom(in *v1beta1.ComputeNodeSpec) ComputeNodeSpec {
+	out := ComputeNodeSpec{
+		ServerVersion:    in.ServerVersion,
+		Replicas:         in.Replicas,
+		Selector:         in.Selector,
+		Probes:           (*ProxyProbe)(in.Probes),
+		ImagePullSecrets: in.ImagePullSecrets,
+		Env:              in.Env,
+		Resources:        in.Resources,
+		ServiceType:      in.ServiceType,
+		Bootstrap: BootstrapConfig{
+			ServerConfig:  convertServerConfigFrom(&in.Bootstrap.ServerConfig),
+			LogbackConfig: LogbackConfig(in.Bootstrap.LogbackConfig),
+			AgentConfig:   convertAgentConfigFrom(&in.Bootstrap.AgentConfig),
+		},
+	}
+	if in.StorageNodeConnector != nil {
+		out.StorageNodeConnector = &StorageNodeConnector{
+			Type:    ConnectorType(in.StorageNodeConnector.Type),
+			Version: in.StorageNodeConnector.Version,
+		}
+	}
+	if in.PortBindings != nil {
+		out.PortBindings = make([]PortBinding, len(in.PortBindings))
+		for i := range in.PortBindings {
+			out.PortBindings[i] = PortBinding(in.PortBindings[i])
+		}
+	}
+	return out
+}
+
+func convertServerConfigTo(in *ServerConfig) v1beta1.ServerConfig {
+	out := v1beta1.ServerConfig{
+		Authority: v1beta1.ComputeNodeAuthority{
+			Privilege: v1beta1.ComputeNodePrivilege{
+				Type: v1beta1.PrivilegeType(in.Authority.Privilege.Type),
+			},
+		},
+		Mode: v1beta1.ComputeNodeServerMode{
+			Repository: v1beta1.Repository{
+				Type:  v1beta1.RepositoryType(in.Mode.Repository.Type),
+				Props: v1beta1.Properties(in.Mode.Repository.Props),
+			},
+			Type: v1beta1.ModeType(in.Mode.Type),
+		},
+		Props: v1beta1.Properties(in.Props),
+	}
+	if in.Authority.Users != nil {
+		out.Authority.Users = make([]v1beta1.ComputeNodeUser, len(in.Authority.Users))
+		for i := range in.Authority.Users {
+			out.Authority.Users[i] = v1beta1.ComputeNodeUser(in.Authority.Users[i])
+		}
+	}
+	return out
+}
+
+func convertServerConfigFrom(in *v1beta1.ServerConfig) ServerConfig {
+	out := ServerConfig{
+		Authority: ComputeNodeAuthority{
+			Privilege: ComputeNodePrivilege{
+				Type: PrivilegeType(in.Authority.Privilege.Type),
+			},
+		},
+		Mode: ComputeNodeServerMode{
+			Repository: Repository{
+				Type:  RepositoryType(in.Mode.Repository.Type),
+				Props: Properties(in.Mode.Repository.Props),
+			},
+			Type: ModeType(in.Mode.Type),
+		},
+		Props: Properties(in.Props),
+	}
+	if in.Authority.Users != nil {
+		out.Authority.Users = make([]ComputeNodeUser, len(in.Authority.Users))
+		for i := range in.Authority.Users {
+			out.Authority.Users[i] = ComputeNodeUser(in.Authority.Users[i])
+		}
+	}
+	return out
+}
+
+func convertAgentConfigTo(in *AgentConfig) v1beta1.AgentConfig {
+	if in.Plugins == nil {
+		return v1beta1.AgentConfig{}
+	}
+
+	plugins := &v1beta1.AgentPlugin{}
+	if l := in.Plugins.Logging; l != nil {
+		plugins.Logging = &v1beta1.PluginLogging{
+			File: v1beta1.LoggingFile{Props: v1beta1.Properties(l.File.Props)},
+		}
+	}
+	if m := in.Plugins.Metrics; m != nil {
+		plugins.Metrics = &v1beta1.PluginMetrics{
+			Prometheus: v1beta1.Prometheus{
+				Host:  m.Prometheus.Host,
+				Port:  m.Prometheus.Port,
+				Props: v1beta1.Properties(m.Prometheus.Props),
+			},
+		}
+	}
+	if t := in.Plugins.Tracing; t != nil {
+		plugins.Tracing = &v1beta1.PluginTracing{
+			OpenTracing:   v1beta1.OpenTracing{Props: v1beta1.Properties(t.OpenTracing.Props)},
+			OpenTelemetry: v1beta1.OpenTelemetry{Props: v1beta1.Properties(t.OpenTelemetry.Props)},
+		}
+	}
+	return v1beta1.AgentConfig{Plugins: plugins}
+}
+
+func convertAgentConfigFrom(in *v1beta1.AgentConfig) AgentConfig {
+	if in.Plugins == nil {
+		return AgentConfig{}
+	}
+
+	plugins := &AgentPlugin{}
+	if l := in.Plugins.Logging; l != nil {
+		plugins.Logging = &PluginLogging{
+			File: LoggingFile{Props: Properties(l.File.Props)},
+		}
+	}
+	if m := in.Plugins.Metrics; m != nil {
+		plugins.Metrics = &PluginMetrics{
+			Prometheus: Prometheus{
+				Host:  m.Prometheus.Host,
+				Port:  m.Prometheus.Port,
+				Props: Properties(m.Prometheus.Props),
+			},
+		}
+	}
+	if t := in.Plugins.Tracing; t != nil {
+		plugins.Tracing = &PluginTracing{
+			OpenTracing:   OpenTracing{Props: Properties(t.OpenTracing.Props)},
+			OpenTelemetry: OpenTelemetry{Props: Properties(t.OpenTelemetry.Props)},
+		}
+	}
+	return AgentConfig{Plugins: plugins}
+}
+
+func convertComputeNodeStatusTo(in *ComputeNodeStatus) v1beta1.ComputeNodeStatus {
+	out := v1beta1.ComputeNodeStatus{
+		Replicas:           in.Replicas,
+		Ready:              in.Ready,
+		ObservedGeneration: in.ObservedGeneration,
+		Phase:              v1beta1.ComputeNodePhaseStatus(in.Phase),
+		LoadBalancer:       v1beta1.LoadBalancerStatus(in.LoadBalancer),
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]v1beta1.ComputeNodeCondition, len(in.Conditions))
+		for i, c := range in.Conditions {
+			out.Conditions[i] = v1beta1.ComputeNodeCondition{
+				Type:               v1beta1.ComputeNodeConditionType(c.Type),
+				Status:             v1beta1.ConditionStatus(c.Status),
+				LastTransitionTime: c.LastTransitionTime,
+				LastUpdateTime:     c.LastUpdateTime,
+				Reason:             c.Reason,
+				Message:            c.Message,
+			}
+		}
+	}
+	return out
+}
+
+func convertComputeNodeStatusFrom(in *v1beta1.ComputeNodeStatus) ComputeNodeStatus {
+	out := ComputeNodeStatus{
+		Replicas:           in.Replicas,
+		Ready:              in.Ready,
+		ObservedGeneration: in.ObservedGeneration,
+		Phase:              ComputeNodePhaseStatus(in.Phase),
+		LoadBalancer:       LoadBalancerStatus(in.LoadBalancer),
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]ComputeNodeCondition, len(in.Conditions))
+		for i, c := range in.Conditions {
+			out.Conditions[i] = ComputeNodeCondition{
+				Type:               ComputeNodeConditionType(c.Type),
+				Status:             ConditionStatus(c.Status),
+				LastTransitionTime: c.LastTransitionTime,
+				LastUpdateTime:     c.LastUpdateTime,
+				Reason:             c.Reason,
+				Message:            c.Message,
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,372 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_ComputeNodeConversion(t *testing.T) {
+	cn := &ComputeNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Labels:      map[string]string{"app": "test"},
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: ComputeNodeSpec{
+			StorageNodeConnector: &StorageNodeConnector{Type: ConnectorTypeMySQL, Version: "5.1.47"},
+			ServerVersion:        "5.3.1",
+			Replicas:             2,
+			Selector:             &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Probes: &ProxyProbe{
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(3307)}},
+				},
+			},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "secret"}},
+			Env:              []corev1.EnvVar{{Name: "PORT", Value: "3307"}},
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+			PortBindings: []PortBinding{{Name: "server", ContainerPort: 3307, ServicePort: 3307, Protocol: corev1.ProtocolTCP}},
+			ServiceType:  corev1.ServiceTypeNodePort,
+			Bootstrap: BootstrapConfig{
+				ServerConfig: ServerConfig{
+					Authority: ComputeNodeAuthority{
+						Users: []ComputeNodeUser{
+							{User: "root@%", Password: "root"},
+							{User: "admin@%", PasswordSecretRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "admin"},
+								Key:                  "password",
+							}},
+						},
+						Privilege: ComputeNodePrivilege{Type: AllPermitted},
+					},
+					Mode: ComputeNodeServerMode{
+						Type: ModeTypeCluster,
+						Repository: Repository{
+							Type:  RepositoryTypeZookeeper,
+							Props: Properties{"namespace": "governance", "server-lists": "zk:2181"},
+						},
+					},
+					Props: Properties{"proxy-frontend-database-protocol-type": "MySQL"},
+				},
+				LogbackConfig: "<configuration/>",
+				AgentConfig: AgentConfig{
+					Plugins: &AgentPlugin{
+						Logging: &PluginLogging{File: LoggingFile{Props: Properties{"level": "INFO"}}},
+						Metrics: &PluginMetrics{Prometheus: Prometheus{Host: "0.0.0.0", Port: 9090}},
+						Tracing: &PluginTracing{OpenTelemetry: OpenTelemetry{Props: Properties{"otel-service-name": "proxy"}}},
+					},
+				},
+			},
+		},
+		Status: ComputeNodeStatus{
+			Replicas: 2,
+			Ready:    "2/2",
+			Phase:    ComputeNodeStatusReady,
+			Conditions: []ComputeNodeCondition{
+				{Type: ComputeNodeConditionReady, Status: ConditionStatusTrue, Reason: "Ready", Message: "ready"},
+			},
+			LoadBalancer: LoadBalancerStatus{ClusterIP: "10.0.0.1"},
+		},
+	}
+
+	hub := &v1beta1.ComputeNode{}
+	assert.NoError(t, cn.ConvertTo(hub))
+	assert.Equal(t, cn.ObjectMeta, hub.ObjectMeta)
+	assert.Equal(t, v1beta1.ConnectorTypeMySQL, hub.Spec.StorageNodeConnector.Type)
+	assert.Equal(t, v1beta1.RepositoryTypeZookeeper, hub.Spec.Bootstrap.ServerConfig.Mode.Repository.Type)
+	assert.Equal(t, cn.Spec.Bootstrap.ServerConfig.Authority.Users[1].PasswordSecretRef, hub.Spec.Bootstrap.ServerConfig.Authority.Users[1].PasswordSecretRef)
+
+	back := &ComputeNode{}
+	assert.NoError(t, back.ConvertFrom(hub))
+	assert.Equal(t, cn, back)
+
+	again := &v1beta1.ComputeNode{}
+	assert.NoError(t, back.ConvertTo(again))
+	assert.Equal(t, hub, again)
+}
+
+func Test_StorageNodeConversion(t *testing.T) {
+	cases := []struct {
+		name     string
+		node     *StorageNode
+		settings *v1beta1.ProviderSettings
+		annos    map[string]string
+	}{
+		{
+			name: "provider settings in annotations",
+			node: &StorageNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"foo":                              "bar",
+						AnnotationsInstanceIdentifier:      "test-instance",
+						AnnotationsInstanceDBName:          "test_db",
+						AnnotationsFinalSnapshotIdentifier: "test-final",
+						// an empty annotation is kept as it is
+						AnnotationsClusterIdentifier: "",
+					},
+				},
+				Spec: StorageNodeSpec{
+					StorageProviderName: "aws-rds-instance",
+					Schema:              "test",
+					Replicas:            1,
+					MasterUserCredential: &BasicCredential{
+						Username: "root",
+						PasswordSecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"},
+							Key:                  "password",
+						},
+					},
+					ComputeNodeRef:  &corev1.LocalObjectReference{Name: "test-compute-node"},
+					LogicDatabase:   "sharding_db",
+					StorageUnitName: "ds_0",
+				},
+				Status: StorageNodeStatus{
+					ObservedGeneration: 1,
+					Phase:              StorageNodePhaseReady,
+					Conditions: StorageNodeConditions{
+						{Type: StorageNodeConditionTypeAvailable, Status: corev1.ConditionTrue, Reason: "Ready"},
+					},
+					Cluster: ClusterStatus{
+						Status:          "available",
+						PrimaryEndpoint: Endpoint{Address: "primary", Port: 3306},
+						ReaderEndpoints: []Endpoint{{Address: "reader", Port: 3306}},
+						Properties:      map[string]string{"arn": "test"},
+					},
+					Instances:  []InstanceStatus{{Status: "available", Endpoint: Endpoint{Address: "primary", Port: 3306}}},
+					Registered: true,
+					StorageUnits: []StorageUnitStatus{
+						{ComputeNodeName: "test-compute-node", LogicDatabase: "sharding_db", StorageUnitName: "ds_0", Registered: true},
+					},
+				},
+			},
+			settings: &v1beta1.ProviderSettings{
+				InstanceIdentifier:      "test-instance",
+				InstanceDBName:          "test_db",
+				FinalSnapshotIdentifier: "test-final",
+				MasterUser: &v1beta1.BasicCredential{
+					Username: "root",
+					PasswordSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"},
+						Key:                  "password",
+					},
+				},
+			},
+			annos: map[string]string{"foo": "bar", AnnotationsClusterIdentifier: ""},
+		},
+		{
+			name: "only provider settings in annotations, the master user ones are kept",
+			node: &StorageNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationsClusterIdentifier:  "test-cluster",
+						AnnotationsMasterUserPassword: "root123456",
+					},
+				},
+				Spec: StorageNodeSpec{StorageProviderName: "aws-aurora", Replicas: 2},
+			},
+			settings: &v1beta1.ProviderSettings{ClusterIdentifier: "test-cluster"},
+			annos:    map[string]string{AnnotationsMasterUserPassword: "root123456"},
+		},
+		{
+			name: "no provider settings",
+			node: &StorageNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec:       StorageNodeSpec{StorageProviderName: "cnpg", Replicas: 1},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			hub := &v1beta1.StorageNode{}
+			assert.NoError(t, c.node.ConvertTo(hub))
+			assert.Equal(t, c.settings, hub.Spec.ProviderSettings)
+			assert.Equal(t, c.annos, hub.Annotations)
+
+			back := &StorageNode{}
+			assert.NoError(t, back.ConvertFrom(hub))
+			assert.Equal(t, c.node, back)
+
+			again := &v1beta1.StorageNode{}
+			assert.NoError(t, back.ConvertTo(again))
+			assert.Equal(t, hub, again)
+		})
+	}
+}
+
+func Test_StorageNodeLegacyStorageUnitConversion(t *testing.T) {
+	annos := map[string]string{
+		AnnotationsRegisterStorageUnitEnabled: "true",
+		AnnotationsComputeNodeName:            "test-compute-node",
+		AnnotationsLogicDatabaseName:          "sharding_db",
+	}
+	node := &StorageNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Annotations: annos},
+		Spec:       StorageNodeSpec{StorageProviderName: "aws-aurora", Replicas: 1},
+		Status:     StorageNodeStatus{Registered: true},
+	}
+
+	hub := &v1beta1.StorageNode{}
+	assert.NoError(t, node.ConvertTo(hub))
+	assert.Equal(t, &corev1.LocalObjectReference{Name: "test-compute-node"}, hub.Spec.ComputeNodeRef)
+	assert.Equal(t, "sharding_db", hub.Spec.LogicDatabase)
+	assert.Equal(t, annos, hub.Annotations)
+	assert.True(t, hub.Status.Registered)
+
+	// the legacy annotations are ignored once the compute node is referred
+	node.Spec.ComputeNodeRef = &corev1.LocalObjectReference{Name: "test-other-compute-node"}
+	node.Spec.LogicDatabase = "other_db"
+	assert.NoError(t, node.ConvertTo(hub))
+	assert.Equal(t, "test-other-compute-node", hub.Spec.ComputeNodeRef.Name)
+	assert.Equal(t, "other_db", hub.Spec.LogicDatabase)
+
+	// not enabled
+	node.Spec.ComputeNodeRef, node.Spec.LogicDatabase = nil, ""
+	node.Annotations = map[string]string{AnnotationsComputeNodeName: "test-compute-node"}
+	assert.NoError(t, node.ConvertTo(hub))
+	assert.Nil(t, hub.Spec.ComputeNodeRef)
+}
+
+func Test_StorageProviderConversion(t *testing.T) {
+	sp := &StorageProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: StorageProviderSpec{
+			Provisioner:   ProvisionerAWSRDSInstance,
+			Parameters:    map[string]string{"engine": "mysql", "engineVersion": "5.7"},
+			ReclaimPolicy: StorageReclaimPolicyDeleteWithFinalSnapshot,
+		},
+	}
+
+	hub := &v1beta1.StorageProvider{}
+	assert.NoError(t, sp.ConvertTo(hub))
+	assert.Equal(t, v1beta1.StorageReclaimPolicyDeleteWithFinalSnapshot, hub.Spec.ReclaimPolicy)
+
+	back := &StorageProvider{}
+	assert.NoError(t, back.ConvertFrom(hub))
+	assert.Equal(t, sp, back)
+}
+
+func Test_ShardingSphereProxyServerConfigConversion(t *testing.T) {
+	t.Run("from v1alpha1", func(t *testing.T) {
+		cfg := &ShardingSphereProxyServerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: ProxyConfigSpec{
+				ClusterConfig: ClusterConfig{
+					Type: "Cluster",
+					Repository: RepositoryConfig{
+						Type: "ZooKeeper",
+						Props: ClusterProps{
+							Namespace:                 "governance",
+							ServerLists:               "zk:2181",
+							RetryIntervalMilliseconds: 500,
+							MaxRetries:                3,
+						},
+					},
+				},
+				Authority: Auth{
+					Users:     []User{{User: "root@%", Password: "root"}},
+					Privilege: &Privilege{Type: "ALL_PERMITTED"},
+				},
+				Props: &Props{
+					KernelExecutorSize:                16,
+					CheckTableMetadataEnabled:         true,
+					ProxyFrontendDatabaseProtocolType: "MySQL",
+				},
+			},
+			Status: ProxyConfigStatus{MetadataRepository: "ZooKeeper"},
+		}
+
+		hub := &v1beta1.ShardingSphereProxyServerConfig{}
+		assert.NoError(t, cfg.ConvertTo(hub))
+		assert.Equal(t, v1beta1.ServerConfig{
+			Authority: v1beta1.ComputeNodeAuthority{
+				Users:     []v1beta1.ComputeNodeUser{{User: "root@%", Password: "root"}},
+				Privilege: v1beta1.ComputeNodePrivilege{Type: v1beta1.AllPermitted},
+			},
+			Mode: v1beta1.ComputeNodeServerMode{
+				Type: v1beta1.ModeTypeCluster,
+				Repository: v1beta1.Repository{
+					Type: v1beta1.RepositoryTypeZookeeper,
+					Props: v1beta1.Properties{
+						"namespace":                 "governance",
+						"server-lists":              "zk:2181",
+						"retryIntervalMilliseconds": "500",
+						"maxRetries":                "3",
+					},
+				},
+			},
+			Props: v1beta1.Properties{
+				"kernel-executor-size":                  "16",
+				"check-table-metadata-enabled":          "true",
+				"proxy-frontend-database-protocol-type": "MySQL",
+			},
+		}, hub.Spec)
+		assert.Nil(t, hub.Annotations)
+
+		back := &ShardingSphereProxyServerConfig{}
+		assert.NoError(t, back.ConvertFrom(hub))
+		assert.Equal(t, cfg, back)
+	})
+
+	t.Run("from v1beta1", func(t *testing.T) {
+		hub := &v1beta1.ShardingSphereProxyServerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Annotations: map[string]string{"foo": "bar"}},
+			Spec: v1beta1.ServerConfig{
+				Mode: v1beta1.ComputeNodeServerMode{
+					Type: v1beta1.ModeTypeStandalone,
+					Repository: v1beta1.Repository{
+						Type:  "JDBC",
+						Props: v1beta1.Properties{"provider": "H2", "maxRetries": "3"},
+					},
+				},
+				Props: v1beta1.Properties{
+					"sql-show":                     "true",
+					"kernel-executor-size":         "0",
+					"check-table-metadata-enabled": "false",
+					"proxy-backend-driver-type":    "JDBC",
+				},
+			},
+		}
+
+		alpha := &ShardingSphereProxyServerConfig{}
+		assert.NoError(t, alpha.ConvertFrom(hub))
+		assert.Equal(t, 3, alpha.Spec.ClusterConfig.Repository.Props.MaxRetries)
+		assert.Equal(t, "JDBC", alpha.Spec.Props.ProxyBackendDriverType)
+		assert.Contains(t, alpha.Annotations, AnnotationsConversionData)
+
+		back := &v1beta1.ShardingSphereProxyServerConfig{}
+		assert.NoError(t, alpha.ConvertTo(back))
+		assert.Equal(t, hub, back)
+	})
+}
@@ -0,0 +1,262 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1beta1"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// AnnotationsConversionData keeps the properties of v1beta1 ShardingSphereProxyServerConfig which could
+// not be represented by the typed props of v1alpha1, so that they survive a round trip through v1alpha1.
+const AnnotationsConversionData = "shardingsphere.apache.org/conversion-data"
+
+var _ conversion.Convertible = &ShardingSphereProxyServerConfig{}
+
+type proxyConfigConversionData struct {
+	Props           v1beta1.Properties `json:"props,omitempty"`
+	RepositoryProps v1beta1.Properties `json:"repositoryProps,omitempty"`
+}
+
+// typedProp binds a key of v1beta1 properties to a typed field of v1alpha1,
+// set reports false if the value could not be kept by the typed field as it is.
+type typedProp struct {
+	key string
+	get func() string
+	set func(string) bool
+}
+
+func stringProp(key string, f *string) typedProp {
+	return typedProp{
+		key: key,
+		get: func() string { return *f },
+		set: func(v string) bool {
+			*f = v
+			return v != ""
+		},
+	}
+}
+
+func intProp(key string, f *int) typedProp {
+	return typedProp{
+		key: key,
+		get: func() string {
+			if *f == 0 {
+				return ""
+			}
+			return strconv.Itoa(*f)
+		},
+		set: func(v string) bool {
+			n, err := strconv.Atoi(v)
+			if err != nil || n == 0 || strconv.Itoa(n) != v {
+				return false
+			}
+			*f = n
+			return true
+		},
+	}
+}
+
+func boolProp(key string, f *bool) typedProp {
+	return typedProp{
+		key: key,
+		get: func() string {
+			if !*f {
+				return ""
+			}
+			return strconv.FormatBool(*f)
+		},
+		set: func(v string) bool {
+			if v != "true" {
+				return false
+			}
+			*f = true
+			return true
+		},
+	}
+}
+
+func (p *Props) typedProps() []typedProp {
+	return []typedProp{
+		intProp("kernel-executor-size", &p.KernelExecutorSize),
+		boolProp("check-table-metadata-enabled", &p.CheckTableMetadataEnabled),
+		intProp("proxy-backend-query-fetch-size", &p.ProxyBackendQueryFetchSize),
+		boolProp("check-duplicate-table-enabled", &p.CheckDuplicateTableEnabled),
+		intProp("proxy-frontend-executor-size", &p.ProxyFrontendExecutorSize),
+		stringProp("proxy-backend-executor-suitable", &p.ProxyBackendExecutorSuitable),
+		stringProp("proxy-backend-driver-type", &p.ProxyBackendDriverType),
+		stringProp("proxy-frontend-database-protocol-type", &p.ProxyFrontendDatabaseProtocolType),
+	}
+}
+
+func (p *ClusterProps) typedProps() []typedProp {
+	return []typedProp{
+		stringProp("namespace", &p.Namespace),
+		stringProp("server-lists", &p.ServerLists),
+		intProp("retryIntervalMilliseconds", &p.RetryIntervalMilliseconds),
+		intProp("maxRetries", &p.MaxRetries),
+		intProp("timeToLiveSeconds", &p.TimeToLiveSeconds),
+		intProp("operationTimeoutMilliseconds", &p.OperationTimeoutMilliseconds),
+		stringProp("digest", &p.Digest),
+	}
+}
+
+// propsTo renders the typed props as v1beta1 properties, a nil map is returned if none is set
+func propsTo(typed []typedProp) v1beta1.Properties {
+	var out v1beta1.Properties
+	for _, p := range typed {
+		if v := p.get(); v != "" {
+			if out == nil {
+				out = v1beta1.Properties{}
+			}
+			out[p.key] = v
+		}
+	}
+	return out
+}
+
+// propsFrom sets the typed props by v1beta1 properties and returns the ones left
+func propsFrom(in v1beta1.Properties, typed []typedProp) v1beta1.Properties {
+	var left v1beta1.Properties
+	for k, v := range in {
+		found := false
+		for _, p := range typed {
+			if p.key == k {
+				found = p.set(v)
+				break
+			}
+		}
+		if !found {
+			if left == nil {
+				left = v1beta1.Properties{}
+			}
+			left[k] = v
+		}
+	}
+	return left
+}
+
+// ConvertTo converts this ShardingSphereProxyServerConfig to the hub version v1beta1
+func (in *ShardingSphereProxyServerConfig) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1beta1.ShardingSphereProxyServerConfig)
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+
+	data := &proxyConfigConversionData{}
+	if v, ok := dst.Annotations[AnnotationsConversionData]; ok {
+		if err := json.Unmarshal([]byte(v), data); err != nil {
+			return err
+		}
+		delete(dst.Annotations, AnnotationsConversionData)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	spec := in.Spec.DeepCopy()
+	dst.Spec = v1beta1.ServerConfig{
+		Mode: v1beta1.ComputeNodeServerMode{
+			Type: v1beta1.ModeType(spec.ClusterConfig.Type),
+			Repository: v1beta1.Repository{
+				Type:  v1beta1.RepositoryType(spec.ClusterConfig.Repository.Type),
+				Props: mergeProps(propsTo(spec.ClusterConfig.Repository.Props.typedProps()), data.RepositoryProps),
+			},
+		},
+	}
+	if spec.Authority.Users != nil {
+		dst.Spec.Authority.Users = make([]v1beta1.ComputeNodeUser, len(spec.Authority.Users))
+		for i := range spec.Authority.Users {
+			dst.Spec.Authority.Users[i] = v1beta1.ComputeNodeUser(spec.Authority.Users[i])
+		}
+	}
+	if spec.Authority.Privilege != nil {
+		dst.Spec.Authority.Privilege.Type = v1beta1.PrivilegeType(spec.Authority.Privilege.Type)
+	}
+	if spec.Props != nil {
+		dst.Spec.Props = mergeProps(propsTo(spec.Props.typedProps()), data.Props)
+		if dst.Spec.Props == nil {
+			dst.Spec.Props = v1beta1.Properties{}
+		}
+	}
+
+	dst.Status = v1beta1.ProxyConfigStatus{MetadataRepository: in.Status.MetadataRepository}
+	return nil
+}
+
+// ConvertFrom converts the hub version v1beta1 to this ShardingSphereProxyServerConfig,
+// the properties not supported by v1alpha1 are kept in the annotation AnnotationsConversionData.
+func (in *ShardingSphereProxyServerConfig) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1beta1.ShardingSphereProxyServerConfig)
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	spec := src.Spec.DeepCopy()
+	in.Spec = ProxyConfigSpec{
+		ClusterConfig: ClusterConfig{
+			Type: string(spec.Mode.Type),
+			Repository: RepositoryConfig{
+				Type: string(spec.Mode.Repository.Type),
+			},
+		},
+	}
+	data := &proxyConfigConversionData{
+		RepositoryProps: propsFrom(spec.Mode.Repository.Props, in.Spec.ClusterConfig.Repository.Props.typedProps()),
+	}
+	if spec.Authority.Users != nil {
+		in.Spec.Authority.Users = make([]User, len(spec.Authority.Users))
+		for i := range spec.Authority.Users {
+			in.Spec.Authority.Users[i] = User(spec.Authority.Users[i])
+		}
+	}
+	if spec.Authority.Privilege.Type != "" {
+		in.Spec.Authority.Privilege = &Privilege{Type: string(spec.Authority.Privilege.Type)}
+	}
+	if spec.Props != nil {
+		in.Spec.Props = &Props{}
+		data.Props = propsFrom(spec.Props, in.Spec.Props.typedProps())
+	}
+
+	if data.Props != nil || data.RepositoryProps != nil {
+		v, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if in.Annotations == nil {
+			in.Annotations = map[string]string{}
+		}
+		in.Annotations[AnnotationsConversionData] = string(v)
+	}
+
+	in.Status = ProxyConfigStatus{MetadataRepository: src.Status.MetadataRepository}
+	return nil
+}
+
+func mergeProps(typed, left v1beta1.Properties) v1beta1.Properties {
+	if len(left) == 0 {
+		return typed
+	}
+	if typed == nil {
+		typed = v1beta1.Properties{}
+	}
+	for k, v := range left {
+		typed[k] = v
+	}
+	return typed
+}
@@ -37,6 +37,7 @@ type ProxyConfigStatus struct {
 //+kubebuilder:printcolumn:JSONPath=".status.metadataRepository",name=MetadataRepository,type=string
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // ShardingSphereProxyServerConfig is the Schema for the proxyconfigs API
 type ShardingSphereProxyServerConfig struct {
@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RestorePhase string
+
+const (
+	RestorePhasePending   RestorePhase = "Pending"
+	RestorePhaseRunning   RestorePhase = "Running"
+	RestorePhaseCompleted RestorePhase = "Completed"
+	RestorePhaseFailed    RestorePhase = "Failed"
+)
+
+// +kubebuilder:object:root=true
+
+// ShardingSphereRestoreList contains a list of ShardingSphereRestore
+type ShardingSphereRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ShardingSphereRestore `json:"items"`
+}
+
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=Phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.backupName",name=Backup,type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+// +kubebuilder:resource:shortName=ssrestore
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ShardingSphereRestore restores a ShardingSphere cluster from a completed ShardingSphereBackup.
+// The data nodes are restored by their agent servers, and then the logic databases of the backup
+// are dropped and the metadata of the backup is imported into ShardingSphere-Proxy.
+type ShardingSphereRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ShardingSphereRestoreSpec `json:"spec,omitempty"`
+	// +optional
+	Status ShardingSphereRestoreStatus `json:"status,omitempty"`
+}
+
+// ShardingSphereRestoreSpec defines the desired state of ShardingSphereRestore
+type ShardingSphereRestoreSpec struct {
+	// BackupName is the ShardingSphereBackup in the same namespace to restore from
+	// +kubebuilder:validation:Required
+	BackupName string `json:"backupName"`
+	// ComputeNodeName is the ComputeNode to restore, the ComputeNode of the backup is used if it is not set
+	// +optional
+	ComputeNodeName string `json:"computeNodeName,omitempty"`
+	// RecoveryTarget replays the wal or binlog of the data nodes up to the target after the backup is restored
+	// +optional
+	RecoveryTarget *RecoveryTarget `json:"recoveryTarget,omitempty"`
+	// ConsistentCSN replays the wal of all data nodes up to the csn of the backup, so the cluster is transactionally consistent
+	// +optional
+	ConsistentCSN bool `json:"consistentCSN,omitempty"`
+}
+
+// RecoveryTarget only one of Time, LSN, Xid, Name, Position and GTID can be set,
+// Position and GTID are of MySQL binlog.
+type RecoveryTarget struct {
+	// +optional
+	Time string `json:"time,omitempty"`
+	// +optional
+	LSN string `json:"lsn,omitempty"`
+	// +optional
+	Xid string `json:"xid,omitempty"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Position string `json:"position,omitempty"`
+	// +optional
+	GTID string `json:"gtid,omitempty"`
+	// Inclusive stops just after the target if it is true, or just before it
+	// +optional
+	// +kubebuilder:default=true
+	Inclusive *bool `json:"inclusive,omitempty"`
+}
+
+// ShardingSphereRestoreStatus defines the actual state of ShardingSphereRestore
+type ShardingSphereRestoreStatus struct {
+	// The generation observed by the restore controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is a brief summary of the restore:
+	// Pending: the restore is not started yet
+	// Running: the restore jobs of data nodes are started
+	// Completed: all data nodes are restored and the metadata is imported
+	// Failed: the restore failed, see message and data nodes
+	// +optional
+	Phase RestorePhase `json:"phase,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// DataNodes are the restore jobs of the storage nodes
+	// +optional
+	DataNodes []DataNodeRestoreStatus `json:"dataNodes,omitempty"`
+}
+
+// DataNodeRestoreStatus is the restore job of a storage node run by its agent server
+type DataNodeRestoreStatus struct {
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+	// +optional
+	JobID string `json:"jobID,omitempty"`
+	// State is the job state reported by the agent server, Running, Succeeded or Failed
+	// +optional
+	State string `json:"state,omitempty"`
+	// +optional
+	ReplayLSN string `json:"replayLSN,omitempty"`
+	// +optional
+	ReplayTime string `json:"replayTime,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ShardingSphereRestore{}, &ShardingSphereRestoreList{})
+}
@@ -17,13 +17,23 @@
 
 package v1alpha1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 // User is a slice about authorized host and password for compute node.
 // Format:
 // user:<username>@<hostname>,hostname is % or empty string means do not care about authorized host
 // password:<password>
+// The password could be referred by passwordSecretRef instead of being written in plaintext.
 type User struct {
-	User     string `json:"user" yaml:"user"`
-	Password string `json:"password" yaml:"password"`
+	User string `json:"user" yaml:"user"`
+	// +optional
+	Password string `json:"password,omitempty" yaml:"password"`
+	// PasswordSecretRef refers to the key of a Secret in the namespace of server config holding the password,
+	// it takes precedence over Password.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty" yaml:"-"`
 }
 
 // Privilege for storage node, the default value is ALL_PRIVILEGES_PERMITTED
@@ -22,6 +22,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// The legacy annotations to register the storage node as a storage unit, which are deprecated by
+// spec.computeNodeRef and spec.logicDatabase. They are only honored if spec.computeNodeRef is not set.
+const (
+	AnnotationsRegisterStorageUnitEnabled = "shardingsphere.apache.org/register-storage-unit-enabled"
+	AnnotationsComputeNodeName            = "shardingsphere.apache.org/compute-node-name"
+	AnnotationsLogicDatabaseName          = "shardingsphere.apache.org/logic-database-name"
+)
+
 type StorageNodePhaseStatus string
 
 const (
@@ -77,8 +85,13 @@ type InstanceStatus struct {
 }
 
 type BasicCredential struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	// +optional
+	Username string `json:"username,omitempty"`
+	// +optional
+	Password string `json:"password,omitempty"`
+	// PasswordSecretRef refers to the key of a Secret holding the password, it takes precedence over Password.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
 }
 
 type Endpoint struct {
@@ -102,6 +115,7 @@ type StorageNodeList struct {
 // +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // StorageNode is the Schema for the ShardingSphere storage unit
 type StorageNode struct {
@@ -127,6 +141,48 @@ type StorageNodeSpec struct {
 	// aws rds cluster will auto create 3 instances(1 primary and 2 replicas).
 	// +kubebuilder:default=1
 	Replicas int32 `json:"replicas"`
+	// MasterUserCredential is the master user of the database, it overrides the master user annotations
+	// and the parameters of storage provider.
+	// +optional
+	MasterUserCredential *BasicCredential `json:"masterUserCredential,omitempty"`
+	// ComputeNodeRef refers to the ComputeNode in the same namespace which the storage node is registered to
+	// as a storage unit once it is ready.
+	// +optional
+	ComputeNodeRef *corev1.LocalObjectReference `json:"computeNodeRef,omitempty"`
+	// LogicDatabase is the logic database of the ComputeNode which the storage unit is registered into,
+	// it is created if it does not exist. It is required with computeNodeRef.
+	// +optional
+	LogicDatabase string `json:"logicDatabase,omitempty"`
+	// StorageUnitName is the name of the registered storage unit, and the default value is ds_<name of storage node>.
+	// +optional
+	StorageUnitName string `json:"storageUnitName,omitempty"`
+}
+
+// LegacyStorageUnitRef returns the compute node and the logic database named by the legacy annotations,
+// the compute node name is empty if registering by the annotations is not enabled.
+func (in *StorageNode) LegacyStorageUnitRef() (computeNodeName, logicDatabase string) {
+	if in.Annotations[AnnotationsRegisterStorageUnitEnabled] != "true" {
+		return "", ""
+	}
+	return in.Annotations[AnnotationsComputeNodeName], in.Annotations[AnnotationsLogicDatabaseName]
+}
+
+// MigrateLegacyStorageUnitAnnotations sets spec.computeNodeRef and spec.logicDatabase by the legacy annotations
+// if spec.computeNodeRef is not set, and reports whether the spec is changed. The annotations are kept, since
+// the storage unit registered by them is recorded into status.storageUnits by the controller.
+func (in *StorageNode) MigrateLegacyStorageUnitAnnotations() bool {
+	if in.Spec.ComputeNodeRef != nil {
+		return false
+	}
+	computeNodeName, logicDatabase := in.LegacyStorageUnitRef()
+	if computeNodeName == "" {
+		return false
+	}
+	in.Spec.ComputeNodeRef = &corev1.LocalObjectReference{Name: computeNodeName}
+	if in.Spec.LogicDatabase == "" {
+		in.Spec.LogicDatabase = logicDatabase
+	}
+	return true
 }
 
 // StorageNodeStatus defines the actual state of a set of storage units
@@ -152,9 +208,28 @@ type StorageNodeStatus struct {
 	// Instances contains the current status of the StorageNode instance
 	Instances []InstanceStatus `json:"instances,omitempty"`
 
-	// Registered indicates whether the StorageNode has been registered to shardingsphere
+	// Registered indicates whether the StorageNode has been registered to the ComputeNode in spec.computeNodeRef
 	// +optional
 	Registered bool `json:"registered,omitempty"`
+
+	// StorageUnits contains the registration state of the storage node on every ComputeNode it has been registered to,
+	// an entry is removed once the storage unit is unregistered.
+	// +optional
+	StorageUnits []StorageUnitStatus `json:"storageUnits,omitempty"`
+}
+
+// StorageUnitStatus is the registration state of the storage node as a storage unit of a ComputeNode
+type StorageUnitStatus struct {
+	ComputeNodeName string `json:"computeNodeName"`
+	LogicDatabase   string `json:"logicDatabase"`
+	StorageUnitName string `json:"storageUnitName"`
+	// Registered indicates whether the storage unit has been registered
+	Registered bool `json:"registered"`
+	// Message is the reason why the storage unit is not registered yet
+	// +optional
+	Message string `json:"message,omitempty"`
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 // AddCondition adds the given condition to the StorageNodeConditions.
@@ -0,0 +1,245 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &StorageNode{}
+
+// providerSettingAnnotations are the annotations of StorageNode which are typed as ProviderSettings in v1beta1,
+// the master user annotations are kept as they are since the master user is typed as spec.masterUserCredential.
+var providerSettingAnnotations = []struct {
+	key string
+	get func(*v1beta1.ProviderSettings) string
+	set func(*v1beta1.ProviderSettings, string)
+}{
+	{
+		key: AnnotationsClusterIdentifier,
+		get: func(s *v1beta1.ProviderSettings) string { return s.ClusterIdentifier },
+		set: func(s *v1beta1.ProviderSettings, v string) { s.ClusterIdentifier = v },
+	},
+	{
+		key: AnnotationsInstanceIdentifier,
+		get: func(s *v1beta1.ProviderSettings) string { return s.InstanceIdentifier },
+		set: func(s *v1beta1.ProviderSettings, v string) { s.InstanceIdentifier = v },
+	},
+	{
+		key: AnnotationsInstanceDBName,
+		get: func(s *v1beta1.ProviderSettings) string { return s.InstanceDBName },
+		set: func(s *v1beta1.ProviderSettings, v string) { s.InstanceDBName = v },
+	},
+	{
+		key: AnnotationsSnapshotIdentifier,
+		get: func(s *v1beta1.ProviderSettings) string { return s.SnapshotIdentifier },
+		set: func(s *v1beta1.ProviderSettings, v string) { s.SnapshotIdentifier = v },
+	},
+	{
+		key: AnnotationsFinalSnapshotIdentifier,
+		get: func(s *v1beta1.ProviderSettings) string { return s.FinalSnapshotIdentifier },
+		set: func(s *v1beta1.ProviderSettings, v string) { s.FinalSnapshotIdentifier = v },
+	},
+}
+
+// ConvertTo converts this StorageNode to the hub version v1beta1, the provider settings in annotations
+// and spec.masterUserCredential are moved to spec.providerSettings, and the legacy storage unit annotations
+// are translated into spec.computeNodeRef and spec.logicDatabase.
+func (in *StorageNode) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1beta1.StorageNode)
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+
+	settings := &v1beta1.ProviderSettings{}
+	moved := false
+	for _, a := range providerSettingAnnotations {
+		if v := dst.Annotations[a.key]; v != "" {
+			a.set(settings, v)
+			delete(dst.Annotations, a.key)
+			moved = true
+		}
+	}
+	if moved && len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	if c := in.Spec.MasterUserCredential; c != nil {
+		settings.MasterUser = &v1beta1.BasicCredential{
+			Username:          c.Username,
+			Password:          c.Password,
+			PasswordSecretRef: c.PasswordSecretRef.DeepCopy(),
+		}
+		moved = true
+	}
+
+	dst.Spec = v1beta1.StorageNodeSpec{
+		StorageProviderName: in.Spec.StorageProviderName,
+		Schema:              in.Spec.Schema,
+		Replicas:            in.Spec.Replicas,
+		LogicDatabase:       in.Spec.LogicDatabase,
+		StorageUnitName:     in.Spec.StorageUnitName,
+	}
+	if in.Spec.ComputeNodeRef != nil {
+		dst.Spec.ComputeNodeRef = &corev1.LocalObjectReference{Name: in.Spec.ComputeNodeRef.Name}
+	} else if computeNodeName, logicDatabase := in.LegacyStorageUnitRef(); computeNodeName != "" {
+		// the legacy storage unit annotations are served as spec.computeNodeRef and spec.logicDatabase in v1beta1
+		dst.Spec.ComputeNodeRef = &corev1.LocalObjectReference{Name: computeNodeName}
+		if dst.Spec.LogicDatabase == "" {
+			dst.Spec.LogicDatabase = logicDatabase
+		}
+	}
+	if moved {
+		dst.Spec.ProviderSettings = settings
+	}
+
+	dst.Status = v1beta1.StorageNodeStatus{
+		ObservedGeneration: in.Status.ObservedGeneration,
+		Phase:              v1beta1.StorageNodePhaseStatus(in.Status.Phase),
+		Cluster: v1beta1.ClusterStatus{
+			Status:          in.Status.Cluster.Status,
+			PrimaryEndpoint: v1beta1.Endpoint(in.Status.Cluster.PrimaryEndpoint),
+			Properties:      copyStringMap(in.Status.Cluster.Properties),
+		},
+		Registered: in.Status.Registered,
+	}
+	if in.Status.Conditions != nil {
+		dst.Status.Conditions = make(v1beta1.StorageNodeConditions, len(in.Status.Conditions))
+		for i, c := range in.Status.Conditions {
+			if c == nil {
+				continue
+			}
+			dst.Status.Conditions[i] = &v1beta1.StorageNodeCondition{
+				Type:           v1beta1.StorageNodeConditionType(c.Type),
+				Status:         c.Status,
+				LastUpdateTime: c.LastUpdateTime,
+				Reason:         c.Reason,
+				Message:        c.Message,
+			}
+		}
+	}
+	if in.Status.Cluster.ReaderEndpoints != nil {
+		dst.Status.Cluster.ReaderEndpoints = make([]v1beta1.Endpoint, len(in.Status.Cluster.ReaderEndpoints))
+		for i := range in.Status.Cluster.ReaderEndpoints {
+			dst.Status.Cluster.ReaderEndpoints[i] = v1beta1.Endpoint(in.Status.Cluster.ReaderEndpoints[i])
+		}
+	}
+	if in.Status.Instances != nil {
+		dst.Status.Instances = make([]v1beta1.InstanceStatus, len(in.Status.Instances))
+		for i, ins := range in.Status.Instances {
+			dst.Status.Instances[i] = v1beta1.InstanceStatus{
+				Status:     ins.Status,
+				Endpoint:   v1beta1.Endpoint(ins.Endpoint),
+				Properties: copyStringMap(ins.Properties),
+			}
+		}
+	}
+	if in.Status.StorageUnits != nil {
+		dst.Status.StorageUnits = make([]v1beta1.StorageUnitStatus, len(in.Status.StorageUnits))
+		for i := range in.Status.StorageUnits {
+			dst.Status.StorageUnits[i] = v1beta1.StorageUnitStatus(in.Status.StorageUnits[i])
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version v1beta1 to this StorageNode, spec.providerSettings are moved back
+// to the annotations and spec.masterUserCredential.
+func (in *StorageNode) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1beta1.StorageNode)
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	if s := src.Spec.ProviderSettings; s != nil {
+		for _, a := range providerSettingAnnotations {
+			if v := a.get(s); v != "" {
+				if in.Annotations == nil {
+					in.Annotations = map[string]string{}
+				}
+				in.Annotations[a.key] = v
+			}
+		}
+	}
+
+	in.Spec = StorageNodeSpec{
+		StorageProviderName: src.Spec.StorageProviderName,
+		Schema:              src.Spec.Schema,
+		Replicas:            src.Spec.Replicas,
+		LogicDatabase:       src.Spec.LogicDatabase,
+		StorageUnitName:     src.Spec.StorageUnitName,
+	}
+	if s := src.Spec.ProviderSettings; s != nil && s.MasterUser != nil {
+		in.Spec.MasterUserCredential = &BasicCredential{
+			Username:          s.MasterUser.Username,
+			Password:          s.MasterUser.Password,
+			PasswordSecretRef: s.MasterUser.PasswordSecretRef.DeepCopy(),
+		}
+	}
+	if src.Spec.ComputeNodeRef != nil {
+		in.Spec.ComputeNodeRef = &corev1.LocalObjectReference{Name: src.Spec.ComputeNodeRef.Name}
+	}
+
+	in.Status = StorageNodeStatus{
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Phase:              StorageNodePhaseStatus(src.Status.Phase),
+		Cluster: ClusterStatus{
+			Status:          src.Status.Cluster.Status,
+			PrimaryEndpoint: Endpoint(src.Status.Cluster.PrimaryEndpoint),
+			Properties:      copyStringMap(src.Status.Cluster.Properties),
+		},
+		Registered: src.Status.Registered,
+	}
+	if src.Status.Conditions != nil {
+		in.Status.Conditions = make(StorageNodeConditions, len(src.Status.Conditions))
+		for i, c := range src.Status.Conditions {
+			if c == nil {
+				continue
+			}
+			in.Status.Conditions[i] = &StorageNodeCondition{
+				Type:           StorageNodeConditionType(c.Type),
+				Status:         c.Status,
+				LastUpdateTime: c.LastUpdateTime,
+				Reason:         c.Reason,
+				Message:        c.Message,
+			}
+		}
+	}
+	if src.Status.Cluster.ReaderEndpoints != nil {
+		in.Status.Cluster.ReaderEndpoints = make([]Endpoint, len(src.Status.Cluster.ReaderEndpoints))
+		for i := range src.Status.Cluster.ReaderEndpoints {
+			in.Status.Cluster.ReaderEndpoints[i] = Endpoint(src.Status.Cluster.ReaderEndpoints[i])
+		}
+	}
+	if src.Status.Instances != nil {
+		in.Status.Instances = make([]InstanceStatus, len(src.Status.Instances))
+		for i, ins := range src.Status.Instances {
+			in.Status.Instances[i] = InstanceStatus{
+				Status:     ins.Status,
+				Endpoint:   Endpoint(ins.Endpoint),
+				Properties: copyStringMap(ins.Properties),
+			}
+		}
+	}
+	if src.Status.StorageUnits != nil {
+		in.Status.StorageUnits = make([]StorageUnitStatus, len(src.Status.StorageUnits))
+		for i := range src.Status.StorageUnits {
+			in.Status.StorageUnits[i] = StorageUnitStatus(src.Status.StorageUnits[i])
+		}
+	}
+	return nil
+}
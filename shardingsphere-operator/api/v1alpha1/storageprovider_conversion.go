@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1beta1"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &StorageProvider{}
+
+// ConvertTo converts this StorageProvider to the hub version v1beta1
+func (in *StorageProvider) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1beta1.StorageProvider)
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.StorageProviderSpec{
+		Provisioner:   in.Spec.Provisioner,
+		Parameters:    copyStringMap(in.Spec.Parameters),
+		ReclaimPolicy: v1beta1.StorageReclaimPolicy(in.Spec.ReclaimPolicy),
+	}
+	dst.Status = v1beta1.StorageProviderStatus{}
+	return nil
+}
+
+// ConvertFrom converts the hub version v1beta1 to this StorageProvider
+func (in *StorageProvider) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1beta1.StorageProvider)
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	in.Spec = StorageProviderSpec{
+		Provisioner:   src.Spec.Provisioner,
+		Parameters:    copyStringMap(src.Spec.Parameters),
+		ReclaimPolicy: StorageReclaimPolicy(src.Spec.ReclaimPolicy),
+	}
+	in.Status = StorageProviderStatus{}
+	return nil
+}
+
+func copyStringMap(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
@@ -76,6 +76,7 @@ type StorageProviderStatus struct {
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster,shortName=sp
+//+kubebuilder:storageversion
 
 // StorageProvider is the Schema for the storageproviders API
 type StorageProvider struct {
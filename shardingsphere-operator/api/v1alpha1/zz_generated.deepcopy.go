@@ -85,7 +85,9 @@ func (in *Auth) DeepCopyInto(out *Auth) {
 	if in.Users != nil {
 		in, out := &in.Users, &out.Users
 		*out = make([]User, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Privilege != nil {
 		in, out := &in.Privilege, &out.Privilege
@@ -126,9 +128,29 @@ func (in *AutomaticScaling) DeepCopy() *AutomaticScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetention) DeepCopyInto(out *BackupRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetention.
+func (in *BackupRetention) DeepCopy() *BackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicCredential) DeepCopyInto(out *BasicCredential) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicCredential.
@@ -376,7 +398,9 @@ func (in *ComputeNodeAuthority) DeepCopyInto(out *ComputeNodeAuthority) {
 	if in.Users != nil {
 		in, out := &in.Users, &out.Users
 		*out = make([]ComputeNodeUser, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	out.Privilege = in.Privilege
 }
@@ -546,6 +570,11 @@ func (in *ComputeNodeStatus) DeepCopy() *ComputeNodeStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComputeNodeUser) DeepCopyInto(out *ComputeNodeUser) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComputeNodeUser.
@@ -633,7 +662,7 @@ func (in *CorruptionParams) DeepCopy() *CorruptionParams {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CredentialType) DeepCopyInto(out *CredentialType) {
 	*out = *in
-	out.BasicCredential = in.BasicCredential
+	in.BasicCredential.DeepCopyInto(&out.BasicCredential)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialType.
@@ -646,6 +675,36 @@ func (in *CredentialType) DeepCopy() *CredentialType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataNodeBackupStatus) DeepCopyInto(out *DataNodeBackupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataNodeBackupStatus.
+func (in *DataNodeBackupStatus) DeepCopy() *DataNodeBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataNodeBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataNodeRestoreStatus) DeepCopyInto(out *DataNodeRestoreStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataNodeRestoreStatus.
+func (in *DataNodeRestoreStatus) DeepCopy() *DataNodeRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataNodeRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DelayParams) DeepCopyInto(out *DelayParams) {
 	*out = *in
@@ -984,6 +1043,31 @@ func (in *OpenTracing) DeepCopy() *OpenTracing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PITRAgent) DeepCopyInto(out *PITRAgent) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITRAgent.
+func (in *PITRAgent) DeepCopy() *PITRAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(PITRAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginLogging) DeepCopyInto(out *PluginLogging) {
 	*out = *in
@@ -1441,6 +1525,26 @@ func (in *ProxyStatus) DeepCopy() *ProxyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryTarget) DeepCopyInto(out *RecoveryTarget) {
+	*out = *in
+	if in.Inclusive != nil {
+		in, out := &in.Inclusive, &out.Inclusive
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryTarget.
+func (in *RecoveryTarget) DeepCopy() *RecoveryTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Repository) DeepCopyInto(out *Repository) {
 	*out = *in
@@ -1557,6 +1661,213 @@ func (in *ServiceType) DeepCopy() *ServiceType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereBackup) DeepCopyInto(out *ShardingSphereBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereBackup.
+func (in *ShardingSphereBackup) DeepCopy() *ShardingSphereBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardingSphereBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereBackupList) DeepCopyInto(out *ShardingSphereBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ShardingSphereBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereBackupList.
+func (in *ShardingSphereBackupList) DeepCopy() *ShardingSphereBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardingSphereBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereBackupSchedule) DeepCopyInto(out *ShardingSphereBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereBackupSchedule.
+func (in *ShardingSphereBackupSchedule) DeepCopy() *ShardingSphereBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardingSphereBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereBackupScheduleList) DeepCopyInto(out *ShardingSphereBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ShardingSphereBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereBackupScheduleList.
+func (in *ShardingSphereBackupScheduleList) DeepCopy() *ShardingSphereBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardingSphereBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereBackupScheduleSpec) DeepCopyInto(out *ShardingSphereBackupScheduleSpec) {
+	*out = *in
+	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
+	out.Retention = in.Retention
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereBackupScheduleSpec.
+func (in *ShardingSphereBackupScheduleSpec) DeepCopy() *ShardingSphereBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereBackupScheduleStatus) DeepCopyInto(out *ShardingSphereBackupScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereBackupScheduleStatus.
+func (in *ShardingSphereBackupScheduleStatus) DeepCopy() *ShardingSphereBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereBackupSpec) DeepCopyInto(out *ShardingSphereBackupSpec) {
+	*out = *in
+	in.Agent.DeepCopyInto(&out.Agent)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereBackupSpec.
+func (in *ShardingSphereBackupSpec) DeepCopy() *ShardingSphereBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereBackupStatus) DeepCopyInto(out *ShardingSphereBackupStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.MetaDataSecretRef != nil {
+		in, out := &in.MetaDataSecretRef, &out.MetaDataSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataNodes != nil {
+		in, out := &in.DataNodes, &out.DataNodes
+		*out = make([]DataNodeBackupStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereBackupStatus.
+func (in *ShardingSphereBackupStatus) DeepCopy() *ShardingSphereBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardingSphereProxy) DeepCopyInto(out *ShardingSphereProxy) {
 	*out = *in
@@ -1675,12 +1986,119 @@ func (in *ShardingSphereProxyServerConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereRestore) DeepCopyInto(out *ShardingSphereRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereRestore.
+func (in *ShardingSphereRestore) DeepCopy() *ShardingSphereRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardingSphereRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereRestoreList) DeepCopyInto(out *ShardingSphereRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ShardingSphereRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereRestoreList.
+func (in *ShardingSphereRestoreList) DeepCopy() *ShardingSphereRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardingSphereRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereRestoreSpec) DeepCopyInto(out *ShardingSphereRestoreSpec) {
+	*out = *in
+	if in.RecoveryTarget != nil {
+		in, out := &in.RecoveryTarget, &out.RecoveryTarget
+		*out = new(RecoveryTarget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereRestoreSpec.
+func (in *ShardingSphereRestoreSpec) DeepCopy() *ShardingSphereRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingSphereRestoreStatus) DeepCopyInto(out *ShardingSphereRestoreStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DataNodes != nil {
+		in, out := &in.DataNodes, &out.DataNodes
+		*out = make([]DataNodeRestoreStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSphereRestoreStatus.
+func (in *ShardingSphereRestoreStatus) DeepCopy() *ShardingSphereRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingSphereRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageNode) DeepCopyInto(out *StorageNode) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1793,6 +2211,16 @@ func (in *StorageNodeList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageNodeSpec) DeepCopyInto(out *StorageNodeSpec) {
 	*out = *in
+	if in.MasterUserCredential != nil {
+		in, out := &in.MasterUserCredential, &out.MasterUserCredential
+		*out = new(BasicCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComputeNodeRef != nil {
+		in, out := &in.ComputeNodeRef, &out.ComputeNodeRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageNodeSpec.
@@ -1827,6 +2255,13 @@ func (in *StorageNodeStatus) DeepCopyInto(out *StorageNodeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StorageUnits != nil {
+		in, out := &in.StorageUnits, &out.StorageUnits
+		*out = make([]StorageUnitStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageNodeStatus.
@@ -1935,9 +2370,30 @@ func (in *StorageProviderStatus) DeepCopy() *StorageProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageUnitStatus) DeepCopyInto(out *StorageUnitStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageUnitStatus.
+func (in *StorageUnitStatus) DeepCopy() *StorageUnitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageUnitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new User.
@@ -0,0 +1,32 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+// v1beta1 is the hub of the conversions, which is converted from and to the other versions
+
+// Hub marks ComputeNode as a conversion hub
+func (*ComputeNode) Hub() {}
+
+// Hub marks StorageNode as a conversion hub
+func (*StorageNode) Hub() {}
+
+// Hub marks StorageProvider as a conversion hub
+func (*StorageProvider) Hub() {}
+
+// Hub marks ShardingSphereProxyServerConfig as a conversion hub
+func (*ShardingSphereProxyServerConfig) Hub() {}
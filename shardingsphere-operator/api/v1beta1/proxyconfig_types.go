@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProxyConfigStatus defines the observed state of ShardingSphereProxyServerConfig
+type ProxyConfigStatus struct {
+	MetadataRepository string `json:"metadataRepository"`
+}
+
+//+kubebuilder:printcolumn:JSONPath=".status.metadataRepository",name=MetadataRepository,type=string
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ShardingSphereProxyServerConfig is the Schema for the proxyconfigs API.
+// Its spec shares the same ServerConfig with the bootstrap of ComputeNode.
+type ShardingSphereProxyServerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerConfig      `json:"spec,omitempty"`
+	Status ProxyConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ShardingSphereProxyServerConfigList contains a list of ShardingSphereProxyServerConfig
+type ShardingSphereProxyServerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ShardingSphereProxyServerConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ShardingSphereProxyServerConfig{}, &ShardingSphereProxyServerConfigList{})
+}
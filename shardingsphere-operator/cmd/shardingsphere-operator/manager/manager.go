@@ -21,13 +21,18 @@ import (
 	"context"
 	"os"
 
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/controllers"
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/metrics"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/webhook"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var (
@@ -44,6 +49,11 @@ func SetupWithOptions(opts *Options) *Manager {
 	logger := zap.New(zap.UseFlagOptions(&opts.ZapOptions))
 	ctrl.SetLogger(logger)
 
+	if err := opts.Validate(); err != nil {
+		logger.Error(err, "invalid options")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), opts.Options)
 	if err != nil {
 		logger.Error(err, "unable to start manager")
@@ -74,11 +84,88 @@ func SetupWithOptions(opts *Options) *Manager {
 		}
 	}
 
+	if opts.Webhook.Enabled {
+		if err := setupWebhooks(mgr, opts); err != nil {
+			logger.Error(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	}
+
 	return &Manager{
 		Manager: mgr,
 	}
 }
 
+// setupWebhooks issues the webhook serving certificate if needed, and registers the defaulting
+// and validating webhooks. The plaintext passwords are rejected as well in strict credentials mode.
+func setupWebhooks(mgr manager.Manager, opts *Options) error {
+	if opts.Webhook.SelfSignedCert {
+		// the cached client is not available before the manager starts
+		c, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+		if err != nil {
+			return err
+		}
+		cm := &webhook.CertManager{
+			Client:                             c,
+			CertDir:                            opts.CertDir,
+			SecretName:                         opts.Webhook.SecretName,
+			ServiceName:                        opts.Webhook.ServiceName,
+			ServiceNamespace:                   opts.Webhook.ServiceNamespace,
+			MutatingWebhookConfigurationName:   opts.Webhook.MutatingWebhookConfigurationName,
+			ValidatingWebhookConfigurationName: opts.Webhook.ValidatingWebhookConfigurationName,
+			ConversionCRDNames:                 conversionCRDNames,
+		}
+		if err := cm.Ensure(context.Background()); err != nil {
+			return err
+		}
+		if err := cm.SetupWithManager(mgr); err != nil {
+			return err
+		}
+	}
+
+	hooks := []struct {
+		obj       runtime.Object
+		defaulter admission.CustomDefaulter
+		validator admission.CustomValidator
+	}{
+		{obj: &v1alpha1.ComputeNode{}, defaulter: &webhook.ComputeNodeWebhook{}, validator: &webhook.ComputeNodeWebhook{}},
+		{obj: &v1alpha1.StorageNode{}, defaulter: &webhook.StorageNodeWebhook{}, validator: &webhook.StorageNodeWebhook{Reader: mgr.GetAPIReader()}},
+		{obj: &v1alpha1.StorageProvider{}, defaulter: &webhook.StorageProviderWebhook{}, validator: &webhook.StorageProviderWebhook{}},
+		{obj: &v1alpha1.Chaos{}, defaulter: &webhook.ChaosWebhook{}, validator: &webhook.ChaosWebhook{}},
+		{obj: &v1alpha1.ShardingSphereProxyServerConfig{}},
+	}
+	for _, h := range hooks {
+		validator := h.validator
+		if opts.StrictCredentials {
+			if validator == nil {
+				validator = &webhook.CredentialValidator{}
+			} else {
+				validator = webhook.ChainValidators(validator, &webhook.CredentialValidator{})
+			}
+		}
+		// the builder registers the conversion webhook and the webhooks of v1beta1 for the kinds served in v1beta1 as well
+		blder := webhook.NewWebhookManagedBy(mgr).For(h.obj)
+		if h.defaulter != nil {
+			blder = blder.WithDefaulter(h.defaulter)
+		}
+		if validator != nil {
+			blder = blder.WithValidator(validator)
+		}
+		if err := blder.Complete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conversionCRDNames are the CustomResourceDefinitions served in both v1alpha1 and v1beta1
+var conversionCRDNames = []string{
+	"computenodes.shardingsphere.apache.org",
+	"storagenodes.shardingsphere.apache.org",
+	"storageproviders.shardingsphere.apache.org",
+	"shardingsphereproxyserverconfigs.shardingsphere.apache.org",
+}
+
 // SetHealthzChecker sets the health checker
 func (mgr *Manager) SetHealthzCheck(path string, check healthz.Checker) *Manager {
 	if err := mgr.Manager.AddHealthzCheck(path, check); err != nil {
@@ -18,10 +18,14 @@
 package manager
 
 import (
+	"errors"
 	"flag"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1beta1"
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/controllers"
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/chaosmesh"
 	cloudnativepg "github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/cloudnative-pg"
@@ -35,6 +39,7 @@ import (
 	dbmeshv1alpha1 "github.com/database-mesh/golang-sdk/kubernetes/api/v1alpha1"
 	"go.uber.org/zap/zapcore"
 	batchV1 "k8s.io/api/batch/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientset "k8s.io/client-go/kubernetes"
@@ -53,6 +58,8 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(chaosv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(batchV1.AddToScheme(scheme))
 	utilruntime.Must(dbmeshv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(cnpgv1.AddToScheme(scheme))
@@ -62,8 +69,23 @@ func init() {
 // Options represents common options for the controller
 type Options struct {
 	ctrl.Options
-	FeatureGates string
-	ZapOptions   zap.Options
+	FeatureGates      string
+	StrictCredentials bool
+	Webhook           WebhookOptions
+	ZapOptions        zap.Options
+}
+
+// WebhookOptions represents options for the admission webhooks
+type WebhookOptions struct {
+	Enabled bool
+	// SelfSignedCert makes the operator issue the serving certificate by itself instead of
+	// loading the one mounted in CertDir, e.g. issued by cert-manager
+	SelfSignedCert                     bool
+	SecretName                         string
+	ServiceName                        string
+	ServiceNamespace                   string
+	MutatingWebhookConfigurationName   string
+	ValidatingWebhookConfigurationName string
 }
 
 var (
@@ -94,6 +116,23 @@ func ParseOptionsFromCmdFlags() *Options {
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&opt.FeatureGates, "feature-gates", "", "A set of key=value pairs that describe feature gates for alpha/experimental features.")
+	flag.BoolVar(&opt.StrictCredentials, "strict-credentials", false,
+		"Reject plaintext passwords of ComputeNode, ShardingSphereProxyServerConfig, StorageNode and StorageProvider by the validating webhook. "+
+			"Enabling this requires enable-webhooks.")
+	// webhook options
+	flag.BoolVar(&opt.Webhook.Enabled, "enable-webhooks", false, "Enable the defaulting and validating webhooks of ComputeNode, StorageNode, StorageProvider and Chaos.")
+	flag.IntVar(&opt.Port, "webhook-port", 9443, "The port the webhook server binds to.")
+	flag.StringVar(&opt.CertDir, "webhook-cert-dir", filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"), "The directory containing tls.crt and tls.key of the webhook server.")
+	flag.BoolVar(&opt.Webhook.SelfSignedCert, "webhook-self-signed-cert", true,
+		"Issue a self-signed serving certificate for the webhook server and inject its CA into the webhook configurations. "+
+			"Disable this if the certificate is mounted in webhook-cert-dir.")
+	flag.StringVar(&opt.Webhook.SecretName, "webhook-secret-name", "shardingsphere-operator-webhook-cert", "The Secret keeping the self-signed certificate of the webhook server.")
+	flag.StringVar(&opt.Webhook.ServiceName, "webhook-service-name", "shardingsphere-operator-webhook", "The Service in front of the webhook server.")
+	flag.StringVar(&opt.Webhook.ServiceNamespace, "webhook-service-namespace", "default", "The namespace of the webhook Service and Secret.")
+	flag.StringVar(&opt.Webhook.MutatingWebhookConfigurationName, "mutating-webhook-configuration-name", "shardingsphere-operator-mutating-webhook-configuration",
+		"The MutatingWebhookConfiguration to inject the CA of the self-signed certificate into.")
+	flag.StringVar(&opt.Webhook.ValidatingWebhookConfigurationName, "validating-webhook-configuration-name", "shardingsphere-operator-validating-webhook-configuration",
+		"The ValidatingWebhookConfiguration to inject the CA of the self-signed certificate into.")
 	// aws client options
 	flag.StringVar(&AwsAccessKeyID, "aws-access-key-id", "", "The AWS access key ID.")
 	flag.StringVar(&AwsSecretAccessKey, "aws-secret-access-key", "", "The AWS secret access key.")
@@ -104,6 +143,14 @@ func ParseOptionsFromCmdFlags() *Options {
 	return opt
 }
 
+// Validate checks the options are consistent with each other
+func (opts *Options) Validate() error {
+	if opts.StrictCredentials && !opts.Webhook.Enabled {
+		return errors.New("strict-credentials requires enable-webhooks")
+	}
+	return nil
+}
+
 // ParseFeatureGates parse options from command line to build features
 func (opts *Options) ParseFeatureGates() []FeatureGateHandler {
 	handlers := []FeatureGateHandler{}
@@ -184,4 +231,36 @@ var featureGatesHandlers = map[string]FeatureGateHandler{
 		}
 		return nil
 	},
+	"Backup": func(mgr manager.Manager) error {
+		if err := (&controllers.BackupReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Log:      mgr.GetLogger(),
+			Recorder: mgr.GetEventRecorderFor(controllers.BackupControllerName),
+			Service:  service.NewServiceClient(mgr.GetClient()),
+		}).SetupWithManager(mgr); err != nil {
+			logger.Error(err, "unable to create controller", "controller", "ShardingSphereBackup")
+			return err
+		}
+		if err := (&controllers.RestoreReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Log:      mgr.GetLogger(),
+			Recorder: mgr.GetEventRecorderFor(controllers.RestoreControllerName),
+			Service:  service.NewServiceClient(mgr.GetClient()),
+		}).SetupWithManager(mgr); err != nil {
+			logger.Error(err, "unable to create controller", "controller", "ShardingSphereRestore")
+			return err
+		}
+		if err := (&controllers.BackupScheduleReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Log:      mgr.GetLogger(),
+			Recorder: mgr.GetEventRecorderFor(controllers.BackupScheduleControllerName),
+		}).SetupWithManager(mgr); err != nil {
+			logger.Error(err, "unable to create controller", "controller", "ShardingSphereBackupSchedule")
+			return err
+		}
+		return nil
+	},
 }
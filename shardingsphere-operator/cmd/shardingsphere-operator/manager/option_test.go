@@ -60,6 +60,8 @@ func Test_ParseOptionsFromCmdFlags(t *testing.T) {
 		"--health-probe-bind-address=localhost:9999",
 		"--leader-elect=true",
 		"--feature-gates=foo=true,bar=false",
+		"--enable-webhooks",
+		"--webhook-service-namespace=shardingsphere",
 	}
 
 	// Call the function being tested
@@ -81,4 +83,47 @@ func Test_ParseOptionsFromCmdFlags(t *testing.T) {
 	if opt.FeatureGates != "foo=true,bar=false" {
 		t.Errorf("Expected opt.FeatureGates to equal 'foo=true,bar=false', but got '%s'", opt.FeatureGates)
 	}
+
+	if !opt.Webhook.Enabled {
+		t.Errorf("Expected opt.Webhook.Enabled to equal 'true', but got '%v'", opt.Webhook.Enabled)
+	}
+
+	if opt.Webhook.ServiceNamespace != "shardingsphere" {
+		t.Errorf("Expected opt.Webhook.ServiceNamespace to equal 'shardingsphere', but got '%s'", opt.Webhook.ServiceNamespace)
+	}
+
+	if opt.Port != 9443 {
+		t.Errorf("Expected opt.Port to equal '9443', but got '%d'", opt.Port)
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		opts    Options
+		invalid bool
+	}{
+		{
+			desc: "Returns no error without strict credentials",
+			opts: Options{},
+		},
+		{
+			desc: "Returns no error if strict credentials with webhooks enabled",
+			opts: Options{StrictCredentials: true, Webhook: WebhookOptions{Enabled: true}},
+		},
+		{
+			desc:    "Returns error if strict credentials without webhooks enabled",
+			opts:    Options{StrictCredentials: true},
+			invalid: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.opts.Validate()
+			if (err != nil) != tC.invalid {
+				t.Errorf("Expected invalid to equal '%v', but got error '%v'", tC.invalid, err)
+			}
+		})
+	}
 }
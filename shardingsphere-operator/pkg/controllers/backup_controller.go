@@ -0,0 +1,523 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/secret"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/service"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/pitr"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/shardingsphere"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/strings/slices"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	BackupControllerName = "backup-controller"
+
+	defaultAgentPort = 443
+
+	// backupMetaDataKey is the key of metadata in the Secret owned by backup
+	backupMetaDataKey = "metadata"
+	backupKeyLength   = 16
+)
+
+// BackupReconciler is a controller for the backups of ShardingSphere clusters
+type BackupReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Service  service.Service
+}
+
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingspherebackups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingspherebackups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingspherebackups/finalizers,verbs=update
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=computenodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=event,verbs=create;patch
+
+// Reconcile handles main function of this controller
+func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.Info(fmt.Sprintf("Reconciling ShardingSphereBackup %s", req.NamespacedName))
+
+	backup := &v1alpha1.ShardingSphereBackup{}
+	if err := r.Get(ctx, req.NamespacedName, backup); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if backup.ObjectMeta.DeletionTimestamp.IsZero() {
+		if !slices.Contains(backup.ObjectMeta.Finalizers, FinalizerName) {
+			backup.ObjectMeta.Finalizers = append(backup.ObjectMeta.Finalizers, FinalizerName)
+			if err := r.Update(ctx, backup); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	} else {
+		if slices.Contains(backup.ObjectMeta.Finalizers, FinalizerName) {
+			return r.finalize(ctx, backup)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	switch backup.Status.Phase {
+	case "", v1alpha1.BackupPhasePending:
+		return r.startBackup(ctx, backup)
+	case v1alpha1.BackupPhaseRunning:
+		return r.checkBackup(ctx, backup)
+	default:
+		return ctrl.Result{}, nil
+	}
+}
+
+/*
+startBackup does what `gs_pitr backup` does: lock the cluster, export the metadata and storage nodes,
+keep the metadata in a Secret, start the backup on every data node by its agent server and unlock the cluster.
+
+The Running phase is persisted with the data nodes and a new backup key before any agent server is called,
+so a requeued or concurrent reconciliation never starts the backups again, and the backup ID of every data node
+is persisted as soon as it is started. The cluster is unlocked as soon as the backups are started, the backups are
+checked by checkBackup then. The backup stays pending and is retried if the cluster can not be locked, it fails
+if anything goes wrong after that, since the metadata exported may not match the data nodes any more.
+*/
+func (r *BackupReconciler) startBackup(ctx context.Context, backup *v1alpha1.ShardingSphereBackup) (ctrl.Result, error) {
+	ss, err := newShardingSphereServer(ctx, r.Client, r.Service, backup.Namespace, backup.Spec.ComputeNodeName)
+	if err != nil {
+		return r.setPending(ctx, backup, err)
+	}
+	defer ss.Close()
+
+	if err := ss.LockForBackup(); err != nil {
+		return r.setPending(ctx, backup, err)
+	}
+	defer func() {
+		if err := ss.Unlock(); err != nil {
+			r.Log.Error(err, "unlock cluster failed", "backup", backup.Name)
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "UnlockFailed", fmt.Sprintf("unlock compute node %s failed: %s", backup.Spec.ComputeNodeName, err))
+		}
+	}()
+
+	metadata, err := ss.ExportMetaData()
+	if err != nil {
+		return r.setFailed(ctx, backup, err)
+	}
+	md, err := shardingsphere.DecodeMetaData(metadata)
+	if err != nil {
+		return r.setFailed(ctx, backup, err)
+	}
+	nodes, err := ss.ExportStorageNodes()
+	if err != nil {
+		return r.setFailed(ctx, backup, err)
+	}
+	cfg, err := agentConfig(ctx, r.Client, backup.Namespace, &backup.Spec.Agent)
+	if err != nil {
+		return r.setFailed(ctx, backup, err)
+	}
+	ref, err := r.saveMetaData(ctx, backup, metadata)
+	if err != nil {
+		return r.setFailed(ctx, backup, err)
+	}
+
+	now := metav1.Now()
+	backup.Status.Phase = v1alpha1.BackupPhaseRunning
+	backup.Status.Message = ""
+	backup.Status.ObservedGeneration = backup.Generation
+	backup.Status.BackupKey = utilrand.String(backupKeyLength)
+	backup.Status.StartTime = &now
+	backup.Status.MetaDataSecretRef = ref
+	if md.SnapshotInfo != nil {
+		backup.Status.CSN = md.SnapshotInfo.Csn
+	}
+
+	instances := backupInstances(nodes)
+	backup.Status.DataNodes = make([]v1alpha1.DataNodeBackupStatus, 0, len(nodes))
+	for _, sn := range nodes {
+		backup.Status.DataNodes = append(backup.Status.DataNodes, v1alpha1.DataNodeBackupStatus{
+			Host:     sn.IP,
+			Port:     int32(sn.Port),
+			Instance: instances[storageNodeKey(sn.IP, int32(sn.Port))],
+		})
+	}
+	if err := r.Status().Update(ctx, backup); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for i, sn := range nodes {
+		dn := &backup.Status.DataNodes[i]
+		out, err := r.backupDataNode(ctx, backup, cfg, sn, dn.Instance)
+		if err != nil {
+			return r.setFailed(ctx, backup, fmt.Errorf("backup data node %s:%d failed: %w", sn.IP, sn.Port, err))
+		}
+
+		dn.BackupID = out.ID
+		dn.Status = pitr.BackupStatusRunning
+		if err := r.updateDataNodes(ctx, backup); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	r.Recorder.Event(backup, corev1.EventTypeNormal, "Started", fmt.Sprintf("backup of %d data nodes started", len(nodes)))
+	return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+}
+
+// updateDataNodes persists the data nodes of backup, they are applied to the latest backup on conflicts
+// as long as it is still started with the same backup key.
+func (r *BackupReconciler) updateDataNodes(ctx context.Context, backup *v1alpha1.ShardingSphereBackup) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Update(ctx, backup)
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+
+		latest := &v1alpha1.ShardingSphereBackup{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(backup), latest); err != nil {
+			return err
+		}
+		if latest.Status.BackupKey != backup.Status.BackupKey {
+			return fmt.Errorf("backup %s is started with another key %s", backup.Name, latest.Status.BackupKey)
+		}
+		latest.Status.DataNodes = backup.Status.DataNodes
+		*backup = *latest
+		return err
+	})
+}
+
+// saveMetaData keeps the metadata in the Secret owned by backup, since it holds the credentials of storage nodes.
+func (r *BackupReconciler) saveMetaData(ctx context.Context, backup *v1alpha1.ShardingSphereBackup, metadata string) (*corev1.SecretKeySelector, error) {
+	sec := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backup.Name + "-metadata",
+			Namespace: backup.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sec, func() error {
+		sec.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(backup, v1alpha1.GroupVersion.WithKind("ShardingSphereBackup")),
+		}
+		sec.Data = map[string][]byte{backupMetaDataKey: []byte(metadata)}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("save metadata into secret %s failed: %w", sec.Name, err)
+	}
+
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: sec.Name},
+		Key:                  backupMetaDataKey,
+	}, nil
+}
+
+func (r *BackupReconciler) backupDataNode(ctx context.Context, backup *v1alpha1.ShardingSphereBackup, cfg *pitr.Config, sn *shardingsphere.StorageNode, instance string) (*pitr.BackupOut, error) {
+	agent, err := pitr.NewAgent(agentAddr(sn.IP, backup.Spec.Agent.Port), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := backup.Spec.ThreadsNum
+	if threads <= 0 {
+		threads = 1
+	}
+	mode := backup.Spec.BackupMode
+	if mode == "" {
+		mode = v1alpha1.BackupModeFull
+	}
+
+	return agent.Backup(ctx, &pitr.BackupIn{
+		DBPort:       sn.Port,
+		DBName:       sn.Database,
+		Username:     sn.Username,
+		Password:     sn.Password,
+		DnBackupPath: backup.Spec.BackupPath,
+		DnThreadsNum: uint8(threads),
+		DnBackupMode: mode,
+		Instance:     instance,
+	})
+}
+
+// checkBackup updates the status of data nodes still running, the backup is completed once all of them are completed.
+// The recorded backup IDs are reused, the backup fails if any data node was not started.
+func (r *BackupReconciler) checkBackup(ctx context.Context, backup *v1alpha1.ShardingSphereBackup) (ctrl.Result, error) {
+	nodes, err := exportStorageNodes(ctx, r.Client, r.Service, backup.Namespace, backup.Spec.ComputeNodeName)
+	if err != nil {
+		backup.Status.Message = err.Error()
+		if err := r.Status().Update(ctx, backup); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+	}
+	cfg, err := agentConfig(ctx, r.Client, backup.Namespace, &backup.Spec.Agent)
+	if err != nil {
+		return r.setFailed(ctx, backup, err)
+	}
+
+	// the backups could not be started consistently any more once the cluster is unlocked
+	for _, dn := range backup.Status.DataNodes {
+		if dn.BackupID == "" {
+			return r.setFailed(ctx, backup, fmt.Errorf("backup of data node %s:%d is not started, the start of backup %s was interrupted", dn.Host, dn.Port, backup.Status.BackupKey))
+		}
+	}
+
+	completed, failed := 0, 0
+	for i := range backup.Status.DataNodes {
+		dn := &backup.Status.DataNodes[i]
+		if dn.Status == pitr.BackupStatusRunning {
+			r.showDataNode(ctx, backup, cfg, nodes[storageNodeKey(dn.Host, dn.Port)], dn)
+		}
+
+		switch dn.Status {
+		case pitr.BackupStatusCompleted:
+			completed++
+		case pitr.BackupStatusFailed:
+			failed++
+		}
+	}
+
+	switch {
+	case failed > 0:
+		return r.setFailed(ctx, backup, fmt.Errorf("backup of %d data nodes failed", failed))
+	case completed == len(backup.Status.DataNodes):
+		now := metav1.Now()
+		backup.Status.Phase = v1alpha1.BackupPhaseCompleted
+		backup.Status.CompletionTime = &now
+		backup.Status.Message = ""
+		if err := r.Status().Update(ctx, backup); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "Completed", "backup completed")
+		return ctrl.Result{}, nil
+	default:
+		backup.Status.Message = ""
+		if err := r.Status().Update(ctx, backup); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+	}
+}
+
+// showDataNode updates the status of dn by the backup detail, the error is kept in the message of dn and checked again later.
+func (r *BackupReconciler) showDataNode(ctx context.Context, backup *v1alpha1.ShardingSphereBackup, cfg *pitr.Config, sn *shardingsphere.StorageNode, dn *v1alpha1.DataNodeBackupStatus) {
+	if sn == nil {
+		dn.Message = "storage node is not found in compute node"
+		return
+	}
+
+	agent, err := pitr.NewAgent(agentAddr(dn.Host, backup.Spec.Agent.Port), cfg)
+	if err != nil {
+		dn.Message = err.Error()
+		return
+	}
+	info, err := agent.ShowDetail(ctx, &pitr.ShowDetailIn{
+		DBPort:       sn.Port,
+		DBName:       sn.Database,
+		Username:     sn.Username,
+		Password:     sn.Password,
+		DnBackupID:   dn.BackupID,
+		DnBackupPath: backup.Spec.BackupPath,
+		Instance:     dn.Instance,
+	})
+	if err != nil {
+		dn.Message = err.Error()
+		return
+	}
+
+	dn.Status = info.Status
+	dn.StopLSN = info.StopLsn
+	dn.RecoveryTime = info.RecoveryTime
+	dn.Message = ""
+}
+
+// finalize deletes the backup sets on data nodes, they can not be deleted any more if the compute node is gone.
+func (r *BackupReconciler) finalize(ctx context.Context, backup *v1alpha1.ShardingSphereBackup) (ctrl.Result, error) {
+	if err := r.deleteBackupSets(ctx, backup); err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "DeleteFailed", err.Error())
+			return ctrl.Result{RequeueAfter: defaultRequeueTime}, err
+		}
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "DeleteSkipped", fmt.Sprintf("backup sets are kept on data nodes: %s", err))
+	}
+
+	controllerutil.RemoveFinalizer(backup, FinalizerName)
+	if err := r.Update(ctx, backup); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *BackupReconciler) deleteBackupSets(ctx context.Context, backup *v1alpha1.ShardingSphereBackup) error {
+	started := false
+	for _, dn := range backup.Status.DataNodes {
+		if dn.BackupID != "" {
+			started = true
+		}
+	}
+	if !started {
+		return nil
+	}
+
+	nodes, err := exportStorageNodes(ctx, r.Client, r.Service, backup.Namespace, backup.Spec.ComputeNodeName)
+	if err != nil {
+		return err
+	}
+	cfg, err := agentConfig(ctx, r.Client, backup.Namespace, &backup.Spec.Agent)
+	if err != nil {
+		return err
+	}
+
+	for _, dn := range backup.Status.DataNodes {
+		if dn.BackupID == "" {
+			continue
+		}
+		sn, ok := nodes[storageNodeKey(dn.Host, dn.Port)]
+		if !ok {
+			continue
+		}
+		agent, err := pitr.NewAgent(agentAddr(dn.Host, backup.Spec.Agent.Port), cfg)
+		if err != nil {
+			return err
+		}
+		if err := agent.DeleteBackup(ctx, &pitr.DeleteBackupIn{
+			DBPort:       sn.Port,
+			DBName:       sn.Database,
+			Username:     sn.Username,
+			Password:     sn.Password,
+			DnBackupPath: backup.Spec.BackupPath,
+			BackupID:     dn.BackupID,
+			Instance:     dn.Instance,
+		}); err != nil {
+			return fmt.Errorf("delete backup %s of data node %s:%d failed: %w", dn.BackupID, dn.Host, dn.Port, err)
+		}
+	}
+	return nil
+}
+
+func (r *BackupReconciler) setPending(ctx context.Context, backup *v1alpha1.ShardingSphereBackup, reason error) (ctrl.Result, error) {
+	backup.Status.Phase = v1alpha1.BackupPhasePending
+	backup.Status.Message = reason.Error()
+	backup.Status.ObservedGeneration = backup.Generation
+	if err := r.Status().Update(ctx, backup); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+}
+
+func (r *BackupReconciler) setFailed(ctx context.Context, backup *v1alpha1.ShardingSphereBackup, reason error) (ctrl.Result, error) {
+	now := metav1.Now()
+	backup.Status.Phase = v1alpha1.BackupPhaseFailed
+	backup.Status.Message = reason.Error()
+	backup.Status.CompletionTime = &now
+	backup.Status.ObservedGeneration = backup.Generation
+	if err := r.Status().Update(ctx, backup); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Event(backup, corev1.EventTypeWarning, "Failed", reason.Error())
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *BackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ShardingSphereBackup{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
+
+// backupInstances give each storage node its own backup instance if several of them run on the same host,
+// they are managed by one agent server and share the backup path.
+func backupInstances(nodes []*shardingsphere.StorageNode) map[string]string {
+	hosts := map[string]int{}
+	for _, sn := range nodes {
+		hosts[sn.IP]++
+	}
+
+	instances := make(map[string]string, len(nodes))
+	for _, sn := range nodes {
+		instance := pitr.DefaultInstance
+		if hosts[sn.IP] > 1 {
+			instance = fmt.Sprintf("%s-%d", pitr.DefaultInstance, sn.Port)
+		}
+		instances[storageNodeKey(sn.IP, int32(sn.Port))] = instance
+	}
+	return instances
+}
+
+func storageNodeKey(host string, port int32) string {
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
+// agentAddr returns the address of the agent server on host, the agent server listens on 443 by default.
+func agentAddr(host string, port int32) string {
+	if port <= 0 {
+		port = defaultAgentPort
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
+// exportStorageNodes returns the storage nodes of compute node with their credentials, keyed by storageNodeKey.
+func exportStorageNodes(ctx context.Context, c client.Client, svcClient service.Service, namespace, computeNodeName string) (map[string]*shardingsphere.StorageNode, error) {
+	ss, err := newShardingSphereServer(ctx, c, svcClient, namespace, computeNodeName)
+	if err != nil {
+		return nil, err
+	}
+	defer ss.Close()
+
+	nodes, err := ss.ExportStorageNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*shardingsphere.StorageNode, len(nodes))
+	for _, sn := range nodes {
+		out[storageNodeKey(sn.IP, int32(sn.Port))] = sn
+	}
+	return out, nil
+}
+
+func agentConfig(ctx context.Context, c client.Client, namespace string, agent *v1alpha1.PITRAgent) (*pitr.Config, error) {
+	cfg := &pitr.Config{}
+	if agent.CASecretRef != nil {
+		ca, err := secret.GetValue(ctx, c, namespace, agent.CASecretRef)
+		if err != nil {
+			return nil, err
+		}
+		cfg.CA = ca
+	}
+	if agent.TokenSecretRef != nil {
+		token, err := secret.GetValue(ctx, c, namespace, agent.TokenSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Token = string(token)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,370 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/secret"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/service"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/pitr"
+	mock_pitr "github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/pitr/mocks"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/shardingsphere"
+	mock_shardingsphere "github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/shardingsphere/mocks"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultTestComputeNode = "test-compute-node"
+	defaultTestBackup      = "test-backup"
+)
+
+var (
+	mockAgent   *mock_pitr.MockIAgent
+	errTestPITR = errors.New("test pitr error")
+
+	testMetaData = base64.StdEncoding.EncodeToString([]byte(`{"meta_data":{"databases":{"sharding_db":"rules"}},"snapshot_info":{"csn":"1024","create_time":"now"}}`))
+	testNodes    = []*shardingsphere.StorageNode{
+		{IP: "10.0.0.1", Port: 5432, Username: "u", Password: "p", Database: "ds_0"},
+		{IP: "10.0.0.1", Port: 5433, Username: "u", Password: "p", Database: "ds_1"},
+	}
+)
+
+// createTestComputeNode creates the compute node and its service which the shardingsphere server is built from
+func createTestComputeNode() {
+	Expect(fakeClient.Create(ctx, &v1alpha1.ComputeNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultTestComputeNode,
+			Namespace: defaultTestNamespace,
+		},
+		Spec: v1alpha1.ComputeNodeSpec{
+			Bootstrap: v1alpha1.BootstrapConfig{
+				ServerConfig: v1alpha1.ServerConfig{
+					Authority: v1alpha1.ComputeNodeAuthority{
+						Users: []v1alpha1.ComputeNodeUser{{User: "root@%", Password: "root"}},
+					},
+					Props: map[string]string{ShardingSphereProtocolType: "openGauss"},
+				},
+			},
+		},
+	})).To(Succeed())
+	Expect(fakeClient.Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultTestComputeNode,
+			Namespace: defaultTestNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "shardingsphere-proxy", Port: 5432}},
+		},
+	})).To(Succeed())
+}
+
+// patchPITR mocks the shardingsphere server of compute node and the agent servers of storage nodes
+func patchPITR() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockSS = mock_shardingsphere.NewMockIServer(mockCtrl)
+	mockAgent = mock_pitr.NewMockIAgent(mockCtrl)
+	monkey.Patch(shardingsphere.NewServer, func(_, _ string, _ uint, _, _ string) (shardingsphere.IServer, error) {
+		return mockSS, nil
+	})
+	monkey.Patch(pitr.NewAgent, func(_ string, _ *pitr.Config) (pitr.IAgent, error) {
+		return mockAgent, nil
+	})
+	mockSS.EXPECT().Close().Return(nil).AnyTimes()
+}
+
+var _ = Describe("ShardingSphereBackup Controller", func() {
+	var (
+		backupReconciler *BackupReconciler
+		req              = ctrl.Request{NamespacedName: client.ObjectKey{Namespace: defaultTestNamespace, Name: defaultTestBackup}}
+	)
+
+	BeforeEach(func() {
+		patchPITR()
+		createTestComputeNode()
+		backupReconciler = &BackupReconciler{
+			Client:   fakeClient,
+			Log:      reconciler.Log,
+			Recorder: record.NewFakeRecorder(100),
+			Service:  service.NewServiceClient(fakeClient),
+		}
+		Expect(fakeClient.Create(ctx, &v1alpha1.ShardingSphereBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      defaultTestBackup,
+				Namespace: defaultTestNamespace,
+			},
+			Spec: v1alpha1.ShardingSphereBackupSpec{
+				ComputeNodeName: defaultTestComputeNode,
+				BackupPath:      "/home/omm/data",
+			},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		monkey.UnpatchAll()
+	})
+
+	getBackup := func() *v1alpha1.ShardingSphereBackup {
+		backup := &v1alpha1.ShardingSphereBackup{}
+		Expect(fakeClient.Get(ctx, req.NamespacedName, backup)).To(Succeed())
+		return backup
+	}
+
+	It("should start the backups of data nodes and unlock the cluster", func() {
+		gomock.InOrder(
+			mockSS.EXPECT().LockForBackup().Return(nil),
+			mockSS.EXPECT().ExportMetaData().Return(testMetaData, nil),
+			mockSS.EXPECT().ExportStorageNodes().Return(testNodes, nil),
+			mockAgent.EXPECT().Backup(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, in *pitr.BackupIn) (*pitr.BackupOut, error) {
+				Expect(in.Instance).To(Equal("ins-default-ss-5432"))
+				Expect(in.DnBackupMode).To(Equal(v1alpha1.BackupModeFull))
+				// the running phase is persisted before any agent server is called
+				backup := getBackup()
+				Expect(backup.Status.Phase).To(Equal(v1alpha1.BackupPhaseRunning))
+				Expect(backup.Status.BackupKey).NotTo(BeEmpty())
+				Expect(backup.Status.DataNodes).To(HaveLen(2))
+				return &pitr.BackupOut{ID: "B0"}, nil
+			}),
+			mockAgent.EXPECT().Backup(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, _ *pitr.BackupIn) (*pitr.BackupOut, error) {
+				Expect(getBackup().Status.DataNodes[0].BackupID).To(Equal("B0"))
+				return &pitr.BackupOut{ID: "B1"}, nil
+			}),
+			mockSS.EXPECT().Unlock().Return(nil),
+		)
+
+		_, err := backupReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		backup := getBackup()
+		Expect(backup.Finalizers).To(ContainElement(FinalizerName))
+		Expect(backup.Status.Phase).To(Equal(v1alpha1.BackupPhaseRunning))
+		Expect(backup.Status.CSN).To(Equal("1024"))
+		Expect(backup.Status.MetaDataSecretRef).NotTo(BeNil())
+		metadata, err := secret.GetValue(ctx, fakeClient, defaultTestNamespace, backup.Status.MetaDataSecretRef)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(metadata)).To(Equal(testMetaData))
+		Expect(backup.Status.DataNodes).To(HaveLen(2))
+		Expect(backup.Status.DataNodes[1].BackupID).To(Equal("B1"))
+		Expect(backup.Status.DataNodes[1].Instance).To(Equal("ins-default-ss-5433"))
+	})
+
+	It("should stay pending if the cluster can not be locked", func() {
+		mockSS.EXPECT().LockForBackup().Return(errTestPITR)
+
+		res, err := backupReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(defaultRequeueTime))
+
+		backup := getBackup()
+		Expect(backup.Status.Phase).To(Equal(v1alpha1.BackupPhasePending))
+		Expect(backup.Status.Message).To(ContainSubstring(errTestPITR.Error()))
+	})
+
+	It("should fail and unlock the cluster if a data node can not be backed up", func() {
+		mockSS.EXPECT().LockForBackup().Return(nil)
+		mockSS.EXPECT().ExportMetaData().Return(testMetaData, nil)
+		mockSS.EXPECT().ExportStorageNodes().Return(testNodes, nil)
+		mockAgent.EXPECT().Backup(gomock.Any(), gomock.Any()).Return(nil, errTestPITR)
+		mockSS.EXPECT().Unlock().Return(nil)
+
+		_, err := backupReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getBackup().Status.Phase).To(Equal(v1alpha1.BackupPhaseFailed))
+	})
+
+	It("should fail without starting the backups again if the start was interrupted", func() {
+		backup := getBackup()
+		backup.Finalizers = []string{FinalizerName}
+		Expect(fakeClient.Update(ctx, backup)).To(Succeed())
+		backup.Status = v1alpha1.ShardingSphereBackupStatus{
+			Phase:     v1alpha1.BackupPhaseRunning,
+			BackupKey: "test-key",
+			DataNodes: []v1alpha1.DataNodeBackupStatus{
+				{Host: "10.0.0.1", Port: 5432, Instance: "ins-default-ss-5432", BackupID: "B0", Status: pitr.BackupStatusRunning},
+				{Host: "10.0.0.1", Port: 5433, Instance: "ins-default-ss-5433"},
+			},
+		}
+		Expect(fakeClient.Status().Update(ctx, backup)).To(Succeed())
+		mockSS.EXPECT().ExportStorageNodes().Return(testNodes, nil)
+
+		_, err := backupReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		backup = getBackup()
+		Expect(backup.Status.Phase).To(Equal(v1alpha1.BackupPhaseFailed))
+		Expect(backup.Status.Message).To(ContainSubstring("test-key"))
+		Expect(backup.Status.DataNodes[0].BackupID).To(Equal("B0"))
+	})
+
+	It("should complete once the backups of all data nodes are completed", func() {
+		backup := getBackup()
+		backup.Finalizers = []string{FinalizerName}
+		Expect(fakeClient.Update(ctx, backup)).To(Succeed())
+		backup.Status = v1alpha1.ShardingSphereBackupStatus{
+			Phase: v1alpha1.BackupPhaseRunning,
+			DataNodes: []v1alpha1.DataNodeBackupStatus{
+				{Host: "10.0.0.1", Port: 5432, Instance: "ins-default-ss-5432", BackupID: "B0", Status: pitr.BackupStatusCompleted},
+				{Host: "10.0.0.1", Port: 5433, Instance: "ins-default-ss-5433", BackupID: "B1", Status: pitr.BackupStatusRunning},
+			},
+		}
+		Expect(fakeClient.Status().Update(ctx, backup)).To(Succeed())
+
+		mockSS.EXPECT().ExportStorageNodes().Return(testNodes, nil)
+		mockAgent.EXPECT().ShowDetail(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, in *pitr.ShowDetailIn) (*pitr.BackupInfo, error) {
+			Expect(in.DnBackupID).To(Equal("B1"))
+			Expect(in.DBName).To(Equal("ds_1"))
+			return &pitr.BackupInfo{ID: "B1", Status: pitr.BackupStatusCompleted, StopLsn: "0/3000060"}, nil
+		})
+
+		_, err := backupReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		backup = getBackup()
+		Expect(backup.Status.Phase).To(Equal(v1alpha1.BackupPhaseCompleted))
+		Expect(backup.Status.CompletionTime).NotTo(BeNil())
+		Expect(backup.Status.DataNodes[1].StopLSN).To(Equal("0/3000060"))
+	})
+
+	It("should delete the backups of data nodes with the finalizer", func() {
+		backup := getBackup()
+		backup.Finalizers = []string{FinalizerName}
+		Expect(fakeClient.Update(ctx, backup)).To(Succeed())
+		backup.Status = v1alpha1.ShardingSphereBackupStatus{
+			Phase: v1alpha1.BackupPhaseCompleted,
+			DataNodes: []v1alpha1.DataNodeBackupStatus{
+				{Host: "10.0.0.1", Port: 5432, Instance: "ins-default-ss-5432", BackupID: "B0", Status: pitr.BackupStatusCompleted},
+			},
+		}
+		Expect(fakeClient.Status().Update(ctx, backup)).To(Succeed())
+		Expect(fakeClient.Delete(ctx, backup)).To(Succeed())
+
+		mockSS.EXPECT().ExportStorageNodes().Return(testNodes, nil)
+		mockAgent.EXPECT().DeleteBackup(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := backupReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fakeClient.Get(ctx, req.NamespacedName, &v1alpha1.ShardingSphereBackup{})).NotTo(Succeed())
+	})
+
+	It("should give storage nodes on the same host their own instances", func() {
+		instances := backupInstances([]*shardingsphere.StorageNode{
+			{IP: "10.0.0.1", Port: 5432},
+			{IP: "10.0.0.1", Port: 5433},
+			{IP: "10.0.0.2", Port: 5432},
+		})
+		Expect(instances).To(Equal(map[string]string{
+			"10.0.0.1:5432": "ins-default-ss-5432",
+			"10.0.0.1:5433": "ins-default-ss-5433",
+			"10.0.0.2:5432": pitr.DefaultInstance,
+		}))
+	})
+})
+
+var _ = Describe("ShardingSphereBackupSchedule Controller", func() {
+	newBackup := func(name, mode string, phase v1alpha1.BackupPhase, age time.Duration) v1alpha1.ShardingSphereBackup {
+		start := metav1.NewTime(time.Now().Add(-age))
+		return v1alpha1.ShardingSphereBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.ShardingSphereBackupSpec{BackupMode: mode},
+			Status:     v1alpha1.ShardingSphereBackupStatus{Phase: phase, StartTime: &start},
+		}
+	}
+	namesOf := func(backups []*v1alpha1.ShardingSphereBackup) []string {
+		names := make([]string, 0, len(backups))
+		for _, b := range backups {
+			names = append(names, b.Name)
+		}
+		return names
+	}
+
+	It("should keep the chain of the incremental backups kept", func() {
+		backups := []v1alpha1.ShardingSphereBackup{
+			newBackup("full-0", v1alpha1.BackupModeFull, v1alpha1.BackupPhaseCompleted, 4*time.Hour),
+			newBackup("full-1", v1alpha1.BackupModeFull, v1alpha1.BackupPhaseCompleted, 3*time.Hour),
+			newBackup("ptrack-1", v1alpha1.BackupModePTrack, v1alpha1.BackupPhaseCompleted, 2*time.Hour),
+			newBackup("failed", v1alpha1.BackupModeFull, v1alpha1.BackupPhaseFailed, 90*time.Minute),
+			newBackup("running", v1alpha1.BackupModePTrack, v1alpha1.BackupPhaseRunning, time.Minute),
+		}
+
+		expired := planRetention(backups, v1alpha1.BackupRetention{RetainCount: 1}, time.Now())
+		Expect(namesOf(expired)).To(ConsistOf("failed", "full-0"))
+
+		expired = planRetention(backups, v1alpha1.BackupRetention{RetainFull: 2}, time.Now())
+		Expect(namesOf(expired)).To(ConsistOf("failed"))
+
+		expired = planRetention(backups, v1alpha1.BackupRetention{RetainDays: 1}, time.Now().Add(24*time.Hour))
+		Expect(namesOf(expired)).To(ConsistOf("failed", "full-0", "full-1", "ptrack-1"))
+	})
+
+	It("should create the backup when it is due", func() {
+		scheduleReconciler := &BackupScheduleReconciler{
+			Client:   fakeClient,
+			Log:      reconciler.Log,
+			Recorder: record.NewFakeRecorder(100),
+		}
+		schedule := &v1alpha1.ShardingSphereBackupSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-schedule",
+				Namespace: defaultTestNamespace,
+			},
+			Spec: v1alpha1.ShardingSphereBackupScheduleSpec{
+				Schedule: "@every 1m",
+				BackupTemplate: v1alpha1.ShardingSphereBackupSpec{
+					ComputeNodeName: defaultTestComputeNode,
+					BackupPath:      "/home/omm/data",
+				},
+			},
+			Status: v1alpha1.ShardingSphereBackupScheduleStatus{
+				LastScheduleTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+			},
+		}
+		Expect(fakeClient.Create(ctx, schedule)).To(Succeed())
+
+		res, err := scheduleReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(schedule)})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(BeNumerically("<=", time.Minute))
+
+		backups := &v1alpha1.ShardingSphereBackupList{}
+		Expect(fakeClient.List(ctx, backups, client.MatchingLabels{v1alpha1.LabelBackupSchedule: schedule.Name})).To(Succeed())
+		Expect(backups.Items).To(HaveLen(1))
+		Expect(backups.Items[0].Spec.ComputeNodeName).To(Equal(defaultTestComputeNode))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(schedule), schedule)).To(Succeed())
+		Expect(schedule.Status.LastBackupName).To(Equal(backups.Items[0].Name))
+
+		// the next run waits for the backup created
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+		Expect(fakeClient.Status().Update(ctx, schedule)).To(Succeed())
+		_, err = scheduleReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(schedule)})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fakeClient.List(ctx, backups, client.MatchingLabels{v1alpha1.LabelBackupSchedule: schedule.Name})).To(Succeed())
+		Expect(backups.Items).To(HaveLen(1))
+	})
+})
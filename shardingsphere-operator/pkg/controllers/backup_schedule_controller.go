@@ -0,0 +1,259 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	BackupScheduleControllerName = "backup-schedule-controller"
+)
+
+// BackupScheduleReconciler is a controller creating backups on schedule and deleting the expired ones
+type BackupScheduleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingspherebackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingspherebackupschedules/status,verbs=get;update;patch
+
+// Reconcile handles main function of this controller
+func (r *BackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.Info(fmt.Sprintf("Reconciling ShardingSphereBackupSchedule %s", req.NamespacedName))
+
+	schedule := &v1alpha1.ShardingSphereBackupSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, schedule); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !schedule.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	sched, err := cron.ParseStandard(schedule.Spec.Schedule)
+	if err != nil {
+		schedule.Status.Message = fmt.Sprintf("invalid schedule %q: %s", schedule.Spec.Schedule, err)
+		schedule.Status.ObservedGeneration = schedule.Generation
+		return ctrl.Result{}, r.Status().Update(ctx, schedule)
+	}
+
+	backups := &v1alpha1.ShardingSphereBackupList{}
+	if err := r.List(ctx, backups, client.InNamespace(schedule.Namespace), client.MatchingLabels{v1alpha1.LabelBackupSchedule: schedule.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	if err := r.prune(ctx, schedule, backups.Items, now); err != nil {
+		r.Recorder.Event(schedule, corev1.EventTypeWarning, "PruneFailed", err.Error())
+	}
+
+	active := false
+	for i := range backups.Items {
+		b := &backups.Items[i]
+		switch b.Status.Phase {
+		case "", v1alpha1.BackupPhasePending, v1alpha1.BackupPhaseRunning:
+			active = true
+		case v1alpha1.BackupPhaseCompleted:
+			if t := b.Status.CompletionTime; t != nil && (schedule.Status.LastSuccessfulTime == nil || schedule.Status.LastSuccessfulTime.Before(t)) {
+				schedule.Status.LastSuccessfulTime = t.DeepCopy()
+			}
+		}
+	}
+
+	last := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		last = schedule.Status.LastScheduleTime.Time
+	}
+	next := sched.Next(last)
+
+	schedule.Status.ObservedGeneration = schedule.Generation
+	schedule.Status.Message = ""
+	switch {
+	case schedule.Spec.Suspend:
+	case next.After(now):
+	case active:
+		// one backup at a time, the missed run is started once the running backup is done
+		schedule.Status.Message = "waiting for the last backup to finish"
+	default:
+		backup, err := r.createBackup(ctx, schedule, now)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		scheduled := metav1.NewTime(now)
+		schedule.Status.LastScheduleTime = &scheduled
+		schedule.Status.LastBackupName = backup.Name
+		next = sched.Next(now)
+	}
+
+	if err := r.Status().Update(ctx, schedule); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if schedule.Spec.Suspend {
+		return ctrl.Result{}, nil
+	}
+	// the backups are watched, so a missed run waiting for the running backup is started once it is done
+	requeue := next.Sub(now)
+	if requeue <= 0 {
+		requeue = defaultRequeueTime
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+func (r *BackupScheduleReconciler) createBackup(ctx context.Context, schedule *v1alpha1.ShardingSphereBackupSchedule, now time.Time) (*v1alpha1.ShardingSphereBackup, error) {
+	// the backups are not owned by the schedule, so they survive the deletion of the schedule
+	backup := &v1alpha1.ShardingSphereBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", schedule.Name, now.Unix()),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				v1alpha1.LabelBackupSchedule: schedule.Name,
+			},
+		},
+		Spec: *schedule.Spec.BackupTemplate.DeepCopy(),
+	}
+	if err := r.Create(ctx, backup); err != nil {
+		return nil, fmt.Errorf("create backup %s failed: %w", backup.Name, err)
+	}
+	r.Recorder.Event(schedule, corev1.EventTypeNormal, "BackupCreated", fmt.Sprintf("backup %s is created", backup.Name))
+	return backup, nil
+}
+
+// prune deletes the backups expired by the retention of schedule, nothing is deleted if no retention is set.
+func (r *BackupScheduleReconciler) prune(ctx context.Context, schedule *v1alpha1.ShardingSphereBackupSchedule, backups []v1alpha1.ShardingSphereBackup, now time.Time) error {
+	retention := schedule.Spec.Retention
+	if retention.RetainCount <= 0 && retention.RetainDays <= 0 && retention.RetainFull <= 0 {
+		return nil
+	}
+
+	for _, b := range planRetention(backups, retention, now) {
+		if err := r.Delete(ctx, b); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete backup %s failed: %w", b.Name, err)
+		}
+		r.Recorder.Event(schedule, corev1.EventTypeNormal, "BackupDeleted", fmt.Sprintf("backup %s is expired", b.Name))
+	}
+	return nil
+}
+
+/*
+planRetention returns the backups expired by retention, like `gs_pitr prune`.
+
+A completed backup is kept if any of the retention policies keeps it. A PTRACK backup depends on the
+previous completed backup, so the whole chain back to the FULL backup is kept if any of its
+member is kept. Failed backups are never used as a parent, so they are always expired, while
+the pending and running ones are never expired.
+*/
+func planRetention(backups []v1alpha1.ShardingSphereBackup, retention v1alpha1.BackupRetention, now time.Time) []*v1alpha1.ShardingSphereBackup {
+	var (
+		expired   []*v1alpha1.ShardingSphereBackup
+		completed []*v1alpha1.ShardingSphereBackup
+		kept      = map[*v1alpha1.ShardingSphereBackup]bool{}
+		fulls     int32
+	)
+	for i := range backups {
+		b := &backups[i]
+		switch b.Status.Phase {
+		case v1alpha1.BackupPhaseCompleted:
+			completed = append(completed, b)
+		case v1alpha1.BackupPhaseFailed:
+			expired = append(expired, b)
+		}
+	}
+	sort.SliceStable(completed, func(i, j int) bool {
+		return startTimeOf(completed[j]).Before(startTimeOf(completed[i]))
+	})
+
+	for i, b := range completed {
+		if retention.RetainCount > 0 && int32(i) < retention.RetainCount {
+			kept[b] = true
+		}
+		if retention.RetainDays > 0 && now.Sub(startTimeOf(b)) < time.Duration(retention.RetainDays)*24*time.Hour {
+			kept[b] = true
+		}
+		// an incremental backup belongs to the chain of the next full backup older than it
+		chain := fulls + 1
+		if b.Spec.BackupMode != v1alpha1.BackupModePTrack {
+			fulls++
+			chain = fulls
+		}
+		if retention.RetainFull > 0 && chain <= retention.RetainFull {
+			kept[b] = true
+		}
+	}
+
+	// the parent of completed[i] is completed[i+1], walk from the newest so the whole chain is kept in one pass.
+	for i := 0; i < len(completed)-1; i++ {
+		if kept[completed[i]] && completed[i].Spec.BackupMode == v1alpha1.BackupModePTrack {
+			kept[completed[i+1]] = true
+		}
+	}
+
+	for _, b := range completed {
+		if !kept[b] {
+			expired = append(expired, b)
+		}
+	}
+	return expired
+}
+
+func startTimeOf(b *v1alpha1.ShardingSphereBackup) time.Time {
+	if b.Status.StartTime != nil {
+		return b.Status.StartTime.Time
+	}
+	return b.CreationTimestamp.Time
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *BackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ShardingSphereBackupSchedule{}).
+		Watches(&source.Kind{Type: &v1alpha1.ShardingSphereBackup{}}, handler.EnqueueRequestsFromMapFunc(scheduleOfBackup)).
+		Complete(r)
+}
+
+// scheduleOfBackup enqueues the schedule which created the backup, so the next run and retention are checked once it is done.
+func scheduleOfBackup(obj client.Object) []reconcile.Request {
+	name, ok := obj.GetLabels()[v1alpha1.LabelBackupSchedule]
+	if !ok || name == "" {
+		return nil
+	}
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}},
+	}
+}
@@ -38,6 +38,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -64,15 +66,37 @@ func (r *ComputeNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Pod{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.computeNodesOfSecret)).
 		Complete(r)
 }
 
+// computeNodesOfSecret enqueues the compute nodes referring to the secret, so their server config follows the changed passwords.
+func (r *ComputeNodeReconciler) computeNodesOfSecret(obj client.Object) []ctrl.Request {
+	cns := &v1alpha1.ComputeNodeList{}
+	if err := r.List(context.TODO(), cns, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "list compute nodes failed", "secret", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	reqs := []ctrl.Request{}
+	for _, cn := range cns.Items {
+		for _, u := range cn.Spec.Bootstrap.ServerConfig.Authority.Users {
+			if u.PasswordSecretRef != nil && u.PasswordSecretRef.Name == obj.GetName() {
+				reqs = append(reqs, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: cn.Namespace, Name: cn.Name}})
+				break
+			}
+		}
+	}
+	return reqs
+}
+
 // +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=computenodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=computenodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 
 // Reconcile handles main function of this controller
 func (r *ComputeNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -273,8 +297,11 @@ func (r *ComputeNodeReconciler) getServiceByNamespacedName(ctx context.Context,
 }
 
 func (r *ComputeNodeReconciler) createConfigMap(ctx context.Context, cn *v1alpha1.ComputeNode) error {
-	cm := r.ConfigMap.Build(ctx, cn)
-	err := r.ConfigMap.Create(ctx, cm)
+	cm, err := r.ConfigMap.Build(ctx, cn)
+	if err != nil {
+		return err
+	}
+	err = r.ConfigMap.Create(ctx, cm)
 	if err != nil && apierrors.IsAlreadyExists(err) || err == nil {
 		return nil
 	}
@@ -282,7 +309,10 @@ func (r *ComputeNodeReconciler) createConfigMap(ctx context.Context, cn *v1alpha
 }
 
 func (r *ComputeNodeReconciler) updateConfigMap(ctx context.Context, cn *v1alpha1.ComputeNode, cm *corev1.ConfigMap) error {
-	exp := r.ConfigMap.Build(ctx, cn)
+	exp, err := r.ConfigMap.Build(ctx, cn)
+	if err != nil {
+		return err
+	}
 	exp.ObjectMeta = cm.ObjectMeta
 	exp.Labels = cm.Labels
 	exp.Annotations = cm.Annotations
@@ -19,8 +19,10 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 
 	shardingspherev1alpha1 "github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/secret"
 	"github.com/go-logr/logr"
 
 	reconcile "github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/reconcile/proxyconfig"
@@ -30,8 +32,11 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -49,6 +54,7 @@ type ProxyConfigReconciler struct {
 // +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingsphereproxyserverconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingsphereproxyserverconfigs/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile the ProxyConfig
 func (r *ProxyConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -64,8 +70,14 @@ func (r *ProxyConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	resolved, err := r.resolveUsers(ctx, run)
+	if err != nil {
+		logger.Error(err, "Error resolving passwords of users")
+		return ctrl.Result{}, err
+	}
+
 	cm := &v1.ConfigMap{}
-	configmap := reconcile.ConstructCascadingConfigmap(run)
+	configmap := reconcile.ConstructCascadingConfigmap(resolved)
 	err = r.Get(ctx, req.NamespacedName, cm)
 	if apierrors.IsNotFound(err) {
 		logger.Info("Creating cascaded configmap")
@@ -106,10 +118,52 @@ func (r *ProxyConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
+// resolveUsers returns a copy of the server config with the passwords read from the secrets referred by its users,
+// the server config itself is returned if no secret is referred.
+func (r *ProxyConfigReconciler) resolveUsers(ctx context.Context, run *shardingspherev1alpha1.ShardingSphereProxyServerConfig) (*shardingspherev1alpha1.ShardingSphereProxyServerConfig, error) {
+	resolved := run
+	for i, u := range run.Spec.Authority.Users {
+		if u.PasswordSecretRef == nil {
+			continue
+		}
+		if resolved == run {
+			resolved = run.DeepCopy()
+		}
+
+		password, err := secret.GetValue(ctx, r.Client, run.Namespace, u.PasswordSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("resolve password of user %s failed: %w", u.User, err)
+		}
+		resolved.Spec.Authority.Users[i].Password = string(password)
+	}
+	return resolved, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ProxyConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&shardingspherev1alpha1.ShardingSphereProxyServerConfig{}).
 		Owns(&v1.ConfigMap{}).
+		Watches(&source.Kind{Type: &v1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.proxyConfigsOfSecret)).
 		Complete(r)
 }
+
+// proxyConfigsOfSecret enqueues the server configs referring to the secret, so the cascaded configmap follows the changed passwords.
+func (r *ProxyConfigReconciler) proxyConfigsOfSecret(obj client.Object) []ctrl.Request {
+	runs := &shardingspherev1alpha1.ShardingSphereProxyServerConfigList{}
+	if err := r.List(context.TODO(), runs, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "Error listing server configs", "secret", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	reqs := []ctrl.Request{}
+	for _, run := range runs.Items {
+		for _, u := range run.Spec.Authority.Users {
+			if u.PasswordSecretRef != nil && u.PasswordSecretRef.Name == obj.GetName() {
+				reqs = append(reqs, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: run.Namespace, Name: run.Name}})
+				break
+			}
+		}
+	}
+	return reqs
+}
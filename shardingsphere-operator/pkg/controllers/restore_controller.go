@@ -0,0 +1,354 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/secret"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/service"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/pitr"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/shardingsphere"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	RestoreControllerName = "restore-controller"
+)
+
+// RestoreReconciler is a controller for restoring ShardingSphere clusters from backups
+type RestoreReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Service  service.Service
+}
+
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingsphererestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingsphererestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=shardingspherebackups,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile handles main function of this controller
+func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.Info(fmt.Sprintf("Reconciling ShardingSphereRestore %s", req.NamespacedName))
+
+	restore := &v1alpha1.ShardingSphereRestore{}
+	if err := r.Get(ctx, req.NamespacedName, restore); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !restore.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	switch restore.Status.Phase {
+	case "", v1alpha1.RestorePhasePending, v1alpha1.RestorePhaseRunning:
+	default:
+		return ctrl.Result{}, nil
+	}
+
+	backup := &v1alpha1.ShardingSphereBackup{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.BackupName}, backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.setFailed(ctx, restore, fmt.Errorf("backup %s is not found", restore.Spec.BackupName))
+		}
+		return ctrl.Result{}, err
+	}
+
+	if restore.Status.Phase == v1alpha1.RestorePhaseRunning {
+		return r.checkRestore(ctx, restore, backup)
+	}
+	return r.startRestore(ctx, restore, backup)
+}
+
+// computeNodeOf returns the compute node to restore, which is the one backed up unless it is specified.
+func computeNodeOf(restore *v1alpha1.ShardingSphereRestore, backup *v1alpha1.ShardingSphereBackup) string {
+	if restore.Spec.ComputeNodeName != "" {
+		return restore.Spec.ComputeNodeName
+	}
+	return backup.Spec.ComputeNodeName
+}
+
+// startRestore starts the restore job on every data node of the backup, the restore waits until the backup is completed.
+func (r *RestoreReconciler) startRestore(ctx context.Context, restore *v1alpha1.ShardingSphereRestore, backup *v1alpha1.ShardingSphereBackup) (ctrl.Result, error) {
+	switch backup.Status.Phase {
+	case v1alpha1.BackupPhaseCompleted:
+	case v1alpha1.BackupPhaseFailed:
+		return r.setFailed(ctx, restore, fmt.Errorf("backup %s is failed", backup.Name))
+	default:
+		return r.setPending(ctx, restore, fmt.Errorf("waiting for backup %s to complete", backup.Name))
+	}
+
+	target, err := recoveryTargetOf(restore, backup)
+	if err != nil {
+		return r.setFailed(ctx, restore, err)
+	}
+
+	nodes, err := exportStorageNodes(ctx, r.Client, r.Service, restore.Namespace, computeNodeOf(restore, backup))
+	if err != nil {
+		return r.setPending(ctx, restore, err)
+	}
+	cfg, err := agentConfig(ctx, r.Client, backup.Namespace, &backup.Spec.Agent)
+	if err != nil {
+		return r.setFailed(ctx, restore, err)
+	}
+
+	now := metav1.Now()
+	restore.Status.StartTime = &now
+	restore.Status.DataNodes = make([]v1alpha1.DataNodeRestoreStatus, 0, len(backup.Status.DataNodes))
+	for _, dn := range backup.Status.DataNodes {
+		sn, ok := nodes[storageNodeKey(dn.Host, dn.Port)]
+		if !ok {
+			return r.setFailed(ctx, restore, fmt.Errorf("data node %s:%d is not found in compute node", dn.Host, dn.Port))
+		}
+
+		job, err := r.restoreDataNode(ctx, backup, cfg, sn, dn, target)
+		if err != nil {
+			return r.setFailed(ctx, restore, fmt.Errorf("restore data node %s:%d failed: %w", dn.Host, dn.Port, err))
+		}
+		restore.Status.DataNodes = append(restore.Status.DataNodes, v1alpha1.DataNodeRestoreStatus{
+			Host:  dn.Host,
+			Port:  dn.Port,
+			JobID: job.ID,
+			State: job.State,
+		})
+	}
+
+	restore.Status.Phase = v1alpha1.RestorePhaseRunning
+	restore.Status.Message = ""
+	restore.Status.ObservedGeneration = restore.Generation
+	if err := r.Status().Update(ctx, restore); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Event(restore, corev1.EventTypeNormal, "Started", fmt.Sprintf("restore of %d data nodes started", len(restore.Status.DataNodes)))
+	return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+}
+
+func (r *RestoreReconciler) restoreDataNode(ctx context.Context, backup *v1alpha1.ShardingSphereBackup, cfg *pitr.Config, sn *shardingsphere.StorageNode, dn v1alpha1.DataNodeBackupStatus, target *pitr.RecoveryTarget) (*pitr.Job, error) {
+	agent, err := pitr.NewAgent(agentAddr(dn.Host, backup.Spec.Agent.Port), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return agent.Restore(ctx, &pitr.RestoreIn{
+		DBPort:         sn.Port,
+		DBName:         sn.Database,
+		Username:       sn.Username,
+		Password:       sn.Password,
+		Instance:       dn.Instance,
+		DnBackupPath:   backup.Spec.BackupPath,
+		DnBackupID:     dn.BackupID,
+		RecoveryTarget: target,
+	})
+}
+
+// checkRestore updates the jobs still running, the metadata of backup is imported into compute node once all of them succeeded.
+func (r *RestoreReconciler) checkRestore(ctx context.Context, restore *v1alpha1.ShardingSphereRestore, backup *v1alpha1.ShardingSphereBackup) (ctrl.Result, error) {
+	cfg, err := agentConfig(ctx, r.Client, backup.Namespace, &backup.Spec.Agent)
+	if err != nil {
+		return r.setFailed(ctx, restore, err)
+	}
+
+	succeeded, failed := 0, 0
+	for i := range restore.Status.DataNodes {
+		dn := &restore.Status.DataNodes[i]
+		if dn.State == pitr.JobStateRunning {
+			showJob(ctx, agentAddr(dn.Host, backup.Spec.Agent.Port), cfg, dn)
+		}
+
+		switch dn.State {
+		case pitr.JobStateSucceeded:
+			succeeded++
+		case pitr.JobStateFailed:
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return r.setFailed(ctx, restore, fmt.Errorf("restore of %d data nodes failed", failed))
+	}
+	if succeeded < len(restore.Status.DataNodes) {
+		if err := r.Status().Update(ctx, restore); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+	}
+
+	if err := r.restoreMetaData(ctx, restore, backup); err != nil {
+		// the data nodes are restored already, only the metadata is retried
+		restore.Status.Message = err.Error()
+		if err := r.Status().Update(ctx, restore); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+	}
+
+	now := metav1.Now()
+	restore.Status.Phase = v1alpha1.RestorePhaseCompleted
+	restore.Status.CompletionTime = &now
+	restore.Status.Message = ""
+	if err := r.Status().Update(ctx, restore); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Event(restore, corev1.EventTypeNormal, "Completed", "restore completed")
+	return ctrl.Result{}, nil
+}
+
+// showJob updates the state of dn by its restore job, the error is kept in the message of dn and checked again later.
+func showJob(ctx context.Context, addr string, cfg *pitr.Config, dn *v1alpha1.DataNodeRestoreStatus) {
+	agent, err := pitr.NewAgent(addr, cfg)
+	if err != nil {
+		dn.Message = err.Error()
+		return
+	}
+	job, err := agent.ShowJob(ctx, dn.JobID)
+	if err != nil {
+		dn.Message = err.Error()
+		return
+	}
+
+	dn.State = job.State
+	dn.Message = job.Error
+	if len(job.Result) > 0 {
+		out := &pitr.RestoreOut{}
+		if err := json.Unmarshal(job.Result, out); err == nil {
+			dn.ReplayLSN = out.ReplayLSN
+			dn.ReplayTime = out.ReplayTime
+		}
+	}
+}
+
+// restoreMetaData drops the logic databases of backup which exist now and imports the metadata in the Secret of backup, like `gs_pitr restore`.
+func (r *RestoreReconciler) restoreMetaData(ctx context.Context, restore *v1alpha1.ShardingSphereRestore, backup *v1alpha1.ShardingSphereBackup) error {
+	ss, err := newShardingSphereServer(ctx, r.Client, r.Service, restore.Namespace, computeNodeOf(restore, backup))
+	if err != nil {
+		return err
+	}
+	defer ss.Close()
+
+	if backup.Status.MetaDataSecretRef == nil {
+		return fmt.Errorf("metadata of backup %s is not found", backup.Name)
+	}
+	metadata, err := secret.GetValue(ctx, r.Client, backup.Namespace, backup.Status.MetaDataSecretRef)
+	if err != nil {
+		return err
+	}
+	backupMetaData, err := shardingsphere.DecodeMetaData(string(metadata))
+	if err != nil {
+		return err
+	}
+	data, err := ss.ExportMetaData()
+	if err != nil {
+		return err
+	}
+	current, err := shardingsphere.DecodeMetaData(data)
+	if err != nil {
+		return err
+	}
+
+	for name := range backupMetaData.MetaData.Databases {
+		if _, ok := current.MetaData.Databases[name]; !ok {
+			continue
+		}
+		if err := ss.DropDatabase(name); err != nil {
+			return err
+		}
+	}
+
+	return ss.ImportMetaData(string(metadata))
+}
+
+// recoveryTargetOf returns the recovery target of restore, nil means replaying all archived logs.
+func recoveryTargetOf(restore *v1alpha1.ShardingSphereRestore, backup *v1alpha1.ShardingSphereBackup) (*pitr.RecoveryTarget, error) {
+	target := &pitr.RecoveryTarget{}
+	if t := restore.Spec.RecoveryTarget; t != nil {
+		target = &pitr.RecoveryTarget{
+			Time:      t.Time,
+			LSN:       t.LSN,
+			Xid:       t.Xid,
+			Name:      t.Name,
+			Position:  t.Position,
+			GTID:      t.GTID,
+			Inclusive: t.Inclusive,
+		}
+	}
+
+	// all data nodes replay to the same csn captured when the cluster was locked for backup
+	if restore.Spec.ConsistentCSN {
+		if backup.Status.CSN == "" {
+			return nil, fmt.Errorf("backup %s has no csn, can not restore to a consistent csn", backup.Name)
+		}
+		target.CSN = backup.Status.CSN
+	}
+
+	set := 0
+	for _, v := range []string{target.Time, target.LSN, target.Xid, target.Name, target.CSN, target.Position, target.GTID} {
+		if v != "" {
+			set++
+		}
+	}
+	switch {
+	case set > 1:
+		return nil, fmt.Errorf("only one of time, lsn, xid, name, csn, position and gtid can be set as recovery target")
+	case set == 0:
+		return nil, nil
+	}
+	return target, nil
+}
+
+func (r *RestoreReconciler) setPending(ctx context.Context, restore *v1alpha1.ShardingSphereRestore, reason error) (ctrl.Result, error) {
+	restore.Status.Phase = v1alpha1.RestorePhasePending
+	restore.Status.Message = reason.Error()
+	restore.Status.ObservedGeneration = restore.Generation
+	if err := r.Status().Update(ctx, restore); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+}
+
+func (r *RestoreReconciler) setFailed(ctx context.Context, restore *v1alpha1.ShardingSphereRestore, reason error) (ctrl.Result, error) {
+	now := metav1.Now()
+	restore.Status.Phase = v1alpha1.RestorePhaseFailed
+	restore.Status.Message = reason.Error()
+	restore.Status.CompletionTime = &now
+	restore.Status.ObservedGeneration = restore.Generation
+	if err := r.Status().Update(ctx, restore); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Event(restore, corev1.EventTypeWarning, "Failed", reason.Error())
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *RestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ShardingSphereRestore{}).
+		Complete(r)
+}
@@ -0,0 +1,174 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/service"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/pitr"
+
+	"bou.ke/monkey"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ShardingSphereRestore Controller", func() {
+	var (
+		restoreReconciler *RestoreReconciler
+		req               = ctrl.Request{NamespacedName: client.ObjectKey{Namespace: defaultTestNamespace, Name: "test-restore"}}
+	)
+
+	BeforeEach(func() {
+		patchPITR()
+		createTestComputeNode()
+		restoreReconciler = &RestoreReconciler{
+			Client:   fakeClient,
+			Log:      reconciler.Log,
+			Recorder: record.NewFakeRecorder(100),
+			Service:  service.NewServiceClient(fakeClient),
+		}
+
+		backup := &v1alpha1.ShardingSphereBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      defaultTestBackup,
+				Namespace: defaultTestNamespace,
+			},
+			Spec: v1alpha1.ShardingSphereBackupSpec{
+				ComputeNodeName: defaultTestComputeNode,
+				BackupPath:      "/home/omm/data",
+			},
+		}
+		Expect(fakeClient.Create(ctx, backup)).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      defaultTestBackup + "-metadata",
+				Namespace: defaultTestNamespace,
+			},
+			Data: map[string][]byte{backupMetaDataKey: []byte(testMetaData)},
+		})).To(Succeed())
+		backup.Status = v1alpha1.ShardingSphereBackupStatus{
+			Phase: v1alpha1.BackupPhaseCompleted,
+			CSN:   "1024",
+			MetaDataSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: defaultTestBackup + "-metadata"},
+				Key:                  backupMetaDataKey,
+			},
+			DataNodes: []v1alpha1.DataNodeBackupStatus{
+				{Host: "10.0.0.1", Port: 5432, Instance: "ins-default-ss-5432", BackupID: "B0", Status: pitr.BackupStatusCompleted},
+				{Host: "10.0.0.1", Port: 5433, Instance: "ins-default-ss-5433", BackupID: "B1", Status: pitr.BackupStatusCompleted},
+			},
+		}
+		Expect(fakeClient.Status().Update(ctx, backup)).To(Succeed())
+
+		Expect(fakeClient.Create(ctx, &v1alpha1.ShardingSphereRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      req.Name,
+				Namespace: req.Namespace,
+			},
+			Spec: v1alpha1.ShardingSphereRestoreSpec{
+				BackupName:    defaultTestBackup,
+				ConsistentCSN: true,
+			},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		monkey.UnpatchAll()
+	})
+
+	getRestore := func() *v1alpha1.ShardingSphereRestore {
+		restore := &v1alpha1.ShardingSphereRestore{}
+		Expect(fakeClient.Get(ctx, req.NamespacedName, restore)).To(Succeed())
+		return restore
+	}
+
+	It("should restore data nodes to the csn of backup and import its metadata", func() {
+		mockSS.EXPECT().ExportStorageNodes().Return(testNodes, nil)
+		mockAgent.EXPECT().Restore(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, in *pitr.RestoreIn) (*pitr.Job, error) {
+			Expect(in.RecoveryTarget.CSN).To(Equal("1024"))
+			return &pitr.Job{ID: in.DnBackupID, State: pitr.JobStateRunning}, nil
+		}).Times(2)
+
+		_, err := restoreReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		restore := getRestore()
+		Expect(restore.Status.Phase).To(Equal(v1alpha1.RestorePhaseRunning))
+		Expect(restore.Status.DataNodes).To(HaveLen(2))
+
+		mockAgent.EXPECT().ShowJob(gomock.Any(), gomock.Any()).Return(&pitr.Job{State: pitr.JobStateSucceeded, Result: []byte(`{"replay_lsn":"0/3000060"}`)}, nil).Times(2)
+		mockSS.EXPECT().ExportMetaData().Return(testMetaData, nil)
+		mockSS.EXPECT().DropDatabase("sharding_db").Return(nil)
+		mockSS.EXPECT().ImportMetaData(testMetaData).Return(nil)
+
+		_, err = restoreReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		restore = getRestore()
+		Expect(restore.Status.Phase).To(Equal(v1alpha1.RestorePhaseCompleted))
+		Expect(restore.Status.DataNodes[0].ReplayLSN).To(Equal("0/3000060"))
+	})
+
+	It("should fail if a restore job failed", func() {
+		restore := getRestore()
+		restore.Status = v1alpha1.ShardingSphereRestoreStatus{
+			Phase: v1alpha1.RestorePhaseRunning,
+			DataNodes: []v1alpha1.DataNodeRestoreStatus{
+				{Host: "10.0.0.1", Port: 5432, JobID: "j0", State: pitr.JobStateRunning},
+			},
+		}
+		Expect(fakeClient.Status().Update(ctx, restore)).To(Succeed())
+		mockAgent.EXPECT().ShowJob(gomock.Any(), "j0").Return(&pitr.Job{State: pitr.JobStateFailed, Error: "replay failed"}, nil)
+
+		_, err := restoreReconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		restore = getRestore()
+		Expect(restore.Status.Phase).To(Equal(v1alpha1.RestorePhaseFailed))
+		Expect(restore.Status.DataNodes[0].Message).To(Equal("replay failed"))
+	})
+
+	It("should accept only one recovery target", func() {
+		restore := &v1alpha1.ShardingSphereRestore{
+			Spec: v1alpha1.ShardingSphereRestoreSpec{
+				RecoveryTarget: &v1alpha1.RecoveryTarget{LSN: "0/3000060"},
+			},
+		}
+		backup := &v1alpha1.ShardingSphereBackup{}
+
+		target, err := recoveryTargetOf(restore, backup)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(target.LSN).To(Equal("0/3000060"))
+
+		restore.Spec.ConsistentCSN = true
+		_, err = recoveryTargetOf(restore, backup)
+		Expect(err).Should(HaveOccurred())
+
+		backup.Status.CSN = "1024"
+		_, err = recoveryTargetOf(restore, backup)
+		Expect(err).Should(HaveOccurred())
+
+		target, err = recoveryTargetOf(&v1alpha1.ShardingSphereRestore{}, backup)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(target).To(BeNil())
+	})
+})
@@ -406,13 +406,12 @@ var _ = Describe("StorageNode Controller Mock Test For AWS Rds Instance", func()
 					Name:      nodeName,
 					Namespace: defaultTestNamespace,
 					Annotations: map[string]string{
-						AnnotationKeyRegisterStorageUnitEnabled: "true",
-						v1alpha1.AnnotationsInstanceDBName:      "test_db",
-						AnnotationKeyComputeNodeName:            cnName,
-						AnnotationKeyLogicDatabaseName:          "sharding_db",
+						v1alpha1.AnnotationsInstanceDBName: "test_db",
 					},
 				},
 				Spec: v1alpha1.StorageNodeSpec{
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: cnName},
+					LogicDatabase:       "sharding_db",
 					StorageProviderName: defaultTestStorageProvider,
 				},
 			}
@@ -549,13 +548,10 @@ var _ = Describe("StorageNode Controller Mock Test For AWS Rds Instance", func()
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-get-shardingsphere-server",
 					Namespace: defaultTestNamespace,
-					Annotations: map[string]string{
-						AnnotationKeyComputeNodeName: "test-get-shardingsphere-server",
-					},
 				},
 			}
 
-			ss, err := reconciler.getShardingsphereServer(ctx, sn)
+			ss, err := reconciler.getShardingsphereServer(ctx, sn, "test-get-shardingsphere-server")
 			Expect(err).To(BeNil())
 			Expect(ss).ToNot(BeNil())
 		})
@@ -618,13 +614,12 @@ var _ = Describe("StorageNode Controller Mock Test For AWS Rds Instance", func()
 					Name:      testName,
 					Namespace: defaultTestNamespace,
 					Annotations: map[string]string{
-						AnnotationKeyComputeNodeName:            testName,
-						AnnotationKeyRegisterStorageUnitEnabled: "true",
-						AnnotationKeyLogicDatabaseName:          testName,
-						v1alpha1.AnnotationsInstanceDBName:      testName,
+						v1alpha1.AnnotationsInstanceDBName: testName,
 					},
 				},
 				Spec: v1alpha1.StorageNodeSpec{
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: testName},
+					LogicDatabase:       testName,
 					StorageProviderName: defaultTestStorageProvider,
 				},
 				Status: v1alpha1.StorageNodeStatus{
@@ -659,6 +654,246 @@ var _ = Describe("StorageNode Controller Mock Test For AWS Rds Instance", func()
 			Expect(sn.Status.Registered).To(BeTrue())
 		})
 
+		It("should register storage unit with the passwords referred by secrets", func() {
+			testName := "test-register-with-secrets"
+			sec := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: defaultTestNamespace,
+				},
+				Data: map[string][]byte{
+					"proxy":    []byte("proxy-password"),
+					"password": []byte("master-password"),
+				},
+			}
+			cn := &v1alpha1.ComputeNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: defaultTestNamespace,
+				},
+				Spec: v1alpha1.ComputeNodeSpec{
+					Bootstrap: v1alpha1.BootstrapConfig{
+						ServerConfig: v1alpha1.ServerConfig{
+							Authority: v1alpha1.ComputeNodeAuthority{
+								Users: []v1alpha1.ComputeNodeUser{
+									{
+										User: "root@%",
+										PasswordSecretRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: testName},
+											Key:                  "proxy",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: defaultTestNamespace,
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Name:     "http",
+							Protocol: "TCP",
+							Port:     3307,
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, sec)).Should(Succeed())
+			Expect(fakeClient.Create(ctx, cn)).Should(Succeed())
+			Expect(fakeClient.Create(ctx, svc)).Should(Succeed())
+
+			sn := &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: defaultTestNamespace,
+					Annotations: map[string]string{
+						v1alpha1.AnnotationsInstanceDBName: testName,
+					},
+				},
+				Spec: v1alpha1.StorageNodeSpec{
+					MasterUserCredential: &v1alpha1.BasicCredential{
+						PasswordSecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: testName},
+							Key:                  "password",
+						},
+					},
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: testName},
+					LogicDatabase:       testName,
+					StorageProviderName: defaultTestStorageProvider,
+				},
+				Status: v1alpha1.StorageNodeStatus{
+					Phase: v1alpha1.StorageNodePhaseReady,
+					Instances: []v1alpha1.InstanceStatus{
+						{
+							Status:   string(dbmeshawsrds.DBInstanceStatusAvailable),
+							Endpoint: v1alpha1.Endpoint{Address: "127.0.0.1", Port: 3306},
+						},
+					},
+				},
+			}
+
+			storageProvider := &v1alpha1.StorageProvider{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: defaultTestStorageProvider,
+				},
+				Spec: v1alpha1.StorageProviderSpec{
+					Provisioner: v1alpha1.ProvisionerAWSRDSInstance,
+					Parameters: map[string]string{
+						"masterUsername": testName,
+					},
+				},
+			}
+
+			monkey.Patch(shardingsphere.NewServer, func(_, _ string, _ uint, _, password string) (shardingsphere.IServer, error) {
+				Expect(password).To(Equal("proxy-password"))
+				return mockSS, nil
+			})
+			mockSS.EXPECT().CreateDatabase(testName).Return(nil)
+			mockSS.EXPECT().Close().Return(nil)
+			mockSS.EXPECT().RegisterStorageUnit(testName, getDSName(sn), "127.0.0.1", uint(3306), testName, testName, "master-password").Return(nil)
+
+			Expect(reconciler.registerStorageUnit(ctx, sn, storageProvider)).To(BeNil())
+			Expect(sn.Status.Registered).To(BeTrue())
+			Expect(reconciler.storageNodesOfSecret(sec)).To(BeEmpty())
+
+			Expect(fakeClient.Create(ctx, sn)).Should(Succeed())
+			Expect(reconciler.storageNodesOfSecret(sec)).To(ConsistOf(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: defaultTestNamespace, Name: testName}}))
+		})
+
+		It("should move the storage unit to the compute node referred", func() {
+			testName := "test-move-storage-unit"
+			for _, name := range []string{testName + "-old", testName + "-new"} {
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComputeNode{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultTestNamespace},
+					Spec: v1alpha1.ComputeNodeSpec{
+						Bootstrap: v1alpha1.BootstrapConfig{
+							ServerConfig: v1alpha1.ServerConfig{
+								Authority: v1alpha1.ComputeNodeAuthority{
+									Users: []v1alpha1.ComputeNodeUser{{User: "root", Password: "root"}},
+								},
+							},
+						},
+					},
+				})).Should(Succeed())
+				Expect(fakeClient.Create(ctx, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultTestNamespace},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{{Name: "http", Protocol: "TCP", Port: 3307}},
+					},
+				})).Should(Succeed())
+			}
+
+			sn := &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: defaultTestNamespace,
+				},
+				Spec: v1alpha1.StorageNodeSpec{
+					StorageProviderName: defaultTestStorageProvider,
+					Schema:              testName,
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: testName + "-new"},
+					LogicDatabase:       "sharding_db",
+				},
+				Status: v1alpha1.StorageNodeStatus{
+					Phase: v1alpha1.StorageNodePhaseNotReady,
+					Instances: []v1alpha1.InstanceStatus{
+						{
+							Status:   string(dbmeshawsrds.DBInstanceStatusAvailable),
+							Endpoint: v1alpha1.Endpoint{Address: "127.0.0.1", Port: 3306},
+						},
+					},
+					Registered: true,
+					StorageUnits: []v1alpha1.StorageUnitStatus{
+						{ComputeNodeName: testName + "-old", LogicDatabase: "sharding_db", StorageUnitName: "ds_0", Registered: true},
+					},
+				},
+			}
+			storageProvider := &v1alpha1.StorageProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultTestStorageProvider},
+				Spec: v1alpha1.StorageProviderSpec{
+					Provisioner: v1alpha1.ProvisionerAWSRDSInstance,
+					Parameters: map[string]string{
+						"masterUsername":     testName,
+						"masterUserPassword": testName,
+					},
+				},
+			}
+
+			// unregistered from the old compute node, and waiting for the storage node to be ready
+			mockSS.EXPECT().UnRegisterStorageUnit("sharding_db", "ds_0").Return(nil)
+			mockSS.EXPECT().Close().Return(nil)
+			Expect(reconciler.registerStorageUnit(ctx, sn, storageProvider)).To(BeNil())
+			Expect(sn.Status.Registered).To(BeFalse())
+			Expect(sn.Status.StorageUnits).To(HaveLen(1))
+			Expect(sn.Status.StorageUnits[0].ComputeNodeName).To(Equal(testName + "-new"))
+			Expect(sn.Status.StorageUnits[0].StorageUnitName).To(Equal(getDSName(sn)))
+			Expect(sn.Status.StorageUnits[0].Registered).To(BeFalse())
+			Expect(sn.Status.StorageUnits[0].Message).ToNot(BeEmpty())
+
+			// registered to the new compute node once ready, the database name falls back to the schema
+			sn.Status.Phase = v1alpha1.StorageNodePhaseReady
+			mockSS.EXPECT().CreateDatabase("sharding_db").Return(nil)
+			mockSS.EXPECT().RegisterStorageUnit("sharding_db", getDSName(sn), "127.0.0.1", uint(3306), testName, testName, testName).Return(nil)
+			mockSS.EXPECT().Close().Return(nil)
+			Expect(reconciler.registerStorageUnit(ctx, sn, storageProvider)).To(BeNil())
+			Expect(sn.Status.Registered).To(BeTrue())
+			Expect(sn.Status.StorageUnits).To(HaveLen(1))
+			Expect(sn.Status.StorageUnits[0].Registered).To(BeTrue())
+			Expect(sn.Status.StorageUnits[0].Message).To(BeEmpty())
+
+			// registered once only
+			Expect(reconciler.registerStorageUnit(ctx, sn, storageProvider)).To(BeNil())
+		})
+
+		It("should keep the storage unit registered by the legacy annotations", func() {
+			testName := "test-legacy-storage-unit"
+			sn := &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: defaultTestNamespace,
+					Annotations: map[string]string{
+						v1alpha1.AnnotationsRegisterStorageUnitEnabled: "true",
+						v1alpha1.AnnotationsComputeNodeName:            testName,
+						v1alpha1.AnnotationsLogicDatabaseName:          "sharding_db",
+						v1alpha1.AnnotationsInstanceDBName:             testName,
+					},
+				},
+				Spec: v1alpha1.StorageNodeSpec{
+					StorageProviderName: defaultTestStorageProvider,
+				},
+				Status: v1alpha1.StorageNodeStatus{
+					Phase:      v1alpha1.StorageNodePhaseReady,
+					Registered: true,
+				},
+			}
+			storageProvider := &v1alpha1.StorageProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultTestStorageProvider},
+				Spec:       v1alpha1.StorageProviderSpec{Provisioner: v1alpha1.ProvisionerAWSRDSInstance},
+			}
+
+			// neither registered again nor unregistered
+			Expect(reconciler.registerStorageUnit(ctx, sn, storageProvider)).To(BeNil())
+			Expect(sn.Status.Registered).To(BeTrue())
+			Expect(sn.Status.StorageUnits).To(HaveLen(1))
+			Expect(sn.Status.StorageUnits[0].ComputeNodeName).To(Equal(testName))
+			Expect(sn.Status.StorageUnits[0].LogicDatabase).To(Equal("sharding_db"))
+			Expect(sn.Status.StorageUnits[0].StorageUnitName).To(Equal(getDSName(sn)))
+			Expect(sn.Status.StorageUnits[0].Registered).To(BeTrue())
+
+			// the same storage unit once the annotations are translated into the spec
+			sn.Spec.ComputeNodeRef = &corev1.LocalObjectReference{Name: testName}
+			sn.Spec.LogicDatabase = "sharding_db"
+			Expect(reconciler.registerStorageUnit(ctx, sn, storageProvider)).To(BeNil())
+			Expect(sn.Status.Registered).To(BeTrue())
+			Expect(sn.Status.StorageUnits).To(HaveLen(1))
+		})
+
 		Context("Test unregisterStorageUnit", func() {
 			BeforeEach(func() {
 				mockCtrl = gomock.NewController(GinkgoT())
@@ -717,23 +952,127 @@ var _ = Describe("StorageNode Controller Mock Test For AWS Rds Instance", func()
 						Name:      testName,
 						Namespace: defaultTestNamespace,
 						Annotations: map[string]string{
-							AnnotationKeyLogicDatabaseName:     testName,
 							v1alpha1.AnnotationsInstanceDBName: testName,
-							AnnotationKeyComputeNodeName:       testName,
 						},
 					},
+					Spec: v1alpha1.StorageNodeSpec{
+						ComputeNodeRef: &corev1.LocalObjectReference{Name: testName},
+						LogicDatabase:  testName,
+					},
 					Status: v1alpha1.StorageNodeStatus{
 						Registered: true,
+						StorageUnits: []v1alpha1.StorageUnitStatus{
+							{ComputeNodeName: testName, LogicDatabase: testName, StorageUnitName: "ds_0", Registered: true},
+							// the compute node has been deleted
+							{ComputeNodeName: "test-deleted-compute-node", LogicDatabase: testName, StorageUnitName: "ds_0", Registered: true},
+						},
 					},
 				}
 				Expect(fakeClient.Create(ctx, sn)).Should(Succeed())
 
-				mockSS.EXPECT().UnRegisterStorageUnit(gomock.Any(), gomock.Any()).Return(nil)
+				mockSS.EXPECT().UnRegisterStorageUnit(testName, "ds_0").Return(nil)
+				mockSS.EXPECT().Close().Return(nil)
+				Expect(reconciler.unregisterStorageUnit(ctx, sn)).To(BeNil())
+				Expect(sn.Status.Registered).To(BeFalse())
+				Expect(sn.Status.StorageUnits).To(BeEmpty())
+			})
+
+			It("should unregister the storage unit registered by the legacy annotations", func() {
+				testName := "test-unregister-legacy-storage-unit"
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComputeNode{
+					ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: defaultTestNamespace},
+					Spec: v1alpha1.ComputeNodeSpec{
+						Bootstrap: v1alpha1.BootstrapConfig{
+							ServerConfig: v1alpha1.ServerConfig{
+								Authority: v1alpha1.ComputeNodeAuthority{
+									Users: []v1alpha1.ComputeNodeUser{{User: "root", Password: "root"}},
+								},
+							},
+						},
+					},
+				})).Should(Succeed())
+				Expect(fakeClient.Create(ctx, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: defaultTestNamespace},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{{Name: "http", Protocol: "TCP", Port: 3307}},
+					},
+				})).Should(Succeed())
+
+				sn := &v1alpha1.StorageNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      testName,
+						Namespace: defaultTestNamespace,
+						Annotations: map[string]string{
+							v1alpha1.AnnotationsRegisterStorageUnitEnabled: "true",
+							v1alpha1.AnnotationsComputeNodeName:            testName,
+							v1alpha1.AnnotationsLogicDatabaseName:          "sharding_db",
+						},
+					},
+					Status: v1alpha1.StorageNodeStatus{
+						Registered: true,
+					},
+				}
+
+				mockSS.EXPECT().UnRegisterStorageUnit("sharding_db", getDSName(sn)).Return(nil)
 				mockSS.EXPECT().Close().Return(nil)
 				Expect(reconciler.unregisterStorageUnit(ctx, sn)).To(BeNil())
+				Expect(sn.Status.Registered).To(BeFalse())
+				Expect(sn.Status.StorageUnits).To(BeEmpty())
 			})
 		})
 	})
+
+	Context("Test resolveMasterUserPassword", func() {
+		It("should return the plaintext password without secret reference", func() {
+			sn := &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-plaintext-password", Namespace: defaultTestNamespace},
+			}
+			password, err := reconciler.resolveMasterUserPassword(ctx, sn, "plaintext")
+			Expect(err).To(BeNil())
+			Expect(password).To(Equal("plaintext"))
+		})
+
+		It("should read the password from the secret referred by masterUserCredential", func() {
+			testName := "test-master-user-secret"
+			Expect(fakeClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: defaultTestNamespace},
+				Data:       map[string][]byte{"master": []byte("secret-password")},
+			})).Should(Succeed())
+
+			sn := &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: defaultTestNamespace},
+				Spec: v1alpha1.StorageNodeSpec{
+					MasterUserCredential: &v1alpha1.BasicCredential{
+						Username: "root",
+						Password: "plaintext",
+						PasswordSecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: testName},
+							Key:                  "master",
+						},
+					},
+				},
+			}
+			password, err := reconciler.resolveMasterUserPassword(ctx, sn, "plaintext")
+			Expect(err).To(BeNil())
+			Expect(password).To(Equal("secret-password"))
+		})
+
+		It("should fail if the secret referred is missing", func() {
+			sn := &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-missing-master-user-secret", Namespace: defaultTestNamespace},
+				Spec: v1alpha1.StorageNodeSpec{
+					MasterUserCredential: &v1alpha1.BasicCredential{
+						PasswordSecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "test-missing-master-user-secret"},
+							Key:                  "password",
+						},
+					},
+				},
+			}
+			_, err := reconciler.resolveMasterUserPassword(ctx, sn, "plaintext")
+			Expect(err).NotTo(BeNil())
+		})
+	})
 })
 
 var _ = Describe("StorageNode Controller Mock Test For AWS Aurora", func() {
@@ -994,14 +1333,13 @@ var _ = Describe("StorageNode Controller Mock Test For AWS Aurora", func() {
 					Name:      name,
 					Namespace: defaultTestNamespace,
 					Annotations: map[string]string{
-						v1alpha1.AnnotationsClusterIdentifier:   "test-aws-aurora",
-						AnnotationKeyRegisterStorageUnitEnabled: "true",
-						AnnotationKeyLogicDatabaseName:          "test-logic-db",
-						v1alpha1.AnnotationsInstanceDBName:      "test-instance-db",
-						AnnotationKeyComputeNodeName:            "test-compute-node",
+						v1alpha1.AnnotationsClusterIdentifier: "test-aws-aurora",
+						v1alpha1.AnnotationsInstanceDBName:    "test-instance-db",
 					},
 				},
 				Spec: v1alpha1.StorageNodeSpec{
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: "test-compute-node"},
+					LogicDatabase:       "test-logic-db",
 					StorageProviderName: "aws-aurora",
 				},
 				Status: v1alpha1.StorageNodeStatus{
@@ -1094,20 +1432,23 @@ var _ = Describe("StorageNode Controller Mock Test For AWS Aurora", func() {
 					Name:      snName,
 					Namespace: defaultTestNamespace,
 					Annotations: map[string]string{
-						v1alpha1.AnnotationsClusterIdentifier:   "test-aws-aurora",
-						AnnotationKeyRegisterStorageUnitEnabled: "true",
-						AnnotationKeyLogicDatabaseName:          "test-logic-db",
-						v1alpha1.AnnotationsInstanceDBName:      "test-instance-db",
-						AnnotationKeyComputeNodeName:            "test-compute-node",
+						v1alpha1.AnnotationsClusterIdentifier: "test-aws-aurora",
+						v1alpha1.AnnotationsInstanceDBName:    "test-instance-db",
 					},
 				},
 				Spec: v1alpha1.StorageNodeSpec{
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: "test-compute-node"},
+					LogicDatabase:       "test-logic-db",
+					StorageUnitName:     "ds_test",
 					StorageProviderName: "aws-aurora",
 					Replicas:            2,
 				},
 				Status: v1alpha1.StorageNodeStatus{
 					Phase:      v1alpha1.StorageNodePhaseReady,
 					Registered: true,
+					StorageUnits: []v1alpha1.StorageUnitStatus{
+						{ComputeNodeName: "test-compute-node", LogicDatabase: "test-logic-db", StorageUnitName: "ds_test", Registered: true},
+					},
 				},
 			}
 			cn := &v1alpha1.ComputeNode{
@@ -1170,7 +1511,7 @@ var _ = Describe("StorageNode Controller Mock Test For AWS Aurora", func() {
 
 			Expect(fakeClient.Delete(ctx, storageNode)).Should(Succeed())
 			// mock shardingsphere
-			mockSS.EXPECT().UnRegisterStorageUnit("test-logic-db", getDSName(storageNode)).Return(nil).Times(1)
+			mockSS.EXPECT().UnRegisterStorageUnit("test-logic-db", "ds_test").Return(nil).Times(1)
 			mockSS.EXPECT().Close().Return(nil).Times(1)
 
 			// mock delete aws aurora
@@ -1445,14 +1786,13 @@ var _ = Describe("StorageNode Controller Mock Test For AWS RDS Cluster", func()
 					Name:      name,
 					Namespace: defaultTestNamespace,
 					Annotations: map[string]string{
-						v1alpha1.AnnotationsClusterIdentifier:   "test-aws-rds-cluster",
-						AnnotationKeyRegisterStorageUnitEnabled: "true",
-						AnnotationKeyLogicDatabaseName:          "test-logic-db",
-						v1alpha1.AnnotationsInstanceDBName:      "test-instance-db",
-						AnnotationKeyComputeNodeName:            "test-compute-node",
+						v1alpha1.AnnotationsClusterIdentifier: "test-aws-rds-cluster",
+						v1alpha1.AnnotationsInstanceDBName:    "test-instance-db",
 					},
 				},
 				Spec: v1alpha1.StorageNodeSpec{
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: "test-compute-node"},
+					LogicDatabase:       "test-logic-db",
 					StorageProviderName: providerName,
 				},
 				Status: v1alpha1.StorageNodeStatus{
@@ -1545,20 +1885,23 @@ var _ = Describe("StorageNode Controller Mock Test For AWS RDS Cluster", func()
 					Name:      snName,
 					Namespace: defaultTestNamespace,
 					Annotations: map[string]string{
-						v1alpha1.AnnotationsClusterIdentifier:   "test-aws-rds-cluster",
-						AnnotationKeyRegisterStorageUnitEnabled: "true",
-						AnnotationKeyLogicDatabaseName:          "test-logic-db",
-						v1alpha1.AnnotationsInstanceDBName:      "test-instance-db",
-						AnnotationKeyComputeNodeName:            "test-compute-node",
+						v1alpha1.AnnotationsClusterIdentifier: "test-aws-rds-cluster",
+						v1alpha1.AnnotationsInstanceDBName:    "test-instance-db",
 					},
 				},
 				Spec: v1alpha1.StorageNodeSpec{
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: "test-compute-node"},
+					LogicDatabase:       "test-logic-db",
+					StorageUnitName:     "ds_test",
 					StorageProviderName: providerName,
 					Replicas:            2,
 				},
 				Status: v1alpha1.StorageNodeStatus{
 					Phase:      v1alpha1.StorageNodePhaseReady,
 					Registered: true,
+					StorageUnits: []v1alpha1.StorageUnitStatus{
+						{ComputeNodeName: "test-compute-node", LogicDatabase: "test-logic-db", StorageUnitName: "ds_test", Registered: true},
+					},
 				},
 			}
 			cn := &v1alpha1.ComputeNode{
@@ -1621,7 +1964,7 @@ var _ = Describe("StorageNode Controller Mock Test For AWS RDS Cluster", func()
 
 			Expect(fakeClient.Delete(ctx, storageNode)).Should(Succeed())
 			// mock shardingsphere
-			mockSS.EXPECT().UnRegisterStorageUnit("test-logic-db", getDSName(storageNode)).Return(nil).Times(1)
+			mockSS.EXPECT().UnRegisterStorageUnit("test-logic-db", "ds_test").Return(nil).Times(1)
 			mockSS.EXPECT().Close().Return(nil).Times(1)
 
 			// mock delete aws rds cluster
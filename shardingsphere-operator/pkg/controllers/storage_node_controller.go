@@ -25,6 +25,7 @@ import (
 
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
 	cloudnativepg "github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/cloudnative-pg"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/secret"
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/service"
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/reconcile/storagenode/aws"
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/shardingsphere"
@@ -44,16 +45,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
 	StorageNodeControllerName = "storage-node-controller"
 	FinalizerName             = "shardingsphere.apache.org/finalizer"
 
-	AnnotationKeyRegisterStorageUnitEnabled = "shardingsphere.apache.org/register-storage-unit-enabled"
-	AnnotationKeyComputeNodeName            = "shardingsphere.apache.org/compute-node-name"
-	AnnotationKeyLogicDatabaseName          = "shardingsphere.apache.org/logic-database-name"
-
 	ShardingSphereProtocolType = "proxy-frontend-database-protocol-type"
 )
 
@@ -79,6 +79,7 @@ type StorageNodeReconciler struct {
 // +kubebuilder:rbac:groups=shardingsphere.apache.org,resources=storageproviders,verbs=get;list;watch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=event,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile handles main function of this controller
 // nolint:gocognit
@@ -161,16 +162,16 @@ func (r *StorageNodeReconciler) finalize(ctx context.Context, node *v1alpha1.Sto
 		return ctrl.Result{}, nil
 	}
 
-	if storageProvider.Spec.Provisioner == v1alpha1.ProvisionerCloudNativePG {
-		return r.finalizeCloudNativePG(ctx, node, storageProvider)
-	}
-
 	// Try to unregister storage unit in shardingsphere.
 	if err = r.unregisterStorageUnit(ctx, node); err != nil {
 		r.Log.Error(err, "failed to delete storage unit")
 		return ctrl.Result{RequeueAfter: defaultRequeueTime}, err
 	}
 
+	if storageProvider.Spec.Provisioner == v1alpha1.ProvisionerCloudNativePG {
+		return r.finalizeCloudNativePG(ctx, node, storageProvider)
+	}
+
 	if err = r.deleteDatabaseCluster(ctx, node, storageProvider); err != nil {
 		r.Log.Error(err, "failed to delete database cluster")
 		return ctrl.Result{RequeueAfter: defaultRequeueTime}, err
@@ -221,10 +222,10 @@ func (r *StorageNodeReconciler) reconcile(ctx context.Context, storageProvider *
 		return ctrl.Result{RequeueAfter: defaultRequeueTime}, err
 	}
 
-	// register storage unit if needed.
-	if err := r.registerStorageUnit(ctx, node, storageProvider); err != nil {
-		r.Recorder.Eventf(node, corev1.EventTypeWarning, "RegisterStorageUnitFailed", "unable to register storage unit %s/%s", node.GetNamespace(), node.GetName())
-		return ctrl.Result{Requeue: true}, err
+	// register storage unit if needed, the registration state is kept in status even if it fails.
+	registerErr := r.registerStorageUnit(ctx, node, storageProvider)
+	if registerErr != nil {
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, "RegisterStorageUnitFailed", "unable to register storage unit %s/%s: %s", node.GetNamespace(), node.GetName(), registerErr)
 	}
 
 	desiredState := computeDesiredState(node.Status)
@@ -238,6 +239,9 @@ func (r *StorageNodeReconciler) reconcile(ctx context.Context, storageProvider *
 		}
 	}
 
+	if registerErr != nil {
+		return ctrl.Result{Requeue: true}, registerErr
+	}
 	return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
 }
 
@@ -386,7 +390,11 @@ func (r *StorageNodeReconciler) reconcileAwsRdsInstance(ctx context.Context, rds
 	}
 
 	if instance == nil && node.Status.Phase != v1alpha1.StorageNodePhaseDeleting {
-		err = rdsClient.CreateInstance(ctx, node, storageProvider.Spec.Parameters)
+		params, err := r.getCreateParams(ctx, node, storageProvider)
+		if err != nil {
+			return err
+		}
+		err = rdsClient.CreateInstance(ctx, node, params)
 		if err != nil {
 			return err
 		}
@@ -433,7 +441,11 @@ func (r *StorageNodeReconciler) reconcileAwsRDSCluster(ctx context.Context, rdsC
 
 	if rc == nil {
 		// create instance
-		err = rdsClient.CreateRDSCluster(ctx, node, storageProvider.Spec.Parameters)
+		params, err := r.getCreateParams(ctx, node, storageProvider)
+		if err != nil {
+			return err
+		}
+		err = rdsClient.CreateRDSCluster(ctx, node, params)
 		if err != nil {
 			return err
 		}
@@ -460,7 +472,11 @@ func (r *StorageNodeReconciler) reconcileAwsAurora(ctx context.Context, rdsClien
 
 	if ac == nil {
 		// create instance
-		err = rdsClient.CreateAuroraCluster(ctx, node, storageProvider.Spec.Parameters)
+		params, err := r.getCreateParams(ctx, node, storageProvider)
+		if err != nil {
+			return err
+		}
+		err = rdsClient.CreateAuroraCluster(ctx, node, params)
 		if err != nil {
 			return err
 		}
@@ -618,42 +634,105 @@ func (r *StorageNodeReconciler) deleteAWSAurora(ctx context.Context, rdsClient a
 	return nil
 }
 
-// registerStorageUnit
+// registerStorageUnit registers the storage node as a storage unit of the compute node referred by spec.computeNodeRef
+// once it is ready, and unregisters the storage units of the compute nodes which are not referred any more.
+// The registration state on every compute node is kept in status.storageUnits.
 func (r *StorageNodeReconciler) registerStorageUnit(ctx context.Context, node *v1alpha1.StorageNode, storageProvider *v1alpha1.StorageProvider) error {
-	// if register storage unit is not enabled, return
-	if node.Annotations[AnnotationKeyRegisterStorageUnitEnabled] != "true" {
-		return nil
+	seedLegacyStorageUnit(node)
+	desired := desiredStorageUnit(node)
+
+	var (
+		units     []v1alpha1.StorageUnitStatus
+		staleErr  error
+		unitIndex = -1
+	)
+	for i := range node.Status.StorageUnits {
+		unit := node.Status.StorageUnits[i]
+		if desired != nil && isSameStorageUnit(&unit, desired) {
+			units = append(units, unit)
+			unitIndex = len(units) - 1
+			continue
+		}
+		if !unit.Registered {
+			continue
+		}
+		// the storage unit of a compute node which is not referred any more
+		if err := r.unregisterStorageUnitFrom(ctx, node, &unit); err != nil {
+			setStorageUnitMessage(&unit, err.Error())
+			units = append(units, unit)
+			if staleErr == nil {
+				staleErr = err
+			}
+		}
+	}
+
+	if desired == nil {
+		node.Status.StorageUnits = units
+		node.Status.Registered = false
+		return staleErr
+	}
+
+	if unitIndex < 0 {
+		units = append(units, *desired)
+		unitIndex = len(units) - 1
 	}
+	node.Status.StorageUnits = units
 
-	if err := r.validateComputeNodeAnnotations(node); err != nil {
+	unit := &node.Status.StorageUnits[unitIndex]
+	err := r.registerStorageUnitTo(ctx, node, storageProvider, unit)
+	node.Status.Registered = unit.Registered
+	if err != nil {
 		return err
 	}
+	return staleErr
+}
 
+// registerStorageUnitTo registers the storage node to the compute node of unit if it is not registered yet,
+// the reason is recorded into unit if the storage node can not be registered for now.
+func (r *StorageNodeReconciler) registerStorageUnitTo(ctx context.Context, node *v1alpha1.StorageNode, storageProvider *v1alpha1.StorageProvider, unit *v1alpha1.StorageUnitStatus) error {
 	// if storage unit is already registered, return
-	if node.Status.Registered {
+	if unit.Registered {
 		return nil
 	}
 
 	// if node is not ready, return
 	if node.Status.Phase != v1alpha1.StorageNodePhaseReady {
 		r.Recorder.Eventf(node, corev1.EventTypeWarning, "RegisterWaiting", "Waiting to register storage unit for node %s/%s: node is not ready", node.GetNamespace(), node.GetName())
+		setStorageUnitMessage(unit, "waiting for the storage node to be ready")
+		return nil
+	}
+
+	if unit.LogicDatabase == "" {
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, "RegisterChecking", "Waiting to register storage unit for node %s/%s: spec.logicDatabase is required", node.GetNamespace(), node.GetName())
+		setStorageUnitMessage(unit, "spec.logicDatabase is required")
 		return nil
 	}
 
-	logicDBName := node.Annotations[AnnotationKeyLogicDatabaseName]
 	dbName := node.Annotations[v1alpha1.AnnotationsInstanceDBName]
+	if dbName == "" {
+		dbName = node.Spec.Schema
+	}
+	if dbName == "" {
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, "RegisterChecking", "Waiting to register storage unit for node %s/%s: database name is required", node.GetNamespace(), node.GetName())
+		setStorageUnitMessage(unit, fmt.Sprintf("database name is required by annotation %s or spec.schema", v1alpha1.AnnotationsInstanceDBName))
+		return nil
+	}
 
-	ssServer, err := r.getShardingsphereServer(ctx, node)
+	ssServer, err := r.getShardingsphereServer(ctx, node, unit.ComputeNodeName)
 	if err != nil {
-		return fmt.Errorf("getShardingsphereServer failed: %w", err)
+		err = fmt.Errorf("getShardingsphereServer failed: %w", err)
+		setStorageUnitMessage(unit, err.Error())
+		return err
 	}
 
 	defer ssServer.Close()
 
-	if err := ssServer.CreateDatabase(logicDBName); err != nil {
-		return fmt.Errorf("create database failed: %w", err)
+	if err := ssServer.CreateDatabase(unit.LogicDatabase); err != nil {
+		err = fmt.Errorf("create database failed: %w", err)
+		setStorageUnitMessage(unit, err.Error())
+		return err
 	}
-	r.Recorder.Eventf(node, corev1.EventTypeNormal, "LogicDatabaseCreated", "LogicDatabase %s is created", logicDBName)
+	r.Recorder.Eventf(node, corev1.EventTypeNormal, "LogicDatabaseCreated", "LogicDatabase %s is created", unit.LogicDatabase)
 
 	var host string
 	var port int32
@@ -664,16 +743,86 @@ func (r *StorageNodeReconciler) registerStorageUnit(ctx context.Context, node *v
 	} else {
 		host, port, username, password = getDatasourceInfoFromCluster(node, storageProvider)
 	}
+	if password, err = r.resolveMasterUserPassword(ctx, node, password); err != nil {
+		setStorageUnitMessage(unit, err.Error())
+		return err
+	}
 
-	if err := ssServer.RegisterStorageUnit(logicDBName, getDSName(node), host, uint(port), dbName, username, password); err != nil {
-		return fmt.Errorf("register storage node failed: %w", err)
+	if err := ssServer.RegisterStorageUnit(unit.LogicDatabase, unit.StorageUnitName, host, uint(port), dbName, username, password); err != nil {
+		err = fmt.Errorf("register storage node failed: %w", err)
+		setStorageUnitMessage(unit, err.Error())
+		return err
 	}
-	r.Recorder.Eventf(node, corev1.EventTypeNormal, "StorageUnitRegistered", "StorageUnit %s:%d/%s is registered", host, port, dbName)
+	r.Recorder.Eventf(node, corev1.EventTypeNormal, "StorageUnitRegistered", "StorageUnit %s:%d/%s is registered to %s", host, port, dbName, unit.ComputeNodeName)
 
-	node.Status.Registered = true
+	unit.Registered = true
+	unit.Message = ""
+	unit.LastUpdateTime = metav1.Now()
 	return nil
 }
 
+// desiredStorageUnit returns the storage unit which the storage node should be registered as, or nil if no compute node is referred.
+// The compute node named by the legacy annotations is referred if spec.computeNodeRef is not set.
+func desiredStorageUnit(node *v1alpha1.StorageNode) *v1alpha1.StorageUnitStatus {
+	var computeNodeName, logicDatabase string
+	if ref := node.Spec.ComputeNodeRef; ref != nil {
+		computeNodeName, logicDatabase = ref.Name, node.Spec.LogicDatabase
+	} else {
+		computeNodeName, logicDatabase = node.LegacyStorageUnitRef()
+	}
+	if computeNodeName == "" {
+		return nil
+	}
+
+	name := node.Spec.StorageUnitName
+	if name == "" {
+		name = getDSName(node)
+	}
+	return &v1alpha1.StorageUnitStatus{
+		ComputeNodeName: computeNodeName,
+		LogicDatabase:   logicDatabase,
+		StorageUnitName: name,
+		LastUpdateTime:  metav1.Now(),
+	}
+}
+
+// seedLegacyStorageUnit records the storage unit registered by the legacy annotations into status.storageUnits,
+// which is only marked by status.registered before status.storageUnits is introduced. So the storage unit is
+// taken as registered instead of being registered again, and it is unregistered once it is not referred any more.
+func seedLegacyStorageUnit(node *v1alpha1.StorageNode) {
+	if !node.Status.Registered || len(node.Status.StorageUnits) > 0 {
+		return
+	}
+	computeNodeName, logicDatabase := node.LegacyStorageUnitRef()
+	if computeNodeName == "" {
+		return
+	}
+	node.Status.StorageUnits = []v1alpha1.StorageUnitStatus{
+		{
+			ComputeNodeName: computeNodeName,
+			LogicDatabase:   logicDatabase,
+			// the storage unit was always registered as ds_<name of storage node> by the legacy annotations
+			StorageUnitName: getDSName(node),
+			Registered:      true,
+			LastUpdateTime:  metav1.Now(),
+		},
+	}
+}
+
+func isSameStorageUnit(a, b *v1alpha1.StorageUnitStatus) bool {
+	return a.ComputeNodeName == b.ComputeNodeName && a.LogicDatabase == b.LogicDatabase && a.StorageUnitName == b.StorageUnitName
+}
+
+// setStorageUnitMessage sets the message of unit, the last update time is only changed with the message
+// to avoid updating the status on every reconciliation.
+func setStorageUnitMessage(unit *v1alpha1.StorageUnitStatus, message string) {
+	if unit.Message == message {
+		return
+	}
+	unit.Message = message
+	unit.LastUpdateTime = metav1.Now()
+}
+
 // getDSName returns the datasource name of the storage node.
 // datasource name only allows letters, numbers and _, and must start with a letter.
 // ref: https://shardingsphere.apache.org/document/current/en/user-manual/shardingsphere-proxy/distsql/syntax/rdl/storage-unit-definition/register-storage-unit/
@@ -685,14 +834,7 @@ func getDatasourceInfoFromInstance(node *v1alpha1.StorageNode, storageProvider *
 	ins := node.Status.Instances[0]
 	host = ins.Endpoint.Address
 	port = ins.Endpoint.Port
-	username = node.Annotations[v1alpha1.AnnotationsMasterUsername]
-	if username == "" {
-		username = storageProvider.Spec.Parameters["masterUsername"]
-	}
-	password = node.Annotations[v1alpha1.AnnotationsMasterUserPassword]
-	if password == "" {
-		password = storageProvider.Spec.Parameters["masterUserPassword"]
-	}
+	username, password = getMasterUser(node, storageProvider)
 	return
 }
 
@@ -700,95 +842,180 @@ func getDatasourceInfoFromCluster(node *v1alpha1.StorageNode, storageProvider *v
 	cluster := node.Status.Cluster
 	host = cluster.PrimaryEndpoint.Address
 	port = cluster.PrimaryEndpoint.Port
-	username = node.Annotations[v1alpha1.AnnotationsMasterUsername]
+	username, password = getMasterUser(node, storageProvider)
+	return
+}
+
+// getMasterUser returns the master user in spec.masterUserCredential of node, then in the annotations of node,
+// and then in the parameters of storage provider. The password referred by secret is resolved by resolveMasterUserPassword.
+func getMasterUser(node *v1alpha1.StorageNode, storageProvider *v1alpha1.StorageProvider) (username, password string) {
+	if c := node.Spec.MasterUserCredential; c != nil {
+		username, password = c.Username, c.Password
+	}
+	if username == "" {
+		username = node.Annotations[v1alpha1.AnnotationsMasterUsername]
+	}
 	if username == "" {
 		username = storageProvider.Spec.Parameters["masterUsername"]
 	}
-	password = node.Annotations[v1alpha1.AnnotationsMasterUserPassword]
+	if password == "" {
+		password = node.Annotations[v1alpha1.AnnotationsMasterUserPassword]
+	}
 	if password == "" {
 		password = storageProvider.Spec.Parameters["masterUserPassword"]
 	}
 	return
 }
 
-func (r *StorageNodeReconciler) unregisterStorageUnit(ctx context.Context, node *v1alpha1.StorageNode) error {
-	if !node.Status.Registered {
+// masterUserPasswordSecretRef returns the secret reference of master user password in spec.masterUserCredential of node, or nil if there is none.
+func masterUserPasswordSecretRef(node *v1alpha1.StorageNode) *corev1.SecretKeySelector {
+	if node.Spec.MasterUserCredential == nil {
 		return nil
 	}
-	if err := r.validateComputeNodeAnnotations(node); err != nil {
-		return err
-	}
+	return node.Spec.MasterUserCredential.PasswordSecretRef
+}
 
-	logicDBName := node.Annotations[AnnotationKeyLogicDatabaseName]
+// resolveMasterUserPassword reads the master user password from the secret referred by node, or returns the plaintext one if no secret is referred.
+func (r *StorageNodeReconciler) resolveMasterUserPassword(ctx context.Context, node *v1alpha1.StorageNode, plaintext string) (string, error) {
+	ref := masterUserPasswordSecretRef(node)
+	if ref == nil {
+		return plaintext, nil
+	}
 
-	ssServer, err := r.getShardingsphereServer(ctx, node)
+	password, err := secret.GetValue(ctx, r.Client, node.Namespace, ref)
 	if err != nil {
-		return fmt.Errorf("getShardingsphereServer failed: %w", err)
+		return "", fmt.Errorf("resolve master user password failed: %w", err)
 	}
+	return string(password), nil
+}
 
-	defer ssServer.Close()
+// getCreateParams returns a copy of the storage provider parameters, with the master user in spec.masterUserCredential of node if there is one.
+func (r *StorageNodeReconciler) getCreateParams(ctx context.Context, node *v1alpha1.StorageNode, storageProvider *v1alpha1.StorageProvider) (map[string]string, error) {
+	params := make(map[string]string, len(storageProvider.Spec.Parameters))
+	for k, v := range storageProvider.Spec.Parameters {
+		params[k] = v
+	}
+	if c := node.Spec.MasterUserCredential; c != nil {
+		if c.Username != "" {
+			params["masterUsername"] = c.Username
+		}
+		if c.Password != "" {
+			params["masterUserPassword"] = c.Password
+		}
+	}
 
-	if err := ssServer.UnRegisterStorageUnit(logicDBName, getDSName(node)); err != nil {
-		return fmt.Errorf("unregister storage unit failed: %w", err)
+	password, err := r.resolveMasterUserPassword(ctx, node, params["masterUserPassword"])
+	if err != nil {
+		return nil, err
 	}
+	if password != "" {
+		params["masterUserPassword"] = password
+	}
+	return params, nil
+}
+
+// unregisterStorageUnit unregisters the storage node from all the compute nodes it has been registered to
+func (r *StorageNodeReconciler) unregisterStorageUnit(ctx context.Context, node *v1alpha1.StorageNode) error {
+	seedLegacyStorageUnit(node)
 
-	r.Recorder.Eventf(node, corev1.EventTypeNormal, "StorageUnitUnRegistered", "StorageUnit of node %s/%s is unregistered", node.GetNamespace(), node.GetName())
+	var (
+		units    []v1alpha1.StorageUnitStatus
+		firstErr error
+	)
+	for i := range node.Status.StorageUnits {
+		unit := node.Status.StorageUnits[i]
+		if !unit.Registered {
+			continue
+		}
+		if err := r.unregisterStorageUnitFrom(ctx, node, &unit); err != nil {
+			setStorageUnitMessage(&unit, err.Error())
+			units = append(units, unit)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
 
+	node.Status.StorageUnits = units
+	// the storage node is still registered if it fails to be unregistered from the compute node referred
 	node.Status.Registered = false
-	return nil
+	if desired := desiredStorageUnit(node); desired != nil {
+		for i := range units {
+			if isSameStorageUnit(&units[i], desired) {
+				node.Status.Registered = true
+			}
+		}
+	}
+	return firstErr
 }
 
-func (r *StorageNodeReconciler) validateComputeNodeAnnotations(node *v1alpha1.StorageNode) error {
-	requiredAnnos := []string{
-		AnnotationKeyLogicDatabaseName,
-		v1alpha1.AnnotationsInstanceDBName,
-		AnnotationKeyComputeNodeName,
+// unregisterStorageUnitFrom unregisters the storage node from the compute node of unit,
+// it is taken as unregistered if the compute node has been deleted.
+func (r *StorageNodeReconciler) unregisterStorageUnitFrom(ctx context.Context, node *v1alpha1.StorageNode, unit *v1alpha1.StorageUnitStatus) error {
+	ssServer, err := r.getShardingsphereServer(ctx, node, unit.ComputeNodeName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("getShardingsphereServer failed: %w", err)
 	}
 
-	for _, anno := range requiredAnnos {
-		if v, ok := node.Annotations[anno]; !ok || v == "" {
-			r.Recorder.Eventf(node, corev1.EventTypeWarning, "RegisterChecking", "Waiting to register storage unit for node %s/%s: annotation %s is required", node.GetNamespace(), node.GetName(), anno)
-			return fmt.Errorf("annotation %s is required", anno)
-		}
+	defer ssServer.Close()
+
+	if err := ssServer.UnRegisterStorageUnit(unit.LogicDatabase, unit.StorageUnitName); err != nil {
+		return fmt.Errorf("unregister storage unit failed: %w", err)
 	}
 
+	r.Recorder.Eventf(node, corev1.EventTypeNormal, "StorageUnitUnRegistered", "StorageUnit of node %s/%s is unregistered from %s", node.GetNamespace(), node.GetName(), unit.ComputeNodeName)
 	return nil
 }
 
-func (r *StorageNodeReconciler) getShardingsphereServer(ctx context.Context, node *v1alpha1.StorageNode) (shardingsphere.IServer, error) {
+func (r *StorageNodeReconciler) getShardingsphereServer(ctx context.Context, node *v1alpha1.StorageNode, computeNodeName string) (shardingsphere.IServer, error) {
+	return newShardingSphereServer(ctx, r.Client, r.Service, node.Namespace, computeNodeName)
+}
+
+// newShardingSphereServer connects to the ShardingSphere-Proxy of the compute node by its service and the first user of its server config.
+func newShardingSphereServer(ctx context.Context, c client.Client, svcClient service.Service, namespace, computeNodeName string) (shardingsphere.IServer, error) {
 	var (
-		driver, host, username, password string
-		port                             uint
+		protocol, host, username, password string
+		port                               uint
 	)
 
 	// get compute node
 	cn := &v1alpha1.ComputeNode{}
-	if err := r.Client.Get(ctx, types.NamespacedName{
-		Name:      node.Annotations[AnnotationKeyComputeNodeName],
-		Namespace: node.Namespace,
+	if err := c.Get(ctx, types.NamespacedName{
+		Name:      computeNodeName,
+		Namespace: namespace,
 	}, cn); err != nil {
 		return nil, fmt.Errorf("get compute node failed: %w", err)
 	}
 
 	serverConf := cn.Spec.Bootstrap.ServerConfig
 
-	driver, ok := serverConf.Props[ShardingSphereProtocolType]
-	if !ok || driver == "" {
-		driver = "mysql"
+	protocol, ok := serverConf.Props[ShardingSphereProtocolType]
+	if !ok || protocol == "" {
+		protocol = shardingsphere.ProtocolMySQL
 	}
-	driver = strings.ToLower(driver)
 
 	if len(serverConf.Authority.Users) == 0 {
 		return nil, fmt.Errorf("no user in compute node %s/%s", cn.Namespace, cn.Name)
 	}
 
-	username = strings.Split(serverConf.Authority.Users[0].User, "@")[0]
-	password = serverConf.Authority.Users[0].Password
+	user := serverConf.Authority.Users[0]
+	username = strings.Split(user.User, "@")[0]
+	password = user.Password
+	if user.PasswordSecretRef != nil {
+		v, err := secret.GetValue(ctx, c, namespace, user.PasswordSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("resolve password of user %s failed: %w", user.User, err)
+		}
+		password = string(v)
+	}
 
 	// get service of compute node
-	svc, err := r.Service.GetByNamespacedName(ctx, types.NamespacedName{
-		Name:      node.Annotations[AnnotationKeyComputeNodeName],
-		Namespace: node.Namespace,
+	svc, err := svcClient.GetByNamespacedName(ctx, types.NamespacedName{
+		Name:      computeNodeName,
+		Namespace: namespace,
 	})
 
 	if err != nil || svc == nil {
@@ -799,7 +1026,7 @@ func (r *StorageNodeReconciler) getShardingsphereServer(ctx context.Context, nod
 
 	port = uint(svc.Spec.Ports[0].Port)
 
-	ssServer, err := shardingsphere.NewServer(driver, host, port, username, password)
+	ssServer, err := shardingsphere.NewServer(protocol, host, port, username, password)
 	if err != nil {
 		return nil, fmt.Errorf("new shardingsphere server failed: %w", err)
 	}
@@ -907,5 +1134,24 @@ func (r *StorageNodeReconciler) updateCloudNativePGCluster(ctx context.Context,
 func (r *StorageNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.StorageNode{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.storageNodesOfSecret)).
 		Complete(r)
 }
+
+// storageNodesOfSecret enqueues the storage nodes referring to the secret by spec.masterUserCredential.passwordSecretRef.
+func (r *StorageNodeReconciler) storageNodesOfSecret(obj client.Object) []reconcile.Request {
+	nodes := &v1alpha1.StorageNodeList{}
+	if err := r.List(context.TODO(), nodes, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "list storage nodes failed", "secret", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	reqs := []reconcile.Request{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if ref := masterUserPasswordSecretRef(node); ref != nil && ref.Name == obj.GetName() {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: node.Namespace, Name: node.Name}})
+		}
+	}
+	return reqs
+}
@@ -19,6 +19,10 @@ package configmap
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/kubernetes/secret"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,7 +34,9 @@ import (
 // NewConfigMapClient returns a new ConfigMap client
 func NewConfigMapClient(c client.Client) ConfigMap {
 	return &configmapClient{
-		builder: builder{},
+		builder: builder{
+			Client: c,
+		},
 		getter: getter{
 			Client: c,
 		},
@@ -60,7 +66,7 @@ type Setter interface {
 
 // Builder build ConfigMap from given ComputeNode
 type Builder interface {
-	Build(context.Context, runtime.Object) *corev1.ConfigMap
+	Build(context.Context, runtime.Object) (*corev1.ConfigMap, error)
 }
 
 type configmapClient struct {
@@ -100,9 +106,39 @@ func (cs setter) Update(ctx context.Context, cm *corev1.ConfigMap) error {
 	return cs.Client.Update(ctx, cm)
 }
 
-type builder struct{}
+type builder struct {
+	client.Client
+}
+
+// Build returns a ConfigMap, the passwords of ComputeNode users referred by secrets are resolved into its server config
+func (b builder) Build(ctx context.Context, obj runtime.Object) (*corev1.ConfigMap, error) {
+	if cn, ok := obj.(*v1alpha1.ComputeNode); ok {
+		resolved, err := b.resolveUsers(ctx, cn)
+		if err != nil {
+			return nil, err
+		}
+		obj = resolved
+	}
+	return NewConfigMap(obj), nil
+}
+
+// resolveUsers returns a copy of ComputeNode with the passwords read from the secrets referred by its users,
+// the ComputeNode itself is returned if no secret is referred.
+func (b builder) resolveUsers(ctx context.Context, cn *v1alpha1.ComputeNode) (*v1alpha1.ComputeNode, error) {
+	resolved := cn
+	for i, u := range cn.Spec.Bootstrap.ServerConfig.Authority.Users {
+		if u.PasswordSecretRef == nil {
+			continue
+		}
+		if resolved == cn {
+			resolved = cn.DeepCopy()
+		}
 
-// Build returns a ConfigMap
-func (b builder) Build(ctx context.Context, obj runtime.Object) *corev1.ConfigMap {
-	return NewConfigMap(obj)
+		password, err := secret.GetValue(ctx, b.Client, cn.Namespace, u.PasswordSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("resolve password of user %s failed: %w", u.User, err)
+		}
+		resolved.Spec.Bootstrap.ServerConfig.Authority.Users[i].Password = string(password)
+	}
+	return resolved, nil
 }
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_BuildComputeNodeConfigMap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"password": []byte("secret-password"),
+		},
+	}).Build()
+
+	newComputeNode := func(users ...v1alpha1.ComputeNodeUser) *v1alpha1.ComputeNode {
+		return &v1alpha1.ComputeNode{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ComputeNode",
+				APIVersion: v1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.ComputeNodeSpec{
+				Bootstrap: v1alpha1.BootstrapConfig{
+					ServerConfig: v1alpha1.ServerConfig{
+						Authority: v1alpha1.ComputeNodeAuthority{
+							Users: users,
+						},
+					},
+				},
+			},
+		}
+	}
+	refTo := func(name, key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		}
+	}
+
+	cases := []struct {
+		cn        *v1alpha1.ComputeNode
+		passwords []string
+		err       bool
+		msg       string
+	}{
+		{
+			cn:        newComputeNode(v1alpha1.ComputeNodeUser{User: "root@%", Password: "root"}),
+			passwords: []string{"root"},
+			msg:       "plaintext password should be kept",
+		},
+		{
+			cn: newComputeNode(
+				v1alpha1.ComputeNodeUser{User: "root@%", Password: "root"},
+				v1alpha1.ComputeNodeUser{User: "test@%", PasswordSecretRef: refTo("test-secret", "password")},
+			),
+			passwords: []string{"root", "secret-password"},
+			msg:       "password referred by secret should be resolved",
+		},
+		{
+			cn:  newComputeNode(v1alpha1.ComputeNodeUser{User: "test@%", PasswordSecretRef: refTo("test-secret", "missing")}),
+			err: true,
+			msg: "missing key should fail",
+		},
+		{
+			cn:  newComputeNode(v1alpha1.ComputeNodeUser{User: "test@%", PasswordSecretRef: refTo("missing", "password")}),
+			err: true,
+			msg: "missing secret should fail",
+		},
+	}
+
+	b := NewConfigMapClient(c)
+	for _, tc := range cases {
+		origin := tc.cn.DeepCopy()
+		cm, err := b.Build(context.TODO(), tc.cn)
+		assert.Equal(t, origin, tc.cn, tc.msg)
+		if tc.err {
+			assert.Error(t, err, tc.msg)
+			continue
+		}
+		assert.NoError(t, err, tc.msg)
+		assert.NotContains(t, cm.Data[ConfigDataKeyForServer], "SecretRef", tc.msg)
+
+		conf := &v1alpha1.ServerConfig{}
+		assert.NoError(t, yaml.Unmarshal([]byte(cm.Data[ConfigDataKeyForServer]), conf), tc.msg)
+		passwords := []string{}
+		for _, u := range conf.Authority.Users {
+			passwords = append(passwords, u.Password)
+		}
+		assert.Equal(t, tc.passwords, passwords, tc.msg)
+	}
+}
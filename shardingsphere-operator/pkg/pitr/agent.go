@@ -0,0 +1,181 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pitr
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	apiBackup     = "/api/backup"
+	apiRestore    = "/api/restore"
+	apiShowDetail = "/api/show"
+	apiJob        = "/api/jobs/%s"
+
+	// DefaultInstance is the backup instance of a storage node which is the only one on its host
+	DefaultInstance = "ins-default-ss"
+
+	// the backup status and the job state reported by agent server
+	BackupStatusRunning   = "Running"
+	BackupStatusCompleted = "Completed"
+	BackupStatusFailed    = "Failed"
+	JobStateRunning       = "Running"
+	JobStateSucceeded     = "Succeeded"
+	JobStateFailed        = "Failed"
+
+	defaultTimeout = 30 * time.Second
+)
+
+// IAgent is the client of the PITR agent server on the host of storage nodes, see pitr/agent.
+type IAgent interface {
+	Backup(ctx context.Context, in *BackupIn) (*BackupOut, error)
+	DeleteBackup(ctx context.Context, in *DeleteBackupIn) error
+	ShowDetail(ctx context.Context, in *ShowDetailIn) (*BackupInfo, error)
+	Restore(ctx context.Context, in *RestoreIn) (*Job, error)
+	ShowJob(ctx context.Context, id string) (*Job, error)
+}
+
+// Config is the TLS and authorization config of agent servers
+type Config struct {
+	// CA is the PEM encoded CA certificate to verify agent servers, the certificates are not verified if it is empty
+	CA []byte
+	// Token is sent as bearer token if it is not empty
+	Token string
+}
+
+type agent struct {
+	addr   string
+	token  string
+	client *http.Client
+}
+
+var _ IAgent = (*agent)(nil)
+
+// NewAgent return the client of the agent server at addr, like `10.0.0.1:443`
+func NewAgent(addr string, cfg *Config) (IAgent, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	if len(cfg.CA) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.CA) {
+			return nil, fmt.Errorf("invalid CA certificate of agent server")
+		}
+		tlsConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	if !strings.HasPrefix(addr, "http") {
+		addr = fmt.Sprintf("https://%s", addr)
+	}
+	return &agent{
+		addr:  addr,
+		token: cfg.Token,
+		client: &http.Client{
+			Timeout:   defaultTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+func (a *agent) Backup(ctx context.Context, in *BackupIn) (*BackupOut, error) {
+	out := &BackupOut{}
+	if err := a.send(ctx, http.MethodPost, apiBackup, in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (a *agent) DeleteBackup(ctx context.Context, in *DeleteBackupIn) error {
+	return a.send(ctx, http.MethodDelete, apiBackup, in, nil)
+}
+
+func (a *agent) ShowDetail(ctx context.Context, in *ShowDetailIn) (*BackupInfo, error) {
+	out := &BackupInfo{}
+	if err := a.send(ctx, http.MethodPost, apiShowDetail, in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (a *agent) Restore(ctx context.Context, in *RestoreIn) (*Job, error) {
+	out := &Job{}
+	if err := a.send(ctx, http.MethodPost, apiRestore, in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (a *agent) ShowJob(ctx context.Context, id string) (*Job, error) {
+	out := &Job{}
+	if err := a.send(ctx, http.MethodGet, fmt.Sprintf(apiJob, id), nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// send the request and decode the data of response into out, the agent server responds {"code":0,"msg":"","data":{}}
+func (a *agent) send(ctx context.Context, method, api string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		bs, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("json marshal error: %w", err)
+		}
+		body = bytes.NewReader(bs)
+	}
+
+	url := a.addr + api
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("new request error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.token))
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request %s error: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	all, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response of %s error: %w", url, err)
+	}
+
+	r := &response{Data: out}
+	if err := json.Unmarshal(all, r); err != nil {
+		return fmt.Errorf("invalid response of %s, status code=%d: %w", url, resp.StatusCode, err)
+	}
+	if r.Code != 0 {
+		return fmt.Errorf("agent server error, code=%d, msg=%s", r.Code, r.Msg)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response status code of %s is not 200, code=%d", url, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pitr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Agent", func() {
+	var (
+		ctx    = context.Background()
+		server *httptest.Server
+		agent  IAgent
+		reqs   []*http.Request
+		bodies []map[string]any
+		resp   string
+	)
+
+	BeforeEach(func() {
+		reqs, bodies = nil, nil
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]any{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			reqs = append(reqs, r)
+			bodies = append(bodies, body)
+			_, _ = w.Write([]byte(resp))
+		}))
+
+		var err error
+		agent, err = NewAgent(server.Listener.Addr().String(), &Config{Token: "token"})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should start backup with bearer token", func() {
+		resp = `{"code":0,"msg":"","data":{"backup_id":"RNQHA1"}}`
+		out, err := agent.Backup(ctx, &BackupIn{DBPort: 5432, DBName: "postgres", DnBackupMode: "FULL", Instance: DefaultInstance})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(out.ID).To(Equal("RNQHA1"))
+
+		Expect(reqs[0].Method).To(Equal(http.MethodPost))
+		Expect(reqs[0].URL.Path).To(Equal(apiBackup))
+		Expect(reqs[0].Header.Get("Authorization")).To(Equal("Bearer token"))
+		Expect(bodies[0]).To(HaveKeyWithValue("instance", DefaultInstance))
+	})
+
+	It("should show the job of restore", func() {
+		resp = `{"code":0,"msg":"","data":{"job_id":"j1","state":"Succeeded","result":{"replay_lsn":"0/3000060"}}}`
+		job, err := agent.ShowJob(ctx, "j1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(job.Done()).To(BeTrue())
+		Expect(reqs[0].URL.Path).To(Equal("/api/jobs/j1"))
+
+		out := &RestoreOut{}
+		Expect(json.Unmarshal(job.Result, out)).To(Succeed())
+		Expect(out.ReplayLSN).To(Equal("0/3000060"))
+	})
+
+	It("should return the error of agent server", func() {
+		resp = `{"code":10001,"msg":"backup not found","data":null}`
+		_, err := agent.ShowDetail(ctx, &ShowDetailIn{DnBackupID: "RNQHA1"})
+		Expect(err).To(MatchError(ContainSubstring("backup not found")))
+	})
+
+	It("should reject the invalid CA", func() {
+		_, err := NewAgent("127.0.0.1:443", &Config{CA: []byte("invalid")})
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pitr
+
+import "encoding/json"
+
+type (
+	response struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+		Data any    `json:"data"`
+	}
+
+	BackupIn struct {
+		DBPort   uint16 `json:"db_port"`
+		DBName   string `json:"db_name"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+
+		DnBackupPath string `json:"dn_backup_path"`
+		DnThreadsNum uint8  `json:"dn_threads_num"`
+		DnBackupMode string `json:"dn_backup_mode"`
+		Instance     string `json:"instance"`
+	}
+
+	BackupOut struct {
+		ID    string `json:"backup_id"`
+		JobID string `json:"job_id"`
+	}
+
+	DeleteBackupIn struct {
+		DBPort   uint16 `json:"db_port"`
+		DBName   string `json:"db_name"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+
+		DnBackupPath string `json:"dn_backup_path"`
+		BackupID     string `json:"backup_id"`
+		Instance     string `json:"instance"`
+	}
+
+	ShowDetailIn struct {
+		DBPort       uint16 `json:"db_port"`
+		DBName       string `json:"db_name"`
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		DnBackupID   string `json:"dn_backup_id"`
+		DnBackupPath string `json:"dn_backup_path"`
+		Instance     string `json:"instance"`
+	}
+
+	BackupInfo struct {
+		ID           string `json:"dn_backup_id"`
+		Mode         string `json:"db_backup_mode"`
+		Instance     string `json:"instance"`
+		StartTime    string `json:"start_time"`
+		EndTime      string `json:"end_time"`
+		Status       string `json:"status"`
+		StartLsn     string `json:"start_lsn"`
+		StopLsn      string `json:"stop_lsn"`
+		RecoveryTime string `json:"recovery_time"`
+	}
+
+	RestoreIn struct {
+		DBPort       uint16 `json:"db_port"`
+		DBName       string `json:"db_name"`
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		Instance     string `json:"instance"`
+		DnBackupPath string `json:"dn_backup_path"`
+		DnBackupID   string `json:"dn_backup_id"`
+
+		RecoveryTarget *RecoveryTarget `json:"recovery_target,omitempty"`
+	}
+
+	// RecoveryTarget only one of Time, LSN, Xid, Name, CSN, Position and GTID can be set.
+	RecoveryTarget struct {
+		Time      string `json:"time,omitempty"`
+		LSN       string `json:"lsn,omitempty"`
+		Xid       string `json:"xid,omitempty"`
+		Name      string `json:"name,omitempty"`
+		CSN       string `json:"csn,omitempty"`
+		Position  string `json:"position,omitempty"`
+		GTID      string `json:"gtid,omitempty"`
+		Inclusive *bool  `json:"inclusive,omitempty"`
+	}
+
+	// RestoreOut is the result of restore job
+	RestoreOut struct {
+		InRecovery bool   `json:"in_recovery"`
+		ReplayLSN  string `json:"replay_lsn"`
+		ReplayTime string `json:"replay_time"`
+	}
+
+	// Job is a background operation of agent server, such as restore.
+	Job struct {
+		ID        string          `json:"job_id"`
+		Kind      string          `json:"kind"`
+		State     string          `json:"state"`
+		Result    json.RawMessage `json:"result,omitempty"`
+		Error     string          `json:"error,omitempty"`
+		StartTime string          `json:"start_time"`
+		EndTime   string          `json:"end_time,omitempty"`
+	}
+)
+
+// Done the job is succeeded or failed
+func (j *Job) Done() bool {
+	return j != nil && j.State != JobStateRunning
+}
@@ -74,7 +74,8 @@ func validCreateInstanceParams(node *v1alpha1.StorageNode, paramsptr *map[string
 	lp := len(params["masterUserPassword"])
 	if lp < 8 || lp > 41 {
 		return errors.New("master user password length should be greater than 8")
-	} else {
+	} else if c := node.Spec.MasterUserCredential; c == nil || c.PasswordSecretRef == nil {
+		// the password referred by secret is never written back in plaintext
 		node.Annotations[v1alpha1.AnnotationsMasterUserPassword] = params["masterUserPassword"]
 	}
 
@@ -24,6 +24,7 @@ import (
 	"github.com/database-mesh/golang-sdk/aws/client/rds"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -63,6 +64,27 @@ var _ = Describe("validCreateInstanceParams", func() {
 			Expect(validCreateInstanceParams(node, &params)).To(BeNil())
 			Expect(params["masterUsername"]).To(Equal("test_test-"))
 		})
+		It("should not write back the password referred by secret", func() {
+			node := &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-instance",
+					Namespace: "test-namespace",
+					Annotations: map[string]string{
+						v1alpha1.AnnotationsInstanceIdentifier: "test-instance",
+					},
+				},
+				Spec: v1alpha1.StorageNodeSpec{
+					MasterUserCredential: &v1alpha1.BasicCredential{
+						PasswordSecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"},
+							Key:                  "password",
+						},
+					},
+				},
+			}
+			Expect(validCreateInstanceParams(node, &params)).To(BeNil())
+			Expect(node.Annotations).NotTo(HaveKey(v1alpha1.AnnotationsMasterUserPassword))
+		})
 	})
 })
 
@@ -24,6 +24,7 @@ package mock_shardingsphere
 import (
 	reflect "reflect"
 
+	shardingsphere "github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/shardingsphere"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -78,6 +79,78 @@ func (mr *MockIServerMockRecorder) CreateDatabase(dbName interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDatabase", reflect.TypeOf((*MockIServer)(nil).CreateDatabase), dbName)
 }
 
+// DropDatabase mocks base method.
+func (m *MockIServer) DropDatabase(dbName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropDatabase", dbName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropDatabase indicates an expected call of DropDatabase.
+func (mr *MockIServerMockRecorder) DropDatabase(dbName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropDatabase", reflect.TypeOf((*MockIServer)(nil).DropDatabase), dbName)
+}
+
+// ExportMetaData mocks base method.
+func (m *MockIServer) ExportMetaData() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMetaData")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportMetaData indicates an expected call of ExportMetaData.
+func (mr *MockIServerMockRecorder) ExportMetaData() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMetaData", reflect.TypeOf((*MockIServer)(nil).ExportMetaData))
+}
+
+// ExportStorageNodes mocks base method.
+func (m *MockIServer) ExportStorageNodes() ([]*shardingsphere.StorageNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportStorageNodes")
+	ret0, _ := ret[0].([]*shardingsphere.StorageNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportStorageNodes indicates an expected call of ExportStorageNodes.
+func (mr *MockIServerMockRecorder) ExportStorageNodes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportStorageNodes", reflect.TypeOf((*MockIServer)(nil).ExportStorageNodes))
+}
+
+// ImportMetaData mocks base method.
+func (m *MockIServer) ImportMetaData(data string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportMetaData", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportMetaData indicates an expected call of ImportMetaData.
+func (mr *MockIServerMockRecorder) ImportMetaData(data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMetaData", reflect.TypeOf((*MockIServer)(nil).ImportMetaData), data)
+}
+
+// LockForBackup mocks base method.
+func (m *MockIServer) LockForBackup() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockForBackup")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockForBackup indicates an expected call of LockForBackup.
+func (mr *MockIServerMockRecorder) LockForBackup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockForBackup", reflect.TypeOf((*MockIServer)(nil).LockForBackup))
+}
+
 // RegisterStorageUnit mocks base method.
 func (m *MockIServer) RegisterStorageUnit(logicDBName, dsName, dsHost string, dsPort uint, dsDBName, dsUser, dsPassword string) error {
 	m.ctrl.T.Helper()
@@ -105,3 +178,17 @@ func (mr *MockIServerMockRecorder) UnRegisterStorageUnit(logicDBName, dsName int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRegisterStorageUnit", reflect.TypeOf((*MockIServer)(nil).UnRegisterStorageUnit), logicDBName, dsName)
 }
+
+// Unlock mocks base method.
+func (m *MockIServer) Unlock() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockIServerMockRecorder) Unlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockIServer)(nil).Unlock))
+}
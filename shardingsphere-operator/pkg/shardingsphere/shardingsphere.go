@@ -19,14 +19,31 @@ package shardingsphere
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+// The frontend protocols of ShardingSphere-Proxy, which is the prop proxy-frontend-database-protocol-type.
+const (
+	ProtocolMySQL      = "mysql"
+	ProtocolPostgreSQL = "postgresql"
+	ProtocolOpenGauss  = "opengauss"
 )
 
 const (
 	// DistSQLCreateDatabase create database if not exists.
 	DistSQLCreateDatabase = `CREATE DATABASE IF NOT EXISTS %s;`
+	// DistSQLCreateDatabaseWithoutIfNotExists create database, for the proxies of PostgreSQL protocol whose dialect
+	// has no IF NOT EXISTS.
+	DistSQLCreateDatabaseWithoutIfNotExists = `CREATE DATABASE %s;`
 	// DistSQLUseDatabase use database.
 	DistSQLUseDatabase = `USE %s;`
 	// DistSQLRegisterStorageUnit register database to shardingsphere by storage unit name and database info.
@@ -39,6 +56,20 @@ const (
 	DistSQLDropRule = `DROP %s RULE %s;`
 	// DistSQLDropTable drop table by table name.
 	DistSQLDropTable = `DROP TABLE %s;`
+	// DistSQLLockForBackup stop writing and lock the csn of openGauss, for backup.
+	DistSQLLockForBackup = `LOCK CLUSTER WITH LOCK_STRATEGY(TYPE(NAME="WRITE", PROPERTIES("lock_csn"=true)));`
+	// DistSQLLockForBackupWithoutCSN stop writing, for backup of the storage nodes which have no csn.
+	DistSQLLockForBackupWithoutCSN = `LOCK CLUSTER WITH LOCK_STRATEGY(TYPE(NAME="WRITE"));`
+	// DistSQLUnlock unlock cluster.
+	DistSQLUnlock = `UNLOCK CLUSTER;`
+	// DistSQLExportMetaData export the cluster metadata encoded in base64.
+	DistSQLExportMetaData = `EXPORT METADATA;`
+	// DistSQLExportStorageNodes export the storage nodes of all logic databases.
+	DistSQLExportStorageNodes = `EXPORT STORAGE NODES;`
+	// DistSQLImportMetaData import the cluster metadata encoded in base64.
+	DistSQLImportMetaData = `IMPORT METADATA '%s';`
+	// DistSQLDropDatabase drop logic database.
+	DistSQLDropDatabase = `DROP DATABASE %s;`
 )
 
 var ruleTypeMap = map[string]string{}
@@ -48,48 +79,150 @@ type Rule struct {
 	Name string
 }
 
+// MetaData is the cluster metadata exported by `EXPORT METADATA`, the csn is locked only if the storage nodes are openGauss.
+type MetaData struct {
+	MetaData struct {
+		Databases map[string]string `json:"databases"`
+		Props     string            `json:"props"`
+		Rules     string            `json:"rules"`
+	} `json:"meta_data"`
+	SnapshotInfo *struct {
+		Csn        string `json:"csn"`
+		CreateTime string `json:"create_time"`
+	} `json:"snapshot_info,omitempty"`
+}
+
+// StorageNode is a storage node exported by `EXPORT STORAGE NODES`, the storage nodes used by several logic databases are exported once.
+type StorageNode struct {
+	IP       string `json:"ip"`
+	Port     uint16 `json:"port,string"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Database string `json:"database"`
+}
+
 type server struct {
-	db *sql.DB
+	db       *sql.DB
+	protocol string
+
+	host     string
+	port     uint
+	user     string
+	password string
 }
 
 type IServer interface {
 	CreateDatabase(dbName string) error
 	RegisterStorageUnit(logicDBName, dsName, dsHost string, dsPort uint, dsDBName, dsUser, dsPassword string) error
 	UnRegisterStorageUnit(logicDBName, dsName string) error
+	LockForBackup() error
+	Unlock() error
+	ExportMetaData() (string, error)
+	ExportStorageNodes() ([]*StorageNode, error)
+	ImportMetaData(data string) error
+	DropDatabase(dbName string) error
 	Close() error
 }
 
 var _ IServer = (*server)(nil)
 
-func NewServer(driver, host string, port uint, user, password string) (IServer, error) {
-	if driver != "mysql" && driver != "postgres" {
-		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+// NewServer connects to the ShardingSphere-Proxy of the given frontend protocol, which is one of
+// MySQL, PostgreSQL and openGauss case-insensitively. The proxies of PostgreSQL protocol, including openGauss,
+// are connected by the PostgreSQL driver, the openGauss ones have to be authenticated by MD5 then.
+func NewServer(protocol, host string, port uint, user, password string) (IServer, error) {
+	protocol, err := normalizeProtocol(protocol)
+	if err != nil {
+		return nil, err
 	}
 
 	if host == "" || port == 0 || user == "" || password == "" {
-		return nil, fmt.Errorf("invalid database config, host=%s, port=%d, user=%s, password=%s", host, port, user, password)
+		return nil, fmt.Errorf("invalid database config, host=%s, port=%d, user=%s", host, port, user)
 	}
 
-	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%d)/", user, password, host, port)
+	s := &server{
+		protocol: protocol,
+		host:     host,
+		port:     port,
+		user:     user,
+		password: password,
+	}
+	db, err := s.open("")
+	if err != nil {
+		return nil, err
+	}
+	s.db = db
+	return s, nil
+}
+
+func normalizeProtocol(protocol string) (string, error) {
+	switch p := strings.ToLower(protocol); p {
+	case ProtocolMySQL, ProtocolOpenGauss:
+		return p, nil
+	case ProtocolPostgreSQL, "postgres":
+		return ProtocolPostgreSQL, nil
+	default:
+		return "", fmt.Errorf("unsupported database protocol: %s", protocol)
+	}
+}
+
+// open connects to the logic database, or to none of them if database is empty
+func (s *server) open(database string) (*sql.DB, error) {
+	driver, dataSourceName := "mysql", fmt.Sprintf("%s:%s@tcp(%s)/%s", s.user, s.password, net.JoinHostPort(s.host, strconv.Itoa(int(s.port))), database)
+	if s.protocol != ProtocolMySQL {
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(s.user, s.password),
+			Host:     net.JoinHostPort(s.host, strconv.Itoa(int(s.port))),
+			Path:     "/" + database,
+			RawQuery: "sslmode=disable",
+		}
+		driver, dataSourceName = "postgres", dsn.String()
+	}
 
 	db, err := sql.Open(driver, dataSourceName)
 	if err != nil {
-		return nil, fmt.Errorf("open database=%s error: %w", dataSourceName, err)
+		return nil, fmt.Errorf("open database=%s error: %w", database, err)
 	}
 
 	// check database connection
 	if err = db.Ping(); err != nil {
-		return nil, fmt.Errorf("ping database=%s error: %w", dataSourceName, err)
+		return nil, fmt.Errorf("ping database=%s error: %w", database, err)
 	}
 
-	return &server{db: db}, nil
+	return db, nil
+}
+
+// useDatabase returns the connection to run the DistSQL of the logic database, and the func to release it.
+// The proxies of MySQL protocol switch to the logic database by USE, while the others can only connect to it.
+func (s *server) useDatabase(logicDBName string) (*sql.DB, func(), error) {
+	if s.protocol == ProtocolMySQL {
+		if _, err := s.db.Exec(fmt.Sprintf(DistSQLUseDatabase, logicDBName)); err != nil {
+			return nil, nil, fmt.Errorf("use database error: %w", err)
+		}
+		return s.db, func() {}, nil
+	}
+
+	db, err := s.open(logicDBName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("use database error: %w", err)
+	}
+	return db, func() { db.Close() }, nil
 }
 
 func (s *server) Close() error {
 	return s.db.Close()
 }
 
+// CreateDatabase creates the logic database if it does not exist
 func (s *server) CreateDatabase(dbName string) error {
+	if s.protocol != ProtocolMySQL {
+		_, err := s.db.Exec(fmt.Sprintf(DistSQLCreateDatabaseWithoutIfNotExists, dbName))
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("create database error: %w", err)
+		}
+		return nil
+	}
+
 	distSQL := fmt.Sprintf(DistSQLCreateDatabase, dbName)
 
 	_, err := s.db.Exec(distSQL)
@@ -101,14 +234,15 @@ func (s *server) CreateDatabase(dbName string) error {
 }
 
 func (s *server) RegisterStorageUnit(logicDBName, dsName, dsHost string, dsPort uint, dsDBName, dsUser, dsPassword string) error {
-	_, err := s.db.Exec(fmt.Sprintf(DistSQLUseDatabase, logicDBName))
+	db, release, err := s.useDatabase(logicDBName)
 	if err != nil {
-		return fmt.Errorf("use database error: %w", err)
+		return err
 	}
+	defer release()
 
 	distSQL := fmt.Sprintf(DistSQLRegisterStorageUnit, dsName, dsHost, dsPort, dsDBName, dsUser, dsPassword)
 
-	_, err = s.db.Exec(distSQL)
+	_, err = db.Exec(distSQL)
 	if err != nil {
 		return fmt.Errorf("register database error: %w", err)
 	}
@@ -117,11 +251,11 @@ func (s *server) RegisterStorageUnit(logicDBName, dsName, dsHost string, dsPort
 }
 
 // getRulesUsed returns all rules used by storage unit name.
-func (s *server) getRulesUsed(dsName string) (rules []*Rule, err error) {
+func (s *server) getRulesUsed(db *sql.DB, dsName string) (rules []*Rule, err error) {
 	rules = make([]*Rule, 0)
 	distSQL := fmt.Sprintf(DistSQLShowRulesUsed, dsName)
 
-	rows, err := s.db.Query(distSQL)
+	rows, err := db.Query(distSQL)
 	if err != nil {
 		return nil, fmt.Errorf("get rules used error: %w", err)
 	}
@@ -142,26 +276,27 @@ func (s *server) getRulesUsed(dsName string) (rules []*Rule, err error) {
 }
 
 func (s *server) UnRegisterStorageUnit(logicDBName, dsName string) error {
-	_, err := s.db.Exec(fmt.Sprintf(DistSQLUseDatabase, logicDBName))
+	db, release, err := s.useDatabase(logicDBName)
 	if err != nil {
-		return fmt.Errorf("use database error: %w", err)
+		return err
 	}
+	defer release()
 
-	rules, err := s.getRulesUsed(dsName)
+	rules, err := s.getRulesUsed(db, dsName)
 	if err != nil {
 		return fmt.Errorf("get rules used error: %w", err)
 	}
 
 	// clean all rules used by storage unit
 	for _, rule := range rules {
-		if err := s.dropRule(rule.Type, rule.Name); err != nil {
+		if err := s.dropRule(db, rule.Type, rule.Name); err != nil {
 			return fmt.Errorf("drop rule error: %w", err)
 		}
 	}
 
 	distSQL := fmt.Sprintf(DistSQLUnRegisterStorageUnit, dsName)
 
-	_, err = s.db.Exec(distSQL)
+	_, err = db.Exec(distSQL)
 	if err != nil {
 		return fmt.Errorf("unregister database error: %w", err)
 	}
@@ -169,17 +304,122 @@ func (s *server) UnRegisterStorageUnit(logicDBName, dsName string) error {
 	return nil
 }
 
-func (s *server) dropRule(ruleType, ruleName string) error {
+func (s *server) dropRule(db *sql.DB, ruleType, ruleName string) error {
 	// convert rule type
 	ruleType = ruleTypeMap[ruleType]
 	distSQL := fmt.Sprintf(DistSQLDropRule, ruleType, ruleName)
-	_, err := s.db.Exec(distSQL)
+	_, err := db.Exec(distSQL)
 	if err != nil {
 		return fmt.Errorf("drop rule fail, err: %s", err)
 	}
 	return nil
 }
 
+// LockForBackup stop writing, the csn is locked too if the proxy is of openGauss, the storage nodes of the others have no csn.
+func (s *server) LockForBackup() error {
+	distSQL := DistSQLLockForBackupWithoutCSN
+	if s.protocol == ProtocolOpenGauss {
+		distSQL = DistSQLLockForBackup
+	}
+	if _, err := s.db.Exec(distSQL); err != nil {
+		return fmt.Errorf("lock for backup error: %w", err)
+	}
+	return nil
+}
+
+func (s *server) Unlock() error {
+	if _, err := s.db.Exec(DistSQLUnlock); err != nil {
+		return fmt.Errorf("unlock error: %w", err)
+	}
+	return nil
+}
+
+// exportOne returns the data column of the export result, which has the columns id, create_time and data.
+func (s *server) exportOne(distSQL string) (string, error) {
+	rows, err := s.db.Query(distSQL)
+	if err != nil {
+		return "", fmt.Errorf("export error: %w", err)
+	}
+	defer rows.Close()
+
+	var id, createTime, data string
+	if rows.Next() {
+		if err := rows.Scan(&id, &createTime, &data); err != nil {
+			return "", fmt.Errorf("scan export result error: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("rows error: %w", err)
+	}
+	if data == "" {
+		return "", fmt.Errorf("export result is empty")
+	}
+	return data, nil
+}
+
+// ExportMetaData returns the cluster metadata encoded in base64, which is imported as is when restored.
+func (s *server) ExportMetaData() (string, error) {
+	return s.exportOne(DistSQLExportMetaData)
+}
+
+// ExportStorageNodes returns the storage nodes of all logic databases without duplicates.
+func (s *server) ExportStorageNodes() ([]*StorageNode, error) {
+	data, err := s.exportOne(DistSQLExportStorageNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	out := struct {
+		StorageNodes map[string][]*StorageNode `json:"storage_nodes"`
+	}{}
+	if err := json.Unmarshal([]byte(data), &out); err != nil {
+		return nil, fmt.Errorf("unmarshal storage nodes error: %w", err)
+	}
+
+	var (
+		nodes []*StorageNode
+		seen  = map[string]struct{}{}
+	)
+	for _, sns := range out.StorageNodes {
+		for _, sn := range sns {
+			key := sn.IP + ":" + strconv.Itoa(int(sn.Port))
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			nodes = append(nodes, sn)
+		}
+	}
+	return nodes, nil
+}
+
+func (s *server) ImportMetaData(data string) error {
+	if _, err := s.db.Exec(fmt.Sprintf(DistSQLImportMetaData, data)); err != nil {
+		return fmt.Errorf("import metadata error: %w", err)
+	}
+	return nil
+}
+
+func (s *server) DropDatabase(dbName string) error {
+	if _, err := s.db.Exec(fmt.Sprintf(DistSQLDropDatabase, dbName)); err != nil {
+		return fmt.Errorf("drop database error: %w", err)
+	}
+	return nil
+}
+
+// DecodeMetaData decodes the cluster metadata exported by ExportMetaData.
+func DecodeMetaData(data string) (*MetaData, error) {
+	bs, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode metadata error: %w", err)
+	}
+	out := &MetaData{}
+	if err := json.Unmarshal(bs, out); err != nil {
+		return nil, fmt.Errorf("unmarshal metadata error: %w", err)
+	}
+	return out, nil
+}
+
 func init() {
 	// init rule type map
 	// implement more rule type if needed
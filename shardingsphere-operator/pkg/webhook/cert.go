@@ -0,0 +1,390 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// CACertName is the key of the CA certificate in the webhook certificate Secret
+	CACertName = "ca.crt"
+
+	certValidity = 10 * 365 * 24 * time.Hour
+	// the certificate is renewed when it expires within certRenewBefore
+	certRenewBefore = 30 * 24 * time.Hour
+
+	conversionPath = "/convert"
+	servicePort    = 443
+)
+
+// CertManager manages the serving certificate of the webhook server.
+// The certificate is signed by a self-signed CA and kept in a Secret, so all the replicas
+// of the operator serve with the same certificate. The CA is injected into the caBundle of
+// the named webhook configurations, and set into the conversion of the named CustomResourceDefinitions.
+type CertManager struct {
+	Client client.Client
+
+	// CertDir is the directory of the webhook server to write tls.crt and tls.key into
+	CertDir string
+	// SecretName is the name of the Secret holding the certificate in ServiceNamespace
+	SecretName string
+	// ServiceName and ServiceNamespace locate the Service in front of the webhook server
+	ServiceName      string
+	ServiceNamespace string
+
+	MutatingWebhookConfigurationName   string
+	ValidatingWebhookConfigurationName string
+	// ConversionCRDNames are the CustomResourceDefinitions served in multiple versions,
+	// which are converted by the webhook server
+	ConversionCRDNames []string
+
+	caBundle []byte
+}
+
+// Ensure makes sure the certificate Secret is valid for the webhook Service, writes the
+// certificate into CertDir, replacing any left over one, and injects the CA into the webhook
+// configurations.
+func (m *CertManager) Ensure(ctx context.Context) error {
+	secret, err := m.ensureSecret(ctx)
+	if err != nil {
+		return fmt.Errorf("ensure webhook certificate secret: %w", err)
+	}
+
+	if err := os.MkdirAll(m.CertDir, 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(m.CertDir, corev1.TLSCertKey), secret.Data[corev1.TLSCertKey], 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(m.CertDir, corev1.TLSPrivateKeyKey), secret.Data[corev1.TLSPrivateKeyKey], 0600); err != nil {
+		return err
+	}
+
+	m.caBundle = secret.Data[CACertName]
+	return m.injectCABundle(ctx)
+}
+
+func (m *CertManager) ensureSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := m.Client.Get(ctx, types.NamespacedName{Namespace: m.ServiceNamespace, Name: m.SecretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	found := err == nil
+	if found && m.isValid(secret) {
+		return secret, nil
+	}
+
+	data, err := m.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.SecretName,
+				Namespace: m.ServiceNamespace,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: data,
+		}
+		if err := m.Client.Create(ctx, secret); err != nil {
+			// another replica has just created the certificate
+			if apierrors.IsAlreadyExists(err) {
+				return m.getSecret(ctx)
+			}
+			return nil, err
+		}
+		return secret, nil
+	}
+
+	secret.Data = data
+	if err := m.Client.Update(ctx, secret); err != nil {
+		// another replica has just renewed the certificate
+		if apierrors.IsConflict(err) {
+			return m.getSecret(ctx)
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (m *CertManager) getSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := m.Client.Get(ctx, types.NamespacedName{Namespace: m.ServiceNamespace, Name: m.SecretName}, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// isValid checks the certificate in the Secret is issued by its CA for the webhook Service,
+// matches its key and is not expiring
+func (m *CertManager) isValid(secret *corev1.Secret) bool {
+	pair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return false
+	}
+	if time.Now().Add(certRenewBefore).After(cert.NotAfter) {
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(secret.Data[CACertName]) {
+		return false
+	}
+	for _, name := range m.dnsNames() {
+		opts := x509.VerifyOptions{
+			DNSName:   name,
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}
+		if _, err := cert.Verify(opts); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *CertManager) dnsNames() []string {
+	return []string{
+		m.ServiceName,
+		fmt.Sprintf("%s.%s", m.ServiceName, m.ServiceNamespace),
+		fmt.Sprintf("%s.%s.svc", m.ServiceName, m.ServiceNamespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", m.ServiceName, m.ServiceNamespace),
+	}
+}
+
+// generate returns a self-signed CA and a serving certificate signed by it
+func (m *CertManager) generate() (map[string][]byte, error) {
+	now := time.Now()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("%s-ca", m.ServiceName)},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano() + 1),
+		Subject:      pkix.Name{CommonName: fmt.Sprintf("%s.%s.svc", m.ServiceName, m.ServiceNamespace)},
+		DNSNames:     m.dnsNames(),
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		CACertName:              pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// SetupWithManager watches the webhook configurations and the CustomResourceDefinitions, and restores
+// the caBundle and the conversion whenever they are changed, e.g. wiped by a helm upgrade. It must be called after Ensure.
+func (m *CertManager) SetupWithManager(mgr ctrl.Manager) error {
+	named := func(names ...string) predicate.Predicate {
+		return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			for _, name := range names {
+				if name != "" && obj.GetName() == name {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("webhook-cabundle").
+		For(&admissionregistrationv1.MutatingWebhookConfiguration{}, builder.WithPredicates(named(m.MutatingWebhookConfigurationName))).
+		Watches(&source.Kind{Type: &admissionregistrationv1.ValidatingWebhookConfiguration{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(named(m.ValidatingWebhookConfigurationName))).
+		Watches(&source.Kind{Type: &apiextensionsv1.CustomResourceDefinition{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(named(m.ConversionCRDNames...))).
+		Complete(reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, m.injectCABundle(ctx)
+		}))
+}
+
+// injectCABundle sets the CA into every webhook of the named configurations whose caBundle differs,
+// a missing configuration is skipped since it may not be installed, e.g. by an older chart.
+func (m *CertManager) injectCABundle(ctx context.Context) error {
+	ca := m.caBundle
+	if len(ca) == 0 {
+		return errors.New("empty CA certificate")
+	}
+
+	if m.MutatingWebhookConfigurationName != "" {
+		mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		err := m.Client.Get(ctx, types.NamespacedName{Name: m.MutatingWebhookConfigurationName}, mwc)
+		switch {
+		case apierrors.IsNotFound(err):
+			log.Info("skip injecting caBundle, MutatingWebhookConfiguration not found", "name", m.MutatingWebhookConfigurationName)
+		case err != nil:
+			return err
+		default:
+			changed := false
+			for i := range mwc.Webhooks {
+				if !bytes.Equal(mwc.Webhooks[i].ClientConfig.CABundle, ca) {
+					mwc.Webhooks[i].ClientConfig.CABundle = ca
+					changed = true
+				}
+			}
+			if changed {
+				if err := m.Client.Update(ctx, mwc); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if m.ValidatingWebhookConfigurationName != "" {
+		vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		err := m.Client.Get(ctx, types.NamespacedName{Name: m.ValidatingWebhookConfigurationName}, vwc)
+		switch {
+		case apierrors.IsNotFound(err):
+			log.Info("skip injecting caBundle, ValidatingWebhookConfiguration not found", "name", m.ValidatingWebhookConfigurationName)
+		case err != nil:
+			return err
+		default:
+			changed := false
+			for i := range vwc.Webhooks {
+				if !bytes.Equal(vwc.Webhooks[i].ClientConfig.CABundle, ca) {
+					vwc.Webhooks[i].ClientConfig.CABundle = ca
+					changed = true
+				}
+			}
+			if changed {
+				if err := m.Client.Update(ctx, vwc); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, name := range m.ConversionCRDNames {
+		if err := m.injectConversion(ctx, name, ca); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// injectConversion sets the conversion of the named CustomResourceDefinition to the webhook server,
+// since the Service of the webhook server is only known when the operator is deployed.
+func (m *CertManager) injectConversion(ctx context.Context, name string, ca []byte) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	err := m.Client.Get(ctx, types.NamespacedName{Name: name}, crd)
+	if apierrors.IsNotFound(err) {
+		log.Info("skip injecting conversion, CustomResourceDefinition not found", "name", name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	path, port := conversionPath, int32(servicePort)
+	conversion := &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: m.ServiceNamespace,
+					Name:      m.ServiceName,
+					Path:      &path,
+					Port:      &port,
+				},
+				CABundle: ca,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	if equality.Semantic.DeepEqual(crd.Spec.Conversion, conversion) {
+		return nil
+	}
+	crd.Spec.Conversion = conversion
+	return m.Client.Update(ctx, crd)
+}
@@ -0,0 +1,247 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// issueTestCert returns a CA and a serving certificate signed by it for the given names
+func issueTestCert(names []string, notAfter time.Time) map[string][]byte {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(Succeed())
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	Expect(err).To(Succeed())
+	ca, err := x509.ParseCertificate(caDER)
+	Expect(err).To(Succeed())
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(Succeed())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: names[0]},
+		DNSNames:     names,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	Expect(err).To(Succeed())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).To(Succeed())
+
+	return map[string][]byte{
+		CACertName:              pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+var _ = Describe("CertManager", func() {
+	var (
+		ctx = context.Background()
+		c   client.Client
+		m   *CertManager
+	)
+
+	getSecret := func() *corev1.Secret {
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: m.ServiceNamespace, Name: m.SecretName}, secret)).To(Succeed())
+		return secret
+	}
+
+	createSecret := func(data map[string][]byte) {
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: m.ServiceNamespace, Name: m.SecretName},
+			Type:       corev1.SecretTypeTLS,
+			Data:       data,
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-mutating"},
+				Webhooks: []admissionregistrationv1.MutatingWebhook{
+					{Name: "mcomputenode.shardingsphere.apache.org"},
+					{Name: "mstoragenode.shardingsphere.apache.org"},
+				},
+			},
+			&admissionregistrationv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-validating"},
+				Webhooks: []admissionregistrationv1.ValidatingWebhook{
+					{Name: "vcomputenode.shardingsphere.apache.org"},
+				},
+			},
+			&apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "computenodes.shardingsphere.apache.org"},
+			},
+		).Build()
+		m = &CertManager{
+			Client:                             c,
+			CertDir:                            GinkgoT().TempDir(),
+			SecretName:                         "test-webhook-cert",
+			ServiceName:                        "test-webhook",
+			ServiceNamespace:                   "default",
+			MutatingWebhookConfigurationName:   "test-mutating",
+			ValidatingWebhookConfigurationName: "test-validating",
+			ConversionCRDNames:                 []string{"computenodes.shardingsphere.apache.org"},
+		}
+	})
+
+	It("should generate the certificate and inject the CA", func() {
+		Expect(m.Ensure(ctx)).To(Succeed())
+
+		secret := getSecret()
+		Expect(m.isValid(secret)).To(BeTrue())
+
+		crt, err := os.ReadFile(filepath.Join(m.CertDir, corev1.TLSCertKey))
+		Expect(err).To(Succeed())
+		Expect(crt).To(Equal(secret.Data[corev1.TLSCertKey]))
+		key, err := os.ReadFile(filepath.Join(m.CertDir, corev1.TLSPrivateKeyKey))
+		Expect(err).To(Succeed())
+		Expect(key).To(Equal(secret.Data[corev1.TLSPrivateKeyKey]))
+
+		mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "test-mutating"}, mwc)).To(Succeed())
+		for _, wh := range mwc.Webhooks {
+			Expect(wh.ClientConfig.CABundle).To(Equal(secret.Data[CACertName]))
+		}
+		vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "test-validating"}, vwc)).To(Succeed())
+		Expect(vwc.Webhooks[0].ClientConfig.CABundle).To(Equal(secret.Data[CACertName]))
+	})
+
+	It("should set the conversion of the CustomResourceDefinitions", func() {
+		Expect(m.Ensure(ctx)).To(Succeed())
+
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "computenodes.shardingsphere.apache.org"}, crd)).To(Succeed())
+		Expect(crd.Spec.Conversion.Strategy).To(Equal(apiextensionsv1.WebhookConverter))
+		Expect(crd.Spec.Conversion.Webhook.ClientConfig.CABundle).To(Equal(getSecret().Data[CACertName]))
+		svc := crd.Spec.Conversion.Webhook.ClientConfig.Service
+		Expect(svc.Namespace).To(Equal("default"))
+		Expect(svc.Name).To(Equal("test-webhook"))
+		Expect(*svc.Path).To(Equal("/convert"))
+	})
+
+	It("should reuse a valid certificate", func() {
+		data := issueTestCert(m.dnsNames(), time.Now().Add(365*24*time.Hour))
+		createSecret(data)
+
+		Expect(m.Ensure(ctx)).To(Succeed())
+		Expect(getSecret().Data).To(Equal(data))
+
+		crt, err := os.ReadFile(filepath.Join(m.CertDir, corev1.TLSCertKey))
+		Expect(err).To(Succeed())
+		Expect(crt).To(Equal(data[corev1.TLSCertKey]))
+	})
+
+	It("should regenerate the certificate for another service", func() {
+		data := issueTestCert([]string{"other-webhook", "other-webhook.default.svc"}, time.Now().Add(365*24*time.Hour))
+		createSecret(data)
+
+		Expect(m.Ensure(ctx)).To(Succeed())
+		secret := getSecret()
+		Expect(secret.Data[corev1.TLSCertKey]).NotTo(Equal(data[corev1.TLSCertKey]))
+		Expect(m.isValid(secret)).To(BeTrue())
+	})
+
+	It("should regenerate the expiring certificate", func() {
+		data := issueTestCert(m.dnsNames(), time.Now().Add(24*time.Hour))
+		createSecret(data)
+
+		Expect(m.Ensure(ctx)).To(Succeed())
+		secret := getSecret()
+		Expect(secret.Data[corev1.TLSCertKey]).NotTo(Equal(data[corev1.TLSCertKey]))
+		Expect(m.isValid(secret)).To(BeTrue())
+	})
+
+	It("should regenerate the certificate not issued by its CA", func() {
+		data := issueTestCert(m.dnsNames(), time.Now().Add(365*24*time.Hour))
+		data[CACertName] = issueTestCert(m.dnsNames(), time.Now().Add(365*24*time.Hour))[CACertName]
+		createSecret(data)
+		Expect(m.isValid(getSecret())).To(BeFalse())
+
+		// a certificate left over in CertDir is replaced as well
+		Expect(os.WriteFile(filepath.Join(m.CertDir, corev1.TLSCertKey), data[corev1.TLSCertKey], 0600)).To(Succeed())
+
+		Expect(m.Ensure(ctx)).To(Succeed())
+		secret := getSecret()
+		Expect(m.isValid(secret)).To(BeTrue())
+		crt, err := os.ReadFile(filepath.Join(m.CertDir, corev1.TLSCertKey))
+		Expect(err).To(Succeed())
+		Expect(crt).To(Equal(secret.Data[corev1.TLSCertKey]))
+	})
+
+	It("should skip the missing webhook configurations", func() {
+		m.MutatingWebhookConfigurationName = "not-exist"
+		m.ValidatingWebhookConfigurationName = "not-exist"
+		m.ConversionCRDNames = []string{"not-exist"}
+		Expect(m.Ensure(ctx)).To(Succeed())
+	})
+
+	It("should restore the wiped CA", func() {
+		Expect(m.Ensure(ctx)).To(Succeed())
+
+		mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "test-mutating"}, mwc)).To(Succeed())
+		mwc.Webhooks[0].ClientConfig.CABundle = nil
+		Expect(c.Update(ctx, mwc)).To(Succeed())
+
+		Expect(m.injectCABundle(ctx)).To(Succeed())
+		Expect(c.Get(ctx, types.NamespacedName{Name: "test-mutating"}, mwc)).To(Succeed())
+		Expect(mwc.Webhooks[0].ClientConfig.CABundle).To(Equal(getSecret().Data[CACertName]))
+	})
+})
@@ -0,0 +1,245 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ChaosWebhook defaults and validates Chaos
+type ChaosWebhook struct{}
+
+var (
+	_ admission.CustomDefaulter = &ChaosWebhook{}
+	_ admission.CustomValidator = &ChaosWebhook{}
+)
+
+// Default sets the direction of network chaos to "to" if it is empty
+func (w *ChaosWebhook) Default(_ context.Context, obj runtime.Object) error {
+	chaos, ok := obj.(*v1alpha1.Chaos)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Chaos but got a %T", obj))
+	}
+
+	if nc := chaos.Spec.NetworkChaos; nc != nil && nc.Direction == "" {
+		nc.Direction = v1alpha1.To
+	}
+	return nil
+}
+
+// ValidateCreate validates the created Chaos
+func (w *ChaosWebhook) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	chaos, ok := obj.(*v1alpha1.Chaos)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Chaos but got a %T", obj))
+	}
+	return invalidOrNil("Chaos", chaos.Name, validateChaosSpec(&chaos.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate validates the updated Chaos
+func (w *ChaosWebhook) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) error {
+	return w.ValidateCreate(ctx, newObj)
+}
+
+// ValidateDelete allows any deletion
+func (w *ChaosWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func validateChaosSpec(spec *v1alpha1.ChaosSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	switch {
+	case spec.PodChaos != nil && spec.NetworkChaos != nil:
+		errs = append(errs, field.Forbidden(path, "podChaos and networkChaos are mutually exclusive"))
+	case spec.PodChaos != nil:
+		errs = append(errs, validatePodChaos(spec.PodChaos, path.Child("podChaos"))...)
+	case spec.NetworkChaos != nil:
+		errs = append(errs, validateNetworkChaos(spec.NetworkChaos, path.Child("networkChaos"))...)
+	default:
+		errs = append(errs, field.Required(path, "one of podChaos and networkChaos is required"))
+	}
+
+	if spec.PressureCfg != nil {
+		errs = append(errs, validatePressureCfg(spec.PressureCfg, path.Child("pressureCfg"))...)
+	}
+
+	return errs
+}
+
+func validatePodChaos(pc *v1alpha1.PodChaosSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	params := path.Child("params")
+	switch pc.Action {
+	case v1alpha1.PodKill:
+		if pc.Params.PodKill == nil {
+			errs = append(errs, field.Required(params.Child("podKill"), "params are required by action PodKill"))
+		} else if pc.Params.PodKill.GracePeriod < 0 {
+			errs = append(errs, field.Invalid(params.Child("podKill", "gracePeriod"), pc.Params.PodKill.GracePeriod, "must be greater than or equal to 0"))
+		}
+	case v1alpha1.PodFailure:
+		if pc.Params.PodFailure == nil {
+			errs = append(errs, field.Required(params.Child("podFailure"), "params are required by action PodFailure"))
+		} else if d := pc.Params.PodFailure.Duration; d != nil {
+			errs = append(errs, validateDuration(*d, params.Child("podFailure", "duration"))...)
+		}
+	case v1alpha1.ContainerKill:
+		if pc.Params.ContainerKill == nil || len(pc.Params.ContainerKill.ContainerNames) == 0 {
+			errs = append(errs, field.Required(params.Child("containerKill", "containerNames"), "containerNames are required by action ContainerKill"))
+		}
+	case v1alpha1.CPUStress:
+		if pc.Params.CPUStress == nil {
+			errs = append(errs, field.Required(params.Child("cpuStress"), "params are required by action CPUStress"))
+		} else {
+			errs = append(errs, validateDuration(pc.Params.CPUStress.Duration, params.Child("cpuStress", "duration"))...)
+			if l := pc.Params.CPUStress.Load; l < 0 || l > 100 {
+				errs = append(errs, field.Invalid(params.Child("cpuStress", "load"), l, "must be between 0 and 100"))
+			}
+		}
+	case v1alpha1.MemoryStress:
+		if pc.Params.MemoryStress == nil {
+			errs = append(errs, field.Required(params.Child("memoryStress"), "params are required by action MemoryStress"))
+		} else {
+			errs = append(errs, validateDuration(pc.Params.MemoryStress.Duration, params.Child("memoryStress", "duration"))...)
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("action"), pc.Action, []string{
+			string(v1alpha1.PodFailure),
+			string(v1alpha1.ContainerKill),
+			string(v1alpha1.PodKill),
+			string(v1alpha1.CPUStress),
+			string(v1alpha1.MemoryStress),
+		}))
+	}
+
+	return errs
+}
+
+func validateNetworkChaos(nc *v1alpha1.NetworkChaosSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if nc.Target == nil {
+		errs = append(errs, field.Required(path.Child("target"), "target is required"))
+	}
+	if nc.Duration != nil {
+		errs = append(errs, validateDuration(*nc.Duration, path.Child("duration"))...)
+	}
+
+	switch nc.Direction {
+	case "", v1alpha1.To, v1alpha1.From, v1alpha1.Both:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("direction"), nc.Direction,
+			[]string{string(v1alpha1.To), string(v1alpha1.From), string(v1alpha1.Both)}))
+	}
+
+	params := path.Child("params")
+	switch nc.Action {
+	case v1alpha1.Delay:
+		if nc.Params.Delay == nil {
+			errs = append(errs, field.Required(params.Child("delay"), "params are required by action Delay"))
+		} else {
+			errs = append(errs, validateDuration(nc.Params.Delay.Latency, params.Child("delay", "latency"))...)
+			if nc.Params.Delay.Jitter != "" {
+				errs = append(errs, validateDuration(nc.Params.Delay.Jitter, params.Child("delay", "jitter"))...)
+			}
+		}
+	case v1alpha1.Loss:
+		if nc.Params.Loss == nil {
+			errs = append(errs, field.Required(params.Child("loss"), "params are required by action Loss"))
+		} else {
+			errs = append(errs, validatePercent(nc.Params.Loss.Loss, params.Child("loss", "loss"))...)
+		}
+	case v1alpha1.Duplication:
+		if nc.Params.Duplication == nil {
+			errs = append(errs, field.Required(params.Child("duplicate"), "params are required by action Duplication"))
+		} else {
+			errs = append(errs, validatePercent(nc.Params.Duplication.Duplication, params.Child("duplicate", "duplicate"))...)
+		}
+	case v1alpha1.Corruption:
+		if nc.Params.Corruption == nil {
+			errs = append(errs, field.Required(params.Child("corrupt"), "params are required by action Corruption"))
+		} else {
+			errs = append(errs, validatePercent(nc.Params.Corruption.Corruption, params.Child("corrupt", "corrupt"))...)
+		}
+	case v1alpha1.Partition, v1alpha1.Bandwidth:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("action"), nc.Action, []string{
+			string(v1alpha1.Delay),
+			string(v1alpha1.Loss),
+			string(v1alpha1.Duplication),
+			string(v1alpha1.Corruption),
+			string(v1alpha1.Partition),
+			string(v1alpha1.Bandwidth),
+		}))
+	}
+
+	return errs
+}
+
+func validatePressureCfg(cfg *v1alpha1.PressureCfg, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if cfg.SsHost == "" {
+		errs = append(errs, field.Required(path.Child("ssHost"), "ssHost is required"))
+	}
+	if cfg.Duration.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("duration"), cfg.Duration.String(), "must be greater than 0"))
+	}
+	if cfg.ReqTime.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("reqTime"), cfg.ReqTime.String(), "must be greater than or equal to 0"))
+	}
+	if cfg.ConcurrentNum < 1 {
+		errs = append(errs, field.Invalid(path.Child("concurrentNum"), cfg.ConcurrentNum, "must be greater than 0"))
+	}
+	if cfg.ReqNum < 1 {
+		errs = append(errs, field.Invalid(path.Child("reqNum"), cfg.ReqNum, "must be greater than 0"))
+	}
+
+	return errs
+}
+
+func validateDuration(d string, path *field.Path) field.ErrorList {
+	if d == "" {
+		return field.ErrorList{field.Required(path, "duration is required")}
+	}
+	if _, err := time.ParseDuration(d); err != nil {
+		return field.ErrorList{field.Invalid(path, d, err.Error())}
+	}
+	return nil
+}
+
+func validatePercent(p string, path *field.Path) field.ErrorList {
+	if p == "" {
+		return field.ErrorList{field.Required(path, "percent is required")}
+	}
+	if v, err := strconv.ParseFloat(p, 64); err != nil || v < 0 || v > 100 {
+		return field.ErrorList{field.Invalid(path, p, "must be a number between 0 and 100")}
+	}
+	return nil
+}
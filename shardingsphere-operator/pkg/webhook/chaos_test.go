@@ -0,0 +1,192 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ChaosWebhook", func() {
+	var (
+		ctx      = context.Background()
+		w        = &ChaosWebhook{}
+		duration string
+		podChaos *v1alpha1.Chaos
+		netChaos *v1alpha1.Chaos
+	)
+
+	BeforeEach(func() {
+		duration = "30s"
+		podChaos = &v1alpha1.Chaos{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pod-chaos", Namespace: "default"},
+			Spec: v1alpha1.ChaosSpec{
+				EmbedChaos: v1alpha1.EmbedChaos{
+					PodChaos: &v1alpha1.PodChaosSpec{
+						Action: v1alpha1.PodFailure,
+						Params: v1alpha1.PodChaosParams{
+							PodFailure: &v1alpha1.PodFailureParams{Duration: &duration},
+						},
+					},
+				},
+				PressureCfg: &v1alpha1.PressureCfg{
+					SsHost:        "root:root@tcp(127.0.0.1:3307)/ds_0",
+					Duration:      metav1.Duration{Duration: 30 * time.Second},
+					ReqTime:       metav1.Duration{Duration: 5 * time.Second},
+					ConcurrentNum: 1,
+					ReqNum:        2,
+				},
+			},
+		}
+		netChaos = &v1alpha1.Chaos{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-network-chaos", Namespace: "default"},
+			Spec: v1alpha1.ChaosSpec{
+				EmbedChaos: v1alpha1.EmbedChaos{
+					NetworkChaos: &v1alpha1.NetworkChaosSpec{
+						Target:   &v1alpha1.PodSelector{Namespaces: []string{"default"}},
+						Action:   v1alpha1.Delay,
+						Duration: &duration,
+						Params: v1alpha1.NetworkChaosParams{
+							Delay: &v1alpha1.DelayParams{Latency: "100ms", Jitter: "10ms"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("should default the direction of network chaos", func() {
+		Expect(w.Default(ctx, netChaos)).To(Succeed())
+		Expect(netChaos.Spec.NetworkChaos.Direction).To(Equal(v1alpha1.To))
+	})
+
+	It("should allow valid chaos", func() {
+		Expect(w.ValidateCreate(ctx, podChaos)).To(Succeed())
+		Expect(w.ValidateCreate(ctx, netChaos)).To(Succeed())
+		Expect(w.ValidateUpdate(ctx, netChaos, netChaos)).To(Succeed())
+	})
+
+	It("should reject both pod chaos and network chaos", func() {
+		podChaos.Spec.NetworkChaos = netChaos.Spec.NetworkChaos
+		err := w.ValidateCreate(ctx, podChaos)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("mutually exclusive"))
+	})
+
+	It("should reject neither pod chaos nor network chaos", func() {
+		podChaos.Spec.PodChaos = nil
+		err := w.ValidateCreate(ctx, podChaos)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("one of podChaos and networkChaos is required"))
+	})
+
+	DescribeTable("should reject invalid pod chaos",
+		func(mutate func(*v1alpha1.PodChaosSpec), field string) {
+			mutate(podChaos.Spec.PodChaos)
+			err := w.ValidateCreate(ctx, podChaos)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(field))
+		},
+		Entry("unsupported action", func(pc *v1alpha1.PodChaosSpec) {
+			pc.Action = "PodReboot"
+		}, "spec.podChaos.action"),
+		Entry("unparsable duration", func(pc *v1alpha1.PodChaosSpec) {
+			d := "30 seconds"
+			pc.Params.PodFailure.Duration = &d
+		}, "spec.podChaos.params.podFailure.duration"),
+		Entry("missing params of PodKill", func(pc *v1alpha1.PodChaosSpec) {
+			pc.Action = v1alpha1.PodKill
+		}, "spec.podChaos.params.podKill"),
+		Entry("missing container names of ContainerKill", func(pc *v1alpha1.PodChaosSpec) {
+			pc.Action = v1alpha1.ContainerKill
+			pc.Params.ContainerKill = &v1alpha1.ContainerKillParams{}
+		}, "spec.podChaos.params.containerKill.containerNames"),
+		Entry("missing duration of CPUStress", func(pc *v1alpha1.PodChaosSpec) {
+			pc.Action = v1alpha1.CPUStress
+			pc.Params.CPUStress = &v1alpha1.CPUStressParams{Load: 50}
+		}, "spec.podChaos.params.cpuStress.duration"),
+		Entry("load out of range of CPUStress", func(pc *v1alpha1.PodChaosSpec) {
+			pc.Action = v1alpha1.CPUStress
+			pc.Params.CPUStress = &v1alpha1.CPUStressParams{Duration: "1m", Load: 200}
+		}, "spec.podChaos.params.cpuStress.load"),
+		Entry("unparsable duration of MemoryStress", func(pc *v1alpha1.PodChaosSpec) {
+			pc.Action = v1alpha1.MemoryStress
+			pc.Params.MemoryStress = &v1alpha1.MemoryStressParams{Duration: "1y"}
+		}, "spec.podChaos.params.memoryStress.duration"),
+	)
+
+	DescribeTable("should reject invalid network chaos",
+		func(mutate func(*v1alpha1.NetworkChaosSpec), field string) {
+			mutate(netChaos.Spec.NetworkChaos)
+			err := w.ValidateCreate(ctx, netChaos)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(field))
+		},
+		Entry("missing target", func(nc *v1alpha1.NetworkChaosSpec) {
+			nc.Target = nil
+		}, "spec.networkChaos.target"),
+		Entry("unparsable duration", func(nc *v1alpha1.NetworkChaosSpec) {
+			d := "forever"
+			nc.Duration = &d
+		}, "spec.networkChaos.duration"),
+		Entry("unsupported direction", func(nc *v1alpha1.NetworkChaosSpec) {
+			nc.Direction = "inbound"
+		}, "spec.networkChaos.direction"),
+		Entry("unsupported action", func(nc *v1alpha1.NetworkChaosSpec) {
+			nc.Action = "Reorder"
+		}, "spec.networkChaos.action"),
+		Entry("unparsable latency", func(nc *v1alpha1.NetworkChaosSpec) {
+			nc.Params.Delay.Latency = "100"
+		}, "spec.networkChaos.params.delay.latency"),
+		Entry("missing params of Loss", func(nc *v1alpha1.NetworkChaosSpec) {
+			nc.Action = v1alpha1.Loss
+		}, "spec.networkChaos.params.loss"),
+		Entry("percent out of range of Corruption", func(nc *v1alpha1.NetworkChaosSpec) {
+			nc.Action = v1alpha1.Corruption
+			nc.Params.Corruption = &v1alpha1.CorruptionParams{Corruption: "120"}
+		}, "spec.networkChaos.params.corrupt.corrupt"),
+	)
+
+	DescribeTable("should reject invalid pressure config",
+		func(mutate func(*v1alpha1.PressureCfg), field string) {
+			mutate(podChaos.Spec.PressureCfg)
+			err := w.ValidateCreate(ctx, podChaos)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(field))
+		},
+		Entry("missing ssHost", func(cfg *v1alpha1.PressureCfg) {
+			cfg.SsHost = ""
+		}, "spec.pressureCfg.ssHost"),
+		Entry("zero duration", func(cfg *v1alpha1.PressureCfg) {
+			cfg.Duration = metav1.Duration{}
+		}, "spec.pressureCfg.duration"),
+		Entry("zero concurrent number", func(cfg *v1alpha1.PressureCfg) {
+			cfg.ConcurrentNum = 0
+		}, "spec.pressureCfg.concurrentNum"),
+		Entry("zero request number", func(cfg *v1alpha1.PressureCfg) {
+			cfg.ReqNum = 0
+		}, "spec.pressureCfg.reqNum"),
+	)
+})
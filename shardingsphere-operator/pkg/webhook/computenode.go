@@ -0,0 +1,174 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ComputeNodeWebhook defaults and validates ComputeNode
+type ComputeNodeWebhook struct{}
+
+var (
+	_ admission.CustomDefaulter = &ComputeNodeWebhook{}
+	_ admission.CustomValidator = &ComputeNodeWebhook{}
+)
+
+// Default sets the service type, the protocol of port bindings and the privilege type if they are empty
+func (w *ComputeNodeWebhook) Default(_ context.Context, obj runtime.Object) error {
+	cn, ok := obj.(*v1alpha1.ComputeNode)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a ComputeNode but got a %T", obj))
+	}
+
+	if cn.Spec.ServiceType == "" {
+		cn.Spec.ServiceType = corev1.ServiceTypeClusterIP
+	}
+	for i := range cn.Spec.PortBindings {
+		if cn.Spec.PortBindings[i].Protocol == "" {
+			cn.Spec.PortBindings[i].Protocol = corev1.ProtocolTCP
+		}
+	}
+	if cn.Spec.Bootstrap.ServerConfig.Authority.Privilege.Type == "" {
+		cn.Spec.Bootstrap.ServerConfig.Authority.Privilege.Type = v1alpha1.AllPermitted
+	}
+	return nil
+}
+
+// ValidateCreate validates the created ComputeNode
+func (w *ComputeNodeWebhook) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	cn, ok := obj.(*v1alpha1.ComputeNode)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a ComputeNode but got a %T", obj))
+	}
+	return invalidOrNil("ComputeNode", cn.Name, validateComputeNodeSpec(&cn.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate validates the updated ComputeNode
+func (w *ComputeNodeWebhook) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) error {
+	return w.ValidateCreate(ctx, newObj)
+}
+
+// ValidateDelete allows any deletion
+func (w *ComputeNodeWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func validateComputeNodeSpec(spec *v1alpha1.ComputeNodeSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if spec.Replicas < 0 {
+		errs = append(errs, field.Invalid(path.Child("replicas"), spec.Replicas, "must be greater than or equal to 0"))
+	}
+
+	if spec.Selector == nil {
+		errs = append(errs, field.Required(path.Child("selector"), "selector is required to select the pods of compute node"))
+	} else if _, err := metav1.LabelSelectorAsSelector(spec.Selector); err != nil {
+		errs = append(errs, field.Invalid(path.Child("selector"), spec.Selector, err.Error()))
+	}
+
+	if c := spec.StorageNodeConnector; c != nil {
+		if c.Type != v1alpha1.ConnectorTypeMySQL && c.Type != v1alpha1.ConnectorTypePostgreSQL {
+			errs = append(errs, field.NotSupported(path.Child("storageNodeConnector", "type"), c.Type,
+				[]string{string(v1alpha1.ConnectorTypeMySQL), string(v1alpha1.ConnectorTypePostgreSQL)}))
+		}
+	}
+
+	errs = append(errs, validatePortBindings(spec.PortBindings, spec.ServiceType, path.Child("portBindings"))...)
+	errs = append(errs, validateServerConfig(&spec.Bootstrap.ServerConfig, path.Child("bootstrap", "serverConfig"))...)
+
+	return errs
+}
+
+func validatePortBindings(bindings []v1alpha1.PortBinding, serviceType corev1.ServiceType, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	names := map[string]bool{}
+	for i, pb := range bindings {
+		p := path.Index(i)
+		if pb.Name != "" {
+			for _, msg := range validation.IsValidPortName(pb.Name) {
+				errs = append(errs, field.Invalid(p.Child("name"), pb.Name, msg))
+			}
+			if names[pb.Name] {
+				errs = append(errs, field.Duplicate(p.Child("name"), pb.Name))
+			}
+			names[pb.Name] = true
+		}
+		for _, msg := range validation.IsValidPortNum(int(pb.ContainerPort)) {
+			errs = append(errs, field.Invalid(p.Child("containerPort"), pb.ContainerPort, msg))
+		}
+		for _, msg := range validation.IsValidPortNum(int(pb.ServicePort)) {
+			errs = append(errs, field.Invalid(p.Child("servicePort"), pb.ServicePort, msg))
+		}
+		if pb.Protocol != "" && pb.Protocol != corev1.ProtocolTCP && pb.Protocol != corev1.ProtocolUDP && pb.Protocol != corev1.ProtocolSCTP {
+			errs = append(errs, field.NotSupported(p.Child("protocol"), pb.Protocol,
+				[]string{string(corev1.ProtocolTCP), string(corev1.ProtocolUDP), string(corev1.ProtocolSCTP)}))
+		}
+		if pb.NodePort != 0 {
+			if serviceType != corev1.ServiceTypeNodePort && serviceType != corev1.ServiceTypeLoadBalancer {
+				errs = append(errs, field.Forbidden(p.Child("nodePort"), "nodePort is only allowed with serviceType NodePort or LoadBalancer"))
+			}
+			for _, msg := range validation.IsValidPortNum(int(pb.NodePort)) {
+				errs = append(errs, field.Invalid(p.Child("nodePort"), pb.NodePort, msg))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateServerConfig(cfg *v1alpha1.ServerConfig, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, u := range cfg.Authority.Users {
+		if u.User == "" {
+			errs = append(errs, field.Required(path.Child("authority", "users").Index(i).Child("user"), "user is required"))
+		}
+	}
+
+	mode := cfg.Mode
+	switch mode.Type {
+	case "", v1alpha1.ModeTypeStandalone:
+	case v1alpha1.ModeTypeCluster:
+		if mode.Repository.Type == "" {
+			errs = append(errs, field.Required(path.Child("mode", "repository", "type"), "repository is required in Cluster mode"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("mode", "type"), mode.Type,
+			[]string{string(v1alpha1.ModeTypeCluster), string(v1alpha1.ModeTypeStandalone)}))
+	}
+
+	if t := mode.Repository.Type; t != "" && t != v1alpha1.RepositoryTypeZookeeper && t != v1alpha1.RepositoryTypeEtcd {
+		errs = append(errs, field.NotSupported(path.Child("mode", "repository", "type"), t,
+			[]string{string(v1alpha1.RepositoryTypeZookeeper), string(v1alpha1.RepositoryTypeEtcd)}))
+	}
+
+	return errs
+}
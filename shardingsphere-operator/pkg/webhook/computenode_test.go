@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ComputeNodeWebhook", func() {
+	var (
+		ctx = context.Background()
+		w   = &ComputeNodeWebhook{}
+		cn  *v1alpha1.ComputeNode
+	)
+
+	BeforeEach(func() {
+		cn = &v1alpha1.ComputeNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-compute-node", Namespace: "default"},
+			Spec: v1alpha1.ComputeNodeSpec{
+				Replicas: 1,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				PortBindings: []v1alpha1.PortBinding{
+					{Name: "server", ContainerPort: 3307, ServicePort: 3307},
+				},
+				Bootstrap: v1alpha1.BootstrapConfig{
+					ServerConfig: v1alpha1.ServerConfig{
+						Authority: v1alpha1.ComputeNodeAuthority{
+							Users: []v1alpha1.ComputeNodeUser{{User: "root@%", Password: "root"}},
+						},
+						Mode: v1alpha1.ComputeNodeServerMode{
+							Type:       v1alpha1.ModeTypeCluster,
+							Repository: v1alpha1.Repository{Type: v1alpha1.RepositoryTypeZookeeper},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Context("Default", func() {
+		It("should set the service type, protocol and privilege", func() {
+			Expect(w.Default(ctx, cn)).To(Succeed())
+			Expect(cn.Spec.ServiceType).To(Equal(corev1.ServiceTypeClusterIP))
+			Expect(cn.Spec.PortBindings[0].Protocol).To(Equal(corev1.ProtocolTCP))
+			Expect(cn.Spec.Bootstrap.ServerConfig.Authority.Privilege.Type).To(Equal(v1alpha1.AllPermitted))
+		})
+
+		It("should keep the specified service type", func() {
+			cn.Spec.ServiceType = corev1.ServiceTypeNodePort
+			Expect(w.Default(ctx, cn)).To(Succeed())
+			Expect(cn.Spec.ServiceType).To(Equal(corev1.ServiceTypeNodePort))
+		})
+	})
+
+	Context("Validate", func() {
+		It("should allow a valid compute node", func() {
+			Expect(w.ValidateCreate(ctx, cn)).To(Succeed())
+			Expect(w.ValidateUpdate(ctx, cn, cn)).To(Succeed())
+		})
+
+		DescribeTable("should reject an invalid compute node",
+			func(mutate func(*v1alpha1.ComputeNode), field string) {
+				mutate(cn)
+				err := w.ValidateCreate(ctx, cn)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(field))
+
+				err = w.ValidateUpdate(ctx, cn, cn)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			},
+			Entry("negative replicas", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.Replicas = -1
+			}, "spec.replicas"),
+			Entry("missing selector", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.Selector = nil
+			}, "spec.selector"),
+			Entry("invalid selector", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.Selector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: "Unknown"},
+				}}
+			}, "spec.selector"),
+			Entry("unsupported connector", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.StorageNodeConnector = &v1alpha1.StorageNodeConnector{Type: "oracle", Version: "1.0.0"}
+			}, "spec.storageNodeConnector.type"),
+			Entry("container port out of range", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.PortBindings[0].ContainerPort = 70000
+			}, "spec.portBindings[0].containerPort"),
+			Entry("missing service port", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.PortBindings[0].ServicePort = 0
+			}, "spec.portBindings[0].servicePort"),
+			Entry("duplicated port name", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.PortBindings = append(cn.Spec.PortBindings, v1alpha1.PortBinding{Name: "server", ContainerPort: 3308, ServicePort: 3308})
+			}, "spec.portBindings[1].name"),
+			Entry("unsupported protocol", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.PortBindings[0].Protocol = "HTTP"
+			}, "spec.portBindings[0].protocol"),
+			Entry("node port with ClusterIP", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.ServiceType = corev1.ServiceTypeClusterIP
+				cn.Spec.PortBindings[0].NodePort = 30307
+			}, "spec.portBindings[0].nodePort"),
+			Entry("missing user", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.Bootstrap.ServerConfig.Authority.Users[0].User = ""
+			}, "spec.bootstrap.serverConfig.authority.users[0].user"),
+			Entry("unsupported mode", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.Bootstrap.ServerConfig.Mode.Type = "Memory"
+			}, "spec.bootstrap.serverConfig.mode.type"),
+			Entry("cluster mode without repository", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.Bootstrap.ServerConfig.Mode.Repository.Type = ""
+			}, "spec.bootstrap.serverConfig.mode.repository.type"),
+			Entry("unsupported repository", func(cn *v1alpha1.ComputeNode) {
+				cn.Spec.Bootstrap.ServerConfig.Mode.Repository.Type = "Consul"
+			}, "spec.bootstrap.serverConfig.mode.repository.type"),
+		)
+
+		It("should allow node port with NodePort service", func() {
+			cn.Spec.ServiceType = corev1.ServiceTypeNodePort
+			cn.Spec.PortBindings[0].NodePort = 30307
+			Expect(w.ValidateCreate(ctx, cn)).To(Succeed())
+		})
+
+		It("should reject other kinds", func() {
+			Expect(apierrors.IsBadRequest(w.ValidateCreate(ctx, &v1alpha1.StorageNode{}))).To(BeTrue())
+		})
+	})
+})
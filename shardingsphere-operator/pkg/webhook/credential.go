@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const plaintextPasswordForbidden = "plaintext password is not allowed in strict credentials mode, refer it by a secret instead"
+
+// CredentialValidator rejects the plaintext passwords of ComputeNode, ShardingSphereProxyServerConfig,
+// StorageNode and StorageProvider, so the passwords could only be referred by secrets.
+// It is registered when the operator runs in strict credentials mode.
+type CredentialValidator struct{}
+
+var _ admission.CustomValidator = &CredentialValidator{}
+
+// ValidateCreate rejects the created object holding plaintext passwords
+func (v *CredentialValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	return validateCredentials(obj)
+}
+
+// ValidateUpdate rejects the updated object holding plaintext passwords
+func (v *CredentialValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) error {
+	return validateCredentials(newObj)
+}
+
+// ValidateDelete allows any deletion
+func (v *CredentialValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func validateCredentials(obj runtime.Object) error {
+	var (
+		errs field.ErrorList
+		kind string
+	)
+
+	switch o := obj.(type) {
+	case *v1alpha1.ComputeNode:
+		kind = "ComputeNode"
+		path := field.NewPath("spec", "bootstrap", "serverConfig", "authority", "users")
+		for i, u := range o.Spec.Bootstrap.ServerConfig.Authority.Users {
+			if u.Password != "" {
+				errs = append(errs, field.Forbidden(path.Index(i).Child("password"), plaintextPasswordForbidden))
+			}
+		}
+	case *v1alpha1.ShardingSphereProxyServerConfig:
+		kind = "ShardingSphereProxyServerConfig"
+		path := field.NewPath("spec", "authority", "users")
+		for i, u := range o.Spec.Authority.Users {
+			if u.Password != "" {
+				errs = append(errs, field.Forbidden(path.Index(i).Child("password"), plaintextPasswordForbidden))
+			}
+		}
+	case *v1alpha1.StorageNode:
+		kind = "StorageNode"
+		if o.Annotations[v1alpha1.AnnotationsMasterUserPassword] != "" {
+			errs = append(errs, field.Forbidden(field.NewPath("metadata", "annotations").Key(v1alpha1.AnnotationsMasterUserPassword), plaintextPasswordForbidden))
+		}
+		if c := o.Spec.MasterUserCredential; c != nil && c.Password != "" {
+			errs = append(errs, field.Forbidden(field.NewPath("spec", "masterUserCredential", "password"), plaintextPasswordForbidden))
+		}
+	case *v1alpha1.StorageProvider:
+		kind = "StorageProvider"
+		if o.Spec.Parameters["masterUserPassword"] != "" {
+			errs = append(errs, field.Forbidden(field.NewPath("spec", "parameters").Key("masterUserPassword"), plaintextPasswordForbidden))
+		}
+	default:
+		return nil
+	}
+
+	return invalidOrNil(kind, obj.(client.Object).GetName(), errs)
+}
@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func Test_CredentialValidator(t *testing.T) {
+	ref := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"},
+		Key:                  "password",
+	}
+
+	cases := []struct {
+		obj     runtime.Object
+		invalid bool
+		msg     string
+	}{
+		{
+			obj: &v1alpha1.ComputeNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.ComputeNodeSpec{
+					Bootstrap: v1alpha1.BootstrapConfig{
+						ServerConfig: v1alpha1.ServerConfig{
+							Authority: v1alpha1.ComputeNodeAuthority{
+								Users: []v1alpha1.ComputeNodeUser{{User: "root@%", Password: "root"}},
+							},
+						},
+					},
+				},
+			},
+			invalid: true,
+			msg:     "compute node with plaintext password should be rejected",
+		},
+		{
+			obj: &v1alpha1.ComputeNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.ComputeNodeSpec{
+					Bootstrap: v1alpha1.BootstrapConfig{
+						ServerConfig: v1alpha1.ServerConfig{
+							Authority: v1alpha1.ComputeNodeAuthority{
+								Users: []v1alpha1.ComputeNodeUser{{User: "root@%", PasswordSecretRef: ref}},
+							},
+						},
+					},
+				},
+			},
+			msg: "compute node with password secret ref should be allowed",
+		},
+		{
+			obj: &v1alpha1.ShardingSphereProxyServerConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.ProxyConfigSpec{
+					Authority: v1alpha1.Auth{
+						Users: []v1alpha1.User{{User: "root@%", PasswordSecretRef: ref}, {User: "test@%", Password: "test"}},
+					},
+				},
+			},
+			invalid: true,
+			msg:     "server config with any plaintext password should be rejected",
+		},
+		{
+			obj: &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						v1alpha1.AnnotationsMasterUserPassword: "root123456",
+					},
+				},
+			},
+			invalid: true,
+			msg:     "storage node with plaintext master user password should be rejected",
+		},
+		{
+			obj: &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.StorageNodeSpec{
+					MasterUserCredential: &v1alpha1.BasicCredential{Username: "root", Password: "root123456"},
+				},
+			},
+			invalid: true,
+			msg:     "storage node with plaintext master user credential should be rejected",
+		},
+		{
+			obj: &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.StorageNodeSpec{
+					MasterUserCredential: &v1alpha1.BasicCredential{Username: "root", PasswordSecretRef: ref},
+				},
+			},
+			msg: "storage node with master user password secret ref should be allowed",
+		},
+		{
+			obj: &v1alpha1.StorageProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.StorageProviderSpec{
+					Parameters: map[string]string{
+						"masterUsername":     "root",
+						"masterUserPassword": "root123456",
+					},
+				},
+			},
+			invalid: true,
+			msg:     "storage provider with plaintext master user password should be rejected",
+		},
+		{
+			obj: &v1alpha1.Chaos{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			msg: "other kinds should be allowed",
+		},
+	}
+
+	v := &CredentialValidator{}
+	for _, c := range cases {
+		err := v.ValidateCreate(context.TODO(), c.obj)
+		assert.Equal(t, c.invalid, apierrors.IsInvalid(err), c.msg)
+		assert.Equal(t, err, v.ValidateUpdate(context.TODO(), nil, c.obj), c.msg)
+		assert.NoError(t, v.ValidateDelete(context.TODO(), c.obj), c.msg)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// hubFor returns the hub version of the kind of gvk registered in scheme, or nil if there is none
+func hubFor(scheme *runtime.Scheme, gvk schema.GroupVersionKind) (conversion.Hub, schema.GroupVersionKind, error) {
+	for known := range scheme.AllKnownTypes() {
+		if known.GroupKind() != gvk.GroupKind() || known.Version == gvk.Version {
+			continue
+		}
+		obj, err := scheme.New(known)
+		if err != nil {
+			return nil, schema.GroupVersionKind{}, err
+		}
+		if hub, ok := obj.(conversion.Hub); ok {
+			return hub, known, nil
+		}
+	}
+	return nil, schema.GroupVersionKind{}, nil
+}
+
+// hubDefaulter defaults the hub object by the defaulter of the spoke version, the hub object
+// is converted to the spoke version before defaulting and converted back after that.
+type hubDefaulter struct {
+	spoke     conversion.Convertible
+	defaulter admission.CustomDefaulter
+}
+
+var _ admission.CustomDefaulter = &hubDefaulter{}
+
+func (d *hubDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	hub, ok := obj.(conversion.Hub)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a hub object but got a %T", obj))
+	}
+
+	spoke, err := toSpoke(d.spoke, hub)
+	if err != nil {
+		return err
+	}
+	if err := d.defaulter.Default(ctx, spoke); err != nil {
+		return err
+	}
+	if err := spoke.ConvertTo(hub); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	return nil
+}
+
+// hubValidator validates the hub objects by the validator of the spoke version,
+// the hub objects are converted to the spoke version before validating.
+type hubValidator struct {
+	spoke     conversion.Convertible
+	validator admission.CustomValidator
+}
+
+var _ admission.CustomValidator = &hubValidator{}
+
+func (v *hubValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	spoke, err := toSpokeObject(v.spoke, obj)
+	if err != nil {
+		return err
+	}
+	return v.validator.ValidateCreate(ctx, spoke)
+}
+
+func (v *hubValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldSpoke, err := toSpokeObject(v.spoke, oldObj)
+	if err != nil {
+		return err
+	}
+	newSpoke, err := toSpokeObject(v.spoke, newObj)
+	if err != nil {
+		return err
+	}
+	return v.validator.ValidateUpdate(ctx, oldSpoke, newSpoke)
+}
+
+func (v *hubValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	spoke, err := toSpokeObject(v.spoke, obj)
+	if err != nil {
+		return err
+	}
+	return v.validator.ValidateDelete(ctx, spoke)
+}
+
+func toSpokeObject(spoke conversion.Convertible, obj runtime.Object) (conversion.Convertible, error) {
+	hub, ok := obj.(conversion.Hub)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a hub object but got a %T", obj))
+	}
+	return toSpoke(spoke, hub)
+}
+
+// toSpoke converts hub to a new object of the same type as spoke
+func toSpoke(spoke conversion.Convertible, hub conversion.Hub) (conversion.Convertible, error) {
+	out, ok := spoke.DeepCopyObject().(conversion.Convertible)
+	if !ok {
+		return nil, apierrors.NewInternalError(fmt.Errorf("%T is not convertible", spoke))
+	}
+	if err := out.ConvertFrom(hub); err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	return out, nil
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1beta1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Hub webhooks", func() {
+	var (
+		ctx    = context.Background()
+		scheme *runtime.Scheme
+		sn     *v1beta1.StorageNode
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+		sn = &v1beta1.StorageNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-storage-node", Namespace: "default"},
+			Spec: v1beta1.StorageNodeSpec{
+				StorageProviderName: "aws-aurora",
+				ProviderSettings: &v1beta1.ProviderSettings{
+					ClusterIdentifier: "test-cluster",
+				},
+			},
+		}
+	})
+
+	It("should find the hub version of the convertible kinds", func() {
+		hub, gvk, err := hubFor(scheme, v1alpha1.GroupVersion.WithKind("StorageNode"))
+		Expect(err).To(BeNil())
+		Expect(hub).To(BeAssignableToTypeOf(&v1beta1.StorageNode{}))
+		Expect(gvk).To(Equal(v1beta1.GroupVersion.WithKind("StorageNode")))
+
+		hub, _, err = hubFor(scheme, v1alpha1.GroupVersion.WithKind("Chaos"))
+		Expect(err).To(BeNil())
+		Expect(hub).To(BeNil())
+	})
+
+	It("should default the hub object by the defaulter of the spoke version", func() {
+		sn.Annotations = map[string]string{
+			v1alpha1.AnnotationsRegisterStorageUnitEnabled: "true",
+			v1alpha1.AnnotationsComputeNodeName:            "test-compute-node",
+			v1alpha1.AnnotationsLogicDatabaseName:          "sharding_db",
+		}
+		d := &hubDefaulter{spoke: &v1alpha1.StorageNode{}, defaulter: &StorageNodeWebhook{}}
+		Expect(d.Default(ctx, sn)).To(Succeed())
+		Expect(sn.Spec.Replicas).To(Equal(int32(1)))
+		Expect(sn.Spec.ComputeNodeRef).To(Equal(&corev1.LocalObjectReference{Name: "test-compute-node"}))
+		Expect(sn.Spec.LogicDatabase).To(Equal("sharding_db"))
+		Expect(sn.Spec.ProviderSettings).To(Equal(&v1beta1.ProviderSettings{ClusterIdentifier: "test-cluster"}))
+	})
+
+	It("should validate the hub objects by the validator of the spoke version", func() {
+		v := &hubValidator{spoke: &v1alpha1.StorageNode{}, validator: &StorageNodeWebhook{}}
+		sn.Spec.Replicas = 1
+		Expect(v.ValidateCreate(ctx, sn)).To(Succeed())
+
+		updated := sn.DeepCopy()
+		updated.Spec.StorageProviderName = "aws-rds-instance"
+		Expect(apierrors.IsInvalid(v.ValidateUpdate(ctx, sn, updated))).To(BeTrue())
+
+		sn.Spec.Replicas = 0
+		Expect(apierrors.IsInvalid(v.ValidateCreate(ctx, sn))).To(BeTrue())
+	})
+
+	It("should reject the plaintext passwords of the hub objects in strict credentials mode", func() {
+		v := &hubValidator{spoke: &v1alpha1.StorageNode{}, validator: &CredentialValidator{}}
+		sn.Spec.ProviderSettings.MasterUser = &v1beta1.BasicCredential{Username: "root", Password: "root123456"}
+		Expect(apierrors.IsInvalid(v.ValidateCreate(ctx, sn))).To(BeTrue())
+	})
+
+	It("should reject an object which is not the hub", func() {
+		d := &hubDefaulter{spoke: &v1alpha1.StorageNode{}, defaulter: &StorageNodeWebhook{}}
+		Expect(apierrors.IsBadRequest(d.Default(ctx, &v1alpha1.StorageNode{}))).To(BeTrue())
+	})
+})
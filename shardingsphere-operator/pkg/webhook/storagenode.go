@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// storageUnitNamePattern is the storage unit name allowed by DistSQL, which only has letters, numbers and _,
+// and starts with a letter
+var storageUnitNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// StorageNodeWebhook defaults and validates StorageNode.
+// The StorageProvider referred by StorageNode is looked up by Reader to check the replicas
+// against its provisioner, a StorageNode referring a missing StorageProvider is allowed
+// since the StorageProvider could be created later.
+type StorageNodeWebhook struct {
+	Reader client.Reader
+}
+
+var (
+	_ admission.CustomDefaulter = &StorageNodeWebhook{}
+	_ admission.CustomValidator = &StorageNodeWebhook{}
+)
+
+// Default sets the replicas to 1 if it is not set, and translates the legacy storage unit annotations
+// into spec.computeNodeRef and spec.logicDatabase
+func (w *StorageNodeWebhook) Default(_ context.Context, obj runtime.Object) error {
+	sn, ok := obj.(*v1alpha1.StorageNode)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a StorageNode but got a %T", obj))
+	}
+
+	if sn.Spec.Replicas == 0 {
+		sn.Spec.Replicas = 1
+	}
+	sn.MigrateLegacyStorageUnitAnnotations()
+	return nil
+}
+
+// ValidateCreate validates the created StorageNode
+func (w *StorageNodeWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	sn, ok := obj.(*v1alpha1.StorageNode)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a StorageNode but got a %T", obj))
+	}
+
+	errs, err := w.validateStorageNodeSpec(ctx, &sn.Spec, field.NewPath("spec"))
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	return invalidOrNil("StorageNode", sn.Name, errs)
+}
+
+// ValidateUpdate validates the updated StorageNode, whose storage provider is immutable
+func (w *StorageNodeWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	sn, ok := newObj.(*v1alpha1.StorageNode)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a StorageNode but got a %T", newObj))
+	}
+	old, ok := oldObj.(*v1alpha1.StorageNode)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a StorageNode but got a %T", oldObj))
+	}
+
+	path := field.NewPath("spec")
+	errs, err := w.validateStorageNodeSpec(ctx, &sn.Spec, path)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if old.Spec.StorageProviderName != sn.Spec.StorageProviderName {
+		errs = append(errs, field.Forbidden(path.Child("storageProviderName"), "storageProviderName is immutable"))
+	}
+	return invalidOrNil("StorageNode", sn.Name, errs)
+}
+
+// ValidateDelete allows any deletion
+func (w *StorageNodeWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (w *StorageNodeWebhook) validateStorageNodeSpec(ctx context.Context, spec *v1alpha1.StorageNodeSpec, path *field.Path) (field.ErrorList, error) {
+	var errs field.ErrorList
+
+	if spec.StorageProviderName == "" {
+		errs = append(errs, field.Required(path.Child("storageProviderName"), "storageProviderName is required"))
+	}
+	if spec.Replicas < 1 {
+		errs = append(errs, field.Invalid(path.Child("replicas"), spec.Replicas, "must be greater than or equal to 1"))
+	}
+	if spec.ComputeNodeRef != nil {
+		if spec.ComputeNodeRef.Name == "" {
+			errs = append(errs, field.Required(path.Child("computeNodeRef", "name"), "name of compute node is required"))
+		}
+		if spec.LogicDatabase == "" {
+			errs = append(errs, field.Required(path.Child("logicDatabase"), "logicDatabase is required with computeNodeRef"))
+		}
+	}
+	if spec.StorageUnitName != "" && !storageUnitNamePattern.MatchString(spec.StorageUnitName) {
+		errs = append(errs, field.Invalid(path.Child("storageUnitName"), spec.StorageUnitName,
+			"must consist of letters, numbers and '_', and start with a letter"))
+	}
+	if len(errs) > 0 || w.Reader == nil {
+		return errs, nil
+	}
+
+	sp := &v1alpha1.StorageProvider{}
+	if err := w.Reader.Get(ctx, types.NamespacedName{Name: spec.StorageProviderName}, sp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errs, nil
+		}
+		return nil, err
+	}
+
+	// aws rds instance is always provisioned as a single instance
+	if sp.Spec.Provisioner == v1alpha1.ProvisionerAWSRDSInstance && spec.Replicas != 1 {
+		errs = append(errs, field.Invalid(path.Child("replicas"), spec.Replicas,
+			fmt.Sprintf("must be 1 with provisioner %s", sp.Spec.Provisioner)))
+	}
+	return errs, nil
+}
@@ -0,0 +1,163 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("StorageNodeWebhook", func() {
+	var (
+		ctx = context.Background()
+		w   *StorageNodeWebhook
+		sn  *v1alpha1.StorageNode
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		w = &StorageNodeWebhook{
+			Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&v1alpha1.StorageProvider{
+					ObjectMeta: metav1.ObjectMeta{Name: "aws-rds-instance"},
+					Spec:       v1alpha1.StorageProviderSpec{Provisioner: v1alpha1.ProvisionerAWSRDSInstance},
+				},
+				&v1alpha1.StorageProvider{
+					ObjectMeta: metav1.ObjectMeta{Name: "aws-aurora"},
+					Spec:       v1alpha1.StorageProviderSpec{Provisioner: v1alpha1.ProvisionerAWSAurora},
+				},
+			).Build(),
+		}
+		sn = &v1alpha1.StorageNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-storage-node", Namespace: "default"},
+			Spec: v1alpha1.StorageNodeSpec{
+				StorageProviderName: "aws-aurora",
+				Replicas:            3,
+			},
+		}
+	})
+
+	It("should default the replicas to 1", func() {
+		sn.Spec.Replicas = 0
+		Expect(w.Default(ctx, sn)).To(Succeed())
+		Expect(sn.Spec.Replicas).To(Equal(int32(1)))
+	})
+
+	It("should translate the legacy storage unit annotations", func() {
+		sn.Annotations = map[string]string{
+			v1alpha1.AnnotationsRegisterStorageUnitEnabled: "true",
+			v1alpha1.AnnotationsComputeNodeName:            "test-compute-node",
+			v1alpha1.AnnotationsLogicDatabaseName:          "sharding_db",
+		}
+		Expect(w.Default(ctx, sn)).To(Succeed())
+		Expect(sn.Spec.ComputeNodeRef).To(Equal(&corev1.LocalObjectReference{Name: "test-compute-node"}))
+		Expect(sn.Spec.LogicDatabase).To(Equal("sharding_db"))
+		Expect(w.ValidateCreate(ctx, sn)).To(Succeed())
+	})
+
+	It("should not override the compute node referred by the legacy storage unit annotations", func() {
+		sn.Spec.ComputeNodeRef = &corev1.LocalObjectReference{Name: "test-compute-node"}
+		sn.Spec.LogicDatabase = "sharding_db"
+		sn.Annotations = map[string]string{
+			v1alpha1.AnnotationsRegisterStorageUnitEnabled: "true",
+			v1alpha1.AnnotationsComputeNodeName:            "test-legacy-compute-node",
+			v1alpha1.AnnotationsLogicDatabaseName:          "legacy_db",
+		}
+		Expect(w.Default(ctx, sn)).To(Succeed())
+		Expect(sn.Spec.ComputeNodeRef.Name).To(Equal("test-compute-node"))
+		Expect(sn.Spec.LogicDatabase).To(Equal("sharding_db"))
+	})
+
+	It("should allow a valid storage node", func() {
+		Expect(w.ValidateCreate(ctx, sn)).To(Succeed())
+	})
+
+	It("should allow a storage node referring a missing storage provider", func() {
+		sn.Spec.StorageProviderName = "not-exist"
+		Expect(w.ValidateCreate(ctx, sn)).To(Succeed())
+	})
+
+	It("should reject a storage node without storage provider", func() {
+		sn.Spec.StorageProviderName = ""
+		err := w.ValidateCreate(ctx, sn)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.storageProviderName"))
+	})
+
+	It("should reject replicas less than 1", func() {
+		sn.Spec.Replicas = 0
+		err := w.ValidateCreate(ctx, sn)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.replicas"))
+	})
+
+	It("should reject multiple replicas of aws rds instance", func() {
+		sn.Spec.StorageProviderName = "aws-rds-instance"
+		err := w.ValidateCreate(ctx, sn)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("must be 1 with provisioner"))
+
+		sn.Spec.Replicas = 1
+		Expect(w.ValidateCreate(ctx, sn)).To(Succeed())
+	})
+
+	It("should require the logic database with the compute node referred", func() {
+		sn.Spec.ComputeNodeRef = &corev1.LocalObjectReference{Name: "test-compute-node"}
+		err := w.ValidateCreate(ctx, sn)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.logicDatabase"))
+
+		sn.Spec.LogicDatabase = "sharding_db"
+		Expect(w.ValidateCreate(ctx, sn)).To(Succeed())
+	})
+
+	It("should reject an invalid storage unit name", func() {
+		sn.Spec.StorageUnitName = "ds-0"
+		err := w.ValidateCreate(ctx, sn)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.storageUnitName"))
+
+		sn.Spec.StorageUnitName = "ds_0"
+		Expect(w.ValidateCreate(ctx, sn)).To(Succeed())
+	})
+
+	It("should reject changing the storage provider", func() {
+		updated := sn.DeepCopy()
+		updated.Spec.StorageProviderName = "aws-rds-instance"
+		updated.Spec.Replicas = 1
+		err := w.ValidateUpdate(ctx, sn, updated)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("storageProviderName is immutable"))
+	})
+
+	It("should allow scaling a storage node", func() {
+		updated := sn.DeepCopy()
+		updated.Spec.Replicas = 5
+		Expect(w.ValidateUpdate(ctx, sn, updated)).To(Succeed())
+	})
+})
@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SupportedProvisioners are the provisioners handled by the StorageNode controller
+var SupportedProvisioners = []string{
+	v1alpha1.ProvisionerAWSRDSInstance,
+	v1alpha1.ProvisionerAWSRDSCluster,
+	v1alpha1.ProvisionerAWSAurora,
+	v1alpha1.ProvisionerCloudNativePG,
+}
+
+// StorageProviderWebhook defaults and validates StorageProvider
+type StorageProviderWebhook struct{}
+
+var (
+	_ admission.CustomDefaulter = &StorageProviderWebhook{}
+	_ admission.CustomValidator = &StorageProviderWebhook{}
+)
+
+// Default sets the reclaim policy to Retain if it is empty
+func (w *StorageProviderWebhook) Default(_ context.Context, obj runtime.Object) error {
+	sp, ok := obj.(*v1alpha1.StorageProvider)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a StorageProvider but got a %T", obj))
+	}
+
+	if sp.Spec.ReclaimPolicy == "" {
+		sp.Spec.ReclaimPolicy = v1alpha1.StorageReclaimPolicyRetain
+	}
+	return nil
+}
+
+// ValidateCreate validates the provisioner and the reclaim policy of the created StorageProvider
+func (w *StorageProviderWebhook) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	sp, ok := obj.(*v1alpha1.StorageProvider)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a StorageProvider but got a %T", obj))
+	}
+	return invalidOrNil("StorageProvider", sp.Name, validateStorageProviderSpec(&sp.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate validates the updated StorageProvider, whose provisioner is immutable
+func (w *StorageProviderWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	sp, ok := newObj.(*v1alpha1.StorageProvider)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a StorageProvider but got a %T", newObj))
+	}
+	old, ok := oldObj.(*v1alpha1.StorageProvider)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a StorageProvider but got a %T", oldObj))
+	}
+
+	path := field.NewPath("spec")
+	errs := validateStorageProviderSpec(&sp.Spec, path)
+	if old.Spec.Provisioner != sp.Spec.Provisioner {
+		errs = append(errs, field.Forbidden(path.Child("provisioner"), "provisioner is immutable"))
+	}
+	return invalidOrNil("StorageProvider", sp.Name, errs)
+}
+
+// ValidateDelete allows any deletion
+func (w *StorageProviderWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func validateStorageProviderSpec(spec *v1alpha1.StorageProviderSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if spec.Provisioner == "" {
+		errs = append(errs, field.Required(path.Child("provisioner"), "provisioner is required"))
+	} else if !isSupportedProvisioner(spec.Provisioner) {
+		errs = append(errs, field.NotSupported(path.Child("provisioner"), spec.Provisioner, SupportedProvisioners))
+	}
+
+	if spec.Provisioner == v1alpha1.ProvisionerCloudNativePG {
+		if ins, ok := spec.Parameters["instances"]; ok {
+			if n, err := strconv.Atoi(ins); err != nil || n < 1 {
+				errs = append(errs, field.Invalid(path.Child("parameters").Key("instances"), ins, "must be a positive integer"))
+			}
+		}
+	}
+
+	switch spec.ReclaimPolicy {
+	case "", v1alpha1.StorageReclaimPolicyRetain, v1alpha1.StorageReclaimPolicyDelete, v1alpha1.StorageReclaimPolicyDeleteWithFinalSnapshot:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("reclaimPolicy"), spec.ReclaimPolicy, []string{
+			string(v1alpha1.StorageReclaimPolicyRetain),
+			string(v1alpha1.StorageReclaimPolicyDelete),
+			string(v1alpha1.StorageReclaimPolicyDeleteWithFinalSnapshot),
+		}))
+	}
+
+	return errs
+}
+
+func isSupportedProvisioner(provisioner string) bool {
+	for _, p := range SupportedProvisioners {
+		if p == provisioner {
+			return true
+		}
+	}
+	return false
+}
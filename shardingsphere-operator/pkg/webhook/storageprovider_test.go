@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("StorageProviderWebhook", func() {
+	var (
+		ctx = context.Background()
+		w   = &StorageProviderWebhook{}
+		sp  *v1alpha1.StorageProvider
+	)
+
+	BeforeEach(func() {
+		sp = &v1alpha1.StorageProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-storage-provider"},
+			Spec: v1alpha1.StorageProviderSpec{
+				Provisioner: v1alpha1.ProvisionerCloudNativePG,
+				Parameters:  map[string]string{"instances": "3"},
+			},
+		}
+	})
+
+	It("should default the reclaim policy to Retain", func() {
+		Expect(w.Default(ctx, sp)).To(Succeed())
+		Expect(sp.Spec.ReclaimPolicy).To(Equal(v1alpha1.StorageReclaimPolicyRetain))
+	})
+
+	It("should allow every supported provisioner", func() {
+		for _, p := range SupportedProvisioners {
+			sp.Spec.Provisioner = p
+			Expect(w.ValidateCreate(ctx, sp)).To(Succeed())
+		}
+	})
+
+	DescribeTable("should reject an invalid storage provider",
+		func(mutate func(*v1alpha1.StorageProvider), field string) {
+			mutate(sp)
+			err := w.ValidateCreate(ctx, sp)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(field))
+		},
+		Entry("missing provisioner", func(sp *v1alpha1.StorageProvider) {
+			sp.Spec.Provisioner = ""
+		}, "spec.provisioner"),
+		Entry("unsupported provisioner", func(sp *v1alpha1.StorageProvider) {
+			sp.Spec.Provisioner = "storageproviders.shardingsphere.apache.org/aws-dynamodb"
+		}, "spec.provisioner"),
+		Entry("invalid instances of cloudnative-pg", func(sp *v1alpha1.StorageProvider) {
+			sp.Spec.Parameters["instances"] = "zero"
+		}, "spec.parameters[instances]"),
+		Entry("unsupported reclaim policy", func(sp *v1alpha1.StorageProvider) {
+			sp.Spec.ReclaimPolicy = "Recycle"
+		}, "spec.reclaimPolicy"),
+	)
+
+	It("should reject changing the provisioner", func() {
+		updated := sp.DeepCopy()
+		updated.Spec.Provisioner = v1alpha1.ProvisionerAWSAurora
+		err := w.ValidateUpdate(ctx, sp, updated)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("provisioner is immutable"))
+	})
+
+	It("should allow changing the reclaim policy", func() {
+		updated := sp.DeepCopy()
+		updated.Spec.ReclaimPolicy = v1alpha1.StorageReclaimPolicyDelete
+		Expect(w.ValidateUpdate(ctx, sp, updated)).To(Succeed())
+	})
+})
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ChainValidators returns a validator which runs the given validators in order and
+// rejects the object with the first error, since only one validating webhook could be
+// registered for each kind.
+func ChainValidators(validators ...admission.CustomValidator) admission.CustomValidator {
+	return chainValidator(validators)
+}
+
+type chainValidator []admission.CustomValidator
+
+func (c chainValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	for _, v := range c {
+		if err := v.ValidateCreate(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c chainValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	for _, v := range c {
+		if err := v.ValidateUpdate(ctx, oldObj, newObj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c chainValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	for _, v := range c {
+		if err := v.ValidateDelete(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidOrNil wraps the field errors of the named object as an Invalid status error
+func invalidOrNil(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type fakeValidator struct {
+	err    error
+	called int
+}
+
+func (v *fakeValidator) ValidateCreate(_ context.Context, _ runtime.Object) error {
+	v.called++
+	return v.err
+}
+
+func (v *fakeValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) error {
+	v.called++
+	return v.err
+}
+
+func (v *fakeValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	v.called++
+	return v.err
+}
+
+var _ = Describe("ChainValidators", func() {
+	var (
+		ctx = context.Background()
+		obj = &v1alpha1.ComputeNode{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	)
+
+	It("should allow the object if all the validators allow it", func() {
+		first, second := &fakeValidator{}, &fakeValidator{}
+		v := ChainValidators(first, second)
+		Expect(v.ValidateCreate(ctx, obj)).To(Succeed())
+		Expect(v.ValidateUpdate(ctx, obj, obj)).To(Succeed())
+		Expect(v.ValidateDelete(ctx, obj)).To(Succeed())
+		Expect(first.called).To(Equal(3))
+		Expect(second.called).To(Equal(3))
+	})
+
+	It("should reject the object with the first error", func() {
+		first, second, third := &fakeValidator{}, &fakeValidator{err: errors.New("second")}, &fakeValidator{err: errors.New("third")}
+		v := ChainValidators(first, second, third)
+		Expect(v.ValidateCreate(ctx, obj)).To(MatchError("second"))
+		Expect(v.ValidateUpdate(ctx, obj, obj)).To(MatchError("second"))
+		Expect(v.ValidateDelete(ctx, obj)).To(MatchError("second"))
+		Expect(third.called).To(BeZero())
+	})
+
+	It("should combine the spec validation and the credential validation", func() {
+		cn := &v1alpha1.ComputeNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1alpha1.ComputeNodeSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				Bootstrap: v1alpha1.BootstrapConfig{
+					ServerConfig: v1alpha1.ServerConfig{
+						Authority: v1alpha1.ComputeNodeAuthority{
+							Users: []v1alpha1.ComputeNodeUser{{User: "root@%", Password: "root"}},
+						},
+					},
+				},
+			},
+		}
+		v := ChainValidators(&ComputeNodeWebhook{}, &CredentialValidator{})
+		err := v.ValidateCreate(ctx, cn)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring(plaintextPasswordForbidden))
+
+		cn.Spec.Replicas = -1
+		err = v.ValidateCreate(ctx, cn)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.replicas"))
+	})
+})
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -107,6 +108,42 @@ func (blder *WebhookBuilder) registerWebhooks() error {
 	if err != nil {
 		return err
 	}
+	return blder.registerHubWebhooks()
+}
+
+// registerHubWebhooks registers the defaulting and validating webhooks of the hub version if the type is convertible,
+// the hub objects are defaulted and validated alike the type by converting them to the type.
+func (blder *WebhookBuilder) registerHubWebhooks() error {
+	spoke, ok := blder.apiType.(ctrlconversion.Convertible)
+	if !ok {
+		return nil
+	}
+	hub, hubGVK, err := hubFor(blder.mgr.GetScheme(), blder.gvk)
+	if err != nil {
+		return err
+	}
+	if hub == nil {
+		return nil
+	}
+
+	if blder.withDefaulter != nil {
+		path := generateMutatePath(hubGVK)
+		if !blder.isAlreadyHandled(path) {
+			log.Info("Registering a mutating webhook",
+				"GVK", hubGVK,
+				"path", path)
+			blder.mgr.GetWebhookServer().Register(path, admission.WithCustomDefaulter(hub, &hubDefaulter{spoke: spoke, defaulter: blder.withDefaulter}))
+		}
+	}
+	if blder.withValidator != nil {
+		path := generateValidatePath(hubGVK)
+		if !blder.isAlreadyHandled(path) {
+			log.Info("Registering a validating webhook",
+				"GVK", hubGVK,
+				"path", path)
+			blder.mgr.GetWebhookServer().Register(path, admission.WithCustomValidator(hub, &hubValidator{spoke: spoke, validator: blder.withValidator}))
+		}
+	}
 	return nil
 }
 
@@ -204,7 +241,10 @@ func (blder *WebhookBuilder) isAlreadyHandled(path string) bool {
 }
 
 func (blder *WebhookBuilder) registerApiservice() {
-	blder.mgr.GetWebhookServer().Register(apiPath, getAPIService())
+	// the webhook server panics on registering a path twice, while the apiservice is shared by all the types
+	if !blder.isAlreadyHandled(apiPath) {
+		blder.mgr.GetWebhookServer().Register(apiPath, getAPIService())
+	}
 }
 
 func getAPIService() http.HandlerFunc {
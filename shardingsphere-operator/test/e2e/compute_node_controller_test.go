@@ -677,7 +677,7 @@ var _ = Describe("Default ConfigMap", func() {
 
 	Context("Assert ObjectMeta", func() {
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 
 		It("name should be equal", func() {
 			Expect(expect.Name).To(Equal(cm.Name))
@@ -692,7 +692,7 @@ var _ = Describe("Default ConfigMap", func() {
 
 	Context("Assert Default Spec Data", func() {
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 
 		It("default server config should be equal", func() {
 			Expect(expect.Data[configmap.ConfigDataKeyForServer]).To(Equal(cm.Data[configmap.ConfigDataKeyForServer]))
@@ -749,7 +749,7 @@ var _ = Describe("Default ConfigMap", func() {
 		}
 
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 		cm = configmap.UpdateComputeNodeConfigMap(cn, cm)
 		cfg := &v1alpha1.ServerConfig{}
 		err := yaml.Unmarshal([]byte(cm.Data[configmap.ConfigDataKeyForServer]), &cfg)
@@ -791,7 +791,7 @@ var _ = Describe("Standalone Server Config", func() {
 
 		expect := &v1alpha1.ServerConfig{}
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 		err := yaml.Unmarshal([]byte(cm.Data[configmap.ConfigDataKeyForServer]), &expect)
 		if err != nil {
 			fmt.Printf("Err: %s\n", err)
@@ -841,7 +841,7 @@ var _ = Describe("Standalone Server Config", func() {
 
 		expect := &v1alpha1.ServerConfig{}
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 		err := yaml.Unmarshal([]byte(cm.Data[configmap.ConfigDataKeyForServer]), &expect)
 		if err != nil {
 			fmt.Printf("Err: %s\n", err)
@@ -907,7 +907,7 @@ var _ = Describe("Cluster Server Config", func() {
 
 	BeforeEach(func() {
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 
 		err := yaml.Unmarshal([]byte(cm.Data[configmap.ConfigDataKeyForServer]), &expect)
 		if err != nil {
@@ -951,7 +951,7 @@ var _ = Describe("Logback Config", func() {
 		)
 
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 		expect = "test_logback_value"
 
 		It("Logback config should be equal", func() {
@@ -983,7 +983,7 @@ var _ = Describe("Logback Config", func() {
 		)
 
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 		expect = configmap.DefaultLogback
 
 		It("Logback config should be equal", func() {
@@ -1048,7 +1048,7 @@ var _ = Describe("Agent Config", func() {
 		)
 
 		c := configmap.NewConfigMapClient(nil)
-		cm := c.Build(ctx, cn)
+		cm, _ := c.Build(ctx, cn)
 
 		err := yaml.Unmarshal([]byte(cm.Data[configmap.ConfigDataKeyForAgent]), &expect)
 		if err != nil {
@@ -26,7 +26,6 @@ import (
 	"time"
 
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/api/v1alpha1"
-	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/controllers"
 	"github.com/apache/shardingsphere-on-cloud/shardingsphere-operator/pkg/reconcile/storagenode/aws"
 
 	"bou.ke/monkey"
@@ -34,6 +33,7 @@ import (
 	dbmeshawsrds "github.com/database-mesh/golang-sdk/aws/client/rds"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -228,15 +228,14 @@ var _ = Describe("StorageNode Controller Suite Test For AWS RDS Instance", func(
 					Name:      nodeName,
 					Namespace: "default",
 					Annotations: map[string]string{
-						v1alpha1.AnnotationsInstanceIdentifier:              instanceIdentifier,
-						controllers.AnnotationKeyRegisterStorageUnitEnabled: "true",
-						v1alpha1.AnnotationsInstanceDBName:                  "test-db-name",
-						controllers.AnnotationKeyComputeNodeName:            "test-compute-node",
-						controllers.AnnotationKeyLogicDatabaseName:          "test-logic-db-name",
+						v1alpha1.AnnotationsInstanceIdentifier: instanceIdentifier,
+						v1alpha1.AnnotationsInstanceDBName:     "test-db-name",
 					},
 				},
 				Spec: v1alpha1.StorageNodeSpec{
 					StorageProviderName: storageProviderName,
+					ComputeNodeRef:      &corev1.LocalObjectReference{Name: "test-compute-node"},
+					LogicDatabase:       "test-logic-db-name",
 				},
 			}
 
@@ -283,6 +282,157 @@ var _ = Describe("StorageNode Controller Suite Test For AWS RDS Instance", func(
 				return apierrors.IsNotFound(err)
 			}, 20, 2).Should(BeTrue())
 		})
+
+		It("should unregister the storage unit registered by the legacy annotations after upgrading", func() {
+			// mock mysql
+			db, dbmock, err := sqlmock.New()
+			Expect(err).Should(Succeed())
+			Expect(dbmock).ShouldNot(BeNil())
+			// the instance is still being created before the legacy status is restored
+			g := monkey.PatchInstanceMethod(reflect.TypeOf(&aws.RdsClient{}), "GetInstance", func(_ *aws.RdsClient, _ context.Context, _ *v1alpha1.StorageNode) (*dbmeshawsrds.DescInstance, error) {
+				return &dbmeshawsrds.DescInstance{
+					DBInstanceStatus: dbmeshawsrds.DBInstanceStatusCreating,
+				}, nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(&aws.RdsClient{}), "DeleteInstance", func(_ *aws.RdsClient, _ context.Context, _ *v1alpha1.StorageNode, _ *v1alpha1.StorageProvider) error {
+				return nil
+			})
+			monkey.Patch(sql.Open, func(_ string, _ string) (*sql.DB, error) {
+				return db, nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(db), "Close", func(_ *sql.DB) error {
+				return nil
+			})
+
+			cnName := "test-compute-node-legacy"
+			cn := &v1alpha1.ComputeNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cnName,
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "test-app-legacy",
+					},
+				},
+				Spec: v1alpha1.ComputeNodeSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app": "test-app-legacy",
+						},
+					},
+					PortBindings: []v1alpha1.PortBinding{
+						{
+							Name:          "http",
+							ContainerPort: 3307,
+							Protocol:      "TCP",
+							ServicePort:   3307,
+						},
+					},
+					Bootstrap: v1alpha1.BootstrapConfig{
+						ServerConfig: v1alpha1.ServerConfig{
+							Authority: v1alpha1.ComputeNodeAuthority{
+								Users: []v1alpha1.ComputeNodeUser{
+									{
+										User:     "test-user",
+										Password: "test-password",
+									},
+								},
+								Privilege: v1alpha1.ComputeNodePrivilege{
+									Type: v1alpha1.AllPermitted,
+								},
+							},
+							Mode: v1alpha1.ComputeNodeServerMode{
+								Repository: v1alpha1.Repository{
+									Type: "ZooKeeper",
+								},
+								Type: "Zookeeper",
+							},
+						},
+					},
+				},
+			}
+
+			// the storage node registered by the legacy annotations before upgrading
+			nodeName := "test-storage-node-legacy"
+			node := &v1alpha1.StorageNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      nodeName,
+					Namespace: "default",
+					Annotations: map[string]string{
+						v1alpha1.AnnotationsInstanceIdentifier:         instanceIdentifier,
+						v1alpha1.AnnotationsInstanceDBName:             "test-db-name",
+						v1alpha1.AnnotationsRegisterStorageUnitEnabled: "true",
+						v1alpha1.AnnotationsComputeNodeName:            cnName,
+						v1alpha1.AnnotationsLogicDatabaseName:          "test-logic-db-name",
+					},
+				},
+				Spec: v1alpha1.StorageNodeSpec{
+					StorageProviderName: storageProviderName,
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, cn)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, node)).Should(Succeed())
+
+			// restore the status written by the operator before upgrading, which has no storage units
+			Eventually(func() error {
+				newSN := &v1alpha1.StorageNode{}
+				if err := k8sClient.Get(ctx, client.ObjectKey{Name: nodeName, Namespace: "default"}, newSN); err != nil {
+					return err
+				}
+				newSN.Status.Registered = true
+				newSN.Status.StorageUnits = nil
+				return k8sClient.Status().Update(ctx, newSN)
+			}, 10*time.Second, 250*time.Millisecond).Should(Succeed())
+
+			g.Unpatch()
+			g = monkey.PatchInstanceMethod(reflect.TypeOf(&aws.RdsClient{}), "GetInstance", func(_ *aws.RdsClient, _ context.Context, _ *v1alpha1.StorageNode) (*dbmeshawsrds.DescInstance, error) {
+				return &dbmeshawsrds.DescInstance{
+					DBInstanceStatus: dbmeshawsrds.DBInstanceStatusAvailable,
+					Endpoint: dbmeshawsrds.Endpoint{
+						Address: "127.0.0.1",
+						Port:    3306,
+					},
+				}, nil
+			})
+
+			Eventually(func() v1alpha1.StorageNodePhaseStatus {
+				newSN := &v1alpha1.StorageNode{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Name: nodeName, Namespace: "default"}, newSN)).Should(Succeed())
+				return newSN.Status.Phase
+			}, time.Second*10, time.Millisecond*250).Should(Equal(v1alpha1.StorageNodePhaseReady))
+
+			// the storage unit is recorded as registered instead of being registered again
+			Eventually(func() []v1alpha1.StorageUnitStatus {
+				newSN := &v1alpha1.StorageNode{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Name: nodeName, Namespace: "default"}, newSN)).Should(Succeed())
+				return newSN.Status.StorageUnits
+			}, 20, 2).Should(ConsistOf(HaveField("Registered", BeTrue())))
+			Expect(dbmock.ExpectationsWereMet()).Should(Succeed())
+
+			// delete storage node
+			Expect(k8sClient.Delete(ctx, node)).Should(Succeed())
+
+			dbmock.ExpectExec(regexp.QuoteMeta("USE")).WillReturnResult(sqlmock.NewResult(1, 1))
+			dbmock.ExpectQuery(regexp.QuoteMeta("SHOW RULES USED STORAGE UNIT")).WillReturnRows(sqlmock.NewRows([]string{"type", "name"}))
+			dbmock.ExpectExec(regexp.QuoteMeta("UNREGISTER STORAGE UNIT")).WillReturnResult(sqlmock.NewResult(0, 0))
+			Eventually(func() v1alpha1.StorageNodePhaseStatus {
+				newSN := &v1alpha1.StorageNode{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Name: nodeName, Namespace: "default"}, newSN)).Should(Succeed())
+				return newSN.Status.Phase
+			}, 20, 2).Should(Equal(v1alpha1.StorageNodePhaseDeleting))
+
+			g.Unpatch()
+			monkey.PatchInstanceMethod(reflect.TypeOf(&aws.RdsClient{}), "GetInstance", func(_ *aws.RdsClient, _ context.Context, _ *v1alpha1.StorageNode) (*dbmeshawsrds.DescInstance, error) {
+				return nil, nil
+			})
+
+			Eventually(func() bool {
+				newSN := &v1alpha1.StorageNode{}
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: nodeName, Namespace: "default"}, newSN)
+				return apierrors.IsNotFound(err)
+			}, 20, 2).Should(BeTrue())
+			Expect(dbmock.ExpectationsWereMet()).Should(Succeed())
+		})
 	})
 })
 